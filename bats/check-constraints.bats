@@ -0,0 +1,154 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT PRIMARY KEY,
+  v1 BIGINT,
+  v2 BIGINT
+);
+INSERT INTO test VALUES (1, 1, 5), (2, 2, NULL);
+SQL
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "check-constraints: add, list and drop" {
+    run dolt constraints add test chk_v1_v2 "v1 < v2"
+    [ "$status" -eq "0" ]
+    run dolt constraints ls
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "test	chk_v1_v2	v1 < v2" ]] || false
+    run dolt constraints add test CHK_V1_V2 "v1 > 0"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "already exists" ]] || false
+    run dolt constraints drop test chk_v1_v2
+    [ "$status" -eq "0" ]
+    run dolt constraints ls test
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "No check constraints" ]] || false
+    run dolt constraints drop test chk_v1_v2
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "does not exist" ]] || false
+}
+
+@test "check-constraints: add validates the expression and existing rows" {
+    run dolt constraints add test chk_v3 "v3 > 0"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "column \`v3\` does not exist" ]] || false
+    run dolt constraints add test chk_v1 "v1 > 1"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "check constraint violation on \`test\`.\`chk_v1\`" ]] || false
+    run dolt constraints ls test
+    [[ "$output" =~ "No check constraints" ]] || false
+}
+
+@test "check-constraints: enforced on INSERT and UPDATE" {
+    dolt constraints add test chk_v1_v2 "v1 < v2"
+    run dolt sql -q "INSERT INTO test VALUES (3, 9, 1)"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "check constraint violation on \`test\`.\`chk_v1_v2\`" ]] || false
+    run dolt sql -q "UPDATE test SET v1 = 10 WHERE pk = 1"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "check constraint violation on \`test\`.\`chk_v1_v2\`" ]] || false
+    run dolt sql -q "INSERT INTO test VALUES (3, 1, NULL), (4, 1, 2)"
+    [ "$status" -eq "0" ]
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk" -r csv
+    [ "$output" = "pk
+1
+2
+3
+4" ]
+}
+
+@test "check-constraints: checked against generated AUTO_INCREMENT values" {
+    dolt sql -q "CREATE TABLE ai (id INT PRIMARY KEY AUTO_INCREMENT, v INT)"
+    dolt constraints add ai chk_ai "v > 0 OR id > 2"
+    run dolt sql -q "INSERT INTO ai (v) VALUES (-3)"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "check constraint violation on \`ai\`.\`chk_ai\`: (id: 1, v: -3)" ]] || false
+    dolt sql -q "INSERT INTO ai (v) VALUES (1), (2)"
+    dolt sql -q "INSERT INTO ai (v) VALUES (-3)"
+    run dolt sql -q "SELECT id, v FROM ai ORDER BY id" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "3,-3" ]] || false
+}
+
+@test "check-constraints: enforced on table import" {
+    dolt constraints add test chk_v1_v2 "v1 < v2"
+    cat <<DELIM > bad.csv
+pk,v1,v2
+5,7,1
+DELIM
+    run dolt table import -u test bad.csv
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "check constraint violation on \`test\`.\`chk_v1_v2\`" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM test WHERE pk = 5" -r csv
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "check-constraints: rows violating constraints are skipped by table import with --continue" {
+    dolt constraints add test chk_v1_v2 "v1 < v2"
+    cat <<DELIM > bad.csv
+pk,v1,v2
+5,7,1
+6,1,7
+DELIM
+    run dolt table import -u --continue test bad.csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Lines skipped: 1" ]] || false
+    run dolt sql -q "SELECT pk FROM test WHERE pk > 4 ORDER BY pk" -r csv
+    [ "$output" = "pk
+6" ]
+}
+
+@test "check-constraints: persisted across commits and branches" {
+    dolt constraints add test chk_v1_v2 "v1 < v2"
+    dolt add test
+    dolt commit -m "added check"
+    dolt checkout -b other
+    run dolt constraints ls
+    [[ "$output" =~ "test	chk_v1_v2	v1 < v2" ]] || false
+    run dolt sql -q "INSERT INTO test VALUES (3, 9, 1)"
+    [ "$status" -eq "1" ]
+}
+
+@test "check-constraints: merge validates constraints" {
+    dolt constraints add test chk_v1_v2 "v1 < v2"
+    dolt add test
+    dolt commit -m "added check"
+    dolt branch other
+    dolt sql -q "UPDATE test SET v1 = 4 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "v1 = 4"
+    dolt checkout other
+    dolt sql -q "UPDATE test SET v2 = 2 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "v2 = 2"
+    dolt checkout master
+    run dolt merge other
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "check constraint violation on \`test\`.\`chk_v1_v2\`" ]] || false
+    run dolt sql -q "SELECT v1, v2 FROM test WHERE pk = 1" -r csv
+    [[ "$output" =~ "4,5" ]] || false
+}
+
+@test "check-constraints: columns used in constraints" {
+    dolt constraints add test chk_v1_v2 "v1 < v2"
+    run dolt sql -q "ALTER TABLE test DROP COLUMN v1"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "used in check constraint \`chk_v1_v2\`" ]] || false
+    run dolt sql -q "ALTER TABLE test RENAME COLUMN v2 TO v3"
+    [ "$status" -eq "0" ]
+    run dolt constraints ls test
+    [[ "$output" =~ "test	chk_v1_v2	v1 < v3" ]] || false
+    run dolt sql -q "INSERT INTO test VALUES (3, 9, 1)"
+    [ "$status" -eq "1" ]
+    dolt constraints drop test chk_v1_v2
+    run dolt sql -q "ALTER TABLE test DROP COLUMN v1"
+    [ "$status" -eq "0" ]
+}
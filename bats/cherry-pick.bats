@@ -0,0 +1,135 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test(pk BIGINT PRIMARY KEY, v varchar(10))"
+    dolt add -A
+    dolt commit -m "Created table"
+    dolt checkout -b branch1
+    dolt sql -q "INSERT INTO test VALUES (1, 'a')"
+    dolt add -A
+    dolt commit -m "Inserted 1"
+    dolt sql -q "INSERT INTO test VALUES (2, 'b')"
+    dolt add -A
+    dolt commit -m "Inserted 2"
+    dolt checkout master
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "cherry-pick: apply a single commit" {
+    run dolt cherry-pick branch1
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "2,b" ]] || false
+    [[ ! "$output" =~ "1,a" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Inserted 2" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "cherry-pick: apply an ancestor commit" {
+    run dolt cherry-pick branch1~1
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "1,a" ]] || false
+    [[ ! "$output" =~ "2,b" ]] || false
+}
+
+@test "cherry-pick: commit that is already applied" {
+    dolt cherry-pick branch1
+    run dolt cherry-pick branch1
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "already applied" ]] || false
+}
+
+@test "cherry-pick: fails with a dirty working set" {
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    run dolt cherry-pick branch1
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "your local changes would be overwritten by cherry-pick" ]] || false
+}
+
+@test "cherry-pick: conflicts are left in the working set" {
+    dolt sql -q "INSERT INTO test VALUES (2, 'c')"
+    dolt add -A
+    dolt commit -m "Inserted 2 on master"
+
+    run dolt cherry-pick branch1
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt conflicts cat test
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "ours" ]] || false
+    [[ "$output" =~ "theirs" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    dolt commit -m "cherry-picked Inserted 2"
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "2,b" ]] || false
+}
+
+@test "cherry-pick: cannot cherry-pick the initial commit" {
+    run dolt cherry-pick HEAD~1
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "without parents" ]] || false
+}
+
+@test "cherry-pick: commit after a table was dropped on the current branch" {
+    dolt sql -q "CREATE TABLE other(pk BIGINT PRIMARY KEY)"
+    dolt add -A
+    dolt commit -m "Created other"
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    dolt add -A
+    dolt commit -m "Inserted 3"
+    dolt checkout master
+    dolt sql -q "DROP TABLE other"
+    dolt add -A
+    dolt commit -m "Dropped other"
+
+    run dolt cherry-pick feature
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "3,c" ]] || false
+
+    run dolt ls
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "other" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "cherry-pick: commit that modifies a table dropped on the current branch" {
+    dolt sql -q "CREATE TABLE other(pk BIGINT PRIMARY KEY)"
+    dolt add -A
+    dolt commit -m "Created other"
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO other VALUES (1)"
+    dolt add -A
+    dolt commit -m "Inserted into other"
+    dolt checkout master
+    dolt sql -q "DROP TABLE other"
+    dolt add -A
+    dolt commit -m "Dropped other"
+
+    run dolt cherry-pick feature
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "table with same name deleted and modified" ]] || false
+}
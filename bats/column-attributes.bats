@@ -0,0 +1,140 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk BIGINT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(20) DEFAULT 'anonymous' COMMENT 'who it''s for',
+    num INT NOT NULL DEFAULT 3,
+    PRIMARY KEY (pk)
+);
+SQL
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "column attributes are shown in the schema" {
+    run dolt schema show test
+    [ $status -eq 0 ]
+    [[ "$output" =~ "\`pk\` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'tag:" ]] || false
+    [[ "$output" =~ "\`name\` VARCHAR(20) DEFAULT 'anonymous' COMMENT 'who it\\'s for tag:" ]] || false
+    [[ "$output" =~ "\`num\` INT NOT NULL DEFAULT 3 COMMENT 'tag:" ]] || false
+}
+
+@test "insert uses default values and generates auto increment values" {
+    dolt sql <<SQL
+INSERT INTO test (num) VALUES (1),(2);
+INSERT INTO test (pk, name) VALUES (10, 'ten');
+INSERT INTO test (name) VALUES ('eleven');
+INSERT INTO test VALUES (NULL, 'twelve', 12);
+SQL
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 6 ]
+    [ "${lines[1]}" = "1,anonymous,1" ]
+    [ "${lines[2]}" = "2,anonymous,2" ]
+    [ "${lines[3]}" = "10,ten,3" ]
+    [ "${lines[4]}" = "11,eleven,3" ]
+    [ "${lines[5]}" = "12,twelve,12" ]
+
+    run dolt sql -q "INSERT INTO test (pk) VALUES (1)"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "duplicate primary key" ]] || false
+}
+
+@test "auto increment values out of the range of the column type are not generated" {
+    dolt sql -q "CREATE TABLE u (pk TINYINT NOT NULL AUTO_INCREMENT PRIMARY KEY, v INT)"
+    dolt sql -q "INSERT INTO u VALUES (127, 1)"
+    run dolt sql -q "INSERT INTO u (v) VALUES (4)"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "out of range" ]] || false
+    run dolt sql -q "SELECT * FROM u" -r csv
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "127,1" ]
+
+    dolt sql -q "CREATE TABLE ub (pk BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY, v INT)"
+    dolt sql -q "INSERT INTO ub VALUES (18446744073709551615, 1)"
+    run dolt sql -q "INSERT INTO ub (v) VALUES (4)"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "out of range" ]] || false
+    run dolt sql -q "SELECT * FROM ub" -r csv
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "18446744073709551615,1" ]
+}
+
+@test "auto increment is only allowed on the first column of an integer primary key" {
+    run dolt sql -q "CREATE TABLE bad (pk BIGINT PRIMARY KEY, v BIGINT AUTO_INCREMENT)"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "AUTO_INCREMENT is only supported on the first column of an integer primary key" ]] || false
+
+    run dolt sql -q "CREATE TABLE bad (pk VARCHAR(10) AUTO_INCREMENT PRIMARY KEY)"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "AUTO_INCREMENT is only supported on the first column of an integer primary key" ]] || false
+
+    run dolt sql -q "ALTER TABLE test ADD COLUMN v BIGINT AUTO_INCREMENT"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "AUTO_INCREMENT is only supported on the first column of an integer primary key" ]] || false
+}
+
+@test "table import uses default values and generates auto increment values" {
+    cat <<CSV > names.csv
+name
+first
+second
+CSV
+    run dolt table import -u test names.csv
+    [ $status -eq 0 ]
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1,first,3" ]
+    [ "${lines[2]}" = "2,second,3" ]
+}
+
+@test "column attributes round trip through schema export and sql export" {
+    dolt sql -q "INSERT INTO test (name) VALUES ('one')"
+
+    run dolt schema export test
+    [ $status -eq 0 ]
+    [[ "$output" =~ "\`pk\` BIGINT NOT NULL AUTO_INCREMENT," ]] || false
+    [[ "$output" =~ "\`name\` VARCHAR(20) DEFAULT 'anonymous' COMMENT 'who it\\'s for'," ]] || false
+
+    dolt add .
+    run dolt schema show test
+    expected_schema="$output"
+    dolt table export test export.sql
+    dolt sql -q "DROP TABLE test"
+    dolt sql < export.sql
+    run dolt schema show test
+    [ $status -eq 0 ]
+    [ "$output" = "$expected_schema" ]
+    run dolt diff --schema
+    [ $status -eq 0 ]
+    [ "$output" = "" ]
+
+    dolt sql -q "INSERT INTO test (num) VALUES (5)"
+    run dolt sql -q "SELECT * FROM test WHERE num = 5" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2,anonymous,5" ]
+}
+
+@test "schema diff shows changes to column attributes" {
+    dolt add .
+    dolt commit -m "created test"
+    dolt sql -q "ALTER TABLE test MODIFY COLUMN num INT NOT NULL DEFAULT 4 COMMENT 'count'"
+
+    run dolt diff --schema
+    [ $status -eq 0 ]
+    [[ "$output" =~ "<   \`num\` INT NOT NULL DEFAULT 3" ]] || false
+    [[ "$output" =~ ">   \`num\` INT NOT NULL DEFAULT 4 COMMENT 'count'" ]] || false
+
+    run dolt diff --schema -r sql
+    [ $status -eq 0 ]
+    [[ "$output" =~ "ALTER TABLE \`test\` MODIFY COLUMN \`num\` INT NOT NULL DEFAULT 4 COMMENT 'count';" ]] || false
+}
@@ -0,0 +1,124 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add -A
+    dolt commit -m "created table test"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "dolt tag create a tag" {
+    run dolt tag v1
+    [ $status -eq 0 ]
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+}
+
+@test "dolt tag create a tag with a message" {
+    run dolt tag v1 -m "the first release"
+    [ $status -eq 0 ]
+    run dolt tag -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "Tagger: " ]] || false
+    [[ "$output" =~ "the first release" ]] || false
+}
+
+@test "dolt tag create a tag at a ref" {
+    dolt sql -q "insert into test values (0,0)"
+    dolt add -A
+    dolt commit -m "inserted a row"
+    run dolt tag v1 HEAD^
+    [ $status -eq 0 ]
+    run dolt sql -q "select count(*) from test as of 'v1'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+    [[ ! "$output" =~ "1" ]] || false
+}
+
+@test "dolt tag cannot create a tag that already exists" {
+    dolt tag v1
+    run dolt tag v1
+    [ $status -ne 0 ]
+    [[ "$output" =~ "tag 'v1' already exists" ]] || false
+}
+
+@test "dolt tag delete a tag" {
+    dolt tag v1
+    dolt tag v2
+    run dolt tag -d v1
+    [ $status -eq 0 ]
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "v2" ]] || false
+    run dolt tag -d v1
+    [ $status -ne 0 ]
+    [[ "$output" =~ "tag 'v1' not found" ]] || false
+}
+
+@test "dolt tag names can be used as commit specs" {
+    dolt tag v1
+    dolt sql -q "insert into test values (0,0)"
+    dolt add -A
+    dolt commit -m "inserted a row"
+    run dolt log v1
+    [ $status -eq 0 ]
+    [[ "$output" =~ "created table test" ]] || false
+    [[ ! "$output" =~ "inserted a row" ]] || false
+    run dolt diff v1 HEAD
+    [ $status -eq 0 ]
+    [[ "$output" =~ "+  | 0" ]] || false
+    run dolt checkout -b from_tag v1
+    [ $status -eq 0 ]
+    run dolt sql -q "select count(*) from test" -r csv
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "dolt_tags system table" {
+    dolt tag v1 -m "the first release"
+    run dolt sql -q "select name, message from dolt_tags" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1,the first release" ]] || false
+}
+
+@test "dolt tag push, clone and fetch tags" {
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt tag v1 -m "the first release"
+    dolt push origin master
+    run dolt push origin v1
+    [ $status -eq 0 ]
+
+    mkdir dolt-repo-clones
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    run dolt tag -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "the first release" ]] || false
+
+    cd ../..
+    dolt tag v2
+    run dolt push origin refs/tags/v2
+    [ $status -eq 0 ]
+
+    cd dolt-repo-clones/test-repo
+    dolt fetch
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v2" ]] || false
+}
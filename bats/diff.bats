@@ -377,3 +377,176 @@ SQL
     [ $status -eq 0 ]
     [ $output -eq $CORRECT_DIFF ]
 }
+
+@test "diff -r json" {
+    dolt sql -q "insert into test values (0, 0, 0, 0, 0, 0)"
+    dolt sql -q "insert into test values (1, 1, 1, 1, 1, 1)"
+    dolt add test
+    dolt commit -m "table created"
+    dolt sql -q "update test set c1 = 10 where pk = 0"
+    dolt sql -q "delete from test where pk = 1"
+    dolt sql -q "insert into test (pk, c1) values (2, 2)"
+    dolt sql -q "alter table test drop column c5"
+
+    run dolt diff -r json
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ '{"tables":[{"from_name":"test","to_name":"test","diff_type":"modified",' ]] || false
+    [[ "$output" =~ '"schema_diff":[{"diff_type":"removed","tag":5,"from":{"name":"c5","type":"BIGINT","primary_key":false,"nullable":true},"to":null}]' ]] || false
+    [[ "$output" =~ '{"diff_type":"modified","from":{"c1":0,"c2":0,"c3":0,"c4":0,"c5":0,"pk":0},"to":{"c1":10,"c2":0,"c3":0,"c4":0,"pk":0}}' ]] || false
+    [[ "$output" =~ '{"diff_type":"removed","from":{"c1":1,"c2":1,"c3":1,"c4":1,"c5":1,"pk":1},"to":null}' ]] || false
+    [[ "$output" =~ '{"diff_type":"added","from":null,"to":{"c1":2,"pk":2}}' ]] || false
+
+    run dolt diff -r json -d --where "to_pk=2"
+    [ "$status" -eq 0 ]
+    [ "$output" = '{"tables":[{"from_name":"test","to_name":"test","diff_type":"modified","data_diff":[{"diff_type":"added","from":null,"to":{"c1":2,"pk":2}}]}]}' ]
+
+    dolt add test
+    dolt commit -m "changed rows"
+    dolt sql -q "create table other (pk int primary key)"
+    dolt sql -q "insert into other values (1)"
+    dolt table rm test
+    run dolt diff -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '{"from_name":null,"to_name":"other","diff_type":"added","schema_diff":[{"diff_type":"added",' ]] || false
+    [[ "$output" =~ '"data_diff":[{"diff_type":"added","from":null,"to":{"pk":1}}]}' ]] || false
+    [[ "$output" =~ '{"from_name":"test","to_name":null,"diff_type":"removed",' ]] || false
+    [[ "$output" =~ '{"diff_type":"removed","from":{"c1":10,"c2":0,"c3":0,"c4":0,"pk":0},"to":null}' ]] || false
+
+    run dolt diff -r json --summary
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--summary cannot be combined" ]] || false
+}
+
+@test "diff -r csv" {
+    dolt sql -q "insert into test values (0, 0, 0, 0, 0, 0)"
+    dolt sql -q "insert into test values (1, 1, 1, 1, 1, 1)"
+    dolt add test
+    dolt commit -m "table created"
+    dolt sql -q "update test set c1 = 10 where pk = 0"
+    dolt sql -q "delete from test where pk = 1"
+    dolt sql -q "insert into test (pk, c1) values (2, 2)"
+    dolt sql -q "alter table test drop column c5"
+
+    run dolt diff -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+    [ "${lines[0]}" = "table,diff_type,from_pk,from_c1,from_c2,from_c3,from_c4,from_c5,to_pk,to_c1,to_c2,to_c3,to_c4" ]
+    [ "${lines[1]}" = "test,modified,0,0,0,0,0,0,0,10,0,0,0" ]
+    [ "${lines[2]}" = "test,removed,1,1,1,1,1,1,,,,," ]
+    [ "${lines[3]}" = "test,added,,,,,,,2,2,,," ]
+
+    run dolt diff -r csv --schema
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = "table,diff_type,tag,from_name,from_type,from_primary_key,from_nullable,to_name,to_type,to_primary_key,to_nullable" ]
+    [ "${lines[1]}" = "test,removed,5,c5,BIGINT,false,true,,,," ]
+
+    run dolt diff -r csv --schema --data
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 6 ]
+    [ "${lines[2]}" = "table,diff_type,from_pk,from_c1,from_c2,from_c3,from_c4,from_c5,to_pk,to_c1,to_c2,to_c3,to_c4" ]
+
+    run dolt diff -r csv --limit 1
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "diff shows renamed tables and columns" {
+    dolt sql -q 'insert into test values (0,0,0,0,0,0)'
+    dolt add .
+    dolt commit -m "table created"
+    dolt table mv test renamed
+    dolt sql -q "alter table renamed rename column c1 to c1_renamed"
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "renamed:        test -> renamed" ]] || false
+    [[ "$output" =~ "renamed column: renamed.c1 -> renamed.c1_renamed" ]] || false
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "diff --dolt a/test b/renamed" ]] || false
+    [[ "$output" =~ "renamed table" ]] || false
+    [[ "$output" =~ "c1_renamed" ]] || false
+
+    run dolt diff --schema
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "~   \`c1_renamed\` BIGINT COMMENT 'tag:1' -- renamed from \`c1\`" ]] || false
+    [[ ! "$output" =~ "<" ]] || false
+    [[ ! "$output" =~ ">" ]] || false
+
+    dolt add renamed
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "renamed column: renamed.c1 -> renamed.c1_renamed" ]] || false
+
+    run dolt diff -r sql
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = 'RENAME TABLE `test` TO `renamed`;' ]
+    [ "${lines[1]}" = 'ALTER TABLE `renamed` RENAME COLUMN `c1` TO `c1_renamed`;' ]
+
+    run dolt diff -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"from_name":"test","to_name":"renamed","diff_type":"renamed"' ]] || false
+    [[ "$output" =~ '"schema_diff":[{"diff_type":"renamed","tag":1,' ]] || false
+}
+
+@test "diff --find-renames shows copied and dropped tables as renamed" {
+    dolt sql -q 'insert into test values (0,0,0,0,0,0), (1,1,1,1,1,1), (2,2,2,2,2,2)'
+    dolt add .
+    dolt commit -m "table created"
+    dolt sql <<SQL
+CREATE TABLE copied (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  c2 BIGINT,
+  c3 BIGINT,
+  c4 BIGINT,
+  c5 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO copied SELECT * FROM test;
+UPDATE copied SET c1 = 10 WHERE pk = 0;
+DROP TABLE test;
+SQL
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added table" ]] || false
+    [[ "$output" =~ "deleted table" ]] || false
+
+    run dolt status --find-renames
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "renamed:        test -> copied" ]] || false
+    [[ ! "$output" =~ "new table:" ]] || false
+
+    run dolt diff --find-renames -r sql
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = 'RENAME TABLE `test` TO `copied`;' ]
+    [[ "${lines[1]}" =~ 'UPDATE `copied` SET `c1`=10' ]] || false
+
+    run dolt diff --find-renames=50%
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "renamed table" ]] || false
+
+    run dolt diff -M40
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "renamed table" ]] || false
+
+    # two of the three rows are unchanged
+    run dolt status -M=70
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "deleted:        test" ]] || false
+    [[ "$output" =~ "new table:      copied" ]] || false
+
+    run dolt status -M70%
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "deleted:        test" ]] || false
+
+    run dolt diff --find-renames=200
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "must be a percentage from 0 to 100" ]] || false
+}
@@ -0,0 +1,68 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt sql -q "insert into test values (0,0),(1,1),(2,2)"
+    dolt add test
+    dolt commit -m "created test table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "dolt gc on a repo with committed data" {
+    run dolt gc
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Reclaimed" ]] || false
+    run dolt sql -q "select count(*) from test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "dolt gc keeps working and staged changes" {
+    dolt sql -q "insert into test values (3,3)"
+    dolt add test
+    dolt sql -q "insert into test values (4,4)"
+    dolt gc
+    run dolt status
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "Changes not staged for commit" ]] || false
+    run dolt sql -q "select count(*) from test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "5" ]] || false
+}
+
+@test "dolt gc keeps branches and removes deleted branches" {
+    dolt checkout -b other
+    dolt sql -q "insert into test values (3,3)"
+    dolt add test
+    dolt commit -m "added a row on other"
+    dolt checkout master
+    dolt gc
+    run dolt log other
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "added a row on other" ]] || false
+    dolt branch -D other
+    run dolt gc
+    [ "$status" -eq "0" ]
+    run dolt log
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "created test table" ]] || false
+}
+
+@test "dolt gc reclaims space after dolt reset --hard" {
+    for i in `seq 10 50`; do
+        dolt sql -q "insert into test values ($i,$i)"
+    done
+    dolt reset --hard
+    run dolt gc
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "Reclaimed 0 B" ]] || false
+    run dolt sql -q "select count(*) from test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "3" ]] || false
+}
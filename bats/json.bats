@@ -0,0 +1,124 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT PRIMARY KEY,
+  v JSON
+);
+INSERT INTO test VALUES (1, '{"a": {"x": 1, "y": [1, 2]}, "b": "str"}'), (2, '[1, 2, 3]'), (3, NULL);
+SQL
+    dolt add test
+    dolt commit -m "added test"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "json: documents are stored canonically" {
+    dolt sql -q "UPDATE test SET v = '{ \"b\" : \"str\", \"a\" : { \"y\" : [1.0, 2], \"x\" : 1 } }' WHERE pk = 1"
+    run dolt status
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "nothing to commit" ]] || false
+    run dolt sql -q "SELECT v FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ '{""a"":{""x"":1,""y"":[1,2]},""b"":""str""}' ]] || false
+}
+
+@test "json: large numbers are kept exactly and invalid documents are rejected" {
+    dolt sql -q "INSERT INTO test VALUES (4, '[9007199254740993, 12345678901234567890]')"
+    run dolt sql -q "SELECT v FROM test WHERE pk = 4" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = '"[9007199254740993,12345678901234567890]"' ]] || false
+    echo 'pk,v' > exact.csv
+    echo '4,"[9007199254740993, 12345678901234567890]"' >> exact.csv
+    dolt add test
+    dolt commit -m "added large numbers"
+    dolt table import -u test exact.csv
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+
+    run dolt sql -q "INSERT INTO test VALUES (5, 'not json')"
+    [ "$status" -ne "0" ]
+    [[ "$output" =~ "invalid JSON document" ]] || false
+    run dolt sql -q "INSERT INTO test VALUES (5, '{\"a\":1e400}')"
+    [ "$status" -ne "0" ]
+    [[ "$output" =~ "invalid JSON document" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM test WHERE pk = 5" -r csv
+    [[ "${lines[1]}" = "0" ]] || false
+}
+
+@test "json: path operators and JSON_EXTRACT" {
+    run dolt sql -q "SELECT pk, v->'$.a.x', v->>'$.b' FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "1,1,str" ]] || false
+    run dolt sql -q "SELECT JSON_EXTRACT(v, '$.a.y') FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ '"[1,2]"' ]] || false
+    run dolt sql -q "SELECT pk FROM test WHERE v->'$.a.x' = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "1" ]] || false
+}
+
+@test "json: diff shows changed paths" {
+    dolt sql -q "UPDATE test SET v = '{\"a\": {\"x\": 5, \"y\": [1, 2, 3]}, \"b\": \"str\", \"c\": true}' WHERE pk = 1"
+    run dolt diff
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ '$.a.x: 1 ' ]] || false
+    [[ "$output" =~ '$.a.x: 5, $.a.y[2]: 3, $.c: true' ]] || false
+    run dolt diff -r sql
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "UPDATE \`test\` SET \`v\`=" ]] || false
+}
+
+@test "json: merge combines edits to different keys" {
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET v = '{\"a\": {\"x\": 1, \"y\": [1, 2]}, \"b\": \"other\"}' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "changed b"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET v = '{\"a\": {\"x\": 2, \"y\": [1, 2]}, \"b\": \"str\", \"c\": 3}' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "changed a.x and added c"
+    run dolt merge other
+    [ "$status" -eq "0" ]
+    run dolt conflicts cat test
+    [[ ! "$output" =~ "ours" ]] || false
+    run dolt sql -q "SELECT v FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ '{""a"":{""x"":2,""y"":[1,2]},""b"":""other"",""c"":3}' ]] || false
+}
+
+@test "json: merge conflicts on edits to the same key" {
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET v = '{\"a\": {\"x\": 3, \"y\": [1, 2]}, \"b\": \"str\"}' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "a.x = 3"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET v = '{\"a\": {\"x\": 2, \"y\": [1, 2]}, \"b\": \"str\"}' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "a.x = 2"
+    run dolt merge other
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+}
+
+@test "json: export and import keep documents" {
+    dolt table export test test.json
+    run cat test.json
+    [[ "$output" =~ '"v":{"a":{"x":1,"y":[1,2]},"b":"str"}' ]] || false
+    dolt sql -q "DELETE FROM test"
+    dolt table import -u test test.json
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+    dolt table export test test.csv
+    dolt sql -q "DELETE FROM test"
+    dolt table import -u test test.csv
+    run dolt sql -q "SELECT v FROM test ORDER BY pk" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = '"{""a"":{""x"":1,""y"":[1,2]},""b"":""str""}"' ]] || false
+    [[ "${lines[2]}" = '"[1,2,3]"' ]] || false
+}
@@ -0,0 +1,102 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cat <<JSONL > logs.jsonl
+{"id": 1, "level": "info", "msg": "started", "ctx": {"host": "a", "pid": 10}}
+{"id": 2, "level": "warn", "msg": "slow", "ctx": {"host": "b", "pid": 11}, "latency": 1.5}
+
+{"id": 3, "level": "error", "msg": "failed", "ctx": {"host": "a"}}
+JSONL
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "jsonl: schema import flattens nested objects" {
+    run dolt schema import -c --pks id logs logs.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`id\` INT UNSIGNED NOT NULL" ]] || false
+    [[ "$output" =~ "\`ctx.host\` LONGTEXT NOT NULL" ]] || false
+    [[ "$output" =~ "\`ctx.pid\` INT UNSIGNED COMMENT" ]] || false
+    [[ "$output" =~ "\`latency\` FLOAT COMMENT" ]] || false
+
+    run dolt schema import -c --pks id --file-type ndjson logs2 logs.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`ctx.host\`" ]] || false
+}
+
+@test "jsonl: import into a new table and export" {
+    run dolt table import -c logs logs.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 3" ]] || false
+
+    run dolt sql -q "SELECT id, \`ctx.host\`, \`ctx.pid\` FROM logs ORDER BY id" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,a,10" ]
+    [ "${lines[2]}" = "2,b,11" ]
+    [ "${lines[3]}" = "3,a," ]
+
+    dolt table export logs export.jsonl
+    run cat export.jsonl
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[0]}" = '{"ctx.host":"a","ctx.pid":10,"id":1,"level":"info","msg":"started"}' ]
+    [ "${lines[2]}" = '{"ctx.host":"a","id":3,"level":"error","msg":"failed"}' ]
+
+    run dolt table export --file-type jsonl logs
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '{"ctx.host":"b","ctx.pid":11,"id":2,"latency":1.5,"level":"warn","msg":"slow"}' ]] || false
+
+    dolt table import -r logs export.jsonl
+    run dolt sql -q "SELECT COUNT(*) FROM logs" -r csv
+    [ "${lines[1]}" = "3" ]
+}
+
+@test "jsonl: import from stdin" {
+    dolt table import -c logs logs.jsonl
+
+    run bash -c "echo '{\"id\": 4, \"level\": \"debug\", \"msg\": \"m\", \"ctx\": {\"host\": \"c\"}}' | dolt table import -u --file-type jsonl logs"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Additions: 1" ]] || false
+    run dolt sql -q "SELECT \`ctx.host\` FROM logs WHERE id = 4" -r csv
+    [ "${lines[1]}" = "c" ]
+
+    run bash -c "echo '{\"id\": 1}' | dolt table import -c --file-type jsonl new_table"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Please specify schema file for .jsonl tables read from stdin" ]] || false
+
+    cat <<SQL > schema.sql
+CREATE TABLE docs (
+  id INT PRIMARY KEY,
+  doc JSON
+);
+SQL
+    run bash -c "echo '{\"id\": 1, \"doc\": {\"b\": [1, 2], \"a\": null}}' | dolt table import -c --schema schema.sql --file-type jsonl docs"
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT doc FROM docs" -r csv
+    [[ "$output" =~ '{""a"":null,""b"":[1,2]}' ]] || false
+}
+
+@test "jsonl: bad lines fail the import unless --continue is given" {
+    dolt table import -c logs logs.jsonl
+    cat <<JSONL > update.jsonl
+{"id": 4, "level": "info", "msg": "ok", "ctx": {"host": "d"}}
+{"id": "not a number", "level": "info", "msg": "bad", "ctx": {"host": "d"}}
+not json
+{"id": 5, "level": "info", "msg": "ok", "ctx": {"host": "e"}}
+JSONL
+
+    run dolt table import -u logs update.jsonl
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "A bad row was encountered" ]] || false
+    [[ "$output" =~ "line 2" ]] || false
+
+    run dolt table import -u --continue logs update.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Lines skipped: 2" ]] || false
+    run dolt sql -q "SELECT id FROM logs ORDER BY id" -r csv
+    [ "${lines[4]}" = "4" ]
+    [ "${lines[5]}" = "5" ]
+}
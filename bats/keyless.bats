@@ -0,0 +1,166 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE keyless (
+    c0 int,
+    c1 int
+);
+INSERT INTO keyless VALUES (0,0),(2,2),(1,1),(1,1);
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "create a table without a primary key" {
+    run dolt schema show keyless
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "PRIMARY KEY" ]] || false
+    run dolt sql -q "SELECT count(*) FROM keyless" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "keyless tables keep duplicate rows" {
+    dolt sql -q "INSERT INTO keyless VALUES (1,1)"
+    run dolt sql -q "SELECT * FROM keyless WHERE c0 = 1" -r csv
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+    [ "${lines[1]}" = "1,1" ]
+    [ "${lines[2]}" = "1,1" ]
+    [ "${lines[3]}" = "1,1" ]
+}
+
+@test "delete and update rows of a keyless table" {
+    dolt sql -q "DELETE FROM keyless WHERE c0 = 1 LIMIT 1"
+    run dolt sql -q "SELECT count(*) FROM keyless WHERE c0 = 1" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+
+    dolt sql -q "UPDATE keyless SET c1 = 9 WHERE c0 = 2"
+    run dolt sql -q "SELECT c1 FROM keyless WHERE c0 = 2" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "9" ]
+
+    dolt sql -q "DELETE FROM keyless"
+    run dolt sql -q "SELECT count(*) FROM keyless" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "indexes are not supported on keyless tables" {
+    run dolt sql -q "CREATE INDEX idx ON keyless (c1)"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "indexes are not supported on tables without a primary key" ]] || false
+}
+
+@test "diff shows each added and removed copy of a row" {
+    dolt sql -q "INSERT INTO keyless VALUES (1,1),(3,3)"
+    dolt sql -q "DELETE FROM keyless WHERE c0 = 0"
+    run dolt diff
+    [ $status -eq 0 ]
+    [[ "$output" =~ "|  +  | 1  | 1  |" ]] || false
+    [[ "$output" =~ "|  +  | 3  | 3  |" ]] || false
+    [[ "$output" =~ "|  -  | 0  | 0  |" ]] || false
+    [[ ! "$output" =~ "|  <  |" ]] || false
+
+    run dolt diff --summary
+    [ $status -eq 0 ]
+    [[ "$output" =~ "2 Rows Added" ]] || false
+    [[ "$output" =~ "1 Row Deleted" ]] || false
+    [[ "$output" =~ "0 Rows Modified" ]] || false
+
+    run dolt diff -r sql
+    [ $status -eq 0 ]
+    [[ "$output" =~ 'DELETE FROM `keyless` WHERE (`c0`=0 AND `c1`=0) LIMIT 1;' ]] || false
+    [[ "$output" =~ 'INSERT INTO `keyless` (`c0`,`c1`) VALUES (1,1);' ]] || false
+}
+
+@test "commit and read the history of a keyless table" {
+    dolt sql -q "INSERT INTO keyless VALUES (1,1)"
+    dolt add .
+    dolt commit -m "added a copy"
+    run dolt sql -q "SELECT count(*) FROM dolt_history_keyless WHERE c0 = 1" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "5" ]
+    run dolt sql -q "SELECT diff_type FROM dolt_diff_keyless WHERE to_c0 = 1" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "added" ]] || false
+}
+
+@test "merge keyless tables" {
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO keyless VALUES (1,1),(3,3)"
+    dolt sql -q "DELETE FROM keyless WHERE c0 = 0"
+    dolt add .
+    dolt commit -m "other"
+    dolt checkout master
+    dolt sql -q "INSERT INTO keyless VALUES (1,1),(4,4)"
+    dolt sql -q "DELETE FROM keyless WHERE c0 = 2"
+    dolt add .
+    dolt commit -m "master"
+
+    run dolt merge other
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+    run dolt sql -q "SELECT c0, count(*) FROM keyless GROUP BY c0 ORDER BY c0" -r csv
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+    [ "${lines[1]}" = "1,4" ]
+    [ "${lines[2]}" = "3,1" ]
+    [ "${lines[3]}" = "4,1" ]
+}
+
+@test "blame a keyless table" {
+    dolt sql -q "INSERT INTO keyless VALUES (1,1)"
+    dolt add .
+    dolt commit -m "added a copy"
+    run dolt blame keyless
+    [ $status -eq 0 ]
+    [[ "$output" =~ "| C0 | C1 |" ]] || false
+    [[ "$output" =~ "| 1  | 1  | added a copy" ]] || false
+    [[ "$output" =~ "| 2  | 2  | init" ]] || false
+}
+
+@test "alter the columns of a keyless table" {
+    dolt sql -q "ALTER TABLE keyless ADD COLUMN c2 int DEFAULT 7"
+    run dolt sql -q "SELECT count(*) FROM keyless WHERE c2 = 7" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "4" ]
+
+    dolt sql -q "ALTER TABLE keyless DROP COLUMN c0"
+    dolt sql -q "DELETE FROM keyless WHERE c1 = 1"
+    run dolt sql -q "SELECT count(*) FROM keyless" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+}
+
+@test "import a csv into a keyless table" {
+    cat <<CSV > dups.csv
+c0,c1
+1,1
+1,1
+2,2
+CSV
+    run dolt table import -c --no-pk imported dups.csv
+    [ $status -eq 0 ]
+    run dolt sql -q "SELECT count(*) FROM imported" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+
+    run dolt table import -u imported dups.csv
+    [ $status -eq 0 ]
+    run dolt sql -q "SELECT count(*) FROM imported" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "6" ]
+
+    run dolt table import -c --no-pk --pk=c0 other dups.csv
+    [ $status -ne 0 ]
+}
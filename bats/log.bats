@@ -89,3 +89,127 @@ teardown() {
     regex='Merge:.*MergeCommit.*'
     [[ "$output" =~ $regex ]] || false
 }
+
+@test "dolt log filters commits by author and date" {
+    stash_current_dolt_user
+    set_dolt_user "Thomas Foolery" "bats-1@email.fake"
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Commit1" --date 2020-01-01T12:00:00
+    set_dolt_user "Richard Tracy" "bats-2@email.fake"
+    dolt sql -q "insert into test values (0,0)"
+    dolt add test
+    dolt commit -m "Commit2" --date 2020-02-01T12:00:00
+    restore_stashed_dolt_user
+    run dolt log --author "Tracy"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Commit2" ]] || false
+    [[ ! "$output" =~ "Commit1" ]] || false
+    run dolt log --author "bats-1@email.fake"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Commit1" ]] || false
+    [[ ! "$output" =~ "Commit2" ]] || false
+    run dolt log --since 2020-01-15 --until 2020-03-01
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Commit2" ]] || false
+    [[ ! "$output" =~ "Commit1" ]] || false
+    run dolt log --until 2020-01-15
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Commit1" ]] || false
+    [[ ! "$output" =~ "Commit2" ]] || false
+    run dolt log --since not-a-date
+    [ $status -eq 1 ]
+    [[ "$output" =~ "invalid --since date" ]] || false
+}
+
+@test "dolt log filters commits by table" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt sql -q "create table test2 (pk int, c1 int, primary key(pk))"
+    dolt add .
+    dolt commit -m "Commit1"
+    dolt sql -q "insert into test values (0,0)"
+    dolt add test
+    dolt commit -m "Commit2"
+    dolt sql -q "insert into test2 values (0,0)"
+    dolt add test2
+    dolt commit -m "Commit3"
+    run dolt log --oneline -- test
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "Commit2" ]] || false
+    [[ "${lines[1]}" =~ "Commit1" ]] || false
+    run dolt log --oneline -- test2
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "Commit3" ]] || false
+    run dolt log --oneline HEAD~1 -- test2
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "Commit1" ]] || false
+    run dolt log --oneline -n 1 -- test test2
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "Commit3" ]] || false
+}
+
+@test "dolt log with --oneline, --merges, --no-merges and --graph" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Commit1"
+    dolt checkout -b test-branch
+    dolt sql -q "insert into test values (0,0)"
+    dolt add test
+    dolt commit -m "Commit2"
+    dolt checkout master
+    dolt sql -q "insert into test values (1,1)"
+    dolt add test
+    dolt commit -m "Commit3"
+    dolt merge test-branch
+    dolt add test
+    dolt commit -m "MergeCommit"
+    run dolt log --oneline
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 5 ]
+    [[ "${lines[0]}" =~ "MergeCommit" ]] || false
+    [[ "${lines[4]}" =~ "Initialize data repository" ]] || false
+    run dolt log --oneline --merges
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "MergeCommit" ]] || false
+    run dolt log --oneline --no-merges
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+    [[ ! "$output" =~ "MergeCommit" ]] || false
+    run dolt log --merges --no-merges
+    [ $status -eq 1 ]
+    run dolt log --oneline --graph
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 7 ]
+    [[ "${lines[0]}" =~ "* " ]] || false
+    [[ "${lines[0]}" =~ "MergeCommit" ]] || false
+    [ "${lines[1]}" = '|\' ]
+    [ "${lines[4]}" = '|/' ]
+    [[ "${lines[5]}" =~ "Commit1" ]] || false
+    run dolt log --graph --merges
+    [ $status -eq 1 ]
+    [[ "$output" =~ "--graph cannot be combined" ]] || false
+}
+
+@test "dolt log --stat" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Commit1"
+    dolt sql -q "insert into test values (0,0),(1,1),(2,2)"
+    dolt add test
+    dolt commit -m "Commit2"
+    dolt sql -q "update test set c1=10 where pk=0"
+    dolt sql -q "delete from test where pk=2"
+    dolt add test
+    dolt commit -m "Commit3"
+    run dolt log --stat -n 2
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1 tables changed, 0 rows added(+), 1 rows modified(*), 1 rows deleted(-)" ]] || false
+    [[ "$output" =~ "1 tables changed, 3 rows added(+), 0 rows modified(*), 0 rows deleted(-)" ]] || false
+    regex='Commit3.*test \| 2.*Commit2.*test \| 3'
+    [[ "$output" =~ $regex ]] || false
+}
@@ -0,0 +1,217 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  name VARCHAR(20),
+  score BIGINT,
+  hits BIGINT,
+  updated BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1,'one',10,100,1),(2,'two',20,200,1);
+SQL
+    dolt add .
+    dolt commit -m "added test table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "merge-strategy declares, lists and removes strategies" {
+    run dolt merge-strategy
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    dolt merge-strategy test theirs
+    dolt merge-strategy -c score test max
+    dolt merge-strategy --column updated test latest --timestamp-column updated
+    run dolt merge-strategy
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "test: theirs" ]
+    [ "${lines[1]}" = "test.score: max" ]
+    [ "${lines[2]}" = "test.updated: latest (timestamp column: updated)" ]
+
+    dolt merge-strategy test ours
+    run dolt merge-strategy
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "test: ours" ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "dolt_merge_strategies" ]] || false
+
+    dolt merge-strategy -d -c score test
+    run dolt merge-strategy
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "score" ]] || false
+
+    run dolt merge-strategy -d -c score test
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no merge strategy declared for 'test.score'" ]] || false
+}
+
+@test "merge-strategy rejects invalid strategies" {
+    run dolt merge-strategy test newest
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid merge strategy" ]] || false
+
+    run dolt merge-strategy test latest
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "requires a timestamp column" ]] || false
+
+    run dolt merge-strategy not_a_table ours
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table 'not_a_table' not found" ]] || false
+
+    run dolt merge-strategy -c not_a_column test ours
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "has no non-primary key column 'not_a_column'" ]] || false
+
+    run dolt merge-strategy -c pk test ours
+    [ "$status" -eq 1 ]
+
+    run dolt merge-strategy test latest -t not_a_column
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "has no column 'not_a_column'" ]] || false
+}
+
+@test "merge resolves conflicting cells using the declared strategies" {
+    dolt merge-strategy test theirs
+    dolt merge-strategy -c score test max
+    dolt merge-strategy -c hits test sum
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET name='theirs', score=5, hits=110 WHERE pk=1"
+    dolt sql -q "DELETE FROM test WHERE pk=2"
+    dolt add .
+    dolt commit -m "changed test on other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET name='ours', score=50, hits=120 WHERE pk=1"
+    dolt sql -q "UPDATE test SET name='modified' WHERE pk=2"
+    dolt add .
+    dolt commit -m "changed test on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Auto-resolved 1 conflict(s) in test.name using the 'theirs' strategy" ]] || false
+    [[ "$output" =~ "Auto-resolved 1 conflict(s) in test.score using the 'max' strategy" ]] || false
+    [[ "$output" =~ "Auto-resolved 1 conflict(s) in test.hits using the 'sum' strategy" ]] || false
+    [[ "$output" =~ "Auto-resolved 1 deleted and modified row(s) in test using the 'theirs' strategy" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[1]}" = "1,theirs,50,130,1" ]
+}
+
+@test "merge resolves conflicting cells using the latest timestamp" {
+    dolt merge-strategy test latest -t updated
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET name='theirs', updated=3 WHERE pk=1"
+    dolt sql -q "UPDATE test SET name='theirs', updated=2 WHERE pk=2"
+    dolt add .
+    dolt commit -m "changed test on other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET name='ours', updated=2 WHERE pk=1"
+    dolt sql -q "UPDATE test SET name='ours', updated=3 WHERE pk=2"
+    dolt add .
+    dolt commit -m "changed test on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Auto-resolved 2 conflict(s) in test.name using the 'latest' strategy" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,theirs,10,100,3" ]
+    [ "${lines[2]}" = "2,ours,20,200,3" ]
+}
+
+@test "merge resolves rows added on both sides using the declared strategies" {
+    dolt merge-strategy test theirs
+    dolt merge-strategy -c hits test sum
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test (pk, score, hits) VALUES (3, 5, 10)"
+    dolt add .
+    dolt commit -m "added a row on other"
+
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (3, 'ours', 50, 20, 1)"
+    dolt add .
+    dolt commit -m "added a row on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Auto-resolved 1 conflict(s) in test.hits using the 'sum' strategy" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 3" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "3,,5,30," ]
+}
+
+@test "merge reports conflicts in columns without a strategy" {
+    dolt merge-strategy -c score test max
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET name='theirs', score=5 WHERE pk=1"
+    dolt add .
+    dolt commit -m "changed test on other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET name='ours', score=50 WHERE pk=1"
+    dolt add .
+    dolt commit -m "changed test on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in test" ]] || false
+}
+
+@test "merge strategies can be declared using sql" {
+    dolt merge-strategy -c score test min
+    dolt sql -q "UPDATE dolt_merge_strategies SET strategy='max' WHERE column_name='score'"
+    dolt add .
+    dolt commit -m "declared merge strategies"
+
+    run dolt merge-strategy
+    [ "$status" -eq 0 ]
+    [ "$output" = "test.score: max" ]
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET score=5 WHERE pk=1"
+    dolt add .
+    dolt commit -m "changed test on other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET score=50 WHERE pk=1"
+    dolt add .
+    dolt commit -m "changed test on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Auto-resolved 1 conflict(s) in test.score using the 'max' strategy" ]] || false
+
+    run dolt sql -q "SELECT score FROM test WHERE pk=1" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "50" ]
+}
@@ -137,3 +137,141 @@ teardown() {
     run dolt merge merge_branch
     [ "$status" -eq 1 ]
 }
+
+@test "merge --no-ff creates a merge commit instead of fast-forwarding" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+
+    run dolt merge --no-ff merge_branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Fast-forward" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "Merge branch 'merge_branch'" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    [[ ! "$output" =~ "merging" ]] || false
+
+    run dolt sql -q 'select count(*) from test1 where pk = 0'
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| 1 " ]] || false
+}
+
+@test "merge --no-ff uses the message given with -m" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+    dolt SQL -q "INSERT INTO test2 values (0,1,2)"
+    dolt add test2
+    dolt commit -m "add pk 0 to test2"
+
+    run dolt merge --no-ff -m "merging the feature" merge_branch
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "merging the feature" ]] || false
+}
+
+@test "merge --no-ff does not commit a merge with conflicts" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+    dolt SQL -q "INSERT INTO test1 values (0,3,4)"
+    dolt add test1
+    dolt commit -m "add conflicting pk 0 to test1"
+
+    run dolt merge --no-ff merge_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add conflicting pk 0 to test1" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "You have unmerged tables" ]] || false
+}
+
+@test "merge --squash stages the changes without recording a merge" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt SQL -q "INSERT INTO test1 values (1,2,3)"
+    dolt add test1
+    dolt commit -m "add pk 1 to test1"
+    dolt checkout master
+
+    run dolt merge --squash merge_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Squash commit -- not updating HEAD" ]] || false
+    [[ ! "$output" =~ "Fast-forward" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added tables" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ ! "$output" =~ "merging" ]] || false
+
+    dolt commit -m "squashed merge_branch"
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "squashed merge_branch" ]] || false
+    [[ ! "$output" =~ "Merge:" ]] || false
+
+    run dolt sql -q 'select count(*) from test1'
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| 2 " ]] || false
+}
+
+@test "merge --no-commit stages the merge without updating the branch" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+
+    run dolt merge --no-commit merge_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "stopped before committing as requested" ]] || false
+    [[ ! "$output" =~ "Fast-forward" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added tables" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "still merging" ]] || false
+    [[ "$output" =~ "test1" ]] || false
+
+    dolt commit -m "merged merge_branch"
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "merged merge_branch" ]] || false
+}
+
+@test "merge --squash and --no-ff cannot be combined" {
+    dolt branch merge_branch
+    run dolt merge --squash --no-ff merge_branch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot combine --squash with --no-ff" ]] || false
+}
@@ -0,0 +1,110 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT PRIMARY KEY,
+  v1 DATE,
+  v2 DATETIME,
+  v3 VARCHAR(20),
+  v4 DECIMAL(10,2),
+  v5 DOUBLE,
+  v6 TINYINT UNSIGNED,
+  v7 JSON
+);
+INSERT INTO test VALUES
+    (1, '2020-04-08', '2020-04-08 11:11:11', 'first', 12.34, 1.5, 200, '{"a":1}'),
+    (2, '2020-04-09', '2020-04-09 12:12:12', 'second', -0.5, -2.25, 0, '[1,2]'),
+    (3, NULL, NULL, NULL, NULL, NULL, NULL, NULL);
+SQL
+    dolt add test
+    dolt commit -m "added test"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "parquet: export a table and import it into a new table" {
+    run dolt table export test test.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f test.parquet ]
+
+    run dolt table import -c test2 test.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 3" ]] || false
+
+    run dolt schema show test2
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`pk\` BIGINT NOT NULL" ]] || false
+    [[ "$output" =~ "\`v1\` DATE" ]] || false
+    [[ "$output" =~ "\`v2\` DATETIME" ]] || false
+    [[ "$output" =~ "\`v4\` DECIMAL(10,2)" ]] || false
+    [[ "$output" =~ "\`v5\` DOUBLE" ]] || false
+    [[ "$output" =~ "\`v6\` TINYINT UNSIGNED" ]] || false
+    [[ "$output" =~ "\`v7\` JSON" ]] || false
+    [[ "$output" =~ "PRIMARY KEY (\`pk\`)" ]] || false
+
+    dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv > expected.csv
+    dolt sql -q "SELECT * FROM test2 ORDER BY pk" -r csv > actual.csv
+    run diff expected.csv actual.csv
+    [ "$status" -eq 0 ]
+}
+
+@test "parquet: update and replace an existing table" {
+    dolt table export test test.parquet
+    dolt sql -q "DELETE FROM test WHERE pk > 1"
+    dolt sql -q "UPDATE test SET v3 = 'changed' WHERE pk = 1"
+
+    run dolt table import -u test test.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 2, Modifications: 1" ]] || false
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+
+    dolt sql -q "INSERT INTO test (pk) VALUES (4)"
+    run dolt table import -r test test.parquet
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
+@test "parquet: import with an explicit primary key" {
+    dolt table export test test.parquet
+
+    run dolt table import -c --pk v3 test2 test.parquet
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Bad Row" ]] || false
+
+    dolt sql -q "DELETE FROM test WHERE pk = 3"
+    dolt table export -f test test.parquet
+    run dolt table import -c --pk v3 test2 test.parquet
+    [ "$status" -eq 0 ]
+    run dolt schema show test2
+    [[ "$output" =~ "PRIMARY KEY (\`v3\`)" ]] || false
+
+    run dolt table import -c --pk missing test3 test.parquet
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "primary key 'missing' is not a column of the parquet file" ]] || false
+}
+
+@test "parquet: file-type parameter and invalid files" {
+    dolt table export --file-type parquet test test.out
+    run dolt table import -c --file-type parquet test2 test.out
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Additions: 3" ]] || false
+
+    echo "pk,v1" > bad.parquet
+    run dolt table import -c test3 bad.parquet
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Error creating reader" ]] || false
+}
+
+@test "parquet: cannot export to stdout" {
+    run dolt table export --file-type parquet test
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Cannot export this format to stdout" ]] || false
+}
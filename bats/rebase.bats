@@ -0,0 +1,303 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test(pk BIGINT PRIMARY KEY, v varchar(10))"
+    dolt add -A
+    dolt commit -m "Created table"
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (1, 'a')"
+    dolt add -A
+    dolt commit -m "Inserted 1"
+    dolt sql -q "INSERT INTO test VALUES (2, 'b')"
+    dolt add -A
+    dolt commit -m "Inserted 2"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (10, 'z')"
+    dolt add -A
+    dolt commit -m "Inserted 10"
+    dolt checkout feature
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "rebase: rebase a branch onto another branch" {
+    run dolt rebase master
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "1,a" ]] || false
+    [[ "$output" =~ "2,b" ]] || false
+    [[ "$output" =~ "10,z" ]] || false
+
+    run dolt log
+    [ "$status" -eq "0" ]
+    [[ "${lines[3]}" =~ "Inserted 2" ]] || false
+    [[ "${lines[7]}" =~ "Inserted 1" ]] || false
+    [[ "${lines[11]}" =~ "Inserted 10" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt rebase master
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "is up to date" ]] || false
+}
+
+@test "rebase: fails with a dirty working set" {
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    run dolt rebase master
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "your local changes would be overwritten" ]] || false
+}
+
+@test "rebase: continue and abort without a rebase in progress" {
+    run dolt rebase --continue
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "No rebase in progress" ]] || false
+
+    run dolt rebase --abort
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "No rebase in progress" ]] || false
+}
+
+@test "rebase: stop on conflicts and continue" {
+    dolt sql -q "INSERT INTO test VALUES (10, 'f')"
+    dolt add -A
+    dolt commit -m "Inserted 10 on feature"
+
+    run dolt rebase master
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "could not apply" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "You are currently rebasing" ]] || false
+
+    run dolt rebase --continue
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "resolve all conflicts" ]] || false
+
+    run dolt cherry-pick master
+    [ "$status" -eq "1" ]
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt rebase --continue
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 10" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "10,f" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "Inserted 10 on feature" ]] || false
+
+    run dolt status
+    [[ ! "$output" =~ "You are currently rebasing" ]] || false
+}
+
+@test "rebase: checkout, commit and merge are refused during a rebase" {
+    dolt sql -q "INSERT INTO test VALUES (10, 'f')"
+    dolt add -A
+    dolt commit -m "Inserted 10 on feature"
+
+    run dolt rebase master
+    [ "$status" -eq "1" ]
+
+    run dolt checkout master
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "checkout is not possible because a rebase is in progress" ]] || false
+
+    run dolt checkout -b other
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "checkout is not possible because a rebase is in progress" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+
+    run dolt commit -m "Resolved conflicts"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "commit is not possible because a rebase is in progress" ]] || false
+
+    run dolt merge master
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "merge is not possible because a rebase is in progress" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "On branch feature" ]] || false
+    [[ "$output" =~ "You are currently rebasing" ]] || false
+
+    run dolt rebase --continue
+    [ "$status" -eq "0" ]
+
+    run dolt checkout master
+    [ "$status" -eq "0" ]
+}
+
+@test "rebase: merges of upstream into the branch are not replayed" {
+    dolt merge master
+    dolt add -A
+    dolt commit -m "Merged master"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (11, 'y')"
+    dolt add -A
+    dolt commit -m "Inserted 11"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "1,a" ]] || false
+    [[ "$output" =~ "2,b" ]] || false
+    [[ "$output" =~ "10,z" ]] || false
+    [[ "$output" =~ "11,y" ]] || false
+
+    run dolt log
+    [ "$status" -eq "0" ]
+    [[ "${lines[3]}" =~ "Inserted 2" ]] || false
+    [[ "${lines[7]}" =~ "Inserted 1" ]] || false
+    [[ "${lines[11]}" =~ "Inserted 11" ]] || false
+    [[ "${lines[15]}" =~ "Inserted 10" ]] || false
+    [[ ! "$output" =~ "Merge" ]] || false
+}
+
+@test "rebase: abort restores the original branch" {
+    dolt sql -q "UPDATE test SET v = 'f' WHERE pk = 1"
+    dolt add -A
+    dolt commit -m "Updated 1 on feature"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (1, 'm')"
+    dolt add -A
+    dolt commit -m "Inserted 1 on master"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ "$status" -eq "1" ]
+
+    run dolt rebase --abort
+    [ "$status" -eq "0" ]
+
+    run dolt log -n 1
+    [[ "$output" =~ "Updated 1 on feature" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    [[ ! "$output" =~ "You are currently rebasing" ]] || false
+}
+
+@test "rebase: gc is refused during a rebase" {
+    dolt sql -q "UPDATE test SET v = 'f' WHERE pk = 1"
+    dolt add -A
+    dolt commit -m "Updated 1 on feature"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (1, 'm')"
+    dolt add -A
+    dolt commit -m "Inserted 1 on master"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ "$status" -eq "1" ]
+
+    run dolt gc
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "garbage collection is not possible because a rebase is in progress" ]] || false
+
+    run dolt rebase --abort
+    [ "$status" -eq "0" ]
+
+    run dolt log -n 1
+    [[ "$output" =~ "Updated 1 on feature" ]] || false
+
+    run dolt gc
+    [ "$status" -eq "0" ]
+}
+
+@test "rebase: interactive squash, drop and reword" {
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    dolt add -A
+    dolt commit -m "Inserted 3"
+    dolt sql -q "INSERT INTO test VALUES (4, 'd')"
+    dolt add -A
+    dolt commit -m "Inserted 4"
+
+    cat > "$BATS_TMPDIR/rebase_editor.sh" <<'SH'
+#!/bin/bash
+if grep -q "^pick" "$1"; then
+    sed -i -e 's/^pick \(.* Inserted 2\)$/squash \1/' -e 's/^pick \(.* Inserted 3\)$/drop \1/' -e 's/^pick \(.* Inserted 4\)$/reword \1/' "$1"
+else
+    sed -i 's/^Inserted 4$/Inserted four/' "$1"
+fi
+SH
+    chmod +x "$BATS_TMPDIR/rebase_editor.sh"
+
+    EDITOR="$BATS_TMPDIR/rebase_editor.sh" run dolt rebase -i master
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "2,b" ]] || false
+    [[ ! "$output" =~ "3,c" ]] || false
+    [[ "$output" =~ "4,d" ]] || false
+
+    run dolt log
+    [ "$status" -eq "0" ]
+    [[ "${lines[3]}" =~ "Inserted four" ]] || false
+    [[ "${lines[7]}" =~ "Inserted 1" ]] || false
+    [[ "${lines[9]}" =~ "Inserted 2" ]] || false
+    [[ "${lines[13]}" =~ "Inserted 10" ]] || false
+}
+
+@test "rebase: an empty or failed reword message stops the rebase" {
+    cat > "$BATS_TMPDIR/rebase_editor.sh" <<'SH'
+#!/bin/bash
+if grep -q "^pick" "$1"; then
+    sed -i 's/^pick/reword/' "$1"
+else
+    sed -i '/^Inserted 2$/d' "$1"
+fi
+SH
+    chmod +x "$BATS_TMPDIR/rebase_editor.sh"
+
+    EDITOR="$BATS_TMPDIR/rebase_editor.sh" run dolt rebase -i master
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "Aborting commit due to empty commit message." ]] || false
+    [[ "$output" =~ "dolt rebase --continue" ]] || false
+
+    EDITOR="false" run dolt rebase --continue
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "failed to get the commit message from the editor" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "Inserted 1" ]] || false
+
+    cat > "$BATS_TMPDIR/rebase_editor.sh" <<'SH'
+#!/bin/bash
+sed -i 's/^Inserted 2$/Inserted two/' "$1"
+SH
+    EDITOR="$BATS_TMPDIR/rebase_editor.sh" run dolt rebase --continue
+    [ "$status" -eq "0" ]
+
+    run dolt log -n 1
+    [[ "$output" =~ "Inserted two" ]] || false
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "2,b" ]] || false
+}
+
+@test "rebase: interactive with an empty todo list does nothing" {
+    EDITOR="sed -i -e d" run dolt rebase -i master
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Nothing to do" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "Inserted 2" ]] || false
+}
@@ -0,0 +1,173 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test(pk BIGINT PRIMARY KEY, v varchar(10))"
+    dolt add -A
+    dolt commit -m "Created table"
+    dolt sql -q "INSERT INTO test VALUES (1, 'a')"
+    dolt add -A
+    dolt commit -m "Inserted 1"
+    dolt sql -q "INSERT INTO test VALUES (2, 'b')"
+    dolt add -A
+    dolt commit -m "Inserted 2"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "revert: revert HEAD" {
+    run dolt revert HEAD
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "1,a" ]] || false
+    [[ ! "$output" =~ "2,b" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Revert 'Inserted 2'" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "revert: revert an ancestor commit" {
+    run dolt revert HEAD~1
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "1,a" ]] || false
+    [[ "$output" =~ "2,b" ]] || false
+}
+
+@test "revert: revert multiple commits" {
+    run dolt revert HEAD HEAD~1
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "1,a" ]] || false
+    [[ ! "$output" =~ "2,b" ]] || false
+
+    run dolt log -n 2
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Revert 'Inserted 2'" ]] || false
+    [[ "$output" =~ "Revert 'Inserted 1'" ]] || false
+}
+
+@test "revert: revert a table creation" {
+    run dolt revert HEAD~2
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "deleted and modified" ]] || false
+
+    dolt sql -q "CREATE TABLE test2(pk BIGINT PRIMARY KEY)"
+    dolt add -A
+    dolt commit -m "Created test2"
+    run dolt revert HEAD
+    [ "$status" -eq "0" ]
+
+    run dolt ls
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "test2" ]] || false
+}
+
+@test "revert: revert a commit after a table was dropped" {
+    dolt sql -q "CREATE TABLE other(pk BIGINT PRIMARY KEY)"
+    dolt add -A
+    dolt commit -m "Created other"
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    dolt add -A
+    dolt commit -m "Inserted 3"
+    dolt sql -q "DROP TABLE other"
+    dolt add -A
+    dolt commit -m "Dropped other"
+
+    run dolt revert HEAD~1
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "2,b" ]] || false
+    [[ ! "$output" =~ "3,c" ]] || false
+
+    run dolt ls
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "other" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Revert 'Inserted 3'" ]] || false
+}
+
+@test "revert: conflicts with later changes" {
+    dolt sql -q "UPDATE test SET v = 'c' WHERE pk = 2"
+    dolt add -A
+    dolt commit -m "Updated 2"
+    run dolt revert HEAD~1
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt conflicts cat test
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "ours" ]] || false
+    [[ "$output" =~ "theirs" ]] || false
+}
+
+@test "revert: fails with a dirty working set" {
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    run dolt revert HEAD
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "your local changes would be overwritten" ]] || false
+}
+
+@test "revert: cannot revert the initial commit" {
+    run dolt revert HEAD~3
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "no parents" ]] || false
+}
+
+@test "revert: revert a merge commit" {
+    dolt checkout -b branch1
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    dolt add -A
+    dolt commit -m "Inserted 3"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (4, 'd')"
+    dolt add -A
+    dolt commit -m "Inserted 4"
+    master_commit=$(dolt log -n 1 | head -n 1 | awk '{print $2}')
+    dolt merge branch1
+    dolt add -A
+    dolt commit -m "Merged branch1"
+
+    run dolt revert HEAD
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "no --mainline option was given" ]] || false
+
+    # the first parent is the branch that was merged into
+    run dolt log -n 1
+    [[ "$output" =~ "Merge: $master_commit" ]] || false
+
+    run dolt revert -m 1 HEAD
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "3,c" ]] || false
+    [[ "$output" =~ "4,d" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Revert 'Merged branch1'" ]] || false
+}
+
+@test "revert: mainline on a non-merge commit" {
+    run dolt revert -m 1 HEAD
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "is not a merge" ]] || false
+}
@@ -42,17 +42,18 @@ teardown() {
     run dolt diff
     [ "$status" -eq 0 ]
     [[ "${lines[0]}" =~ "diff --dolt a/test b/quiz" ]] || false
-    [[ "${lines[1]}" =~ "--- a/test @" ]] || false
-    [[ "${lines[2]}" =~ "+++ b/quiz @" ]] || false
+    [[ "${lines[1]}" =~ "renamed table" ]] || false
+    [[ "${lines[2]}" =~ "--- a/test @" ]] || false
+    [[ "${lines[3]}" =~ "+++ b/quiz @" ]] || false
     run dolt status
     [ "$status" -eq 0 ]
-    skip "table renames currently ignored by status"
-    [[ "$output" =~ "deleted:        test" ]] || false
-    [[ "$output" =~ "new table:      quiz" ]] || false
+    [[ "$output" =~ "Changes not staged for commit:" ]] || false
+    [[ "$output" =~ "renamed:        test -> quiz" ]] || false
     dolt add .
     run dolt status
     [ "$status" -eq 0 ]
-    [[ "$output" =~ "renamed:    test -> quiz" ]] || false
+    [[ "$output" =~ "Changes to be committed:" ]] || false
+    [[ "$output" =~ "renamed:        test -> quiz" ]] || false
 }
 
 @test "dolt schema rename column" {
@@ -0,0 +1,132 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE places (
+  pk BIGINT PRIMARY KEY,
+  loc POINT,
+  route LINESTRING,
+  area POLYGON,
+  shape GEOMETRY
+);
+INSERT INTO places VALUES
+  (1, ST_GeomFromText('POINT(1 2)'), ST_GeomFromText('LINESTRING(0 0,1 1,2 0)'), ST_GeomFromText('POLYGON((0 0,4 0,4 4,0 4,0 0))'), ST_GeomFromText('POINT(-122.4 37.8)', 4326)),
+  (2, ST_GeomFromText('POINT(7 8)'), NULL, NULL, ST_GeomFromText('LINESTRING(0 0,3 4)'));
+SQL
+    dolt add places
+    dolt commit -m "added places"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "spatial: columns have spatial types" {
+    run dolt schema show places
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "\`loc\` POINT" ]] || false
+    [[ "$output" =~ "\`route\` LINESTRING" ]] || false
+    [[ "$output" =~ "\`area\` POLYGON" ]] || false
+    [[ "$output" =~ "\`shape\` GEOMETRY" ]] || false
+    dolt sql -q "ALTER TABLE places ADD COLUMN loc2 point"
+    run dolt sql -q "DESCRIBE places" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "loc2,POINT" ]] || false
+}
+
+@test "spatial: ST_ functions" {
+    run dolt sql -q "SELECT pk, ST_AsText(loc), ST_X(loc), ST_Y(loc), ST_SRID(shape) FROM places ORDER BY pk" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "1,POINT(1 2),1,2,4326" ]] || false
+    [[ "${lines[2]}" = "2,POINT(7 8),7,8,0" ]] || false
+    run dolt sql -q "SELECT ST_Distance(loc, area), ST_Distance(loc, ST_GeomFromText('POINT(4 6)')), ST_Distance(loc, route) FROM places WHERE pk = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "0,5,1" ]] || false
+    run dolt sql -q "SELECT pk FROM places WHERE loc = ST_GeomFromText('POINT(7 8)')" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "2" ]] || false
+    run dolt sql -q "SELECT ST_Distance(shape, loc) FROM places WHERE pk = 1"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "different SRIDs" ]] || false
+    run dolt sql -q "SELECT ST_X(route) FROM places WHERE pk = 1"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "expected a POINT" ]] || false
+}
+
+@test "spatial: invalid values are rejected" {
+    run dolt sql -q "INSERT INTO places (pk, loc) VALUES (3, ST_GeomFromText('LINESTRING(0 0,1 1)'))"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "cannot store a LINESTRING in a POINT column" ]] || false
+    run dolt sql -q "INSERT INTO places (pk, area) VALUES (3, ST_GeomFromText('POLYGON((0 0,1 0,1 1))'))"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "invalid POLYGON" ]] || false
+    run dolt sql -q "INSERT INTO places (pk, loc) VALUES (3, ST_GeomFromText('POINT(1)'))"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "invalid WKT" ]] || false
+    run dolt sql -q "CREATE TABLE bad (g POINT PRIMARY KEY)"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "cannot be used in a primary key" ]] || false
+}
+
+@test "spatial: diff shows well-known text" {
+    dolt sql -q "UPDATE places SET loc = ST_GeomFromText('POINT(5 6)', 4326) WHERE pk = 1"
+    run dolt diff
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "POINT(1 2)" ]] || false
+    [[ "$output" =~ "SRID=4326;POINT(5 6)" ]] || false
+    run dolt diff -r sql
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "ST_GeomFromText('POINT(5 6)', 4326)" ]] || false
+}
+
+@test "spatial: export and import csv" {
+    query="SELECT pk, ST_AsText(loc), ST_AsText(route), ST_AsText(area), ST_AsText(shape), ST_SRID(shape) FROM places ORDER BY pk"
+    expected=$(dolt sql -q "$query" -r csv)
+    dolt table export places places.csv
+    run cat places.csv
+    [[ "$output" =~ '1,POINT(1 2),"LINESTRING(0 0,1 1,2 0)","POLYGON((0 0,4 0,4 4,0 4,0 0))",SRID=4326;POINT(-122.4 37.8)' ]] || false
+    dolt sql -q "DELETE FROM places"
+    run dolt table import -u places places.csv
+    [ "$status" -eq "0" ]
+    run dolt sql -q "$query" -r csv
+    [ "$status" -eq "0" ]
+    [ "$output" = "$expected" ]
+}
+
+@test "spatial: export and import json" {
+    query="SELECT pk, ST_AsText(loc), ST_AsText(route), ST_AsText(area), ST_AsText(shape), ST_SRID(shape) FROM places ORDER BY pk"
+    expected=$(dolt sql -q "$query" -r csv)
+    dolt table export places places.json
+    run cat places.json
+    [[ "$output" =~ '"shape":{"type":"Point","coordinates":[-122.4,37.8]}' ]] || false
+    [[ "$output" =~ '"loc":"POINT(7 8)"' ]] || false
+    dolt sql -q "DELETE FROM places"
+    run dolt table import -u places places.json
+    [ "$status" -eq "0" ]
+    run dolt sql -q "$query" -r csv
+    [ "$status" -eq "0" ]
+    [ "$output" = "$expected" ]
+}
+
+@test "spatial: import GeoJSON geometries" {
+    cat <<JSON > places.json
+{"rows": [{"pk": 3, "loc": {"type": "Point", "coordinates": [10.5, -20, 100]}, "area": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}}]}
+JSON
+    run dolt table import -u places places.json
+    [ "$status" -eq "0" ]
+    run dolt sql -q "SELECT ST_AsText(loc), ST_SRID(loc), ST_AsText(area) FROM places WHERE pk = 3" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = 'POINT(10.5 -20),4326,"POLYGON((0 0,1 0,1 1,0 0))"' ]] || false
+}
+
+@test "spatial: sql export can be imported" {
+    dolt table export places places.sql
+    mkdir imported && cd imported
+    dolt init
+    dolt sql < ../places.sql
+    run dolt sql -q "SELECT ST_AsText(shape), ST_SRID(shape) FROM places WHERE pk = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "POINT(-122.4 37.8),4326" ]] || false
+}
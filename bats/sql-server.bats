@@ -147,6 +147,33 @@ teardown() {
     server_query 0 "SET @@repo1_head=hashof('test_branch');SELECT * FROM one_pk ORDER by pk" ";pk,c1,c2\n0,None,None\n1,1,None\n2,2,2\n3,3,3"
 }
 
+@test "test sessions on branches" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    cd repo1
+    dolt sql -q "CREATE TABLE one_pk (pk BIGINT NOT NULL COMMENT 'tag:0', c1 BIGINT COMMENT 'tag:1', PRIMARY KEY (pk))"
+    dolt add .
+    dolt commit -m "create one_pk"
+    dolt branch feature
+    start_sql_server repo1
+
+    # changes made in a session connected to repo1/feature are committed to feature
+    DEFAULT_DB="repo1/feature"
+    multi_query 1 "
+    INSERT INTO one_pk (pk,c1) VALUES (0,0),(1,1);
+    SELECT COMMIT('insert on feature')"
+    server_query 1 "SELECT * FROM one_pk ORDER BY pk" "pk,c1\n0,0\n1,1"
+
+    # and are not made to master or its working set
+    DEFAULT_DB="repo1"
+    server_query 1 "SELECT * FROM one_pk" "pk,c1"
+    server_query 1 "SELECT latest_commit_message FROM dolt_branches WHERE name = 'feature'" "latest_commit_message\ninsert on feature"
+
+    # a session may switch to feature using USE or DOLT_CHECKOUT
+    server_query 1 "USE \`repo1/feature\`;SELECT * FROM one_pk ORDER BY pk" ";pk,c1\n0,0\n1,1"
+    server_query 1 "SELECT DOLT_CHECKOUT('feature') IS NOT NULL AS checked_out;SELECT * FROM one_pk ORDER BY pk" "checked_out\n1;pk,c1\n0,0\n1,1"
+}
+
 @test "test multi db with use statements" {
     skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
 
@@ -737,3 +737,67 @@ SQL
     [ $status -eq 0 ]
     [[ "$output" =~ "2020-02-17 00:00:00" ]] || false
 }
+
+@test "sql commit author is taken from session variables" {
+    run dolt sql -r csv -q "SELECT @@dolt_user_name, @@dolt_user_email"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "$(current_dolt_user_name),$(current_dolt_user_email)" ]] || false
+
+    run dolt sql -r csv <<SQL
+SET @@dolt_user_name = 'Alice';
+SET @@dolt_user_email = 'alice@example.com';
+SELECT COMMIT('commit by alice') AS h;
+SQL
+    [ $status -eq 0 ]
+    HASH=${lines[1]}
+
+    run dolt log $HASH
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Author: Alice <alice@example.com>" ]] || false
+
+    run dolt sql <<SQL
+SET @@dolt_user_email = '';
+SELECT COMMIT('no author');
+SQL
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Set @@dolt_user_name and @@dolt_user_email" ]] || false
+}
+
+@test "sql dolt_checkout commits to the branch checked out" {
+    dolt add .
+    dolt commit -m "add tables"
+    dolt branch feature
+
+    run dolt sql <<SQL
+SELECT DOLT_CHECKOUT('feature') IS NOT NULL;
+INSERT INTO one_pk (pk,c1,c2,c3,c4,c5) VALUES (10,10,10,10,10,10);
+SELECT COMMIT('insert on feature') IS NOT NULL;
+SQL
+    [ $status -eq 0 ]
+
+    run dolt log feature
+    [ $status -eq 0 ]
+    [[ "$output" =~ "insert on feature" ]] || false
+
+    # the row was not added to master or its working set
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    run dolt sql -r csv -q "SELECT COUNT(*) FROM one_pk WHERE pk = 10"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+    run dolt sql -r csv -q "SELECT COUNT(*) FROM one_pk WHERE pk = 10" feature
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt sql <<SQL
+SELECT DOLT_CHECKOUT('feature') IS NOT NULL;
+INSERT INTO one_pk (pk,c1,c2,c3,c4,c5) VALUES (11,11,11,11,11,11);
+SELECT DOLT_CHECKOUT('master');
+SQL
+    [ $status -ne 0 ]
+    [[ "$output" =~ "uncommitted changes on branch feature" ]] || false
+
+    run dolt sql -q "SELECT DOLT_CHECKOUT('missing')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "branch not found: missing" ]] || false
+}
@@ -0,0 +1,173 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test(pk BIGINT PRIMARY KEY, v varchar(10))"
+    dolt sql -q "INSERT INTO test VALUES (1, 'a')"
+    dolt add -A
+    dolt commit -m "Created table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "stash: nothing to stash" {
+    run dolt stash
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "No local changes to save" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq "0" ]
+    [ "$output" = "" ]
+}
+
+@test "stash: stash and pop staged and unstaged changes" {
+    dolt sql -q "INSERT INTO test VALUES (2, 'b')"
+    dolt add test
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    dolt sql -q "CREATE TABLE test2(pk BIGINT PRIMARY KEY)"
+
+    run dolt stash
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Saved working directory and index state WIP on master" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt ls
+    [[ ! "$output" =~ "test2" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "stash@{0}: WIP on master" ]] || false
+
+    run dolt stash pop
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Dropped stash@{0}" ]] || false
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "2,b" ]] || false
+    [[ "$output" =~ "3,c" ]] || false
+
+    run dolt ls
+    [[ "$output" =~ "test2" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "Changes not staged for commit" ]] || false
+
+    run dolt stash list
+    [ "$output" = "" ]
+}
+
+@test "stash: switch branches with stashed changes" {
+    dolt branch other
+    dolt sql -q "INSERT INTO test VALUES (2, 'b')"
+    dolt stash
+    dolt checkout other
+    run dolt stash apply
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "2,b" ]] || false
+
+    run dolt stash list
+    [[ "$output" =~ "stash@{0}" ]] || false
+}
+
+@test "stash: list, apply and drop by index" {
+    dolt sql -q "INSERT INTO test VALUES (2, 'b')"
+    dolt stash -m "first"
+    dolt sql -q "INSERT INTO test VALUES (3, 'c')"
+    dolt stash -m "second"
+
+    run dolt stash list
+    [ "$status" -eq "0" ]
+    [[ "${lines[0]}" =~ "stash@{0}: On master: second" ]] || false
+    [[ "${lines[1]}" =~ "stash@{1}: On master: first" ]] || false
+
+    run dolt stash apply stash@{1}
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "2,b" ]] || false
+    [[ ! "$output" =~ "3,c" ]] || false
+
+    run dolt stash drop stash@{1}
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "Dropped stash@{1}" ]] || false
+
+    run dolt stash list
+    [ "${#lines[@]}" -eq "1" ]
+    [[ "${lines[0]}" =~ "second" ]] || false
+
+    run dolt stash drop stash@{3}
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "is not a valid stash" ]] || false
+
+    dolt stash drop
+    run dolt stash pop
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "no stash entries found" ]] || false
+}
+
+@test "stash: conflicts when popping keep the stash" {
+    dolt sql -q "UPDATE test SET v = 's' WHERE pk = 1"
+    dolt stash
+    dolt sql -q "UPDATE test SET v = 'w' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "Updated 1"
+
+    run dolt stash pop
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "The stash entry is kept" ]] || false
+
+    run dolt conflicts cat test
+    [[ "$output" =~ "ours" ]] || false
+    [[ "$output" =~ "theirs" ]] || false
+
+    run dolt stash list
+    [[ "$output" =~ "stash@{0}" ]] || false
+}
+
+@test "stash: errors merging the staged changes keep the stash" {
+    dolt sql -q "INSERT INTO test VALUES (2, 'b')"
+    dolt add test
+    dolt stash
+    dolt sql -q "DROP TABLE test"
+    dolt add test
+    dolt checkout test
+
+    run dolt stash pop
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "deleted and modified" ]] || false
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ ! "$output" =~ "2,b" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq "0" ]
+    [ "${#lines[@]}" -eq 1 ]
+}
+
+@test "stash: stash changes to docs" {
+    echo "readme" > README.md
+    dolt add README.md
+    dolt commit -m "Added README"
+    echo "changed" > README.md
+
+    run dolt stash
+    [ "$status" -eq "0" ]
+    [[ "$(cat README.md)" = "readme" ]] || false
+
+    run dolt stash pop
+    [ "$status" -eq "0" ]
+    [[ "$(cat README.md)" = "changed" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "README.md" ]] || false
+}
@@ -139,7 +139,6 @@ SQL
 }
 
 @test "types: BLOB" {
-    skip "This is not yet persisted in dolt"
     dolt sql <<SQL
 CREATE TABLE test (
   pk BIGINT NOT NULL,
@@ -619,6 +618,27 @@ SQL
     [[ "$output" =~ "\`v\` INT UNSIGNED" ]] || false
 }
 
+@test "types: JSON" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v JSON,
+  PRIMARY KEY (pk)
+);
+SQL
+    run dolt schema show
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "\`v\` JSON" ]] || false
+    dolt sql -q "INSERT INTO test VALUES (1, '{\"b\": 2, \"a\": [1, 2.0]}');"
+    run dolt sql -q "SELECT * FROM test"
+    [ "$status" -eq "0" ]
+    [[ "${lines[3]}" =~ ' {"a":[1,2],"b":2} ' ]] || false
+    dolt sql -q "UPDATE test SET v='[\"abc\", null]' WHERE pk=1;"
+    run dolt sql -q "SELECT * FROM test"
+    [ "$status" -eq "0" ]
+    [[ "${lines[3]}" =~ ' ["abc",null] ' ]] || false
+}
+
 @test "types: LONG" {
     dolt sql <<SQL
 CREATE TABLE test (
@@ -646,7 +666,6 @@ SQL
 }
 
 @test "types: LONGBLOB" {
-    skip "This is not yet persisted in dolt"
     dolt sql <<SQL
 CREATE TABLE test (
   pk BIGINT NOT NULL,
@@ -689,7 +708,6 @@ SQL
 }
 
 @test "types: MEDIUMBLOB" {
-    skip "This is not yet persisted in dolt"
     dolt sql <<SQL
 CREATE TABLE test (
   pk BIGINT NOT NULL,
@@ -1067,7 +1085,6 @@ SQL
 }
 
 @test "types: TINYBLOB" {
-    skip "This is not yet persisted in dolt"
     dolt sql <<SQL
 CREATE TABLE test (
   pk BIGINT NOT NULL,
@@ -1252,4 +1269,49 @@ SQL
     [ "$status" -eq "1" ]
     run dolt sql -q "INSERT INTO test VALUES (2, '2156');"
     [ "$status" -eq "1" ]
-}
\ No newline at end of file
+}
+@test "types: large TEXT values are summarized in diffs" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v LONGTEXT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add test
+    dolt commit -m "created table"
+    doc=$(printf 'dolt %.0s' {1..2000})
+    dolt sql -q "INSERT INTO test VALUES (1, '$doc'), (2, 'short');"
+    run dolt sql -q "SELECT pk, LENGTH(v) FROM test WHERE pk = 1" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "1,10000" ]] || false
+    run dolt diff
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "<TEXT 10000 bytes #" ]] || false
+    [[ "$output" =~ " short " ]] || false
+    [[ ! "$output" =~ "dolt dolt" ]] || false
+    run dolt diff -r sql
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "dolt dolt" ]] || false
+}
+
+@test "types: TEXT columns can be indexed but BLOB columns cannot" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  t TEXT,
+  b BLOB,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 'a', 'a'), (2, 'b', 'b');
+SQL
+    dolt sql -q "CREATE INDEX idx_t ON test (t);"
+    run dolt sql -q "SELECT pk FROM test WHERE t = 'b'" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "2" ]] || false
+    run dolt sql -q "CREATE INDEX idx_b ON test (b);"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "BLOB column \`b\` cannot be used in an index" ]] || false
+    run dolt sql -q "CREATE TABLE test2 (pk BLOB PRIMARY KEY);"
+    [ "$status" -eq "1" ]
+}
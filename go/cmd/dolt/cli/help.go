@@ -184,7 +184,11 @@ func OptionsUsage(ap *argparser.ArgParser, indent string, lineLen int) string {
 	for _, supOpt := range ap.Supported {
 		argHelpFmt := "--%[2]s"
 
-		if supOpt.Abbrev != "" && supOpt.ValDesc != "" {
+		if supOpt.OptType == argparser.OptionalFlagOrValue && supOpt.Abbrev != "" {
+			argHelpFmt = "-%[1]s[=<%[3]s>], --%[2]s[=<%[3]s>]"
+		} else if supOpt.OptType == argparser.OptionalFlagOrValue {
+			argHelpFmt = "--%[2]s[=<%[3]s>]"
+		} else if supOpt.Abbrev != "" && supOpt.ValDesc != "" {
 			argHelpFmt = "-%[1]s <%[3]s>, --%[2]s=<%[3]s>"
 		} else if supOpt.Abbrev != "" {
 			argHelpFmt = "-%[1]s, --%[2]s"
@@ -37,7 +37,9 @@ import (
 
 var blameDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Show what revision and author last modified each row of a table`,
-	LongDesc:  `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.`,
+	LongDesc: `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.
+
+The rows of a table without a primary key are identified by their values. Each distinct row is annotated with the revision which last changed the number of copies of the row.`,
 	Synopsis: []string{
 		`[{{.LessThan}}rev{{.GreaterThan}}] {{.LessThan}}tablename{{.GreaterThan}}`,
 	},
@@ -48,6 +50,10 @@ type blameInfo struct {
 	// Key represents the primary key of the row
 	Key types.Value
 
+	// KeyStrs are the values identifying the row when it is displayed. These are the primary key values of the row, or
+	// all of its values if its table has no primary key.
+	KeyStrs []string
+
 	// CommitHash is the commit hash of the commit which last modified the row
 	CommitHash string
 
@@ -223,7 +229,12 @@ func blameGraphFromCommit(ctx context.Context, dEnv *env.DoltEnv, commit *doltdb
 
 	nbf := tbl.Format()
 
-	blameGraph, err := blameGraphFromRows(ctx, nbf, rows)
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blameGraph, err := blameGraphFromRows(ctx, nbf, sch, rows)
 	if err != nil {
 		return nil, err
 	}
@@ -250,7 +261,7 @@ ROWLOOP:
 			}
 		}
 		// didn't find blame for a row...something's wrong
-		return nil, fmt.Errorf("couldn't find blame for row with primary key %v", strings.Join(getPKStrs(ctx, node.Key), ", "))
+		return nil, fmt.Errorf("couldn't find blame for row with primary key %v", strings.Join(node.KeyStrs, ", "))
 	}
 
 	return blameGraph, nil
@@ -399,11 +410,14 @@ func schemaFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) (
 }
 
 func pkColNamesFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) ([]string, error) {
-	schema, err := schemaFromCommit(ctx, c, tableName)
+	sch, err := schemaFromCommit(ctx, c, tableName)
 	if err != nil {
 		return nil, fmt.Errorf("error getting schema for commit: %v", err)
 	}
-	return schema.GetPKCols().GetColumnNames(), nil
+	if schema.IsKeyless(sch) {
+		return sch.GetAllCols().GetColumnNames(), nil
+	}
+	return sch.GetPKCols().GetColumnNames(), nil
 }
 
 // rowChanged returns true if the row identified by `rowPK` changed between the parent-child commit pair
@@ -455,17 +469,28 @@ func rowChanged(ctx context.Context, input blameInput, rowPK types.Value) (bool,
 		return true, nil
 	}
 
-	return !row.AreEqual(*parentRow, *childRow, input.ParentSchema), nil
+	// a row of a keyless table changes when the number of copies of the row changes
+	return !row.AreEqual(*parentRow, *childRow, input.ParentSchema) || row.Cardinality(*parentRow) != row.Cardinality(*childRow), nil
 }
 
-func blameGraphFromRows(ctx context.Context, nbf *types.NomsBinFormat, rows types.Map) (*blameGraph, error) {
+func blameGraphFromRows(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, rows types.Map) (*blameGraph, error) {
 	graph := make(blameGraph)
 	err := rows.IterAll(ctx, func(key, val types.Value) error {
 		hash, err := key.Hash(nbf)
 		if err != nil {
 			return err
 		}
-		graph[hash] = blameInfo{Key: key}
+
+		keyStrs := getPKStrs(ctx, key)
+		if schema.IsKeyless(sch) {
+			r, err := row.FromNoms(sch, key.(types.Tuple), val.(types.Tuple))
+			if err != nil {
+				return err
+			}
+			keyStrs = getValStrs(r, sch)
+		}
+
+		graph[hash] = blameInfo{Key: key, KeyStrs: keyStrs}
 		return nil
 	})
 	if err != nil {
@@ -494,6 +519,7 @@ func (bg *blameGraph) AssignBlame(rowPK types.Value, nbf *types.NomsBinFormat, c
 
 	(*bg)[pkHash] = blameInfo{
 		Key:         rowPK,
+		KeyStrs:     (*bg)[pkHash].KeyStrs,
 		CommitHash:  commitHash.String(),
 		Author:      meta.Name,
 		Description: meta.Description,
@@ -517,6 +543,20 @@ func getPKStrs(ctx context.Context, pk types.Value) (strs []string) {
 	return strs
 }
 
+// getValStrs returns the values of all of a row's columns
+func getValStrs(r row.Row, sch schema.Schema) (strs []string) {
+	_, _ = r.IterSchema(sch, func(tag uint64, val types.Value) (stop bool, err error) {
+		if types.IsNull(val) {
+			strs = append(strs, "<NULL>")
+		} else {
+			strs = append(strs, fmt.Sprintf("%v", val))
+		}
+		return false, nil
+	})
+
+	return strs
+}
+
 func truncateString(str string, maxLength int) string {
 	if maxLength < 0 || len(str) <= maxLength {
 		return str
@@ -547,7 +587,7 @@ func (bg *blameGraph) String(ctx context.Context, pkColNames []string) string {
 	t := table.NewWriter()
 	t.AppendHeader(header)
 	for _, v := range *bg {
-		pkVals := getPKStrs(ctx, v.Key)
+		pkVals := v.KeyStrs
 		dataVals := []string{
 			truncateString(v.Description, 50),
 			v.Author,
@@ -98,7 +98,9 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 
 	if newBranch, newBranchOk := apr.GetValue(coBranchArg); newBranchOk {
 		var verr errhand.VerboseError
-		if len(newBranch) == 0 {
+		if verr = checkNoRebaseInProgress(dEnv, "checkout"); verr != nil {
+			return HandleVErrAndExitCode(verr, usagePrt)
+		} else if len(newBranch) == 0 {
 			verr = errhand.BuildDError("error: cannot checkout empty string").Build()
 		} else {
 			verr = checkoutNewBranch(ctx, dEnv, newBranch, apr)
@@ -117,7 +119,12 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 		verr := errhand.BuildDError("error: unable to determine type of checkout").AddCause(err).Build()
 		return HandleVErrAndExitCode(verr, usagePrt)
 	} else if isBranch {
-		verr := checkoutBranch(ctx, dEnv, name)
+		verr := checkNoRebaseInProgress(dEnv, "checkout")
+
+		if verr == nil {
+			verr = checkoutBranch(ctx, dEnv, name)
+		}
+
 		return HandleVErrAndExitCode(verr, usagePrt)
 	}
 
@@ -141,6 +148,10 @@ func checkoutRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, name string) e
 	if ref, refExists, err := getRemoteBranchRef(ctx, dEnv, name); err != nil {
 		return errhand.BuildDError("fatal: unable to read from data repository.").AddCause(err).Build()
 	} else if refExists {
+		if verr := checkNoRebaseInProgress(dEnv, "checkout"); verr != nil {
+			return verr
+		}
+
 		return checkoutNewBranchFromStartPt(ctx, dEnv, name, ref.String())
 	} else {
 		return errhand.BuildDError("error: could not find %s", name).Build()
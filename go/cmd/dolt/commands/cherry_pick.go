@@ -0,0 +1,242 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var cherryPickDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply the changes introduced by an existing commit.",
+	LongDesc: `Applies the changes introduced by {{.LessThan}}commit{{.GreaterThan}} to the current branch, and records a new commit with the original commit message.
+
+The changes are computed with a three-way merge which uses the parent of {{.LessThan}}commit{{.GreaterThan}} as the common ancestor of the current {{.EmphasisLeft}}HEAD{{.EmphasisRight}} and {{.LessThan}}commit{{.GreaterThan}}. The working set must be clean before cherry-picking.
+
+If the changes cannot be applied cleanly, the merged tables and their conflicts are left in the working set and no commit is made. Resolve the conflicts using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}, then add the affected tables using {{.EmphasisLeft}}dolt add{{.EmphasisRight}} and commit the result using {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}.`,
+	Synopsis: []string{
+		`{{.LessThan}}commit{{.GreaterThan}}`,
+	},
+}
+
+type CherryPickCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CherryPickCmd) Name() string {
+	return "cherry-pick"
+}
+
+// Description returns a description of the command
+func (cmd CherryPickCmd) Description() string {
+	return cherryPickDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd CherryPickCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+}
+
+func (cmd CherryPickCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit whose changes should be applied to the current branch."})
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd CherryPickCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_CHERRY_PICK
+}
+
+// Exec executes the command
+func (cmd CherryPickCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	verr := checkCanApplyCommit(ctx, dEnv, "cherry-pick")
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, apr.Arg(0))
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	mergedRoot, tblToStats, verr := cherryPick(ctx, dEnv, cm)
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build(), usage)
+	}
+
+	return commitMergedRoot(ctx, dEnv, mergedRoot, tblToStats, meta.Description, usage)
+}
+
+// cherryPick merges the changes between |cm| and its parent into the HEAD root of the current branch.
+func cherryPick(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) (*doltdb.RootValue, map[string]*merge.MergeStats, errhand.VerboseError) {
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to get parents of commit").AddCause(err).Build()
+	} else if numParents == 0 {
+		return nil, nil, errhand.BuildDError("error: cannot cherry-pick a commit without parents").Build()
+	} else if numParents > 1 {
+		return nil, nil, errhand.BuildDError("error: cannot cherry-pick a merge commit").Build()
+	}
+
+	parent, err := dEnv.DoltDB.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to get parent of commit").AddCause(err).Build()
+	}
+
+	return mergeCommitChanges(ctx, dEnv, cm, parent)
+}
+
+// mergeCommitChanges applies the changes needed to go from the root of |from| to the root of |to| to the HEAD root of
+// the current branch using a three-way merge.
+func mergeCommitChanges(ctx context.Context, dEnv *env.DoltEnv, to, from *doltdb.Commit) (*doltdb.RootValue, map[string]*merge.MergeStats, errhand.VerboseError) {
+	headRoot, err := dEnv.HeadRoot(ctx)
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to get head root").AddCause(err).Build()
+	}
+
+	toRoot, err := to.GetRootValue()
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to get root of commit").AddCause(err).Build()
+	}
+
+	fromRoot, err := from.GetRootValue()
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to get root of commit").AddCause(err).Build()
+	}
+
+	mergedRoot, tblToStats, err := merge.MergeRoots(ctx, headRoot, toRoot, fromRoot, dEnv.DoltDB.ValueReadWriter())
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to apply the changes of the commit").AddCause(err).Build()
+	}
+
+	return mergedRoot, tblToStats, nil
+}
+
+// checkCanApplyCommit returns an error if the working set is not in a state where the changes of another commit can
+// be applied to it.
+func checkCanApplyCommit(ctx context.Context, dEnv *env.DoltEnv, operation string) errhand.VerboseError {
+	if dEnv.IsMergeActive() {
+		return errhand.BuildDError("error: %s is not possible because you have not committed an active merge.", operation).Build()
+	}
+
+	if verr := checkNoRebaseInProgress(dEnv, operation); verr != nil {
+		return verr
+	}
+
+	stagedTbls, notStagedTbls, err := diff.GetTableDiffs(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to determine the state of the working set").AddCause(err).Build()
+	}
+
+	if len(stagedTbls.Tables) != 0 || len(notStagedTbls.Tables) != 0 {
+		bdr := errhand.BuildDError("error: your local changes would be overwritten by %s.", operation)
+		bdr.AddDetails("hint: commit your changes or reset them to proceed.")
+		return bdr.Build()
+	}
+
+	return nil
+}
+
+// commitMergedRoot updates the working set to |mergedRoot|.  If there are no conflicts, the merged root is also staged
+// and committed with the message given, otherwise the conflicts are left for the user to resolve.
+func commitMergedRoot(ctx context.Context, dEnv *env.DoltEnv, mergedRoot *doltdb.RootValue, tblToStats map[string]*merge.MergeStats, msg string, usage cli.UsagePrinter) int {
+	headRoot, err := dEnv.HeadRoot(ctx)
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get head root").AddCause(err).Build(), usage)
+	}
+
+	if headHash, err := headRoot.HashOf(); err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get head root").AddCause(err).Build(), usage)
+	} else if mergedHash, err := mergedRoot.HashOf(); err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to hash merged root").AddCause(err).Build(), usage)
+	} else if headHash == mergedHash {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: no changes to commit, the changes of the commit are already applied.").Build(), usage)
+	}
+
+	verr := UpdateWorkingWithVErr(dEnv, mergedRoot)
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	err = actions.SaveDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build(), usage)
+	}
+
+	if hasConflicts := printSuccessStats(tblToStats); hasConflicts {
+		cli.Println("Automatic merge failed; fix conflicts and then commit the result.")
+		return 1
+	}
+
+	verr = UpdateStagedWithVErr(dEnv, mergedRoot)
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	err = actions.CommitStaged(ctx, dEnv, actions.CommitStagedProps{
+		Message:          msg,
+		Date:             time.Now(),
+		AllowEmpty:       false,
+		CheckForeignKeys: true,
+		CheckConstraints: true,
+	})
+
+	if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	return LogCmd{}.Exec(ctx, "log", []string{"-n=1"}, dEnv)
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnstcmds
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var addDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Adds a CHECK constraint to a table.",
+	LongDesc: `{{.EmphasisLeft}}dolt constraints add{{.EmphasisRight}} adds a CHECK constraint with the given name to a table in the working set. The expression is a SQL boolean expression over the columns of the table, such as {{.EmphasisLeft}}"age >= 0 AND age < 150"{{.EmphasisRight}}. +
+
+Rows are rejected when the expression evaluates to false, while rows where it evaluates to NULL are allowed. Every existing row of the table must satisfy the constraint for it to be added.`,
+	Synopsis: []string{
+		"{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}expression{{.GreaterThan}}",
+	},
+}
+
+type AddCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd AddCmd) Name() string {
+	return "add"
+}
+
+// Description returns a description of the command
+func (cmd AddCmd) Description() string {
+	return "Adds a CHECK constraint to a table."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd AddCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return commands.CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, addDocs, ap))
+}
+
+func (cmd AddCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table to add the constraint to."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the constraint."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"expression", "The SQL expression that every row of the table must satisfy."})
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd AddCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_CONSTRAINTS
+}
+
+// Exec executes the command
+func (cmd AddCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, addDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 3 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("The table, constraint name and expression must be provided.").Build(), usage)
+	}
+
+	return commands.HandleVErrAndExitCode(addCheck(ctx, dEnv, apr.Arg(0), apr.Arg(1), apr.Arg(2)), usage)
+}
+
+func addCheck(ctx context.Context, dEnv *env.DoltEnv, tableName, name, expression string) errhand.VerboseError {
+	working, table, sch, verr := getWorkingTable(ctx, dEnv, tableName)
+	if verr != nil {
+		return verr
+	}
+
+	check, err := sch.Checks().AddCheck(name, expression)
+	if err != nil {
+		return errhand.BuildDError("Unable to add the constraint.").AddCause(err).Build()
+	}
+	cc, err := doltdb.NewCheckConstraint(tableName, sch, check)
+	if err != nil {
+		return errhand.BuildDError("Unable to add the constraint.").AddCause(err).Build()
+	}
+
+	rowData, err := table.GetRowData(ctx)
+	if err != nil {
+		return errhand.BuildDError("Unable to read the rows of `%s`.", tableName).AddCause(err).Build()
+	}
+	err = cc.ValidateData(ctx, rowData)
+	if err != nil {
+		return errhand.BuildDError("Existing rows of `%s` do not satisfy the constraint.", tableName).AddCause(err).Build()
+	}
+
+	return updateWorkingSchema(ctx, dEnv, working, tableName, table, sch)
+}
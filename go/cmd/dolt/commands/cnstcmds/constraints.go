@@ -0,0 +1,68 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnstcmds
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+)
+
+var Commands = cli.NewSubCommandHandler("constraints", "Commands for adding, dropping and listing the CHECK constraints of tables.", []cli.Command{
+	AddCmd{},
+	DropCmd{},
+	LsCmd{},
+})
+
+// getWorkingTable returns the working root, along with the table of the given name and its schema.
+func getWorkingTable(ctx context.Context, dEnv *env.DoltEnv, tableName string) (*doltdb.RootValue, *doltdb.Table, schema.Schema, errhand.VerboseError) {
+	working, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return nil, nil, nil, errhand.BuildDError("Unable to get working.").AddCause(err).Build()
+	}
+	table, ok, err := working.GetTable(ctx, tableName)
+	if err != nil {
+		return nil, nil, nil, errhand.BuildDError("Unable to get table `%s`.", tableName).AddCause(err).Build()
+	}
+	if !ok {
+		return nil, nil, nil, errhand.BuildDError("The table `%s` does not exist.", tableName).Build()
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, nil, errhand.BuildDError("Unable to get schema for `%s`.", tableName).AddCause(err).Build()
+	}
+	return working, table, sch, nil
+}
+
+// updateWorkingSchema writes the table with the given schema to the working set.
+func updateWorkingSchema(ctx context.Context, dEnv *env.DoltEnv, working *doltdb.RootValue, tableName string, table *doltdb.Table, sch schema.Schema) errhand.VerboseError {
+	table, err := table.UpdateSchema(ctx, sch)
+	if err != nil {
+		return errhand.BuildDError("Unable to update the schema of `%s`.", tableName).AddCause(err).Build()
+	}
+	working, err = working.PutTable(ctx, tableName, table)
+	if err != nil {
+		return errhand.BuildDError("Unable to update the table `%s`.", tableName).AddCause(err).Build()
+	}
+	err = dEnv.UpdateWorkingRoot(ctx, working)
+	if err != nil {
+		return errhand.BuildDError("Unable to update the working set.").AddCause(err).Build()
+	}
+	return nil
+}
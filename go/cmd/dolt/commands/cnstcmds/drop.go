@@ -0,0 +1,88 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnstcmds
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var dropDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Drops a CHECK constraint from a table.",
+	LongDesc:  `{{.EmphasisLeft}}dolt constraints drop{{.EmphasisRight}} removes the CHECK constraint with the given name from a table in the working set.`,
+	Synopsis: []string{
+		"{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+	},
+}
+
+type DropCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd DropCmd) Name() string {
+	return "drop"
+}
+
+// Description returns a description of the command
+func (cmd DropCmd) Description() string {
+	return "Drops a CHECK constraint from a table."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd DropCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return commands.CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, dropDocs, ap))
+}
+
+func (cmd DropCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table to drop the constraint from."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the constraint."})
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd DropCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_CONSTRAINTS
+}
+
+// Exec executes the command
+func (cmd DropCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, dropDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 2 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("Both the table and constraint names must be provided.").Build(), usage)
+	}
+
+	tableName := apr.Arg(0)
+	working, table, sch, verr := getWorkingTable(ctx, dEnv, tableName)
+	if verr == nil {
+		if _, err := sch.Checks().DropCheck(apr.Arg(1)); err != nil {
+			verr = errhand.BuildDError("Unable to drop the constraint.").AddCause(err).Build()
+		} else {
+			verr = updateWorkingSchema(ctx, dEnv, working, tableName, table, sch)
+		}
+	}
+
+	return commands.HandleVErrAndExitCode(verr, usage)
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnstcmds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var lsDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Lists the CHECK constraints of tables.",
+	LongDesc:  `{{.EmphasisLeft}}dolt constraints ls{{.EmphasisRight}} lists the CHECK constraints of the tables in the working set. You may provide a table name to only list the constraints of that table.`,
+	Synopsis: []string{
+		"[{{.LessThan}}table{{.GreaterThan}}]",
+	},
+}
+
+type LsCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd LsCmd) Name() string {
+	return "ls"
+}
+
+// Description returns a description of the command
+func (cmd LsCmd) Description() string {
+	return "Lists the CHECK constraints of tables."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd LsCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return commands.CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, lsDocs, ap))
+}
+
+func (cmd LsCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table to list the constraints of. If one is not specified, then the constraints of all tables are listed."})
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd LsCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_CONSTRAINTS
+}
+
+// Exec executes the command
+func (cmd LsCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, lsDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() > 1 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("Only one table may be provided at a time.").Build(), usage)
+	}
+
+	working, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to get working.").AddCause(err).Build(), nil)
+	}
+
+	tableNames := apr.Args()
+	if len(tableNames) == 0 {
+		tableNames, err = working.GetTableNames(ctx)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to get tables.").AddCause(err).Build(), nil)
+		}
+		sort.Strings(tableNames)
+	}
+
+	found := false
+	for _, tableName := range tableNames {
+		_, _, sch, verr := getWorkingTable(ctx, dEnv, tableName)
+		if verr != nil {
+			return commands.HandleVErrAndExitCode(verr, nil)
+		}
+		for _, check := range sch.Checks().AllChecks() {
+			cli.Println(fmt.Sprintf("%s\t%s\t%s", tableName, check.Name, check.Expression))
+			found = true
+		}
+	}
+
+	if !found {
+		cli.Println("No check constraints in the working set.")
+	}
+
+	return 0
+}
@@ -80,7 +80,7 @@ func (cmd CommitCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsString(commitMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the commit message.")
 	ap.SupportsFlag(allowEmptyFlag, "", "Allow recording a commit that has the exact same data as its sole parent. This is usually a mistake, so it is disabled by default. This option bypasses that safety.")
 	ap.SupportsString(dateParam, "", "date", "Specify the date used in the commit. If not specified the current system time is used.")
-	ap.SupportsFlag(forceFlag, "f", "Ignores any foreign key warnings and proceeds with the commit.")
+	ap.SupportsFlag(forceFlag, "f", "Ignores any foreign key warnings and check constraint violations and proceeds with the commit.")
 	return ap
 }
 
@@ -90,6 +90,10 @@ func (cmd CommitCmd) Exec(ctx context.Context, commandStr string, args []string,
 	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, commitDocs, ap))
 	apr := cli.ParseArgs(ap, args, help)
 
+	if verr := checkNoRebaseInProgress(dEnv, "commit"); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
 	msg, msgOk := apr.GetValue(commitMessageArg)
 	if !msgOk {
 		msg = getCommitMessageFromEditor(ctx, dEnv)
@@ -110,6 +114,7 @@ func (cmd CommitCmd) Exec(ctx context.Context, commandStr string, args []string,
 		Date:             t,
 		AllowEmpty:       apr.Contains(allowEmptyFlag),
 		CheckForeignKeys: !apr.Contains(forceFlag),
+		CheckConstraints: !apr.Contains(forceFlag),
 	})
 	if err == nil {
 		// if the commit was successful, print it out using the log command
@@ -188,19 +193,25 @@ func handleCommitErr(ctx context.Context, dEnv *env.DoltEnv, err error, usage cl
 func getCommitMessageFromEditor(ctx context.Context, dEnv *env.DoltEnv) string {
 	var finalMsg string
 	initialMsg := buildInitalCommitMsg(ctx, dEnv)
-	backupEd := "vim"
-	if ed, edSet := os.LookupEnv("EDITOR"); edSet {
-		backupEd = ed
-	}
-	editorStr := dEnv.Config.GetStringOrDefault(env.DoltEditor, backupEd)
+	editorStr := getEditorString(dEnv)
 
 	cli.ExecuteWithStdioRestored(func() {
-		commitMsg, _ := editor.OpenCommitEditor(*editorStr, initialMsg)
+		commitMsg, _ := editor.OpenCommitEditor(editorStr, initialMsg)
 		finalMsg = parseCommitMessage(commitMsg)
 	})
 	return finalMsg
 }
 
+// getEditorString returns the editor configured for dolt, falling back to $EDITOR and then vim
+func getEditorString(dEnv *env.DoltEnv) string {
+	backupEd := "vim"
+	if ed, edSet := os.LookupEnv("EDITOR"); edSet {
+		backupEd = ed
+	}
+
+	return *dEnv.Config.GetStringOrDefault(env.DoltEditor, backupEd)
+}
+
 func buildInitalCommitMsg(ctx context.Context, dEnv *env.DoltEnv) string {
 	initialNoColor := color.NoColor
 	color.NoColor = true
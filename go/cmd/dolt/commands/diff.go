@@ -16,6 +16,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -61,6 +62,8 @@ const (
 
 	TabularDiffOutput diffOutput = 1
 	SQLDiffOutput     diffOutput = 2
+	JSONDiffOutput    diffOutput = 3
+	CSVDiffOutput     diffOutput = 4
 
 	DataFlag    = "data"
 	SchemaFlag  = "schema"
@@ -68,8 +71,12 @@ const (
 	whereParam  = "where"
 	limitParam  = "limit"
 	SQLFlag     = "sql"
+
+	FindRenamesFlag = "find-renames"
 )
 
+const findRenamesDesc = "Find tables which were dropped and added with the same columns and at least {{.EmphasisLeft}}n{{.EmphasisRight}} percent of their rows in common, and show them as renamed. Defaults to 50%."
+
 type DiffSink interface {
 	GetSchema() schema.Schema
 	ProcRowWithProps(r row.Row, props pipeline.ReadableMap) error
@@ -90,8 +97,12 @@ Show changes between the working and staged tables, changes between the working
 {{.EmphasisLeft}}dolt diff [--options] <commit> <commit> [<tables>...]{{.EmphasisRight}}
    This is to view the changes between two arbitrary {{.EmphasisLeft}}commit{{.EmphasisRight}}.
 
+Tables renamed with {{.EmphasisLeft}}dolt table mv{{.EmphasisRight}} or {{.EmphasisLeft}}RENAME TABLE{{.EmphasisRight}}, and columns renamed without otherwise being changed, are shown as renamed. A table which was copied to a new table and then dropped is shown as a drop and an add, unless {{.EmphasisLeft}}--find-renames[=n]{{.EmphasisRight}} is given. It finds the dropped tables which have the same columns as an added table and at least {{.EmphasisLeft}}n{{.EmphasisRight}} percent of its rows, which defaults to 50%, and shows them as renamed.
+
 The diffs displayed can be limited to show the first N by providing the parameter {{.EmphasisLeft}}--limit N{{.EmphasisRight}} where {{.EmphasisLeft}}N{{.EmphasisRight}} is the number of diffs to display.
 
+Diffs can be written for other programs to read by providing {{.EmphasisLeft}}-r json{{.EmphasisRight}} or {{.EmphasisLeft}}-r csv{{.EmphasisRight}}. Every changed row is written with a {{.EmphasisLeft}}diff_type{{.EmphasisRight}} of added, removed or modified, and its values before and after the change. JSON output is a single document with an element for each changed table, holding the column changes of its schema as {{.EmphasisLeft}}schema_diff{{.EmphasisRight}} and its row changes as {{.EmphasisLeft}}data_diff{{.EmphasisRight}}. Renamed tables and columns have a {{.EmphasisLeft}}diff_type{{.EmphasisRight}} of renamed. CSV output has a header line and block of lines for each changed table, separated by blank lines. It shows only the data changes unless {{.EmphasisLeft}}--schema{{.EmphasisRight}} is given.
+
 In order to filter which diffs are displayed {{.EmphasisLeft}}--where key=value{{.EmphasisRight}} can be used.  The key in this case would be either {{.EmphasisLeft}}to_COLUMN_NAME{{.EmphasisRight}} or {{.EmphasisLeft}}from_COLUMN_NAME{{.EmphasisRight}}. where {{.EmphasisLeft}}from_COLUMN_NAME=value{{.EmphasisRight}} would filter based on the original value and {{.EmphasisLeft}}to_COLUMN_NAME{{.EmphasisRight}} would select based on its updated value.
 `,
 	Synopsis: []string{
@@ -108,6 +119,10 @@ type diffArgs struct {
 	limit      int
 	where      string
 	query      string
+	// findRenames is set if tables which were dropped and added with at least minSimilarity percent of their rows in
+	// common should be shown as renamed
+	findRenames   bool
+	minSimilarity int
 }
 
 type DiffCmd struct{}
@@ -138,13 +153,32 @@ func (cmd DiffCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(DataFlag, "d", "Show only the data changes, do not show the schema changes (Both shown by default).")
 	ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 	ap.SupportsFlag(SummaryFlag, "", "Show summary of data changes")
-	ap.SupportsString(formatFlag, "r", "result output format", "How to format diff output. Valid values are tabular & sql. Defaults to tabular. ")
+	ap.SupportsString(formatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json & csv. Defaults to tabular. ")
 	ap.SupportsString(whereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 	ap.SupportsInt(limitParam, "", "record_count", "limits to the first N diffs.")
 	ap.SupportsString(queryFlag, "q", "query", "diffs the results of a query at two commits")
+	ap.SupportsFlagWithOptionalValue(FindRenamesFlag, "M", "n", findRenamesDesc)
 	return ap
 }
 
+// parseFindRenames returns the rename similarity threshold given with --find-renames, as a percentage, and whether
+// the option was given at all. The threshold may be given with or without a trailing %.
+func parseFindRenames(apr *argparser.ArgParseResults) (int, bool, error) {
+	val, ok := apr.GetValue(FindRenamesFlag)
+	if !ok {
+		return 0, false, nil
+	} else if val == "" {
+		return diff.DefaultRenameSimilarity, true, nil
+	}
+
+	n, err := strconv.Atoi(strings.TrimSuffix(val, "%"))
+	if err != nil || n < 0 || n > 100 {
+		return 0, false, fmt.Errorf("invalid Arguments: --%s must be a percentage from 0 to 100, not '%s'", FindRenamesFlag, val)
+	}
+
+	return n, true, nil
+}
+
 // Exec executes the command
 func (cmd DiffCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
 	ap := cmd.createArgParser()
@@ -168,6 +202,11 @@ func (cmd DiffCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
+	if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == CSVDiffOutput {
+		// docs are not written in formats meant to be read by other programs
+		return 0
+	}
+
 	err = diffDoltDocs(ctx, dEnv, fromRoot, toRoot, dArgs)
 
 	if err != nil {
@@ -209,10 +248,17 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 
 	f, _ := apr.GetValue(formatFlag)
 	switch strings.ToLower(f) {
-	case "tablular":
+	case "tabular", "tablular":
 		dArgs.diffOutput = TabularDiffOutput
 	case "sql":
 		dArgs.diffOutput = SQLDiffOutput
+	case "json":
+		dArgs.diffOutput = JSONDiffOutput
+	case "csv":
+		dArgs.diffOutput = CSVDiffOutput
+		if !apr.Contains(SchemaFlag) {
+			dArgs.diffParts = DataOnlyDiff
+		}
 	case "":
 		dArgs.diffOutput = TabularDiffOutput
 	default:
@@ -222,6 +268,8 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 	if apr.Contains(SummaryFlag) {
 		if apr.Contains(SchemaFlag) || apr.Contains(DataFlag) {
 			return nil, nil, nil, fmt.Errorf("invalid Arguments: --summary cannot be combined with --schema or --data")
+		} else if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == CSVDiffOutput {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --summary cannot be combined with --%s %s", formatFlag, f)
 		}
 		dArgs.diffParts = Summary
 	}
@@ -229,6 +277,12 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 	dArgs.limit, _ = apr.GetInt(limitParam)
 	dArgs.where = apr.GetValueOrDefault(whereParam, "")
 
+	dArgs.minSimilarity, dArgs.findRenames, err = parseFindRenames(apr)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	from, to, leftover, err := getDiffRoots(ctx, dEnv, apr.Args())
 
 	if err != nil {
@@ -352,6 +406,18 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 		return errhand.BuildDError("error: unable to diff tables").AddCause(err).Build()
 	}
 
+	if dArgs.findRenames {
+		tableDeltas, err = diff.FindRenamedTables(ctx, tableDeltas, dArgs.minSimilarity)
+		if err != nil {
+			return errhand.BuildDError("error: unable to find renamed tables").AddCause(err).Build()
+		}
+	}
+
+	if dArgs.diffOutput == JSONDiffOutput {
+		cli.Print(`{"tables":[`)
+	}
+
+	numTables := 0
 	for _, td := range tableDeltas {
 
 		if !dArgs.tableSet.Contains(td.FromName) && !dArgs.tableSet.Contains(td.ToName) {
@@ -359,6 +425,9 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 		}
 
 		tblName := td.ToName
+		if td.IsDrop() {
+			tblName = td.FromName
+		}
 		fromTable := td.FromTable
 		toTable := td.ToTable
 
@@ -385,6 +454,16 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 			return errhand.BuildDError("cannot retrieve schema for table %s", td.ToName).AddCause(err).Build()
 		}
 
+		if dArgs.diffOutput == JSONDiffOutput {
+			if numTables > 0 {
+				cli.Print(",")
+			}
+			cli.Printf(`{"from_name":%s,"to_name":%s,"diff_type":%s`, jsonTableName(td.FromName), jsonTableName(td.ToName), jsonTableDiffType(td))
+		} else if dArgs.diffOutput == CSVDiffOutput && numTables > 0 {
+			cli.Println()
+		}
+		numTables++
+
 		fromMap, toMap, err := td.GetMaps(ctx)
 		if err != nil {
 			return errhand.BuildDError("could not get row data for table %s", td.ToName).AddCause(err).Build()
@@ -396,26 +475,67 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 		}
 
 		if dArgs.diffParts&SchemaOnlyDiff != 0 {
+			if dArgs.diffOutput == JSONDiffOutput {
+				cli.Print(`,"schema_diff":`)
+			}
 			verr = diffSchemas(ctx, td, dArgs)
 		}
 
-		if dArgs.diffParts&DataOnlyDiff != 0 {
+		if verr == nil && dArgs.diffParts&DataOnlyDiff != 0 {
 			if td.IsDrop() && dArgs.diffOutput == SQLDiffOutput {
 				continue // don't output DELETE FROM statements after DROP TABLE
-			} else if td.IsAdd() {
+			} else if td.IsAdd() && dArgs.diffOutput == SQLDiffOutput {
 				fromSch = toSch
 			}
-			verr = diffRows(ctx, fromMap, toMap, fromSch, toSch, dArgs, tblName)
+
+			if dArgs.diffOutput == JSONDiffOutput {
+				cli.Print(`,"data_diff":`)
+			} else if dArgs.diffOutput == CSVDiffOutput && dArgs.diffParts&SchemaOnlyDiff != 0 {
+				cli.Println()
+			}
+			verr = diffRows(ctx, toRoot.VRW(), fromMap, toMap, fromSch, toSch, dArgs, tblName)
 		}
 
 		if verr != nil {
 			return verr
 		}
+
+		if dArgs.diffOutput == JSONDiffOutput {
+			cli.Print("}")
+		}
+	}
+
+	if dArgs.diffOutput == JSONDiffOutput {
+		cli.Println("]}")
 	}
 
 	return nil
 }
 
+// jsonTableName returns the name of a table as a JSON string, or null if the table does not exist.
+func jsonTableName(tblName string) string {
+	if tblName == "" {
+		return "null"
+	}
+
+	data, _ := json.Marshal(tblName)
+	return string(data)
+}
+
+// jsonTableDiffType returns the diff_type of a table delta as a JSON string.
+func jsonTableDiffType(td diff.TableDelta) string {
+	switch {
+	case td.IsAdd():
+		return `"` + diff.DiffTypeAdded + `"`
+	case td.IsDrop():
+		return `"` + diff.DiffTypeRemoved + `"`
+	case td.IsRename():
+		return `"` + diff.DiffTypeRenamed + `"`
+	default:
+		return `"` + diff.DiffTypeModified + `"`
+	}
+}
+
 func diffSchemas(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.VerboseError {
 	if dArgs.diffOutput == TabularDiffOutput {
 		fromSch, toSch, err := td.GetSchemas(ctx)
@@ -434,11 +554,38 @@ func diffSchemas(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errha
 		diffs, unionTags := diff.DiffSchemas(fromSch, toSch)
 
 		return tabularSchemaDiff(td.ToName, unionTags, diffs)
+	} else if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == CSVDiffOutput {
+		return structuredSchemaDiff(ctx, td, dArgs)
 	}
 
 	return sqlSchemaDiff(ctx, td)
 }
 
+// structuredSchemaDiff writes the column changes of a table as JSON or CSV. All of the columns of an added or dropped
+// table are written as added or removed.
+func structuredSchemaDiff(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.VerboseError {
+	fromSch, toSch, err := td.GetSchemas(ctx)
+	if err != nil {
+		return errhand.BuildDError("cannot retrieve schema for table %s", td.ToName).AddCause(err).Build()
+	}
+
+	if dArgs.diffOutput == JSONDiffOutput {
+		err = diff.WriteJSONSchemaDiff(cli.CliOut, fromSch, toSch)
+	} else {
+		tblName := td.ToName
+		if td.IsDrop() {
+			tblName = td.FromName
+		}
+		err = diff.WriteCSVSchemaDiff(iohelp.NopWrCloser(cli.CliOut), fromSch, toSch, tblName)
+	}
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to write schema diff for table %s", td.ToName).AddCause(err).Build()
+	}
+
+	return nil
+}
+
 func tabularSchemaDiff(tableName string, tags []uint64, diffs map[uint64]diff.SchemaDifference) errhand.VerboseError {
 	cli.Println("  CREATE TABLE", tableName, "(")
 
@@ -465,6 +612,13 @@ func tabularSchemaDiff(tableName string, tags []uint64, diffs map[uint64]diff.Sc
 				oldPks = append(oldPks, sqlfmt.QuoteIdentifier(dff.Old.Name))
 			}
 			cli.Println(color.RedString("- " + sqlfmt.FmtColWithTag(2, 0, 0, *dff.Old)))
+		case diff.SchDiffColRenamed:
+			if dff.New.IsPartOfPK {
+				newPks = append(newPks, sqlfmt.QuoteIdentifier(dff.New.Name))
+				oldPks = append(oldPks, sqlfmt.QuoteIdentifier(dff.Old.Name))
+			}
+			renamedFrom := " -- renamed from " + sqlfmt.QuoteIdentifier(dff.Old.Name)
+			cli.Println(color.YellowString("~ " + sqlfmt.FmtColWithTag(2, 0, 0, *dff.New) + renamedFrom))
 		case diff.SchDiffColModified:
 			// changed in sch2
 			oldSqlType := dff.Old.TypeInfo.ToSqlType()
@@ -496,8 +650,8 @@ func tabularSchemaDiff(tableName string, tags []uint64, diffs map[uint64]diff.Sc
 				}
 				cli.Println(sqlfmt.FmtColWithTag(4, 0, 0, *dff.New))
 			} else {
-				cli.Println("< " + sqlfmt.FmtColWithNameAndType(2, nameLen, typeLen, n0, t0, *dff.Old))
-				cli.Println("> " + sqlfmt.FmtColWithNameAndType(2, nameLen, typeLen, n1, t1, *dff.New))
+				cli.Println("< " + fmtColComment(sqlfmt.FmtColWithNameAndType(2, nameLen, typeLen, n0, t0, *dff.Old), *dff.Old))
+				cli.Println("> " + fmtColComment(sqlfmt.FmtColWithNameAndType(2, nameLen, typeLen, n1, t1, *dff.New), *dff.New))
 			}
 		}
 	}
@@ -517,6 +671,14 @@ func tabularSchemaDiff(tableName string, tags []uint64, diffs map[uint64]diff.Sc
 	return nil
 }
 
+// fmtColComment appends the comment of a column, if it has one, to its formatted definition.
+func fmtColComment(colStr string, col schema.Column) string {
+	if len(col.Comment) == 0 {
+		return colStr
+	}
+	return colStr + " COMMENT " + sqlfmt.QuoteComment(col.Comment)
+}
+
 func sqlSchemaDiff(ctx context.Context, td diff.TableDelta) errhand.VerboseError {
 	fromSch, toSch, err := td.GetSchemas(ctx)
 	if err != nil {
@@ -544,9 +706,19 @@ func sqlSchemaDiff(ctx context.Context, td diff.TableDelta) errhand.VerboseError
 			case diff.SchDiffColAdded:
 				cli.Println(sqlfmt.AlterTableAddColStmt(td.ToName, sqlfmt.FmtCol(0, 0, 0, *cd.New)))
 			case diff.SchDiffColRemoved:
-				cli.Print(sqlfmt.AlterTableDropColStmt(td.ToName, cd.Old.Name))
+				cli.Println(sqlfmt.AlterTableDropColStmt(td.ToName, cd.Old.Name))
+			case diff.SchDiffColRenamed:
+				cli.Println(sqlfmt.AlterTableRenameColStmt(td.ToName, cd.Old.Name, cd.New.Name))
 			case diff.SchDiffColModified:
-				cli.Print(sqlfmt.AlterTableRenameColStmt(td.ToName, cd.Old.Name, cd.New.Name))
+				if cd.Old.Name != cd.New.Name {
+					cli.Println(sqlfmt.AlterTableRenameColStmt(td.ToName, cd.Old.Name, cd.New.Name))
+				}
+
+				renamed := *cd.Old
+				renamed.Name = cd.New.Name
+				if !renamed.Equals(*cd.New) {
+					cli.Println(sqlfmt.AlterTableModifyColStmt(td.ToName, sqlfmt.FmtCol(0, 0, 0, *cd.New)))
+				}
 			}
 		}
 	}
@@ -582,7 +754,7 @@ func fromNamer(name string) string {
 	return diff.From + "_" + name
 }
 
-func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch schema.Schema, dArgs *diffArgs, tblName string) errhand.VerboseError {
+func diffRows(ctx context.Context, vrw types.ValueReadWriter, fromRows, toRows types.Map, fromSch, toSch schema.Schema, dArgs *diffArgs, tblName string) errhand.VerboseError {
 	joiner, err := rowconv.NewJoiner(
 		[]rowconv.NamedSchema{
 			{Name: diff.From, Sch: fromSch},
@@ -595,7 +767,7 @@ func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch sc
 		return errhand.BuildDError("").AddCause(err).Build()
 	}
 
-	unionSch, ds, verr := createSplitter(fromSch, toSch, joiner, dArgs)
+	unionSch, ds, verr := createSplitter(ctx, vrw, fromSch, toSch, joiner, dArgs)
 	if verr != nil {
 		return verr
 	}
@@ -626,9 +798,14 @@ func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch sc
 	}
 
 	var sink DiffSink
-	if dArgs.diffOutput == TabularDiffOutput {
+	switch dArgs.diffOutput {
+	case TabularDiffOutput:
 		sink, err = diff.NewColorDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, numHeaderRows)
-	} else {
+	case JSONDiffOutput:
+		sink, err = diff.NewJSONDiffSink(iohelp.NopWrCloser(cli.CliOut), fromSch, toSch)
+	case CSVDiffOutput:
+		sink, err = diff.NewCSVDiffSink(iohelp.NopWrCloser(cli.CliOut), fromSch, toSch, tblName)
+	default:
 		sink, err = diff.NewSQLDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, tblName)
 	}
 
@@ -644,12 +821,12 @@ func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch sc
 		return true
 	}
 
-	p, verr := buildPipeline(dArgs, joiner, ds, unionSch, src, sink, badRowCallback)
+	p, verr := buildPipeline(ctx, vrw, dArgs, joiner, ds, unionSch, src, sink, badRowCallback)
 	if verr != nil {
 		return verr
 	}
 
-	if dArgs.diffOutput != SQLDiffOutput {
+	if dArgs.diffOutput == TabularDiffOutput {
 		if schemasEqual {
 			schRow, err := untyped.NewRowFromTaggedStrings(toRows.Format(), unionSch, newColNames)
 
@@ -688,10 +865,10 @@ func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch sc
 	return nil
 }
 
-func buildPipeline(dArgs *diffArgs, joiner *rowconv.Joiner, ds *diff.DiffSplitter, untypedUnionSch schema.Schema, src *diff.RowDiffSource, sink DiffSink, badRowCB pipeline.BadRowCallback) (*pipeline.Pipeline, errhand.VerboseError) {
+func buildPipeline(ctx context.Context, vrw types.ValueReadWriter, dArgs *diffArgs, joiner *rowconv.Joiner, ds *diff.DiffSplitter, untypedUnionSch schema.Schema, src *diff.RowDiffSource, sink DiffSink, badRowCB pipeline.BadRowCallback) (*pipeline.Pipeline, errhand.VerboseError) {
 	var where FilterFn
 	var selTrans *SelectTransform
-	where, err := ParseWhere(joiner.GetSchema(), dArgs.where)
+	where, err := ParseWhere(ctx, vrw, joiner.GetSchema(), dArgs.where)
 
 	if err != nil {
 		return nil, errhand.BuildDError("error: failed to parse where clause").AddCause(err).SetPrintUsage().Build()
@@ -754,7 +931,7 @@ func mapTagToColName(sch, untypedUnionSch schema.Schema) (map[uint64]string, err
 	return tagToCol, nil
 }
 
-func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.Joiner, dArgs *diffArgs) (schema.Schema, *diff.DiffSplitter, errhand.VerboseError) {
+func createSplitter(ctx context.Context, vrw types.ValueReadWriter, fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.Joiner, dArgs *diffArgs) (schema.Schema, *diff.DiffSplitter, errhand.VerboseError) {
 
 	var unionSch schema.Schema
 	if dArgs.diffOutput == TabularDiffOutput {
@@ -779,29 +956,40 @@ func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.
 		unionSch = toSch
 	}
 
+	// json and csv output write each version of a row with the names and types of its columns in that version, so old
+	// rows are not converted to the union schema
+	oldDestSch := unionSch
+	if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == CSVDiffOutput {
+		oldDestSch = fromSch
+	}
+
 	newToUnionConv := rowconv.IdentityConverter
-	if toSch != nil {
+	// a table which was added or dropped has no rows to convert from the version in which it does not exist
+	if toSch != nil && toSch.GetAllCols().Size() > 0 {
 		newToUnionMapping, err := rowconv.TagMapping(toSch, unionSch)
 
 		if err != nil {
 			return nil, nil, errhand.BuildDError("Error creating unioned mapping").AddCause(err).Build()
 		}
 
-		newToUnionConv, _ = rowconv.NewRowConverter(newToUnionMapping)
+		newToUnionConv, _ = rowconv.NewRowConverter(ctx, vrw, newToUnionMapping)
 	}
 
 	oldToUnionConv := rowconv.IdentityConverter
-	if fromSch != nil {
-		oldToUnionMapping, err := rowconv.TagMapping(fromSch, unionSch)
+	if fromSch != nil && fromSch.GetAllCols().Size() > 0 {
+		oldToUnionMapping, err := rowconv.TagMapping(fromSch, oldDestSch)
 
 		if err != nil {
 			return nil, nil, errhand.BuildDError("Error creating unioned mapping").AddCause(err).Build()
 		}
 
-		oldToUnionConv, _ = rowconv.NewRowConverter(oldToUnionMapping)
+		oldToUnionConv, _ = rowconv.NewRowConverter(ctx, vrw, oldToUnionMapping)
 	}
 
 	ds := diff.NewDiffSplitter(joiner, oldToUnionConv, newToUnionConv)
+	if dArgs.diffOutput == TabularDiffOutput {
+		ds.ShowCellDiffSummaries()
+	}
 	return unionSch, ds, nil
 }
 
@@ -903,6 +1091,10 @@ func printTableDiffSummary(td diff.TableDelta) {
 		_, _ = bold.Println("added table")
 	} else {
 		_, _ = bold.Printf("diff --dolt a/%s b/%s\n", td.FromName, td.ToName)
+		if td.IsRename() {
+			_, _ = bold.Println("renamed table")
+		}
+
 		h1, err := td.FromTable.HashOf()
 
 		if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/earl"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/datas"
 )
 
 const (
@@ -222,6 +223,34 @@ func fetchRefSpecs(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEn
 		}
 	}
 
+	srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	return fetchFollowTags(ctx, dEnv, srcDB, dEnv.DoltDB)
+}
+
+// fetchFollowTags fetches all tags from the source DB that don't exist in the destination DB.  Tags are immutable, so
+// local tags are never overwritten by remote tags with the same name.
+func fetchFollowTags(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB) errhand.VerboseError {
+	err := actions.IterResolvedTags(ctx, srcDB, func(tag *doltdb.Tag) (bool, error) {
+		wg, progChan, pullerEventCh := runProgFuncs()
+		err := actions.FetchTag(ctx, dEnv, srcDB, destDB, tag, progChan, pullerEventCh)
+		stopProgFuncs(wg, progChan, pullerEventCh)
+
+		if err == doltdb.ErrUpToDate || err == datas.ErrTagExists {
+			return false, nil
+		}
+
+		return false, err
+	})
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to fetch tags").AddCause(err).Build()
+	}
+
 	return nil
 }
 
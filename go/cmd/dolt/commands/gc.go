@@ -0,0 +1,127 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+var gcDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Cleans up unreferenced data from the repository.",
+	LongDesc: `Searches the repository for data that is no longer referenced and no longer needed.
+
+Data that is reachable from a branch, remote tracking branch, or any other ref is kept, as is the data in the working set and the staging area. Everything else is removed from disk. This typically reclaims space after commands such as {{.EmphasisLeft}}dolt reset --hard{{.EmphasisRight}}, {{.EmphasisLeft}}dolt branch -d{{.EmphasisRight}} or a large {{.EmphasisLeft}}dolt table import{{.EmphasisRight}}.`,
+	Synopsis: []string{
+		"",
+	},
+}
+
+type GarbageCollectionCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd GarbageCollectionCmd) Name() string {
+	return "gc"
+}
+
+// Description returns a description of the command
+func (cmd GarbageCollectionCmd) Description() string {
+	return gcDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd GarbageCollectionCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, gcDocs, ap))
+}
+
+func (cmd GarbageCollectionCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd GarbageCollectionCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_GARBAGE_COLLECTION
+}
+
+// Exec executes the command
+func (cmd GarbageCollectionCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, gcDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 0 {
+		usage()
+		return 1
+	}
+
+	// the original head and the commits left to replay are only referenced by the repo state during a rebase
+	verr := checkNoRebaseInProgress(dEnv, "garbage collection")
+
+	if verr == nil {
+		verr = garbageCollect(ctx, dEnv)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func garbageCollect(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	before, err := dEnv.DoltDB.Size(ctx)
+
+	if err == chunks.ErrUnsupportedOperation {
+		return errhand.BuildDError("error: this database does not support garbage collection").Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to get the size of the database").AddCause(err).Build()
+	}
+
+	keepers := []hash.Hash{dEnv.RepoState.WorkingHash(), dEnv.RepoState.StagedHash()}
+	if dEnv.IsMergeActive() {
+		keepers = append(keepers, hash.Parse(dEnv.RepoState.Merge.PreMergeWorking))
+	}
+
+	err = dEnv.DoltDB.GC(ctx, keepers...)
+
+	if err == chunks.ErrUnsupportedOperation {
+		return errhand.BuildDError("error: this database does not support garbage collection").Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to garbage collect the database").AddCause(err).Build()
+	}
+
+	after, err := dEnv.DoltDB.Size(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get the size of the database").AddCause(err).Build()
+	}
+
+	var reclaimed uint64
+	if after < before {
+		reclaimed = before - after
+	}
+
+	cli.Printf("Reclaimed %s. Database size is now %s.\n", humanize.Bytes(reclaimed), humanize.Bytes(after))
+
+	return nil
+}
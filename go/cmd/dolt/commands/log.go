@@ -17,68 +17,58 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
+	"regexp"
 	"strings"
 
 	"github.com/fatih/color"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/atomicerr"
 	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 const (
 	numLinesParam = "number"
+	authorParam   = "author"
+	sinceParam    = "since"
+	untilParam    = "until"
+	mergesParam   = "merges"
+	noMergesParam = "no-merges"
+	onelineParam  = "oneline"
+	graphParam    = "graph"
+	statParam     = "stat"
 )
 
 var logDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Show commit logs`,
 	LongDesc: `Shows the commit logs
 
-The command takes options to control what is shown and how.`,
-	Synopsis: []string{
-		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [{{.LessThan}}commit{{.GreaterThan}}]`,
-	},
-}
-
-type commitLoggerFunc func(*doltdb.CommitMeta, []hash.Hash, hash.Hash)
-
-func logToStdOutFunc(cm *doltdb.CommitMeta, parentHashes []hash.Hash, ch hash.Hash) {
-	cli.Println(color.YellowString("commit %s", ch.String()))
+The command takes options to control what is shown and how.
 
-	if len(parentHashes) > 1 {
-		printMerge(parentHashes)
-	}
-
-	printAuthor(cm)
-	printDate(cm)
-	printDesc(cm)
-}
+By default, the history of the current branch is shown. If a {{.LessThan}}commit{{.GreaterThan}} is given, the history of that commit is shown instead. If one or more {{.LessThan}}table{{.GreaterThan}} names are given after {{.EmphasisLeft}}--{{.EmphasisRight}}, only the commits which changed at least one of those tables are shown. A merge commit is only considered to have changed a table if the table differs from each of the merge commit's parents.
 
-func printMerge(hashes []hash.Hash) {
-	cli.Print("Merge:")
-	for _, h := range hashes {
-		cli.Print(" " + h.String())
-	}
-	cli.Println()
-}
-
-func printAuthor(cm *doltdb.CommitMeta) {
-	cli.Printf("Author: %s <%s>\n", cm.Name, cm.Email)
-}
-
-func printDate(cm *doltdb.CommitMeta) {
-	timeStr := cm.FormatTS()
-	cli.Println("Date:  ", timeStr)
+The commits shown can also be limited by author using {{.EmphasisLeft}}--author{{.EmphasisRight}}, by date using {{.EmphasisLeft}}--since{{.EmphasisRight}} and {{.EmphasisLeft}}--until{{.EmphasisRight}}, and to or excluding merge commits using {{.EmphasisLeft}}--merges{{.EmphasisRight}} and {{.EmphasisLeft}}--no-merges{{.EmphasisRight}}.`,
+	Synopsis: []string{
+		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [--author {{.LessThan}}pattern{{.GreaterThan}}] [--since {{.LessThan}}date{{.GreaterThan}}] [--until {{.LessThan}}date{{.GreaterThan}}] [--merges|--no-merges] [--oneline] [--graph] [--stat] [{{.LessThan}}commit{{.GreaterThan}}] [-- {{.LessThan}}table{{.GreaterThan}}...]`,
+	},
 }
 
-func printDesc(cm *doltdb.CommitMeta) {
-	formattedDesc := "\n\t" + strings.Replace(cm.Description, "\n", "\n\t", -1) + "\n"
-	cli.Println(formattedDesc)
+type logOpts struct {
+	numLines int
+	oneline  bool
+	graph    bool
+	stat     bool
 }
 
 type LogCmd struct{}
@@ -107,20 +97,26 @@ func (cmd LogCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) er
 func createLogArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsInt(numLinesParam, "n", "num_commits", "Limit the number of commits to output")
+	ap.SupportsString(authorParam, "", "pattern", "Limit the commits output to those whose author, formatted as {{.EmphasisLeft}}name <email>{{.EmphasisRight}}, matches the regular expression given.")
+	ap.SupportsString(sinceParam, "", "date", "Limit the commits output to those made at or after the date given.")
+	ap.SupportsString(untilParam, "", "date", "Limit the commits output to those made at or before the date given.")
+	ap.SupportsFlag(mergesParam, "", "Only output merge commits.")
+	ap.SupportsFlag(noMergesParam, "", "Do not output merge commits.")
+	ap.SupportsFlag(onelineParam, "", "Output each commit on a single line giving its hash and the first line of its message.")
+	ap.SupportsFlag(graphParam, "", "Draw the topology of the history to the left of the commits output.")
+	ap.SupportsFlag(statParam, "", "Output the number of rows added, modified and deleted in each table changed by each commit. Merge commits are not diffed.")
 	return ap
 }
 
 // Exec executes the command
 func (cmd LogCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
-	return logWithLoggerFunc(ctx, commandStr, args, dEnv, logToStdOutFunc)
-}
-
-func logWithLoggerFunc(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, loggerFunc commitLoggerFunc) int {
 	ap := createLogArgParser()
 	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, logDocs, ap))
 	apr := cli.ParseArgs(ap, args, help)
 
-	if apr.NArg() > 1 {
+	revArgs, tblNames := splitLogArgs(apr.Args())
+
+	if len(revArgs) > 1 {
 		usage()
 		return 1
 	}
@@ -131,8 +127,36 @@ func logWithLoggerFunc(ctx context.Context, commandStr string, args []string, dE
 		return 1
 	}
 
-	numLines := apr.GetIntOrDefault(numLinesParam, -1)
-	return logCommits(ctx, dEnv, cs, loggerFunc, numLines)
+	filters, verr := getLogFilters(dEnv.DoltDB, apr, tblNames)
+
+	if verr == nil && apr.Contains(graphParam) && len(filters) > 0 {
+		verr = errhand.BuildDError("fatal: --graph cannot be combined with options which limit the commits output").Build()
+	}
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	opts := logOpts{
+		numLines: apr.GetIntOrDefault(numLinesParam, -1),
+		oneline:  apr.Contains(onelineParam),
+		graph:    apr.Contains(graphParam),
+		stat:     apr.Contains(statParam),
+	}
+
+	return HandleVErrAndExitCode(logCommits(ctx, dEnv, cs, filters, opts), usage)
+}
+
+// splitLogArgs splits the positional arguments into the arguments before "--", which name the commit to start from,
+// and the table names after it.
+func splitLogArgs(args []string) (revArgs, tblNames []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+
+	return args, nil
 }
 
 func parseCommitSpec(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (*doltdb.CommitSpec, error) {
@@ -150,51 +174,257 @@ func parseCommitSpec(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (*doltdb
 	return cs, nil
 }
 
-func logCommits(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, loggerFunc commitLoggerFunc, numLines int) int {
+func getLogFilters(ddb *doltdb.DoltDB, apr *argparser.ArgParseResults, tblNames []string) ([]doltdb.CommitFilter, errhand.VerboseError) {
+	var filters []doltdb.CommitFilter
+
+	if pattern, ok := apr.GetValue(authorParam); ok {
+		re, err := regexp.Compile(pattern)
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: invalid author pattern '%s'", pattern).AddCause(err).Build()
+		}
+
+		filters = append(filters, doltdb.AuthorFilter(re))
+	}
+
+	if dateStr, ok := apr.GetValue(sinceParam); ok {
+		t, err := parseDate(dateStr)
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: invalid --since date").AddCause(err).Build()
+		}
+
+		filters = append(filters, doltdb.SinceFilter(t))
+	}
+
+	if dateStr, ok := apr.GetValue(untilParam); ok {
+		t, err := parseDate(dateStr)
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: invalid --until date").AddCause(err).Build()
+		}
+
+		filters = append(filters, doltdb.UntilFilter(t))
+	}
+
+	if apr.Contains(mergesParam) && apr.Contains(noMergesParam) {
+		return nil, errhand.BuildDError("fatal: --merges and --no-merges cannot be used together").Build()
+	} else if apr.Contains(mergesParam) {
+		filters = append(filters, doltdb.MergeFilter(true))
+	} else if apr.Contains(noMergesParam) {
+		filters = append(filters, doltdb.MergeFilter(false))
+	}
+
+	if len(tblNames) > 0 {
+		filters = append(filters, doltdb.TablesFilter(ddb, tblNames))
+	}
+
+	return filters, nil
+}
+
+func logCommits(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, filters []doltdb.CommitFilter, opts logOpts) errhand.VerboseError {
 	commit, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoState.CWBHeadRef())
 
 	if err != nil {
-		cli.PrintErrln(color.HiRedString("Fatal error: cannot get HEAD commit for current branch."))
-		return 1
+		return errhand.BuildDError(color.HiRedString("Fatal error: cannot get HEAD commit for current branch.")).Build()
 	}
 
 	h, err := commit.HashOf()
 
 	if err != nil {
-		cli.PrintErrln(color.HiRedString("Fatal error: failed to get commit hash"))
-		return 1
+		return errhand.BuildDError(color.HiRedString("Fatal error: failed to get commit hash")).Build()
 	}
 
-	commits, err := commitwalk.GetTopNTopoOrderedCommits(ctx, dEnv.DoltDB, h, numLines)
+	var itr doltdb.CommitItr
+	itr, err = commitwalk.GetTopologicalOrderIterator(ctx, dEnv.DoltDB, h)
 
 	if err != nil {
-		cli.PrintErrln("Error retrieving commit.")
-		return 1
+		return errhand.BuildDError("Error retrieving commit.").AddCause(err).Build()
+	}
+
+	if len(filters) > 0 {
+		itr = doltdb.NewFilteringCommitItr(itr, doltdb.AllFilters(filters...))
 	}
 
-	for _, comm := range commits {
+	var graph *commitGraph
+	if opts.graph {
+		graph = &commitGraph{}
+	}
+
+	for n := 0; opts.numLines < 0 || n < opts.numLines; n++ {
+		cmHash, comm, err := itr.Next(ctx)
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errhand.BuildDError("Error retrieving commit.").AddCause(err).Build()
+		}
+
 		meta, err := comm.GetCommitMeta()
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get commit metadata")
-			return 1
+			return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
 		}
 
 		pHashes, err := comm.ParentHashes(ctx)
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get parent hashes")
-			return 1
+			return errhand.BuildDError("error: failed to get parent hashes").AddCause(err).Build()
+		}
+
+		var lines []string
+		if opts.oneline {
+			lines = formatCommitOneline(meta, cmHash)
+		} else {
+			lines = formatCommit(meta, pHashes, cmHash)
+		}
+
+		if opts.stat && len(pHashes) == 1 {
+			statLines, verr := getCommitStat(ctx, dEnv.DoltDB, comm)
+
+			if verr != nil {
+				return verr
+			}
+
+			lines = append(lines, statLines...)
+
+			if !opts.oneline && len(statLines) > 0 {
+				lines = append(lines, "")
+			}
+		}
+
+		if graph != nil {
+			printGraphCommit(graph.next(cmHash, pHashes), lines)
+		} else {
+			for _, line := range lines {
+				cli.Println(line)
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatCommitOneline(cm *doltdb.CommitMeta, ch hash.Hash) []string {
+	desc := cm.Description
+	if i := strings.Index(desc, "\n"); i != -1 {
+		desc = desc[:i]
+	}
+
+	return []string{color.YellowString("%s", ch.String()) + " " + desc}
+}
+
+func formatCommit(cm *doltdb.CommitMeta, parentHashes []hash.Hash, ch hash.Hash) []string {
+	lines := []string{color.YellowString("commit %s", ch.String())}
+
+	if len(parentHashes) > 1 {
+		merge := "Merge:"
+		for _, h := range parentHashes {
+			merge += " " + h.String()
+		}
+
+		lines = append(lines, merge)
+	}
+
+	lines = append(lines, fmt.Sprintf("Author: %s <%s>", cm.Name, cm.Email))
+	lines = append(lines, "Date:   "+cm.FormatTS())
+	lines = append(lines, "")
+
+	for _, descLine := range strings.Split(cm.Description, "\n") {
+		lines = append(lines, "\t"+descLine)
+	}
+
+	return append(lines, "")
+}
+
+func printGraphCommit(row graphRow, lines []string) {
+	for _, line := range row.before {
+		cli.Println(line)
+	}
+
+	cli.Println(row.commit + " " + lines[0])
+
+	for _, line := range row.after {
+		cli.Println(line)
+	}
+
+	for _, line := range lines[1:] {
+		cli.Println(strings.TrimRight(row.padding+line, " "))
+	}
+}
+
+// getCommitStat returns the lines output by --stat for a commit with a single parent
+func getCommitStat(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) ([]string, errhand.VerboseError) {
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to resolve parent commit").AddCause(err).Build()
+	}
+
+	fromRoot, err := parent.GetRootValue()
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
+	}
+
+	toRoot, err := cm.GetRootValue()
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
+	}
+
+	tblDeltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to diff commit").AddCause(err).Build()
+	}
+
+	tblToStats := make(map[string]*merge.MergeStats)
+	for _, td := range tblDeltas {
+		tblName := td.ToName
+		if td.IsDrop() {
+			tblName = td.FromName
+		}
+
+		from, to, err := td.GetMaps(ctx)
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to get row data for table '%s'", tblName).AddCause(err).Build()
 		}
 
-		cmHash, err := comm.HashOf()
+		acc, err := summarizeRowChanges(ctx, from, to)
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get commit hash")
-			return 1
+			return nil, errhand.BuildDError("error: failed to diff table '%s'", tblName).AddCause(err).Build()
+		}
+
+		tblToStats[tblName] = &merge.MergeStats{
+			Operation:     merge.TableModified,
+			Adds:          int(acc.Adds),
+			Deletes:       int(acc.Removes),
+			Modifications: int(acc.Changes),
 		}
-		loggerFunc(meta, pHashes, cmHash)
 	}
 
-	return 0
+	return formatModifications(tblToStats), nil
+}
+
+func summarizeRowChanges(ctx context.Context, from, to types.Map) (diff.DiffSummaryProgress, error) {
+	ae := atomicerr.New()
+	ch := make(chan diff.DiffSummaryProgress)
+	go func() {
+		defer close(ch)
+		err := diff.Summary(ctx, ch, from, to)
+
+		ae.SetIfError(err)
+	}()
+
+	acc := diff.DiffSummaryProgress{}
+	for p := range ch {
+		acc.Adds += p.Adds
+		acc.Removes += p.Removes
+		acc.Changes += p.Changes
+	}
+
+	return acc, ae.Get()
 }
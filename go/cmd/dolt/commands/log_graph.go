@@ -0,0 +1,139 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// commitGraph renders the topology of the commits printed by dolt log --graph. Commits must be rendered in topological
+// order, children before their parents. Each lane of the graph is a column holding a commit which has yet to be
+// rendered.
+type commitGraph struct {
+	lanes []hash.Hash
+}
+
+// graphRow is the rendering of the graph for a single commit
+type graphRow struct {
+	// before are the lines printed before the commit, in which lanes holding the same commit are joined
+	before []string
+	// commit is the prefix of the first line of the commit
+	commit string
+	// after are the lines printed after the first line of the commit, in which lanes of a merge's parents fork
+	after []string
+	// padding is the prefix of the remaining lines of the commit
+	padding string
+}
+
+func (g *commitGraph) laneIndex(h hash.Hash, from int) int {
+	for i := from; i < len(g.lanes); i++ {
+		if g.lanes[i] == h {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// next renders the commit with hash |h| and the given parents, and moves the commit's lanes on to its parents.
+func (g *commitGraph) next(h hash.Hash, parents []hash.Hash) graphRow {
+	var row graphRow
+
+	idx := g.laneIndex(h, 0)
+	if idx == -1 {
+		g.lanes = append(g.lanes, h)
+		idx = len(g.lanes) - 1
+	}
+
+	// join the other lanes holding this commit into its lane
+	for j := g.laneIndex(h, idx+1); j != -1; j = g.laneIndex(h, idx+1) {
+		row.before = append(row.before, g.shiftLine(j, '/'))
+		g.lanes = append(g.lanes[:j], g.lanes[j+1:]...)
+	}
+
+	row.commit = g.line(func(i int) byte {
+		if i == idx {
+			return '*'
+		}
+
+		return '|'
+	})
+
+	if len(parents) == 0 {
+		if idx < len(g.lanes)-1 {
+			row.after = append(row.after, g.shiftLine(idx+1, '/'))
+		}
+
+		g.lanes = append(g.lanes[:idx], g.lanes[idx+1:]...)
+	} else {
+		g.lanes[idx] = parents[0]
+
+		pos := idx + 1
+		for _, parent := range parents[1:] {
+			if g.laneIndex(parent, 0) != -1 {
+				continue
+			}
+
+			row.after = append(row.after, g.shiftLine(pos, '\\'))
+			g.lanes = append(g.lanes[:pos], append([]hash.Hash{parent}, g.lanes[pos:]...)...)
+			pos++
+		}
+	}
+
+	if len(g.lanes) > 0 {
+		row.padding = g.line(func(int) byte { return '|' }) + " "
+	} else {
+		row.padding = strings.Repeat(" ", len(row.commit)+1)
+	}
+
+	return row
+}
+
+// line returns a line with a character for each lane
+func (g *commitGraph) line(laneChar func(i int) byte) string {
+	chars := make([]byte, 0, 2*len(g.lanes))
+	for i := range g.lanes {
+		if i > 0 {
+			chars = append(chars, ' ')
+		}
+
+		chars = append(chars, laneChar(i))
+	}
+
+	return string(chars)
+}
+
+// shiftLine returns a line in which the lanes starting at |from| move one lane to the left, drawn using '/', or one
+// lane to the right, drawn using '\'.
+func (g *commitGraph) shiftLine(from int, shift byte) string {
+	chars := []byte(strings.Repeat(" ", 2*len(g.lanes)))
+	for i := range g.lanes {
+		if i < from {
+			chars[2*i] = '|'
+		} else if shift == '/' {
+			chars[2*i-1] = '/'
+		} else {
+			chars[2*i+1] = '\\'
+		}
+	}
+
+	if shift == '\\' && from > 0 {
+		chars[2*from-1] = '\\'
+	}
+
+	return strings.TrimRight(string(chars), " ")
+}
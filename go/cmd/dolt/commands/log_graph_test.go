@@ -0,0 +1,64 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+func TestCommitGraph(t *testing.T) {
+	// m merges b into a, and both a and b are children of root
+	m, a, b, root := hash.Of([]byte("m")), hash.Of([]byte("a")), hash.Of([]byte("b")), hash.Of([]byte("root"))
+
+	commits := []struct {
+		h       hash.Hash
+		parents []hash.Hash
+	}{
+		{m, []hash.Hash{a, b}},
+		{a, []hash.Hash{root}},
+		{b, []hash.Hash{root}},
+		{root, nil},
+	}
+
+	var lines []string
+	g := &commitGraph{}
+	for _, cm := range commits {
+		row := g.next(cm.h, cm.parents)
+		lines = append(lines, row.before...)
+		lines = append(lines, row.commit)
+		lines = append(lines, row.after...)
+		lines = append(lines, row.padding)
+	}
+
+	expected := []string{
+		"*",
+		`|\`,
+		"| | ",
+		"* |",
+		"| | ",
+		"| *",
+		"| | ",
+		"|/",
+		"*",
+		"  ",
+	}
+
+	assert.Equal(t, expected, lines)
+	assert.Empty(t, g.lanes)
+}
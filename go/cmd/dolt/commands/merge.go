@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/fatih/color"
 
@@ -29,13 +30,17 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
 	"github.com/liquidata-inc/dolt/go/store/hash"
 )
 
 const (
-	abortParam = "abort"
+	abortParam    = "abort"
+	noFFParam     = "no-ff"
+	squashParam   = "squash"
+	noCommitParam = "no-commit"
 )
 
 var mergeDocs = cli.CommandDocumentationContent{
@@ -45,10 +50,12 @@ var mergeDocs = cli.CommandDocumentationContent{
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. git merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
+
+By default, a merge that can be resolved as a fast-forward only updates the branch pointer. Otherwise the merged tables are staged and the merge is completed using {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}. The {{.EmphasisLeft}}--no-ff{{.EmphasisRight}}, {{.EmphasisLeft}}--squash{{.EmphasisRight}} and {{.EmphasisLeft}}--no-commit{{.EmphasisRight}} options change how the result of the merge is recorded.
 `,
 
 	Synopsis: []string{
-		"{{.LessThan}}branch{{.GreaterThan}}",
+		"[--no-ff] [--squash] [--no-commit] [-m {{.LessThan}}msg{{.GreaterThan}}] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
 	},
 }
@@ -79,6 +86,10 @@ func (cmd MergeCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 func (cmd MergeCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsFlag(abortParam, "", abortDetails)
+	ap.SupportsFlag(noFFParam, "", "Create a merge commit even when the merge resolves as a fast-forward. If the merge is clean, the merge commit is created immediately.")
+	ap.SupportsFlag(squashParam, "", "Merge the changes into the working set and stage them without making a commit or recording a merge, so that the next {{.EmphasisLeft}}dolt commit{{.EmphasisRight}} creates a single commit on top of the current branch.")
+	ap.SupportsFlag(noCommitParam, "", "Perform the merge and stop before creating a merge commit, leaving the merged tables staged so that they can be inspected. The branch is not updated, even when the merge could be resolved as a fast-forward.")
+	ap.SupportsString(commitMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the message of the merge commit created by {{.EmphasisLeft}}--no-ff{{.EmphasisRight}}.")
 	return ap
 }
 
@@ -93,6 +104,10 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, mergeDocs, ap))
 	apr := cli.ParseArgs(ap, args, help)
 
+	if verr := checkNoRebaseInProgress(dEnv, "merge"); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
 	var verr errhand.VerboseError
 	if apr.Contains(abortParam) {
 		if !dEnv.IsMergeActive() {
@@ -108,6 +123,17 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 		}
 
 		commitSpecStr := apr.Arg(0)
+		opts := mergeOpts{
+			noFF:     apr.Contains(noFFParam),
+			squash:   apr.Contains(squashParam),
+			noCommit: apr.Contains(noCommitParam),
+		}
+		opts.msg, _ = apr.GetValue(commitMessageArg)
+
+		if opts.squash && opts.noFF {
+			cli.PrintErrln("fatal: You cannot combine --squash with --no-ff.")
+			return 1
+		}
 
 		var root *doltdb.RootValue
 		root, verr = GetWorkingWithVErr(dEnv)
@@ -129,7 +155,7 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 			}
 
 			if verr == nil {
-				verr = mergeCommitSpec(ctx, dEnv, commitSpecStr)
+				verr = mergeCommitSpec(ctx, dEnv, commitSpecStr, opts)
 			}
 		}
 	}
@@ -151,7 +177,24 @@ func abortMerge(ctx context.Context, doltEnv *env.DoltEnv) errhand.VerboseError
 	return errhand.BuildDError("fatal: failed to revert changes").AddCause(err).Build()
 }
 
-func mergeCommitSpec(ctx context.Context, dEnv *env.DoltEnv, commitSpecStr string) errhand.VerboseError {
+// mergeOpts are the options controlling how the result of a merge is recorded
+type mergeOpts struct {
+	// noFF creates a merge commit even when the merge could be resolved as a fast-forward
+	noFF bool
+	// squash stages the merged tables without recording the merge
+	squash bool
+	// noCommit stops before the merge commit is created and never fast-forwards the branch
+	noCommit bool
+	// msg is the message of the merge commit created when noFF is set
+	msg string
+}
+
+// fastForward returns whether a merge that can be resolved as a fast-forward should just update the branch pointer
+func (opts mergeOpts) fastForward() bool {
+	return !opts.noFF && !opts.squash && !opts.noCommit
+}
+
+func mergeCommitSpec(ctx context.Context, dEnv *env.DoltEnv, commitSpecStr string, opts mergeOpts) errhand.VerboseError {
 	cm1, verr := ResolveCommitWithVErr(dEnv, "HEAD")
 
 	if verr != nil {
@@ -198,13 +241,13 @@ func mergeCommitSpec(ctx context.Context, dEnv *env.DoltEnv, commitSpecStr strin
 		return bldr.Build()
 	}
 
-	if ok, err := cm1.CanFastForwardTo(ctx, cm2); ok {
+	if ok, err := cm1.CanFastForwardTo(ctx, cm2); ok && opts.fastForward() {
 		return executeFFMerge(ctx, dEnv, cm2, workingDiffs)
 	} else if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
 		cli.Println("Already up to date.")
 		return nil
 	} else {
-		return executeMerge(ctx, dEnv, cm1, cm2, workingDiffs)
+		return executeMerge(ctx, dEnv, cm1, cm2, workingDiffs, opts, commitSpecStr)
 	}
 }
 
@@ -284,7 +327,7 @@ and take the hash for your current branch and use it for the value for "staged"
 	return nil
 }
 
-func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, workingDiffs map[string]hash.Hash) errhand.VerboseError {
+func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, workingDiffs map[string]hash.Hash, opts mergeOpts, commitSpecStr string) errhand.VerboseError {
 	mergedRoot, tblToStats, err := merge.MergeCommits(ctx, dEnv.DoltDB, cm1, cm2)
 
 	if err != nil {
@@ -307,16 +350,19 @@ func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commi
 		}
 	}
 
-	h2, err := cm2.HashOf()
+	// a squashed merge is committed as a regular commit on top of the current branch, so no merge is recorded
+	if !opts.squash {
+		h2, err := cm2.HashOf()
 
-	if err != nil {
-		return errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
-	}
+		if err != nil {
+			return errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
+		}
 
-	err = dEnv.RepoState.StartMerge(h2.String(), dEnv.FS)
+		err = dEnv.RepoState.StartMerge(h2.String(), dEnv.FS)
 
-	if err != nil {
-		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+		if err != nil {
+			return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+		}
 	}
 
 	unstagedDocs, err := actions.GetUnstagedDocs(ctx, dEnv)
@@ -340,6 +386,12 @@ func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commi
 			if verr != nil {
 				// Log a new message here to indicate that merge was successful, only staging failed.
 				cli.Println("Unable to stage changes: add and commit to finish merge")
+			} else if opts.squash {
+				cli.Println("Squash commit -- not updating HEAD")
+			} else if opts.noCommit {
+				cli.Println("Automatic merge went well; stopped before committing as requested")
+			} else if opts.noFF {
+				verr = commitMerge(ctx, dEnv, opts.msg, commitSpecStr)
 			}
 		}
 	}
@@ -347,10 +399,39 @@ func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commi
 	return verr
 }
 
+// commitMerge commits the staged result of a merge, recording the merged commit as the second parent of the new
+// commit.
+func commitMerge(ctx context.Context, dEnv *env.DoltEnv, msg, commitSpecStr string) errhand.VerboseError {
+	if msg == "" {
+		msg = fmt.Sprintf("Merge commit '%s'", commitSpecStr)
+
+		if isBranch, err := dEnv.DoltDB.HasRef(ctx, ref.NewBranchRef(commitSpecStr)); err != nil {
+			return errhand.BuildDError("error: failed to read branches").AddCause(err).Build()
+		} else if isBranch {
+			msg = fmt.Sprintf("Merge branch '%s'", commitSpecStr)
+		}
+	}
+
+	err := actions.CommitStaged(ctx, dEnv, actions.CommitStagedProps{
+		Message:          msg,
+		Date:             time.Now(),
+		AllowEmpty:       true,
+		CheckForeignKeys: true,
+		CheckConstraints: true,
+	})
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to commit merge").AddCause(err).Build()
+	}
+
+	return nil
+}
+
 func printSuccessStats(tblToStats map[string]*merge.MergeStats) bool {
 	printModifications(tblToStats)
 	printAdditions(tblToStats)
 	printDeletions(tblToStats)
+	printAutoResolutions(tblToStats)
 	return printConflicts(tblToStats)
 }
 
@@ -370,6 +451,27 @@ func printDeletions(tblToStats map[string]*merge.MergeStats) {
 	}
 }
 
+func printAutoResolutions(tblToStats map[string]*merge.MergeStats) {
+	var tbls []string
+	for tblName, stats := range tblToStats {
+		if len(stats.AutoResolutions) > 0 {
+			tbls = append(tbls, tblName)
+		}
+	}
+
+	sort.Strings(tbls)
+
+	for _, tblName := range tbls {
+		for _, res := range tblToStats[tblName].AutoResolutions {
+			if res.Column == "" {
+				cli.Println(fmt.Sprintf("Auto-resolved %d deleted and modified row(s) in %s using the '%s' strategy", res.Count, tblName, res.Strategy))
+			} else {
+				cli.Println(fmt.Sprintf("Auto-resolved %d conflict(s) in %s.%s using the '%s' strategy", res.Count, tblName, res.Column, res.Strategy))
+			}
+		}
+	}
+}
+
 func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	hasConflicts := false
 	for tblName, stats := range tblToStats {
@@ -385,6 +487,14 @@ func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 }
 
 func printModifications(tblToStats map[string]*merge.MergeStats) {
+	for _, line := range formatModifications(tblToStats) {
+		cli.Println(line)
+	}
+}
+
+// formatModifications returns a line for each modified table giving the number of rows changed, followed by a summary
+// line, or no lines if no tables were modified.
+func formatModifications(tblToStats map[string]*merge.MergeStats) []string {
 	maxNameLen := 0
 	maxModCount := 0
 	rowsAdded := 0
@@ -412,13 +522,14 @@ func printModifications(tblToStats map[string]*merge.MergeStats) {
 	}
 
 	if len(tbls) == 0 {
-		return
+		return nil
 	}
 
 	sort.Strings(tbls)
 	modCountStrLen := len(strconv.FormatInt(int64(maxModCount), 10))
 	format := fmt.Sprintf("%%-%ds | %%-%ds %%s", maxNameLen, modCountStrLen)
 
+	lines := make([]string, 0, len(tbls)+1)
 	for _, tbl := range tbls {
 		stats := tblToStats[tbl]
 		if stats.Operation == merge.TableModified {
@@ -426,12 +537,12 @@ func printModifications(tblToStats map[string]*merge.MergeStats) {
 			modCountStr := strconv.FormatInt(int64(modCount), 10)
 			visualizedChanges := visualizeChangeTypes(stats, maxModCount)
 
-			cli.Println(fmt.Sprintf(format, tbl, modCountStr, visualizedChanges))
+			lines = append(lines, fmt.Sprintf(format, tbl, modCountStr, visualizedChanges))
 		}
 	}
 
 	details := fmt.Sprintf("%d tables changed, %d rows added(+), %d rows modified(*), %d rows deleted(-)", len(tbls), rowsAdded, rowsChanged, rowsDeleted)
-	cli.Println(details)
+	return append(lines, details)
 }
 
 func visualizeChangeTypes(stats *merge.MergeStats, maxMods int) string {
@@ -0,0 +1,209 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	strategyColumnParam    = "column"
+	strategyTimestampParam = "timestamp-column"
+)
+
+var mergeStrategyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Declare how merges resolve conflicting changes to a table",
+	LongDesc: `When both sides of a merge change the same cell of a table, the merge reports a conflict. Merge strategies declared for a table, or for one of its columns, resolve such conflicts automatically. Strategies are stored in the {{.EmphasisLeft}}dolt_merge_strategies{{.EmphasisRight}} system table, which is versioned like any other table and can also be edited using {{.EmphasisLeft}}dolt sql{{.EmphasisRight}}. The strategies of the current branch are used when merging.
+
+With no arguments, the declared strategies are listed. With a table and a strategy, the strategy is declared for the table, or for one of its columns using {{.EmphasisLeft}}--column{{.EmphasisRight}}. A strategy declared for a column takes precedence over the strategy declared for its table. With {{.EmphasisLeft}}-d{{.EmphasisRight}}, the strategy declared for the table or column is removed.
+
+The following strategies are supported:
+
+{{.EmphasisLeft}}ours{{.EmphasisRight}}: use the value of the current branch. When declared for a table, rows deleted on one side and modified on the other are also resolved using the current branch.
+
+{{.EmphasisLeft}}theirs{{.EmphasisRight}}: use the value of the branch being merged. When declared for a table, rows deleted on one side and modified on the other are also resolved using the branch being merged.
+
+{{.EmphasisLeft}}max{{.EmphasisRight}}, {{.EmphasisLeft}}min{{.EmphasisRight}}: use the larger or the smaller of the two values.
+
+{{.EmphasisLeft}}latest{{.EmphasisRight}}: use the value of the row with the latest value in the column given with {{.EmphasisLeft}}--timestamp-column{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}sum{{.EmphasisRight}}: apply the changes of both sides to the common ancestor's value, e.g. both increments of a counter. Only numeric columns are resolved.
+`,
+	Synopsis: []string{
+		"",
+		"[--column {{.LessThan}}column{{.GreaterThan}}] [--timestamp-column {{.LessThan}}column{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}strategy{{.GreaterThan}}",
+		"-d [--column {{.LessThan}}column{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}}",
+	},
+}
+
+type MergeStrategyCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd MergeStrategyCmd) Name() string {
+	return "merge-strategy"
+}
+
+// Description returns a description of the command
+func (cmd MergeStrategyCmd) Description() string {
+	return "Declare how merges resolve conflicting changes."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd MergeStrategyCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, mergeStrategyDocs, ap))
+}
+
+func (cmd MergeStrategyCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table the strategy applies to."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"strategy", "One of ours, theirs, max, min, latest or sum."})
+	ap.SupportsString(strategyColumnParam, "c", "column", "The column the strategy applies to. If omitted, the strategy applies to every column of the table which has no strategy of its own.")
+	ap.SupportsString(strategyTimestampParam, "t", "column", "The column whose latest value decides the result of the latest strategy.")
+	ap.SupportsFlag(deleteFlag, "d", "Remove the strategy declared for the table or column.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd MergeStrategyCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_MERGE_STRATEGY
+}
+
+// Exec executes the command
+func (cmd MergeStrategyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, mergeStrategyDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	colName := apr.GetValueOrDefault(strategyColumnParam, "")
+
+	switch {
+	case apr.Contains(deleteFlag):
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		verr = removeMergeStrategy(ctx, dEnv, root, apr.Arg(0), colName)
+	case apr.NArg() == 2:
+		decl := merge.StrategyDecl{
+			TableName:       apr.Arg(0),
+			ColumnName:      colName,
+			Strategy:        merge.Strategy(apr.Arg(1)),
+			TimestampColumn: apr.GetValueOrDefault(strategyTimestampParam, ""),
+		}
+
+		verr = setMergeStrategy(ctx, dEnv, root, decl)
+	case apr.NArg() == 0:
+		verr = listMergeStrategies(ctx, root)
+	default:
+		usage()
+		return 1
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func listMergeStrategies(ctx context.Context, root *doltdb.RootValue) errhand.VerboseError {
+	decls, err := merge.GetStrategyDecls(ctx, root)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read merge strategies").AddCause(err).Build()
+	}
+
+	for _, decl := range decls {
+		target := decl.TableName
+		if decl.ColumnName != "" {
+			target += "." + decl.ColumnName
+		}
+
+		if decl.Strategy == merge.StrategyLatest {
+			cli.Println(fmt.Sprintf("%s: %s (timestamp column: %s)", target, decl.Strategy, decl.TimestampColumn))
+		} else {
+			cli.Println(fmt.Sprintf("%s: %s", target, decl.Strategy))
+		}
+	}
+
+	return nil
+}
+
+func setMergeStrategy(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, decl merge.StrategyDecl) errhand.VerboseError {
+	tbl, ok, err := root.GetTable(ctx, decl.TableName)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read table '%s'", decl.TableName).AddCause(err).Build()
+	} else if !ok {
+		return errhand.BuildDError("error: table '%s' not found", decl.TableName).Build()
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get schema of table '%s'", decl.TableName).AddCause(err).Build()
+	}
+
+	if decl.ColumnName != "" {
+		if _, ok := sch.GetNonPKCols().GetByName(decl.ColumnName); !ok {
+			return errhand.BuildDError("error: table '%s' has no non-primary key column '%s'", decl.TableName, decl.ColumnName).Build()
+		}
+	}
+
+	if decl.TimestampColumn != "" {
+		if _, ok := sch.GetAllCols().GetByName(decl.TimestampColumn); !ok {
+			return errhand.BuildDError("error: table '%s' has no column '%s'", decl.TableName, decl.TimestampColumn).Build()
+		}
+	}
+
+	root, err = merge.SetStrategy(ctx, root, decl)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to declare merge strategy").AddCause(err).Build()
+	}
+
+	return UpdateWorkingWithVErr(dEnv, root)
+}
+
+func removeMergeStrategy(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, tblName, colName string) errhand.VerboseError {
+	root, err := merge.RemoveStrategy(ctx, root, tblName, colName)
+
+	if err == merge.ErrStrategyNotFound {
+		target := tblName
+		if colName != "" {
+			target += "." + colName
+		}
+
+		return errhand.BuildDError("error: no merge strategy declared for '%s'", target).Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to remove merge strategy").AddCause(err).Build()
+	}
+
+	return UpdateWorkingWithVErr(dEnv, root)
+}
@@ -119,11 +119,17 @@ func pullRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, r env.Remote, srcR
 		return verr
 	}
 
+	verr = fetchFollowTags(ctx, dEnv, srcDB, dEnv.DoltDB)
+
+	if verr != nil {
+		return verr
+	}
+
 	err = dEnv.DoltDB.FastForward(ctx, destRef, srcDBCommit)
 
 	if err != nil {
 		return errhand.BuildDError("error: fetch failed").AddCause(err).Build()
 	}
 
-	return mergeCommitSpec(ctx, dEnv, destRef.String())
+	return mergeCommitSpec(ctx, dEnv, destRef.String(), mergeOpts{})
 }
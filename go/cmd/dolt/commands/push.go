@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -112,6 +113,29 @@ func (cmd PushCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	}
 
 	remote, remoteOK := remotes[remoteName]
+
+	if len(args) == 2 || (remoteOK && len(args) == 1) {
+		tagRemoteName, refSpecStr := remoteName, args[0]
+		if len(args) == 2 {
+			tagRemoteName, refSpecStr = args[0], args[1]
+		}
+
+		tagRef, isTag, err := getTagRefToPush(ctx, dEnv, refSpecStr)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read from db").AddCause(err).Build(), usage)
+		} else if isTag {
+			tagRemote, ok := remotes[tagRemoteName]
+
+			if !ok {
+				cli.PrintErrln("fatal: unknown remote " + tagRemoteName)
+				return 1
+			}
+
+			return HandleVErrAndExitCode(pushTagToRemote(ctx, dEnv, tagRef, tagRemote), usage)
+		}
+	}
+
 	currentBranch := dEnv.RepoState.CWBHeadRef()
 	upstream, hasUpstream := dEnv.RepoState.Branches[currentBranch.GetPath()]
 
@@ -307,6 +331,77 @@ func pushToRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpda
 	return nil
 }
 
+// getTagRefToPush returns the TagRef for |refSpecStr| if it names a local tag.  A tag can be given either by its full
+// ref (e.g. refs/tags/v1) or by its name, as long as there is no local branch with the same name.
+func getTagRefToPush(ctx context.Context, dEnv *env.DoltEnv, refSpecStr string) (ref.TagRef, bool, error) {
+	tagPrefix := ref.PrefixForType(ref.TagRefType)
+
+	if strings.HasPrefix(refSpecStr, tagPrefix) {
+		return ref.NewTagRef(refSpecStr), true, nil
+	} else if ref.IsRef(refSpecStr) || strings.Contains(refSpecStr, ":") || !ref.IsValidTagName(refSpecStr) {
+		return ref.TagRef{}, false, nil
+	}
+
+	hasBranch, err := dEnv.DoltDB.HasRef(ctx, ref.NewBranchRef(refSpecStr))
+
+	if err != nil || hasBranch {
+		return ref.TagRef{}, false, err
+	}
+
+	tagRef := ref.NewTagRef(refSpecStr)
+	hasTag, err := dEnv.DoltDB.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return ref.TagRef{}, false, err
+	}
+
+	return tagRef, hasTag, nil
+}
+
+func pushTagToRemote(ctx context.Context, dEnv *env.DoltEnv, tagRef ref.TagRef, remote env.Remote) errhand.VerboseError {
+	evt := events.GetEventFromContext(ctx)
+
+	u, err := earl.Parse(remote.Url)
+
+	if err == nil {
+		if u.Scheme != "" {
+			evt.SetAttribute(eventsapi.AttributeID_REMOTE_URL_SCHEME, u.Scheme)
+		}
+	}
+
+	tag, err := dEnv.DoltDB.ResolveTag(ctx, tagRef)
+
+	if err == doltdb.ErrTagNotFound {
+		return errhand.BuildDError("error: tag '%s' not found.", tagRef.GetPath()).Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to read tag '%s'", tagRef.GetPath()).AddCause(err).Build()
+	}
+
+	destDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = actions.PushTag(ctx, dEnv, tagRef, dEnv.DoltDB, destDB, tag, progChan, pullerEventCh)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err == doltdb.ErrUpToDate {
+		cli.Println("Everything up-to-date")
+	} else if err == datas.ErrTagExists {
+		cli.Printf("To %s\n", remote.Url)
+		cli.Printf("! [rejected]          %s -> %s (already exists)\n", tagRef.String(), tagRef.String())
+		cli.Printf("error: failed to push some refs to '%s'\n", remote.Url)
+		cli.Println("hint: Updates were rejected because the tag already exists in the remote.")
+		return errhand.BuildDError("").Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: push failed").AddCause(err).Build()
+	}
+
+	return nil
+}
+
 func pullerProgFunc(pullerEventCh chan datas.PullerEvent) {
 	var pos int
 	for evt := range pullerEventCh {
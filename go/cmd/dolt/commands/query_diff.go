@@ -56,7 +56,7 @@ func diffQuery(ctx context.Context, dEnv *env.DoltEnv, fromRoot, toRoot *doltdb.
 		return errhand.VerboseErrorFromError(err)
 	}
 
-	p, err := buildQueryDiffPipeline(qd, doltSch, joiner)
+	p, err := buildQueryDiffPipeline(ctx, qd, doltSch, joiner)
 
 	if err != nil {
 		return errhand.BuildDError("error building diff pipeline").AddCause(err).Build()
@@ -81,7 +81,7 @@ func doltSchWithPKFromSqlSchema(sch sql.Schema) schema.Schema {
 	return schema.SchemaFromCols(newCC)
 }
 
-func nextQueryDiff(qd *querydiff.QueryDiffer, joiner *rowconv.Joiner) (row.Row, pipeline.ImmutableProperties, error) {
+func nextQueryDiff(ctx context.Context, vrw types.ValueReadWriter, qd *querydiff.QueryDiffer, joiner *rowconv.Joiner) (row.Row, pipeline.ImmutableProperties, error) {
 	fromRow, toRow, err := qd.NextDiff()
 	if err != nil {
 		return nil, pipeline.ImmutableProperties{}, err
@@ -90,7 +90,7 @@ func nextQueryDiff(qd *querydiff.QueryDiffer, joiner *rowconv.Joiner) (row.Row,
 	rows := make(map[string]row.Row)
 	if fromRow != nil {
 		sch := joiner.SchemaForName(diff.From)
-		oldRow, err := dsqle.SqlRowToDoltRow(types.Format_Default, fromRow, sch)
+		oldRow, err := dsqle.SqlRowToDoltRow(ctx, vrw, fromRow, sch)
 		if err != nil {
 			return nil, pipeline.ImmutableProperties{}, err
 		}
@@ -99,7 +99,7 @@ func nextQueryDiff(qd *querydiff.QueryDiffer, joiner *rowconv.Joiner) (row.Row,
 
 	if toRow != nil {
 		sch := joiner.SchemaForName(diff.To)
-		newRow, err := dsqle.SqlRowToDoltRow(types.Format_Default, toRow, sch)
+		newRow, err := dsqle.SqlRowToDoltRow(ctx, vrw, toRow, sch)
 		if err != nil {
 			return nil, pipeline.ImmutableProperties{}, err
 		}
@@ -114,9 +114,11 @@ func nextQueryDiff(qd *querydiff.QueryDiffer, joiner *rowconv.Joiner) (row.Row,
 	return joinedRow, pipeline.ImmutableProperties{}, nil
 }
 
-func buildQueryDiffPipeline(qd *querydiff.QueryDiffer, doltSch schema.Schema, joiner *rowconv.Joiner) (*pipeline.Pipeline, error) {
+func buildQueryDiffPipeline(ctx context.Context, qd *querydiff.QueryDiffer, doltSch schema.Schema, joiner *rowconv.Joiner) (*pipeline.Pipeline, error) {
+	// query results are only printed, so values stored out of row, such as blobs, can be held in memory
+	vrw := types.NewMemoryValueStore()
 
-	unionSch, ds, verr := createSplitter(doltSch, doltSch, joiner, &diffArgs{diffOutput: TabularDiffOutput})
+	unionSch, ds, verr := createSplitter(ctx, vrw, doltSch, doltSch, joiner, &diffArgs{diffOutput: TabularDiffOutput})
 	if verr != nil {
 		return nil, verr
 	}
@@ -143,7 +145,7 @@ func buildQueryDiffPipeline(qd *querydiff.QueryDiffer, doltSch schema.Schema, jo
 	sinkProcFunc := pipeline.ProcFuncForSinkFunc(sink.ProcRowWithProps)
 
 	srcProcFunc := pipeline.ProcFuncForSourceFunc(func() (row.Row, pipeline.ImmutableProperties, error) {
-		return nextQueryDiff(qd, joiner)
+		return nextQueryDiff(ctx, vrw, qd, joiner)
 	})
 
 	p := pipeline.NewAsyncPipeline(srcProcFunc, sinkProcFunc, transforms, badRowCB)
@@ -0,0 +1,657 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/rebase"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/editor"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	continueParam    = "continue"
+	interactiveParam = "interactive"
+)
+
+var rebaseDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Reapply commits on top of another base commit.",
+	LongDesc: `Replays the commits of the current branch which are not reachable from {{.LessThan}}upstream{{.GreaterThan}} on top of {{.LessThan}}upstream{{.GreaterThan}}, one at a time, and updates the current branch to the result. Merge commits are not replayed, so the rebased history is linear. The working set must be clean before rebasing.
+
+With {{.EmphasisLeft}}--interactive{{.EmphasisRight}}, the list of commits to be replayed is opened in an editor before rebasing. The list can be reordered, and each commit can be picked as is, reworded, squashed or fixed up into the previous commit, or dropped.
+
+If a commit cannot be applied cleanly, the rebase stops and leaves the conflicts in the working set. Resolve the conflicts using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}, add the affected tables using {{.EmphasisLeft}}dolt add{{.EmphasisRight}}, and then run {{.EmphasisLeft}}dolt rebase --continue{{.EmphasisRight}}. {{.EmphasisLeft}}dolt rebase --abort{{.EmphasisRight}} returns the current branch to the state it was in before the rebase started.`,
+	Synopsis: []string{
+		`[-i] {{.LessThan}}upstream{{.GreaterThan}}`,
+		`--continue`,
+		`--abort`,
+	},
+}
+
+type RebaseCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RebaseCmd) Name() string {
+	return "rebase"
+}
+
+// Description returns a description of the command
+func (cmd RebaseCmd) Description() string {
+	return rebaseDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RebaseCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+}
+
+func (cmd RebaseCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"upstream", "The branch or commit to rebase the current branch onto."})
+	ap.SupportsFlag(interactiveParam, "i", "Edit the list of commits to be rebased before rebasing.")
+	ap.SupportsFlag(continueParam, "", "Continue the rebase after resolving conflicts.")
+	ap.SupportsFlag(abortParam, "", "Abort the rebase and return the current branch to its original state.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd RebaseCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_REBASE
+}
+
+// Exec executes the command
+func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.Contains(abortParam) || apr.Contains(continueParam) {
+		if apr.NArg() != 0 || (apr.Contains(abortParam) && apr.Contains(continueParam)) {
+			usage()
+			return 1
+		}
+
+		if !dEnv.IsRebaseActive() {
+			cli.PrintErrln("fatal: No rebase in progress?")
+			return 1
+		}
+
+		if apr.Contains(abortParam) {
+			return HandleVErrAndExitCode(abortRebase(ctx, dEnv), usage)
+		}
+
+		verr := continueRebase(ctx, dEnv)
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		return runRebase(ctx, dEnv, usage)
+	}
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	if dEnv.IsRebaseActive() {
+		bdr := errhand.BuildDError("error: a rebase is already in progress.")
+		bdr.AddDetails(`hint: use "dolt rebase --continue" or "dolt rebase --abort".`)
+		return HandleVErrAndExitCode(bdr.Build(), usage)
+	}
+
+	started, verr := startRebase(ctx, dEnv, apr.Arg(0), apr.Contains(interactiveParam))
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	} else if !started {
+		return 0
+	}
+
+	return runRebase(ctx, dEnv, usage)
+}
+
+// startRebase builds the todo list for rebasing the current branch onto |upstreamSpec|, records it in the repo state
+// and resets the current branch to the upstream commit. It returns false if there is nothing to rebase.
+func startRebase(ctx context.Context, dEnv *env.DoltEnv, upstreamSpec string, interactive bool) (bool, errhand.VerboseError) {
+	verr := checkCanApplyCommit(ctx, dEnv, "rebase")
+
+	if verr != nil {
+		return false, verr
+	}
+
+	headCm, verr := ResolveCommitWithVErr(dEnv, "HEAD")
+
+	if verr != nil {
+		return false, verr
+	}
+
+	upstream, verr := ResolveCommitWithVErr(dEnv, upstreamSpec)
+
+	if verr != nil {
+		return false, verr
+	}
+
+	headHash, err := headCm.HashOf()
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	upstreamHash, err := upstream.HashOf()
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	mergeBase, err := doltdb.GetCommitAncestor(ctx, headCm, upstream)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to find the common ancestor").AddCause(err).Build()
+	}
+
+	mergeBaseHash, err := mergeBase.HashOf()
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	if mergeBaseHash == upstreamHash && !interactive {
+		cli.Printf("Current branch %s is up to date.\n", dEnv.RepoState.CWBHeadRef().GetPath())
+		return false, nil
+	}
+
+	commits, err := rebase.CommitsToRebase(ctx, dEnv.DoltDB, headCm, mergeBase)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to find the commits to rebase").AddCause(err).Build()
+	}
+
+	items, err := rebase.NewTodoList(commits)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to build the rebase todo list").AddCause(err).Build()
+	}
+
+	if interactive {
+		items, verr = editTodoList(dEnv, items, headHash.String(), upstreamHash.String())
+
+		if verr != nil {
+			return false, verr
+		} else if len(items) == 0 {
+			cli.Println("Nothing to do")
+			return false, nil
+		}
+	}
+
+	err = dEnv.RepoState.StartRebase(headHash.String(), upstreamHash.String(), rebase.FormatTodoList(items), dEnv.FS)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to save the rebase state").AddCause(err).Build()
+	}
+
+	verr = resetBranchToCommit(ctx, dEnv, upstream)
+
+	if verr != nil {
+		return false, verr
+	}
+
+	return true, nil
+}
+
+// editTodoList opens the todo list in the user's editor and returns the edited list
+func editTodoList(dEnv *env.DoltEnv, items []rebase.TodoItem, headHash, upstreamHash string) ([]rebase.TodoItem, errhand.VerboseError) {
+	initialTodo := strings.Join(rebase.FormatTodoList(items), "\n") + "\n\n" +
+		fmt.Sprintf("# Rebase %s onto %s (%d commands)\n#", headHash, upstreamHash, len(items)) + rebase.TodoHelp
+
+	var todo string
+	var err error
+	cli.ExecuteWithStdioRestored(func() {
+		todo, err = editor.OpenCommitEditor(getEditorString(dEnv), initialTodo)
+	})
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to edit the rebase todo list").AddCause(err).Build()
+	}
+
+	items, err = rebase.ParseTodoList(todo)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: invalid rebase todo list").AddCause(err).Build()
+	}
+
+	return items, nil
+}
+
+// runRebase applies the remaining steps of the todo list in the repo state to the current branch. If a commit cannot
+// be applied cleanly the rebase stops, leaving the conflicts in the working set.
+func runRebase(ctx context.Context, dEnv *env.DoltEnv, usage cli.UsagePrinter) int {
+	rs := dEnv.RepoState.Rebase
+	for len(rs.Todo) > 0 {
+		item, err := rebase.ParseTodoItem(rs.Todo[0])
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: corrupted rebase state").AddCause(err).Build(), usage)
+		}
+
+		rs.Current = rs.Todo[0]
+		rs.Todo = rs.Todo[1:]
+		err = dEnv.RepoState.Save(dEnv.FS)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to save the rebase state").AddCause(err).Build(), usage)
+		}
+
+		if item.Action == rebase.Drop {
+			continue
+		}
+
+		cm, verr := ResolveCommitWithVErr(dEnv, item.Commit.String())
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		headCm, verr := ResolveCommitWithVErr(dEnv, "HEAD")
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		rebasedCm, conflictedRoot, tblToStats, err := rebase.ReplayCommit(ctx, dEnv.DoltDB, cm, headCm)
+
+		if err != nil {
+			bdr := errhand.BuildDError("error: could not apply %s... %s", item.Commit.String(), item.Summary)
+			return HandleVErrAndExitCode(bdr.AddCause(err).Build(), usage)
+		}
+
+		if conflictedRoot != nil {
+			verr = UpdateWorkingWithVErr(dEnv, conflictedRoot)
+
+			if verr != nil {
+				return HandleVErrAndExitCode(verr, usage)
+			}
+
+			err = actions.SaveDocsFromWorking(ctx, dEnv)
+
+			if err != nil {
+				return HandleVErrAndExitCode(errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build(), usage)
+			}
+
+			printSuccessStats(tblToStats)
+			cli.Printf("error: could not apply %s... %s\n", item.Commit.String(), item.Summary)
+			cli.Println(`hint: Resolve all conflicts manually, mark them as resolved with "dolt add <table>",`)
+			cli.Println(`hint: then run "dolt rebase --continue". To abort and get back to the state before`)
+			cli.Println(`hint: "dolt rebase", run "dolt rebase --abort".`)
+			return 1
+		}
+
+		verr = commitRebaseStep(ctx, dEnv, item, cm, rebasedCm)
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	err := dEnv.RepoState.ClearRebase(dEnv.FS)
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to clear the rebase state").AddCause(err).Build(), usage)
+	}
+
+	cli.Printf("Successfully rebased and updated %s.\n", dEnv.RepoState.CWBHeadRef().String())
+	return 0
+}
+
+// continueRebase commits the resolved changes of the step that stopped the rebase
+func continueRebase(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	rs := dEnv.RepoState.Rebase
+
+	if rs.Current == "" {
+		return nil
+	}
+
+	item, err := rebase.ParseTodoItem(rs.Current)
+
+	if err != nil {
+		return errhand.BuildDError("error: corrupted rebase state").AddCause(err).Build()
+	}
+
+	if item.Action == rebase.Drop {
+		return nil
+	}
+
+	workingRoot, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get working root").AddCause(err).Build()
+	}
+
+	if has, err := workingRoot.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		bdr := errhand.BuildDError("error: you must resolve all conflicts before continuing the rebase.")
+		bdr.AddDetails(`hint: resolve the conflicts using "dolt conflicts", then add the affected tables using "dolt add <table>".`)
+		return bdr.Build()
+	}
+
+	_, notStagedTbls, err := diff.GetTableDiffs(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to determine the state of the working set").AddCause(err).Build()
+	}
+
+	if len(notStagedTbls.Tables) != 0 {
+		bdr := errhand.BuildDError("error: you have unstaged changes.")
+		bdr.AddDetails(`hint: add them using "dolt add <table>" and run "dolt rebase --continue" again.`)
+		return bdr.Build()
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, item.Commit.String())
+
+	if verr != nil {
+		return verr
+	}
+
+	return commitRebaseStep(ctx, dEnv, item, cm, nil)
+}
+
+// commitRebaseStep commits the result of replaying |cm| according to |item|. |rebasedCm| is the commit written by
+// replaying |cm| cleanly, and is nil if the replay stopped on conflicts, in which case the resolved staged root is
+// committed. Picked and reworded commits are committed on top of HEAD, keeping the author of the original commit.
+// Squashed and fixed up commits replace HEAD with a commit combining the changes of both.
+func commitRebaseStep(ctx context.Context, dEnv *env.DoltEnv, item rebase.TodoItem, cm, rebasedCm *doltdb.Commit) errhand.VerboseError {
+	headCm, verr := ResolveCommitWithVErr(dEnv, "HEAD")
+
+	if verr != nil {
+		return verr
+	}
+
+	var root *doltdb.RootValue
+	var err error
+	if rebasedCm != nil {
+		root, err = rebasedCm.GetRootValue()
+	} else {
+		root, err = dEnv.StagedRoot(ctx)
+	}
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get the root of the rebased changes").AddCause(err).Build()
+	}
+
+	rootHash, err := root.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to hash the root of the rebased changes").AddCause(err).Build()
+	}
+
+	headRoot, err := headCm.GetRootValue()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get head root").AddCause(err).Build()
+	}
+
+	headRootHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to hash head root").AddCause(err).Build()
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
+	}
+
+	author := meta
+	msg := meta.Description
+	parents := []*doltdb.Commit{headCm}
+	switch item.Action {
+	case rebase.Pick, rebase.Reword:
+		if rootHash == headRootHash {
+			// the changes of the commit are already applied
+			return clearCurrentRebaseStep(dEnv)
+		}
+
+		if item.Action == rebase.Pick && rebasedCm != nil {
+			// the replayed commit already keeps the metadata of the original
+			verr = resetBranchToCommit(ctx, dEnv, rebasedCm)
+
+			if verr != nil {
+				return verr
+			}
+
+			return clearCurrentRebaseStep(dEnv)
+		}
+
+		if item.Action == rebase.Reword {
+			msg, err = getRebaseMessageFromEditor(dEnv, msg)
+
+			if err != nil {
+				return stopRebaseStep(ctx, dEnv, root, err)
+			}
+		}
+
+	case rebase.Squash, rebase.Fixup:
+		author, err = headCm.GetCommitMeta()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
+		}
+
+		parents, err = dEnv.DoltDB.ResolveAllParents(ctx, headCm)
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get parents of commit").AddCause(err).Build()
+		}
+
+		msg = author.Description
+		if item.Action == rebase.Squash {
+			msg, err = getRebaseMessageFromEditor(dEnv, author.Description+"\n\n"+meta.Description)
+
+			if err != nil {
+				return stopRebaseStep(ctx, dEnv, root, err)
+			}
+		}
+	}
+
+	newMeta, err := doltdb.NewCommitMetaWithUserTS(author.Name, author.Email, msg, author.Time())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to commit the changes of %s", item.Commit.String()).AddCause(err).Build()
+	}
+
+	tblNames, err := root.GetTableNames(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get table names").AddCause(err).Build()
+	}
+
+	root, err = root.UpdateSuperSchemasFromOther(ctx, tblNames, root)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update super schemas").AddCause(err).Build()
+	}
+
+	valHash, err := dEnv.DoltDB.WriteRootValue(ctx, root)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to write root value").AddCause(err).Build()
+	}
+
+	newCm, err := dEnv.DoltDB.CommitDanglingWithParentCommits(ctx, valHash, parents, newMeta)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to commit the changes of %s", item.Commit.String()).AddCause(err).Build()
+	}
+
+	verr = resetBranchToCommit(ctx, dEnv, newCm)
+
+	if verr != nil {
+		return verr
+	}
+
+	return clearCurrentRebaseStep(dEnv)
+}
+
+func clearCurrentRebaseStep(dEnv *env.DoltEnv) errhand.VerboseError {
+	dEnv.RepoState.Rebase.Current = ""
+	err := dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to save the rebase state").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// getRebaseMessageFromEditor returns the commit message edited from |initialMsg|. If the message is empty,
+// actions.ErrEmptyCommitMessage is returned.
+func getRebaseMessageFromEditor(dEnv *env.DoltEnv, initialMsg string) (string, error) {
+	initialMsg += "\n\n# Please enter the commit message for your changes. Lines starting\n" +
+		"# with '#' will be ignored, and an empty message aborts the commit.\n"
+
+	var commitMsg string
+	var err error
+	cli.ExecuteWithStdioRestored(func() {
+		commitMsg, err = editor.OpenCommitEditor(getEditorString(dEnv), initialMsg)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	finalMsg := parseCommitMessage(commitMsg)
+
+	if strings.TrimSpace(finalMsg) == "" {
+		return "", actions.ErrEmptyCommitMessage
+	}
+
+	return finalMsg, nil
+}
+
+// stopRebaseStep stops the rebase on the current step when its commit message could not be edited, leaving |root|,
+// the changes of the step, staged so that "dolt rebase --continue" commits them.
+func stopRebaseStep(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, msgErr error) errhand.VerboseError {
+	verr := UpdateWorkingWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = UpdateStagedWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	err := actions.SaveDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	var bdr *errhand.DErrorBuilder
+	if msgErr == actions.ErrEmptyCommitMessage {
+		bdr = errhand.BuildDError("Aborting commit due to empty commit message.")
+	} else {
+		bdr = errhand.BuildDError("error: failed to get the commit message from the editor").AddCause(msgErr)
+	}
+
+	bdr.AddDetails(`hint: run "dolt rebase --continue" to edit the message again, or "dolt rebase --abort" to abort the rebase.`)
+	return bdr.Build()
+}
+
+// abortRebase returns the current branch, staged and working roots to the original head of the rebase
+func abortRebase(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	origHead, verr := ResolveCommitWithVErr(dEnv, dEnv.RepoState.Rebase.OrigHead)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = resetBranchToCommit(ctx, dEnv, origHead)
+
+	if verr != nil {
+		return verr
+	}
+
+	err := dEnv.RepoState.ClearRebase(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to clear the rebase state").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// checkNoRebaseInProgress returns an error if a rebase is in progress, as |operation| would change the branch being
+// rebased out from under it.
+func checkNoRebaseInProgress(dEnv *env.DoltEnv, operation string) errhand.VerboseError {
+	if dEnv.IsRebaseActive() {
+		bdr := errhand.BuildDError("error: %s is not possible because a rebase is in progress.", operation)
+		bdr.AddDetails(`hint: use "dolt rebase --continue" or "dolt rebase --abort".`)
+		return bdr.Build()
+	}
+
+	return nil
+}
+
+// resetBranchToCommit points the current branch at |cm| and sets the staged and working roots to its root
+func resetBranchToCommit(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) errhand.VerboseError {
+	err := dEnv.DoltDB.SetHead(ctx, dEnv.RepoState.CWBHeadRef(), cm)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update the current branch").AddCause(err).Build()
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get root of commit").AddCause(err).Build()
+	}
+
+	verr := UpdateWorkingWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = UpdateStagedWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	err = actions.SaveDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	return nil
+}
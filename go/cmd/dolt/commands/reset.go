@@ -262,7 +262,7 @@ func printNotStaged(ctx context.Context, dEnv *env.DoltEnv, staged *doltdb.RootV
 			tdt := notStagedTbls.TableToType[tblName]
 
 			if tdt != diff.AddedTable && !doltdb.IsReadOnlySystemTable(tblName) {
-				lines = append(lines, fmt.Sprintf("%s\t%s", tblDiffTypeToShortLabel[tdt], tblName))
+				lines = append(lines, fmt.Sprintf("%s\t%s", tblDiffTypeToShortLabel[tdt], tblStatusName(notStagedTbls, tblName)))
 			}
 		}
 
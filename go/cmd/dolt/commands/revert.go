@@ -0,0 +1,175 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	mainlineParam = "mainline"
+)
+
+var revertDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Undo the changes introduced by existing commits.",
+	LongDesc: `Given one or more existing commits, reverts the changes that the related commits introduced, and records a new commit for each of them. The working set must be clean before reverting.
+
+The inverse of the changes between each commit and its parent is applied to {{.EmphasisLeft}}HEAD{{.EmphasisRight}} using a three-way merge, and the resulting commit has the message "Revert '{{.LessThan}}original message{{.GreaterThan}}'". If the changes cannot be reverted cleanly, the merged tables and their conflicts are left in the working set and no commit is made. Resolve the conflicts using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}, then add the affected tables using {{.EmphasisLeft}}dolt add{{.EmphasisRight}} and commit the result using {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}.
+
+Reverting a merge commit requires specifying which parent of the merge should be considered the mainline using {{.EmphasisLeft}}--mainline{{.EmphasisRight}}. The changes relative to that parent will be reverted. Parents are numbered from 1, starting with the branch that was merged into, in the order in which they are listed on the {{.EmphasisLeft}}Merge:{{.EmphasisRight}} line of {{.EmphasisLeft}}dolt log{{.EmphasisRight}}.`,
+	Synopsis: []string{
+		`[-m {{.LessThan}}parent-number{{.GreaterThan}}] {{.LessThan}}commit{{.GreaterThan}}...`,
+	},
+}
+
+type RevertCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RevertCmd) Name() string {
+	return "revert"
+}
+
+// Description returns a description of the command
+func (cmd RevertCmd) Description() string {
+	return revertDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RevertCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+}
+
+func (cmd RevertCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commits to revert."})
+	ap.SupportsInt(mainlineParam, "m", "parent-number", "The parent number (starting from 1, the branch that was merged into) of the mainline when reverting a merge commit.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd RevertCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_REVERT
+}
+
+// Exec executes the command
+func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	mainline := 0
+	if apr.Contains(mainlineParam) {
+		var ok bool
+		mainline, ok = apr.GetInt(mainlineParam)
+
+		if !ok || mainline < 1 {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: mainline must be a parent number starting from 1").Build(), usage)
+		}
+	}
+
+	// resolve all the commits before any of them are reverted so that relative specs like HEAD~1 refer to the commits
+	// as they were when the command was run.
+	var commits []*doltdb.Commit
+	for _, cSpecStr := range apr.Args() {
+		cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr)
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		commits = append(commits, cm)
+	}
+
+	for _, cm := range commits {
+		verr := checkCanApplyCommit(ctx, dEnv, "revert")
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		mergedRoot, tblToStats, verr := revert(ctx, dEnv, cm, mainline)
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build(), usage)
+		}
+
+		msg := fmt.Sprintf("Revert '%s'", meta.Description)
+		if res := commitMergedRoot(ctx, dEnv, mergedRoot, tblToStats, msg, usage); res != 0 {
+			return res
+		}
+	}
+
+	return 0
+}
+
+// revert merges the inverse of the changes between |cm| and its parent into the HEAD root of the current branch. If
+// |cm| is a merge commit, |mainline| is the 1-based index of the parent the changes are relative to.
+func revert(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit, mainline int) (*doltdb.RootValue, map[string]*merge.MergeStats, errhand.VerboseError) {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
+	}
+
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to get parents of commit").AddCause(err).Build()
+	}
+
+	parentIdx := 0
+	switch {
+	case numParents == 0:
+		return nil, nil, errhand.BuildDError("error: cannot revert commit %s as it has no parents", h.String()).Build()
+	case numParents > 1 && mainline == 0:
+		return nil, nil, errhand.BuildDError("error: commit %s is a merge but no --mainline option was given.", h.String()).Build()
+	case numParents == 1 && mainline != 0:
+		return nil, nil, errhand.BuildDError("error: mainline was specified but commit %s is not a merge.", h.String()).Build()
+	case mainline > numParents:
+		return nil, nil, errhand.BuildDError("error: commit %s does not have parent %d", h.String(), mainline).Build()
+	case mainline != 0:
+		parentIdx = mainline - 1
+	}
+
+	parent, err := dEnv.DoltDB.ResolveParent(ctx, cm, parentIdx)
+
+	if err != nil {
+		return nil, nil, errhand.BuildDError("error: failed to get parent of commit").AddCause(err).Build()
+	}
+
+	return mergeCommitChanges(ctx, dEnv, parent, cm)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
@@ -79,7 +80,7 @@ A mapping file can be used to map fields between the file being imported and the
 
 ` + MappingFileHelp + `
 
-In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (Currently only csv is supported).  For files separated by a delimiter other than a ',', the --delim parameter can be used to specify a delimeter.
+In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv and jsonl).  For files separated by a delimiter other than a ',', the --delim parameter can be used to specify a delimeter.
 
 If the parameter {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} is supplied a sql statement will be generated showing what would be executed if this were run without the --dry-run flag
 
@@ -350,6 +351,17 @@ func inferSchemaFromFile(ctx context.Context, nbf *types.NomsBinFormat, impOpts
 		}
 	case "psv":
 		csvInfo.SetDelim("|")
+	case "jsonl", "ndjson":
+		// nested objects are flattened into dotted column names
+		rd, err := json.OpenJSONLReader(root.VRW(), impOpts.fileName, filesys.LocalFS, nil)
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to create a JSONLReader.").AddCause(err).Build()
+		}
+
+		defer rd.Close(ctx)
+
+		return inferSchemaFromReader(ctx, root, rd, impOpts)
 	default:
 		return nil, errhand.BuildDError("error: unsupported file type '%s'", impOpts.fileType).Build()
 	}
@@ -370,6 +382,10 @@ func inferSchemaFromFile(ctx context.Context, nbf *types.NomsBinFormat, impOpts
 
 	defer rd.Close(ctx)
 
+	return inferSchemaFromReader(ctx, root, rd, impOpts)
+}
+
+func inferSchemaFromReader(ctx context.Context, root *doltdb.RootValue, rd table.TableReadCloser, impOpts *importOptions) (schema.Schema, errhand.VerboseError) {
 	infCols, err := actions.InferColumnTypesFromTableReader(ctx, root, rd, impOpts)
 
 	if err != nil {
@@ -15,6 +15,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"strings"
 
@@ -28,7 +29,7 @@ import (
 
 type FilterFn = func(r row.Row) (matchesFilter bool)
 
-func ParseWhere(sch schema.Schema, whereClause string) (FilterFn, error) {
+func ParseWhere(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, whereClause string) (FilterFn, error) {
 	if whereClause == "" {
 		return func(r row.Row) bool {
 			return true
@@ -73,7 +74,7 @@ func ParseWhere(sch schema.Schema, whereClause string) (FilterFn, error) {
 			val = types.String(valStr)
 		} else {
 			var err error
-			val, err = cols[0].TypeInfo.ParseValue(&valStr)
+			val, err = cols[0].TypeInfo.ParseValue(ctx, vrw, &valStr)
 			if err != nil {
 				return nil, errors.New("unable to convert '" + valStr + "' to " + col.TypeInfo.String())
 			}
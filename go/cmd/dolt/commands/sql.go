@@ -41,6 +41,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
@@ -232,6 +233,8 @@ func (cmd SqlCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		sql.WithIndexRegistry(sql.NewIndexRegistry()),
 		sql.WithViewRegistry(sql.NewViewRegistry()))
 	sqlCtx.Set(sqlCtx, sql.AutoCommitSessionVar, sql.Boolean, true)
+	sqlCtx.Set(sqlCtx, dsqle.UserNameSessionVar, sql.Text, dsess.Username)
+	sqlCtx.Set(sqlCtx, dsqle.UserEmailSessionVar, sql.Text, dsess.Email)
 
 	roots := make(map[string]*doltdb.RootValue)
 
@@ -318,10 +321,11 @@ func (cmd SqlCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
-	// If the SQL session wrote a new root value, update the working set with it
+	// If the SQL session wrote a new root value, update the working set with it, unless the session checked out a branch
+	// other than the repository's
 	for name, origRoot := range initialRoots {
 		root := roots[name]
-		if origRoot != root {
+		if origRoot != root && !dsess.HasOwnWorkingSet(name) {
 			currEnv := mrEnv[name]
 			verr = UpdateWorkingWithVErr(currEnv, root)
 		}
@@ -1154,6 +1158,8 @@ func (se *sqlEngine) getRoots(sqlCtx *sql.Context) (map[string]*doltdb.RootValue
 
 // Execute a SQL statement and return values for printing.
 func (se *sqlEngine) query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, error) {
+	query = dsqle.RewriteQuery(query)
+	ctx.ApplyOpts(sql.WithQuery(query))
 	return se.engine.Query(ctx, query)
 }
 
@@ -1239,15 +1245,21 @@ func (se *sqlEngine) prettyPrintResults(ctx context.Context, sqlSch sql.Schema,
 	var rowFn func(r sql.Row) (row.Row, error)
 	switch se.resultFormat {
 	case formatJson:
+		// results are only written out, so values stored out of row, such as blobs, can be held in memory
+		vrw := types.NewMemoryValueStore()
 		rowFn = func(r sql.Row) (r2 row.Row, err error) {
-			return dsqle.SqlRowToDoltRow(nbf, r, doltSch)
+			return dsqle.SqlRowToDoltRow(ctx, vrw, r, doltSch)
 		}
 	default:
 		rowFn = func(r sql.Row) (row.Row, error) {
 			taggedVals := make(row.TaggedValues)
 			for i, col := range r {
 				if col != nil {
-					taggedVals[uint64(i)] = types.String(fmt.Sprintf("%v", col))
+					str, err := sqlValueAsString(sqlSch[i].Type, col)
+					if err != nil {
+						return nil, err
+					}
+					taggedVals[uint64(i)] = types.String(str)
 				}
 			}
 			return row.New(nbf, untypedSch, taggedVals)
@@ -1282,6 +1294,20 @@ func (se *sqlEngine) prettyPrintResults(ctx context.Context, sqlSch sql.Schema,
 	return nil
 }
 
+// sqlValueAsString returns the string representation of the value given, which is of the sql type given.
+func sqlValueAsString(sqlType sql.Type, val interface{}) (string, error) {
+	if b, ok := val.([]byte); ok {
+		return string(b), nil
+	}
+
+	if sqlType == sql.JSON || sqlType == typeinfo.JSONSqlType {
+		// documents returned from functions such as JSON_EXTRACT are decoded values
+		return typeinfo.MarshalJSON(val)
+	}
+
+	return fmt.Sprintf("%v", val), nil
+}
+
 func printOKResult(ctx context.Context, iter sql.RowIter) error {
 	row, err := iter.Next()
 	defer iter.Close()
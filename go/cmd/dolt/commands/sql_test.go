@@ -147,7 +147,7 @@ func TestCreateTable(t *testing.T) {
 		query       string
 		expectedRes int
 	}{
-		{"create table people (id int)", 1}, // no primary key
+		{"create table people (id int)", 0}, // no primary key
 		{"create table", 1},                 // bad syntax
 		{"create table (id int ", 1},        // bad syntax
 		{"create table people (id int primary key)", 0},
@@ -0,0 +1,304 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
+)
+
+// metricsPath is the path at which the metrics listener exports the server's metrics
+const metricsPath = "/metrics"
+
+// queryDurationBuckets are the upper bounds, in seconds, of the buckets of the query latency histograms
+var queryDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// durationHistogram counts the durations observed in each of the queryDurationBuckets
+type durationHistogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+func (hist *durationHistogram) observe(seconds float64) {
+	for i, bound := range queryDurationBuckets {
+		if seconds <= bound {
+			hist.buckets[i]++
+			break
+		}
+	}
+
+	hist.count++
+	hist.sum += seconds
+}
+
+// serverMetrics collects the metrics of a server: its queries, connections and commits, and the storage of its
+// databases. It is an http.Handler which writes them in the Prometheus text format.
+type serverMetrics struct {
+	mu          *sync.Mutex
+	queries     map[string]*durationHistogram
+	queryErrors map[string]uint64
+	commits     map[string]uint64
+	connections int64
+
+	dbs []dsqle.Database
+}
+
+var _ http.Handler = &serverMetrics{}
+
+func newServerMetrics(dbs []dsqle.Database) *serverMetrics {
+	return &serverMetrics{
+		mu:          &sync.Mutex{},
+		queries:     make(map[string]*durationHistogram),
+		queryErrors: make(map[string]uint64),
+		commits:     make(map[string]uint64),
+		dbs:         dbs,
+	}
+}
+
+// statementType returns the type of the statement of the query given, such as select or insert, which labels the
+// query metrics.
+func statementType(query string) string {
+	return strings.ToLower(sqlparser.Preview(query).String())
+}
+
+// queryDone records a query, the time it took and whether it failed.
+func (m *serverMetrics) queryDone(query string, d time.Duration, err error) {
+	stmtType := statementType(query)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hist, ok := m.queries[stmtType]
+
+	if !ok {
+		hist = &durationHistogram{buckets: make([]uint64, len(queryDurationBuckets))}
+		m.queries[stmtType] = hist
+	}
+
+	hist.observe(d.Seconds())
+
+	if err != nil {
+		m.queryErrors[stmtType]++
+	}
+}
+
+func (m *serverMetrics) connectionOpened() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connections++
+}
+
+func (m *serverMetrics) connectionClosed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connections--
+}
+
+// commitWritten records a commit written to the database given by COMMIT() or MERGE().
+func (m *serverMetrics) commitWritten(dbName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.commits[dbName]++
+}
+
+// serve starts an HTTP listener on the address given which exports the metrics, and returns a function which stops it.
+func (m *serverMetrics) serve(addr string) (func() error, error) {
+	l, err := net.Listen("tcp", addr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, m)
+	srv := &http.Server{Handler: mux}
+
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	return srv.Close, nil
+}
+
+// ServeHTTP implements http.Handler
+func (m *serverMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	err := m.write(r.Context(), w)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// write writes the metrics in the Prometheus text format.
+func (m *serverMetrics) write(ctx context.Context, w io.Writer) error {
+	storage, err := m.storageMetrics(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	m.writeServerMetrics(bw)
+	storage.write(bw)
+	return bw.Flush()
+}
+
+func (m *serverMetrics) writeServerMetrics(w *bufio.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	writeHeader(w, "dolt_sql_server_query_duration_seconds", "histogram", "The time taken by queries, by statement type.")
+	for _, stmtType := range sortedKeys(m.queries) {
+		hist := m.queries[stmtType]
+		label := fmt.Sprintf("statement=%s", quoteLabel(stmtType))
+
+		var cumulative uint64
+		for i, bound := range queryDurationBuckets {
+			cumulative += hist.buckets[i]
+			fmt.Fprintf(w, "dolt_sql_server_query_duration_seconds_bucket{%s,le=\"%s\"} %d\n", label, formatFloat(bound), cumulative)
+		}
+
+		fmt.Fprintf(w, "dolt_sql_server_query_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", label, hist.count)
+		fmt.Fprintf(w, "dolt_sql_server_query_duration_seconds_sum{%s} %s\n", label, formatFloat(hist.sum))
+		fmt.Fprintf(w, "dolt_sql_server_query_duration_seconds_count{%s} %d\n", label, hist.count)
+	}
+
+	writeHeader(w, "dolt_sql_server_query_errors_total", "counter", "The number of queries which failed, by statement type.")
+	for _, stmtType := range sortedKeys(m.queries) {
+		fmt.Fprintf(w, "dolt_sql_server_query_errors_total{statement=%s} %d\n", quoteLabel(stmtType), m.queryErrors[stmtType])
+	}
+
+	writeHeader(w, "dolt_sql_server_connections", "gauge", "The number of open connections.")
+	fmt.Fprintf(w, "dolt_sql_server_connections %d\n", m.connections)
+
+	writeHeader(w, "dolt_sql_server_commits_total", "counter", "The number of commits written by COMMIT() and MERGE(), by database.")
+	for _, db := range m.dbs {
+		fmt.Fprintf(w, "dolt_sql_server_commits_total{database=%s} %d\n", quoteLabel(db.Name()), m.commits[db.Name()])
+	}
+}
+
+// dbStorageMetrics are the metrics of the storage of a database.
+type dbStorageMetrics struct {
+	name           string
+	hasStats       bool
+	memTableHits   uint64
+	memTableMisses uint64
+	hasTableFiles  bool
+	tableFiles     int
+}
+
+type storageMetrics []dbStorageMetrics
+
+// storageMetrics reads the metrics of the storage of each database from the statistics of its NomsBlockStore.
+// Databases which are not stored in table files, such as in memory databases, have none.
+func (m *serverMetrics) storageMetrics(ctx context.Context) (storageMetrics, error) {
+	var metrics storageMetrics
+	for _, db := range m.dbs {
+		dbMetrics := dbStorageMetrics{name: db.Name()}
+		ddb := db.GetDoltDB()
+
+		stats := ddb.CSMetrics()
+
+		if csMetrics, ok := stats.(chunks.CSMetrics); ok {
+			stats = csMetrics.Delegate
+		}
+
+		if stats, ok := stats.(nbs.Stats); ok {
+			dbMetrics.hasStats = true
+			dbMetrics.memTableHits = atomic.LoadUint64(&stats.MemTableHits)
+			dbMetrics.memTableMisses = atomic.LoadUint64(&stats.MemTableMisses)
+
+			tableFiles, err := ddb.TableFileCount(ctx)
+
+			if err != nil {
+				return nil, err
+			}
+
+			dbMetrics.hasTableFiles = true
+			dbMetrics.tableFiles = tableFiles
+		}
+
+		metrics = append(metrics, dbMetrics)
+	}
+
+	return metrics, nil
+}
+
+func (metrics storageMetrics) write(w *bufio.Writer) {
+	writeHeader(w, "dolt_nbs_memtable_hits_total", "counter", "The number of chunks read from the memtable of chunks not yet persisted to table files, by database.")
+	for _, db := range metrics {
+		if db.hasStats {
+			fmt.Fprintf(w, "dolt_nbs_memtable_hits_total{database=%s} %d\n", quoteLabel(db.name), db.memTableHits)
+		}
+	}
+
+	writeHeader(w, "dolt_nbs_memtable_misses_total", "counter", "The number of chunks read which were not in the memtable, and were read from table files, by database.")
+	for _, db := range metrics {
+		if db.hasStats {
+			fmt.Fprintf(w, "dolt_nbs_memtable_misses_total{database=%s} %d\n", quoteLabel(db.name), db.memTableMisses)
+		}
+	}
+
+	writeHeader(w, "dolt_nbs_table_files", "gauge", "The number of table files storing each database.")
+	for _, db := range metrics {
+		if db.hasTableFiles {
+			fmt.Fprintf(w, "dolt_nbs_table_files{database=%s} %d\n", quoteLabel(db.name), db.tableFiles)
+		}
+	}
+}
+
+func writeHeader(w *bufio.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabel returns the label value given quoted and escaped as required by the Prometheus text format.
+func quoteLabel(val string) string {
+	return `"` + labelEscaper.Replace(val) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedKeys(m map[string]*durationHistogram) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementType(t *testing.T) {
+	assert.Equal(t, "select", statementType("SELECT * FROM people"))
+	assert.Equal(t, "insert", statementType("  insert into people values (1)"))
+	assert.Equal(t, "ddl", statementType("CREATE TABLE t (pk int primary key)"))
+	assert.Equal(t, "commit", statementType("COMMIT"))
+	assert.Equal(t, "unknown", statementType("GRANT SELECT ON *.* TO 'bob'"))
+}
+
+func TestServerMetricsWrite(t *testing.T) {
+	m := newServerMetrics(nil)
+	m.queryDone("SELECT 1", 2*time.Millisecond, nil)
+	m.queryDone("SELECT 2", 200*time.Millisecond, nil)
+	m.queryDone("SELECT 3", time.Minute, errors.New("timeout"))
+	m.queryDone("INSERT INTO t VALUES (1)", 20*time.Millisecond, nil)
+	m.connectionOpened()
+	m.connectionOpened()
+	m.connectionClosed()
+
+	var buf bytes.Buffer
+	err := m.write(context.Background(), &buf)
+	require.NoError(t, err)
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE dolt_sql_server_query_duration_seconds histogram\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_bucket{statement="select",le="0.005"} 1`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_bucket{statement="select",le="0.1"} 1`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_bucket{statement="select",le="0.25"} 2`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_bucket{statement="select",le="10"} 2`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_bucket{statement="select",le="+Inf"} 3`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_sum{statement="select"} 60.202`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_count{statement="select"} 3`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_count{statement="insert"} 1`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_errors_total{statement="select"} 1`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_errors_total{statement="insert"} 0`+"\n")
+	assert.Contains(t, out, "dolt_sql_server_connections 1\n")
+}
+
+func TestQuoteLabel(t *testing.T) {
+	assert.Equal(t, `"dolt"`, quoteLabel("dolt"))
+	assert.Equal(t, `"a\"b\\c\nd"`, quoteLabel("a\"b\\c\nd"))
+}
@@ -0,0 +1,357 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	sqle "github.com/liquidata-inc/go-mysql-server"
+	"github.com/liquidata-inc/go-mysql-server/server"
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/opentracing/opentracing-go"
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/privileges"
+)
+
+// erSecureTransportRequired is the MySQL error returned to clients which do not use TLS when it is required
+const erSecureTransportRequired = 3159
+
+// privilegeHandler executes the statements which manage users and privileges, which the sql engine does not support,
+// and passes every other query to the engine's handler. If the server requires secure transport, it also refuses the
+// commands of connections which do not use TLS. Database names such as `mydb/feature-x`, given when connecting or in a
+// USE statement, select the database and check out the branch in the connection's session. START TRANSACTION and
+// ROLLBACK are executed by the connection's session, which also starts the transactions of each statement. The
+// handler records the server's connections and queries in its metrics.
+type privilegeHandler struct {
+	*server.Handler
+	sm            *server.SessionManager
+	users         *privileges.UserStore
+	requireSecure bool
+	metrics       *serverMetrics
+}
+
+var _ mysql.Handler = privilegeHandler{}
+
+// checkSecureTransport returns an error if the server requires secure transport and the connection does not use TLS.
+// The listener writes the same error during the handshake, but does not close the connection.
+func (h privilegeHandler) checkSecureTransport(c *mysql.Conn) error {
+	if h.requireSecure && c.Capabilities&mysql.CapabilityClientSSL == 0 {
+		return mysql.NewSQLError(erSecureTransportRequired, mysql.SSUnknownSQLState, "Connections using insecure transport are prohibited while require_secure_transport is enabled.")
+	}
+
+	return nil
+}
+
+// NewConnection implements mysql.Handler
+func (h privilegeHandler) NewConnection(c *mysql.Conn) {
+	h.metrics.connectionOpened()
+	h.Handler.NewConnection(c)
+}
+
+// ConnectionClosed implements mysql.Handler
+func (h privilegeHandler) ConnectionClosed(c *mysql.Conn) {
+	h.metrics.connectionClosed()
+	h.Handler.ConnectionClosed(c)
+}
+
+// ComInitDB implements mysql.Handler
+func (h privilegeHandler) ComInitDB(c *mysql.Conn, schemaName string) error {
+	if err := h.checkSecureTransport(c); err != nil {
+		return err
+	}
+
+	return h.useDatabase(c, schemaName)
+}
+
+// useDatabase selects the database given for the connection. If the name also gives a branch, the branch is checked
+// out in the connection's session. The user must have at least the SELECT privilege on the database.
+func (h privilegeHandler) useDatabase(c *mysql.Conn, schemaName string) error {
+	dbName, branch, ok := dsqle.SplitBranchDatabase(schemaName)
+
+	if !ok {
+		dbName = schemaName
+	}
+
+	if dbName != "" {
+		req := privileges.Requirement{Database: dbName, Privilege: privileges.PrivilegeSelect}
+		if _, allowed := h.users.Check(c.User, []privileges.Requirement{req}); !allowed {
+			return mysql.NewSQLError(mysql.ERDBAccessDenied, mysql.SSUnknownSQLState, "Access denied for user '%s' to database '%s'", c.User, dbName)
+		}
+	}
+
+	if !ok {
+		return h.Handler.ComInitDB(c, schemaName)
+	}
+
+	ctx, err := h.sm.NewContext(c)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = dsqle.DSessFromSess(ctx.Session).CheckoutBranch(ctx, dbName, branch)
+
+	if sql.ErrDatabaseNotFound.Is(err) {
+		return mysql.NewSQLError(mysql.ERBadDb, mysql.SSUnknownSQLState, "%s", err.Error())
+	} else if err != nil {
+		return mysql.NewSQLError(mysql.ERUnknownError, mysql.SSUnknownSQLState, "%s", err.Error())
+	}
+
+	return h.Handler.ComInitDB(c, dbName)
+}
+
+// parseUseBranch returns the database name of the query given if it is a USE statement naming a branch of a database,
+// such as USE `mydb/feature-x`, which the sql engine cannot resolve.
+func parseUseBranch(query string) (string, bool) {
+	trimmed := strings.TrimSpace(query)
+	if len(trimmed) < 3 || !strings.EqualFold(trimmed[:3], "use") || !strings.Contains(trimmed, dsqle.BranchDatabaseSeparator) {
+		return "", false
+	}
+
+	stmt, err := sqlparser.Parse(trimmed)
+
+	if err != nil {
+		return "", false
+	}
+
+	use, ok := stmt.(*sqlparser.Use)
+
+	if !ok {
+		return "", false
+	}
+
+	dbName := use.DBName.String()
+	_, _, ok = dsqle.SplitBranchDatabase(dbName)
+	return dbName, ok
+}
+
+// parseTransactionStatement returns the statement of the query given if it is START TRANSACTION, BEGIN or ROLLBACK,
+// which the sql engine does not execute.
+func parseTransactionStatement(query string) (sqlparser.Statement, bool) {
+	trimmed := strings.TrimSpace(query)
+	lower := strings.ToLower(trimmed)
+	if !strings.HasPrefix(lower, "start") && !strings.HasPrefix(lower, "begin") && !strings.HasPrefix(lower, "rollback") {
+		return nil, false
+	}
+
+	stmt, err := sqlparser.Parse(trimmed)
+
+	if err != nil {
+		return nil, false
+	}
+
+	switch stmt.(type) {
+	case *sqlparser.Begin, *sqlparser.Rollback:
+		return stmt, true
+	default:
+		return nil, false
+	}
+}
+
+// execTransactionStatement starts or rolls back a transaction in the connection's session.
+func (h privilegeHandler) execTransactionStatement(c *mysql.Conn, stmt sqlparser.Statement) error {
+	ctx, err := h.sm.NewContext(c)
+
+	if err != nil {
+		return err
+	}
+
+	dsess := dsqle.DSessFromSess(ctx.Session)
+
+	if _, ok := stmt.(*sqlparser.Begin); ok {
+		err = dsess.StartTransaction(ctx)
+	} else {
+		err = dsess.RollbackTransaction(ctx)
+	}
+
+	return transactionError(err)
+}
+
+// runStatement starts the transactions of a statement in the connection's session, so that statements outside of a
+// transaction read the latest working set, and then runs it.
+func (h privilegeHandler) runStatement(c *mysql.Conn, run func() error) error {
+	ctx, err := h.sm.NewContext(c)
+
+	if err != nil {
+		return err
+	}
+
+	err = dsqle.DSessFromSess(ctx.Session).StartStatement(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return transactionError(run())
+}
+
+// transactionError returns MySQL's serialization failure for transactions which could not be committed because they
+// conflict with changes committed concurrently, so that clients know to restart them.
+func transactionError(err error) error {
+	if dsqle.ErrTransactionConflict.Is(err) {
+		return mysql.NewSQLError(mysql.ERLockDeadlock, mysql.SSLockDeadlock, "%s", err.Error())
+	}
+
+	return err
+}
+
+// ComPrepare implements mysql.Handler
+func (h privilegeHandler) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Field, error) {
+	if err := h.checkSecureTransport(c); err != nil {
+		return nil, err
+	}
+
+	return h.Handler.ComPrepare(c, query)
+}
+
+// ComStmtExecute implements mysql.Handler
+func (h privilegeHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	start := time.Now()
+	err := h.comStmtExecute(c, prepare, callback)
+	h.metrics.queryDone(prepare.PrepareStmt, time.Since(start), err)
+	return err
+}
+
+func (h privilegeHandler) comStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	if err := h.checkSecureTransport(c); err != nil {
+		return err
+	}
+
+	return h.runStatement(c, func() error {
+		return h.Handler.ComStmtExecute(c, prepare, callback)
+	})
+}
+
+// ComQuery implements mysql.Handler
+func (h privilegeHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	start := time.Now()
+	err := h.comQuery(c, query, callback)
+	h.metrics.queryDone(query, time.Since(start), err)
+	return err
+}
+
+func (h privilegeHandler) comQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	if err := h.checkSecureTransport(c); err != nil {
+		return err
+	}
+
+	if dbName, ok := parseUseBranch(query); ok {
+		if err := h.useDatabase(c, dbName); err != nil {
+			return err
+		}
+
+		return callback(&sqltypes.Result{})
+	}
+
+	if txStmt, ok := parseTransactionStatement(query); ok {
+		if err := h.execTransactionStatement(c, txStmt); err != nil {
+			return err
+		}
+
+		return callback(&sqltypes.Result{})
+	}
+
+	stmt, err := privileges.ParseStatement(query)
+
+	if err != nil {
+		return mysql.NewSQLError(mysql.ERParseError, mysql.SSUnknownSQLState, "%s", err.Error())
+	} else if stmt == nil {
+		return h.runStatement(c, func() error {
+			return h.Handler.ComQuery(c, dsqle.RewriteQuery(query), callback)
+		})
+	}
+
+	rows, err := privileges.ExecStatement(h.users, c.User, stmt)
+
+	if err == privileges.ErrNotSuperuser {
+		return mysql.NewSQLError(mysql.ERSpecifiedAccessDenied, mysql.SSUnknownSQLState, "%s", err.Error())
+	} else if err != nil {
+		return mysql.NewSQLError(mysql.ERUnknownError, mysql.SSUnknownSQLState, "%s", err.Error())
+	}
+
+	showGrants, ok := stmt.(privileges.ShowGrants)
+
+	if !ok {
+		return callback(&sqltypes.Result{})
+	}
+
+	user := showGrants.User
+	if user == "" {
+		user = c.User
+	}
+
+	result := &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: fmt.Sprintf("Grants for %s", user), Type: sqltypes.VarChar}},
+	}
+
+	for _, row := range rows {
+		result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewVarChar(row)})
+	}
+
+	result.RowsAffected = uint64(len(result.Rows))
+	return callback(result)
+}
+
+// newServer creates a server in the same way as server.NewServer, but with a handler which executes the statements
+// managing the given users. If tlsConfig is not nil, clients may negotiate TLS during the handshake, and must do so if
+// requireSecure is set. The server's connections and queries are recorded in the metrics given.
+func newServer(cfg server.Config, e *sqle.Engine, sb server.SessionBuilder, users *privileges.UserStore, tlsConfig *tls.Config, requireSecure bool, metrics *serverMetrics) (*server.Server, error) {
+	if cfg.ConnReadTimeout < 0 {
+		cfg.ConnReadTimeout = 0
+	}
+
+	if cfg.ConnWriteTimeout < 0 {
+		cfg.ConnWriteTimeout = 0
+	}
+
+	if cfg.MaxConnections == 0 {
+		cfg.MaxConnections = 1
+	}
+
+	sm := server.NewSessionManager(sb, opentracing.NoopTracer{}, e.Catalog.HasDB, e.Catalog.MemoryManager, cfg.Address)
+	handler := server.NewHandler(e, sm, cfg.ConnReadTimeout)
+	l, err := server.NewListener(cfg.Protocol, cfg.Address, handler)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vtListnr, err := mysql.NewListenerWithConfig(mysql.ListenerConfig{
+		Listener:           l,
+		AuthServer:         cfg.Auth.Mysql(),
+		Handler:            privilegeHandler{handler, sm, users, requireSecure, metrics},
+		ConnReadTimeout:    cfg.ConnReadTimeout,
+		ConnWriteTimeout:   cfg.ConnWriteTimeout,
+		MaxConns:           cfg.MaxConnections,
+		ConnReadBufferSize: mysql.DefaultConnBufferSize,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	vtListnr.TLSConfig = tlsConfig
+	vtListnr.RequireSecureTransport = requireSecure
+
+	return &server.Server{Listener: vtListnr}, nil
+}
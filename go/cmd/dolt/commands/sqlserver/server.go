@@ -16,6 +16,7 @@ package sqlserver
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"strconv"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/liquidata-inc/go-mysql-server/auth"
 	"github.com/liquidata-inc/go-mysql-server/server"
 	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/liquidata-inc/go-mysql-server/sql/analyzer"
 	"github.com/sirupsen/logrus"
 	"vitess.io/vitess/go/mysql"
 
@@ -33,6 +35,7 @@ import (
 	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/dfunctions"
 	_ "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/privileges"
 )
 
 // Serve starts a MySQL-compatible server. Returns any errors that were encountered.
@@ -73,15 +76,19 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		logrus.SetLevel(level)
 	}
 
-	permissions := auth.AllPermissions
-	if serverConfig.ReadOnly() {
-		permissions = auth.ReadPerm
+	users, err := privileges.NewUserStore(dEnv.FS, serverConfig.PrivilegeFilePath())
+
+	if err != nil {
+		return err, nil
 	}
 
-	userAuth := auth.NewAudit(auth.NewNativeSingle(serverConfig.User(), serverConfig.Password(), permissions), auth.NewAuditLog(logrus.StandardLogger()))
-	sqlEngine := sqle.NewDefault()
+	users.AddSuperuser(serverConfig.User(), serverConfig.Password())
 
-	err := sqlEngine.Catalog.Register(dfunctions.DoltFunctions...)
+	userAuth := auth.NewAudit(privileges.NewAuth(users, serverConfig.ReadOnly()), auth.NewAuditLog(logrus.StandardLogger()))
+	catalog := sql.NewCatalog()
+	sqlEngine := sqle.New(catalog, analyzer.NewDefault(catalog), &sqle.Config{Auth: userAuth})
+
+	err = sqlEngine.Catalog.Register(dfunctions.DoltFunctions...)
 
 	if err != nil {
 		return nil, err
@@ -117,11 +124,25 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 	}
 
 	sqlEngine.AddDatabase(sql.NewInformationSchemaDatabase(sqlEngine.Catalog))
+	metrics := newServerMetrics(dbs)
+
+	var tlsConfig *tls.Config
+	if serverConfig.TLSCert() != "" {
+		certs, err := newCertificateReloader(dEnv.FS, serverConfig.TLSKey(), serverConfig.TLSCert())
+
+		if err != nil {
+			return err, nil
+		}
+
+		stopReloading := certs.reloadOnSignal()
+		defer stopReloading()
+		tlsConfig = certs.tlsConfig()
+	}
 
 	hostPort := net.JoinHostPort(serverConfig.Host(), strconv.Itoa(serverConfig.Port()))
 	readTimeout := time.Duration(serverConfig.ReadTimeout()) * time.Millisecond
 	writeTimeout := time.Duration(serverConfig.WriteTimeout()) * time.Millisecond
-	mySQLServer, startError = server.NewServer(
+	mySQLServer, startError = newServer(
 		server.Config{
 			Protocol:         "tcp",
 			Address:          hostPort,
@@ -133,7 +154,11 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 			// to the value of mysql that we support.
 		},
 		sqlEngine,
-		newSessionBuilder(sqlEngine, username, email, serverConfig.AutoCommit()),
+		newSessionBuilder(sqlEngine, newCommitAuthors(serverConfig, username, email), serverConfig.AutoCommit(), metrics),
+		users,
+		tlsConfig,
+		serverConfig.RequireSecureTransport(),
+		metrics,
 	)
 
 	if startError != nil {
@@ -141,6 +166,21 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		return
 	}
 
+	if serverConfig.MetricsPort() != 0 {
+		var stopMetrics func() error
+		metricsHostPort := net.JoinHostPort(serverConfig.MetricsHost(), strconv.Itoa(serverConfig.MetricsPort()))
+		stopMetrics, startError = metrics.serve(metricsHostPort)
+
+		if startError != nil {
+			cli.PrintErr(startError)
+			return
+		}
+
+		defer func() {
+			_ = stopMetrics()
+		}()
+	}
+
 	serverController.registerCloseFunction(startError, mySQLServer.Close)
 	closeError = mySQLServer.Start()
 	if closeError != nil {
@@ -150,15 +190,44 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 	return
 }
 
-func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommit bool) server.SessionBuilder {
+// commitAuthors gives the author of the commits made by each user of the server.
+type commitAuthors struct {
+	serverUser string
+	repoAuthor CommitAuthor
+	byUser     map[string]CommitAuthor
+}
+
+func newCommitAuthors(serverConfig ServerConfig, repoUsername, repoEmail string) commitAuthors {
+	return commitAuthors{serverConfig.User(), CommitAuthor{repoUsername, repoEmail}, serverConfig.CommitAuthors()}
+}
+
+// forUser returns the author of the commits made by the user given, and whether it is set in the server config. A
+// user whose author is given in the server config uses that author, and may not change it. The server's own user uses
+// the author from the repository config. Any other user is the author of their commits under their user name, and
+// must set @@dolt_user_email before committing.
+func (ca commitAuthors) forUser(user string) (author CommitAuthor, fixed bool) {
+	if author, ok := ca.byUser[user]; ok {
+		return author, true
+	} else if user == ca.serverUser {
+		return ca.repoAuthor, false
+	}
+
+	return CommitAuthor{Name: user}, false
+}
+
+func newSessionBuilder(sqlEngine *sqle.Engine, authors commitAuthors, autocommit bool, metrics *serverMetrics) server.SessionBuilder {
 	return func(ctx context.Context, conn *mysql.Conn, host string) (sql.Session, *sql.IndexRegistry, *sql.ViewRegistry, error) {
 		mysqlSess := sql.NewSession(host, conn.RemoteAddr().String(), conn.User, conn.ConnectionID)
-		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, username, email, dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())...)
+		author, authorFixed := authors.forUser(conn.User)
+		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, author.Name, author.Email, dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())...)
 
 		if err != nil {
 			return nil, nil, nil, err
 		}
 
+		doltSess.AuthorFixed = authorFixed
+
+		doltSess.CommitListener = metrics.commitWritten
 		err = doltSess.Set(ctx, sql.AutoCommitSessionVar, sql.Boolean, autocommit)
 
 		if err != nil {
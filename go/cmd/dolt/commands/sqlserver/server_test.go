@@ -15,6 +15,8 @@
 package sqlserver
 
 import (
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -24,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
@@ -113,6 +116,9 @@ func TestServerBadArgs(t *testing.T) {
 		{"-P", "90000"},
 		{"-u", ""},
 		{"-l", "everything"},
+		{"--tls-key", "key.pem"},
+		{"--require-secure-transport"},
+		{"--tls-key", "missing_key.pem", "--tls-cert", "missing_cert.pem"},
 	}
 
 	for _, test := range tests {
@@ -220,6 +226,483 @@ func TestServerSelect(t *testing.T) {
 	}
 }
 
+func TestServerPrivileges(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15301).withMaxConnections(4)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	const dbName = "dolt"
+	root, err := dbr.Open("mysql", ConnectionString(serverConfig)+dbName, nil)
+	require.NoError(t, err)
+	defer root.Close()
+
+	for _, query := range []string{
+		"CREATE USER 'reader'@'%' IDENTIFIED BY 'pass'",
+		"GRANT SELECT ON dolt.* TO reader",
+	} {
+		_, err = root.Exec(query)
+		require.NoError(t, err, query)
+	}
+
+	reader, err := dbr.Open("mysql", "reader:pass@tcp(localhost:15301)/"+dbName, nil)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var count int
+	err = reader.QueryRow("SELECT COUNT(*) FROM people").Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	_, err = reader.Exec("DELETE FROM people")
+	assert.Error(t, err)
+	_, err = reader.Exec("CREATE USER writer")
+	assert.Error(t, err)
+
+	var grant string
+	err = reader.QueryRow("SHOW GRANTS").Scan(&grant)
+	require.NoError(t, err)
+	assert.Equal(t, "GRANT USAGE ON *.* TO `reader`", grant)
+
+	// a user without privileges on a database may not use it, or check out its branches
+	_, err = root.Exec("CREATE USER 'nobody'@'%' IDENTIFIED BY 'pass'")
+	require.NoError(t, err)
+	nobody, err := dbr.Open("mysql", "nobody:pass@tcp(localhost:15301)/"+dbName, nil)
+	require.NoError(t, err)
+	defer nobody.Close()
+	assert.Error(t, nobody.Ping())
+
+	nobody, err = dbr.Open("mysql", "nobody:pass@tcp(localhost:15301)/", nil)
+	require.NoError(t, err)
+	defer nobody.Close()
+	_, err = nobody.Exec("USE " + dbName)
+	assert.Error(t, err)
+	_, err = nobody.Exec("USE `" + dbName + "/master`")
+	assert.Error(t, err)
+
+	_, err = reader.Exec("USE `" + dbName + "/master`")
+	assert.NoError(t, err)
+
+	_, err = root.Exec("DROP USER reader")
+	require.NoError(t, err)
+
+	badConn, err := dbr.Open("mysql", "reader:pass@tcp(localhost:15301)/"+dbName, nil)
+	require.NoError(t, err)
+	defer badConn.Close()
+	assert.Error(t, badConn.Ping())
+}
+
+func TestServerCommitAuthors(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15303).withMaxConnections(4).
+		withCommitAuthors(map[string]CommitAuthor{"writer": {Name: "Writer", Email: "writer@fake.horse"}})
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	const dbName = "dolt"
+	root, err := dbr.Open("mysql", ConnectionString(serverConfig)+dbName, nil)
+	require.NoError(t, err)
+	defer root.Close()
+
+	for _, query := range []string{
+		"CREATE USER 'writer'@'%' IDENTIFIED BY 'pass'",
+		"CREATE USER 'other'@'%' IDENTIFIED BY 'pass'",
+		"GRANT ALL ON dolt.* TO writer",
+		"GRANT ALL ON dolt.* TO other",
+	} {
+		_, err = root.Exec(query)
+		require.NoError(t, err, query)
+	}
+
+	commitAuthor := func(conn *dbr.Connection) (*doltdb.CommitMeta, error) {
+		var h string
+		err := conn.QueryRow("SELECT COMMIT('commit message')").Scan(&h)
+		if err != nil {
+			return nil, err
+		}
+
+		cs, err := doltdb.NewCommitSpec(h)
+		require.NoError(t, err)
+		cm, err := env.DoltDB.Resolve(context.Background(), cs, nil)
+		require.NoError(t, err)
+		return cm.GetCommitMeta()
+	}
+
+	meta, err := commitAuthor(root)
+	require.NoError(t, err)
+	assert.Equal(t, "billy bob", meta.Name)
+	assert.Equal(t, "bigbillieb@fake.horse", meta.Email)
+
+	writer, err := dbr.Open("mysql", "writer:pass@tcp(localhost:15303)/"+dbName, nil)
+	require.NoError(t, err)
+	defer writer.Close()
+	writer.SetMaxOpenConns(1)
+	meta, err = commitAuthor(writer)
+	require.NoError(t, err)
+	assert.Equal(t, "Writer", meta.Name)
+	assert.Equal(t, "writer@fake.horse", meta.Email)
+
+	// the author given in the server config may not be changed by the user
+	_, err = writer.Exec("SET @@dolt_user_name = 'Other'")
+	assert.Error(t, err)
+	_, err = writer.Exec("SET @@DOLT_USER_EMAIL = 'other@fake.horse'")
+	assert.Error(t, err)
+	meta, err = commitAuthor(writer)
+	require.NoError(t, err)
+	assert.Equal(t, "Writer", meta.Name)
+	assert.Equal(t, "writer@fake.horse", meta.Email)
+
+	// a single connection is used so that the session variable is set for the commit
+	other, err := dbr.Open("mysql", "other:pass@tcp(localhost:15303)/"+dbName, nil)
+	require.NoError(t, err)
+	defer other.Close()
+	other.SetMaxOpenConns(1)
+	_, err = commitAuthor(other)
+	assert.Error(t, err)
+
+	_, err = other.Exec("SET @@dolt_user_email = 'other@fake.horse'")
+	require.NoError(t, err)
+	meta, err = commitAuthor(other)
+	require.NoError(t, err)
+	assert.Equal(t, "other", meta.Name)
+	assert.Equal(t, "other@fake.horse", meta.Email)
+}
+
+func TestServerBranchSessions(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15304).withMaxConnections(4)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	// each connection uses a single session, which keeps the branch it checked out
+	connect := func(dbName string) *dbr.Connection {
+		conn, err := dbr.Open("mysql", ConnectionString(serverConfig), nil)
+		require.NoError(t, err)
+		conn.SetMaxOpenConns(1)
+		_, err = conn.Exec("USE `" + dbName + "`")
+		require.NoError(t, err)
+		return conn
+	}
+
+	queryString := func(conn *dbr.Connection, query string) string {
+		var res string
+		err := conn.QueryRow(query).Scan(&res)
+		require.NoError(t, err, query)
+		return res
+	}
+
+	countPeople := func(conn *dbr.Connection) int {
+		var count int
+		err := conn.QueryRow("SELECT COUNT(*) FROM people").Scan(&count)
+		require.NoError(t, err)
+		return count
+	}
+
+	master := connect("dolt/master")
+	defer master.Close()
+	h := queryString(master, "SELECT COMMIT('add people')")
+	masterHead := queryString(master, "SELECT hashof('master')")
+	assert.Equal(t, h, masterHead)
+	_, err = master.Exec("INSERT INTO dolt_branches (name, hash) VALUES ('feature', hashof('master'))")
+	require.NoError(t, err)
+
+	feature := connect("dolt/feature")
+	defer feature.Close()
+	_, err = feature.Exec("DELETE FROM people WHERE name = 'Bill Billerson'")
+	require.NoError(t, err)
+	assert.Equal(t, 2, countPeople(feature))
+	assert.Equal(t, 3, countPeople(master))
+
+	_, err = feature.Exec("SELECT dolt_checkout('master')")
+	assert.Error(t, err)
+
+	h = queryString(feature, "SELECT COMMIT('remove bill')")
+	assert.Equal(t, h, queryString(master, "SELECT hashof('feature')"))
+	assert.Equal(t, masterHead, queryString(master, "SELECT hashof('master')"))
+
+	_, err = master.Exec("USE `dolt/feature`")
+	require.NoError(t, err)
+	assert.Equal(t, 2, countPeople(master))
+	assert.Equal(t, masterHead, queryString(master, "SELECT dolt_checkout('master')"))
+	assert.Equal(t, 3, countPeople(master))
+
+	_, err = master.Exec("USE `dolt/missing`")
+	assert.Error(t, err)
+	_, err = master.Exec("USE `missing/master`")
+	assert.Error(t, err)
+
+	// the commit to master was staged in the repository, and the changes on feature were not made to its working set
+	assert.Equal(t, env.RepoState.Staged, env.RepoState.Working)
+	working, err := env.WorkingRoot(context.Background())
+	require.NoError(t, err)
+	tbl, ok, err := working.GetTable(context.Background(), "people")
+	require.NoError(t, err)
+	require.True(t, ok)
+	rows, err := tbl.GetRowData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), rows.Len())
+}
+
+func TestServerTransactions(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15305).withMaxConnections(4)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	connect := func() *dbr.Connection {
+		conn, err := dbr.Open("mysql", ConnectionString(serverConfig), nil)
+		require.NoError(t, err)
+		conn.SetMaxOpenConns(1)
+		_, err = conn.Exec("USE dolt")
+		require.NoError(t, err)
+		return conn
+	}
+
+	exec := func(conn *dbr.Connection, query string) {
+		_, err := conn.Exec(query)
+		require.NoError(t, err, query)
+	}
+
+	age := func(conn *dbr.Connection, name string) int {
+		var res int
+		err := conn.QueryRow("SELECT age FROM people WHERE name = '" + name + "'").Scan(&res)
+		require.NoError(t, err, name)
+		return res
+	}
+
+	a := connect()
+	defer a.Close()
+	b := connect()
+	defer b.Close()
+
+	// transactions read the snapshot they started from, and changes to different rows are merged
+	billAge := age(a, "Bill Billerson")
+	exec(a, "START TRANSACTION")
+	exec(a, "UPDATE people SET age = 1 WHERE name = 'Bill Billerson'")
+	exec(b, "START TRANSACTION")
+	exec(b, "UPDATE people SET age = 2 WHERE name = 'John Johnson'")
+	exec(a, "COMMIT")
+	assert.Equal(t, billAge, age(b, "Bill Billerson"))
+	exec(b, "COMMIT")
+	assert.Equal(t, 1, age(b, "Bill Billerson"))
+	assert.Equal(t, 2, age(a, "John Johnson"))
+
+	// outside of transactions every statement reads the latest working set
+	exec(b, "UPDATE people SET age = 3 WHERE name = 'John Johnson'")
+	assert.Equal(t, 3, age(a, "John Johnson"))
+
+	// changes to the same row conflict, and the transaction committed last is rolled back
+	exec(a, "START TRANSACTION")
+	exec(a, "UPDATE people SET age = 10 WHERE name = 'Rob Robertson'")
+	exec(b, "START TRANSACTION")
+	exec(b, "UPDATE people SET age = 20 WHERE name = 'Rob Robertson'")
+	exec(a, "COMMIT")
+	_, err = b.Exec("COMMIT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1213")
+	assert.Equal(t, 10, age(b, "Rob Robertson"))
+
+	// rolled back changes are discarded
+	exec(a, "BEGIN")
+	exec(a, "DELETE FROM people")
+	exec(a, "ROLLBACK")
+	assert.Equal(t, 10, age(a, "Rob Robertson"))
+
+	// sessions on a branch other than the repository's keep their changes in their own working set, and transactions
+	// on it are rolled back in the same way
+	master := connect()
+	defer master.Close()
+	exec(master, "USE `dolt/master`")
+	exec(master, "SELECT COMMIT('people')")
+	exec(master, "INSERT INTO dolt_branches (name, hash) VALUES ('feature', hashof('master'))")
+	feature := connect()
+	defer feature.Close()
+	exec(feature, "USE `dolt/feature`")
+	exec(feature, "UPDATE people SET age = 30 WHERE name = 'Rob Robertson'")
+	exec(feature, "START TRANSACTION")
+	exec(feature, "UPDATE people SET age = 31 WHERE name = 'Rob Robertson'")
+	assert.Equal(t, 31, age(feature, "Rob Robertson"))
+	exec(feature, "ROLLBACK")
+	assert.Equal(t, 30, age(feature, "Rob Robertson"))
+	exec(feature, "BEGIN")
+	exec(feature, "DELETE FROM people WHERE name = 'Rob Robertson'")
+	exec(feature, "COMMIT")
+	exec(feature, "ROLLBACK")
+	var count int
+	err = feature.QueryRow("SELECT COUNT(*) FROM people").Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 10, age(a, "Rob Robertson"))
+
+	working, err := env.WorkingRoot(context.Background())
+	require.NoError(t, err)
+	tbl, ok, err := working.GetTable(context.Background(), "people")
+	require.NoError(t, err)
+	require.True(t, ok)
+	rows, err := tbl.GetRowData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), rows.Len())
+}
+
+func TestServerTransactionsDropTable(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15307).withMaxConnections(4)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	connect := func() *dbr.Connection {
+		conn, err := dbr.Open("mysql", ConnectionString(serverConfig), nil)
+		require.NoError(t, err)
+		conn.SetMaxOpenConns(1)
+		_, err = conn.Exec("USE dolt")
+		require.NoError(t, err)
+		return conn
+	}
+
+	exec := func(conn *dbr.Connection, query string) {
+		_, err := conn.Exec(query)
+		require.NoError(t, err, query)
+	}
+
+	hasTable := func(tblName string) bool {
+		working, err := env.WorkingRoot(context.Background())
+		require.NoError(t, err)
+		ok, err := working.HasTable(context.Background(), tblName)
+		require.NoError(t, err)
+		return ok
+	}
+
+	a := connect()
+	defer a.Close()
+	b := connect()
+	defer b.Close()
+
+	exec(a, "CREATE TABLE dropped (pk INT PRIMARY KEY)")
+	exec(a, "CREATE TABLE modified (pk INT PRIMARY KEY)")
+
+	// a table dropped while another transaction changes other tables stays dropped
+	exec(b, "START TRANSACTION")
+	exec(b, "UPDATE people SET age = 1 WHERE name = 'Bill Billerson'")
+	exec(a, "DROP TABLE dropped")
+	exec(b, "COMMIT")
+	assert.False(t, hasTable("dropped"))
+	var age int
+	err = a.QueryRow("SELECT age FROM people WHERE name = 'Bill Billerson'").Scan(&age)
+	require.NoError(t, err)
+	assert.Equal(t, 1, age)
+
+	// a table dropped while another transaction changes it conflicts, and the transaction committed last is rolled back
+	exec(b, "START TRANSACTION")
+	exec(b, "INSERT INTO modified VALUES (1)")
+	exec(a, "DROP TABLE modified")
+	_, err = b.Exec("COMMIT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1213")
+	assert.False(t, hasTable("modified"))
+}
+
+func TestServerMetrics(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15306).withMetrics("localhost", 15307)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	conn, err := dbr.Open("mysql", ConnectionString(serverConfig)+"dolt", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	_, err = conn.Exec("UPDATE people SET age = 33 WHERE name = 'Bill Billerson'")
+	require.NoError(t, err)
+	var h string
+	err = conn.QueryRow("SELECT COMMIT('update bill')").Scan(&h)
+	require.NoError(t, err)
+	_, err = conn.Exec("SELECT * FROM missing")
+	require.Error(t, err)
+
+	resp, err := http.Get("http://localhost:15307/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	out := string(body)
+
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_count{statement="update"} 1`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_duration_seconds_count{statement="select"} 2`+"\n")
+	assert.Contains(t, out, `dolt_sql_server_query_errors_total{statement="select"} 1`+"\n")
+	assert.Contains(t, out, "dolt_sql_server_connections 1\n")
+	assert.Contains(t, out, `dolt_sql_server_commits_total{database="dolt"} 1`+"\n")
+}
+
+func TestServerTLS(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	writeTestCert(t, env.FS)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15302).withMaxConnections(4).
+		withTLS(testKeyPath, testCertPath, true)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	const dbName = "dolt"
+	secure, err := dbr.Open("mysql", ConnectionString(serverConfig)+dbName+"?tls=skip-verify", nil)
+	require.NoError(t, err)
+	defer secure.Close()
+
+	var count int
+	err = secure.QueryRow("SELECT COUNT(*) FROM people").Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	insecure, err := dbr.Open("mysql", ConnectionString(serverConfig)+dbName, nil)
+	require.NoError(t, err)
+	defer insecure.Close()
+	err = insecure.QueryRow("SELECT COUNT(*) FROM people").Scan(&count)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insecure")
+}
+
 func createEnvWithSeedData(t *testing.T) *env.DoltEnv {
 	dEnv := dtestutils.CreateTestEnv()
 	imt, sch := dtestutils.CreateTestDataTable(true)
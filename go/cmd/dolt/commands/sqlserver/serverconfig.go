@@ -93,6 +93,29 @@ type ServerConfig interface {
 	DatabaseNamesAndPaths() []env.EnvNameAndPath
 	// MaxConnections returns the maximum number of simultaneous connections the server will allow.  The default is 1
 	MaxConnections() uint64
+	// PrivilegeFilePath returns the path of the file storing the users created using sql and their privileges. If it is
+	// empty, such users are lost when the server stops.
+	PrivilegeFilePath() string
+	// TLSKey returns the path of the PEM encoded private key used for TLS connections. TLS is disabled if it is empty.
+	TLSKey() string
+	// TLSCert returns the path of the PEM encoded certificate chain used for TLS connections. TLS is disabled if it is
+	// empty.
+	TLSCert() string
+	// RequireSecureTransport returns whether the server rejects connections which do not use TLS.
+	RequireSecureTransport() bool
+	// CommitAuthors returns the authors of the commits made by the users of the server, by user name.
+	CommitAuthors() map[string]CommitAuthor
+	// MetricsHost returns the domain of the HTTP listener which exports the server's metrics.
+	MetricsHost() string
+	// MetricsPort returns the port of the HTTP listener which exports the server's metrics. The listener is disabled if
+	// it is 0.
+	MetricsPort() int
+}
+
+// CommitAuthor is the name and email of the author of the commits made by a user of the server.
+type CommitAuthor struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
 }
 
 type commandLineServerConfig struct {
@@ -106,6 +129,13 @@ type commandLineServerConfig struct {
 	dbNamesAndPaths []env.EnvNameAndPath
 	autoCommit      bool
 	maxConnections  uint64
+	privilegeFile   string
+	tlsKey          string
+	tlsCert         string
+	requireSecure   bool
+	commitAuthors   map[string]CommitAuthor
+	metricsHost     string
+	metricsPort     int
 }
 
 // Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -158,6 +188,42 @@ func (cfg *commandLineServerConfig) MaxConnections() uint64 {
 	return cfg.maxConnections
 }
 
+// PrivilegeFilePath returns the path of the file storing the users created using sql and their privileges.
+func (cfg *commandLineServerConfig) PrivilegeFilePath() string {
+	return cfg.privilegeFile
+}
+
+// TLSKey returns the path of the PEM encoded private key used for TLS connections.
+func (cfg *commandLineServerConfig) TLSKey() string {
+	return cfg.tlsKey
+}
+
+// TLSCert returns the path of the PEM encoded certificate chain used for TLS connections.
+func (cfg *commandLineServerConfig) TLSCert() string {
+	return cfg.tlsCert
+}
+
+// RequireSecureTransport returns whether the server rejects connections which do not use TLS.
+func (cfg *commandLineServerConfig) RequireSecureTransport() bool {
+	return cfg.requireSecure
+}
+
+// CommitAuthors returns the authors of the commits made by the users of the server, by user name.
+func (cfg *commandLineServerConfig) CommitAuthors() map[string]CommitAuthor {
+	return cfg.commitAuthors
+}
+
+// MetricsHost returns the domain of the HTTP listener which exports the server's metrics.
+func (cfg *commandLineServerConfig) MetricsHost() string {
+	return cfg.metricsHost
+}
+
+// MetricsPort returns the port of the HTTP listener which exports the server's metrics. The listener is disabled if it
+// is 0.
+func (cfg *commandLineServerConfig) MetricsPort() int {
+	return cfg.metricsPort
+}
+
 // DatabaseNamesAndPaths returns an array of env.EnvNameAndPathObjects corresponding to the databases to be loaded in
 // a multiple db configuration. If nil is returned the server will look for a database in the current directory and
 // give it a name automatically.
@@ -207,6 +273,35 @@ func (cfg *commandLineServerConfig) withLogLevel(loglevel LogLevel) *commandLine
 	return cfg
 }
 
+// withMaxConnections updates the maximum number of connections and returns the called `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withMaxConnections(maxConnections uint64) *commandLineServerConfig {
+	cfg.maxConnections = maxConnections
+	return cfg
+}
+
+// withTLS updates the paths of the TLS key and certificate and returns the called `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withTLS(tlsKey, tlsCert string, requireSecure bool) *commandLineServerConfig {
+	cfg.tlsKey = tlsKey
+	cfg.tlsCert = tlsCert
+	cfg.requireSecure = requireSecure
+	return cfg
+}
+
+// withCommitAuthors updates the authors of the commits made by the users of the server and returns the called
+// `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withCommitAuthors(commitAuthors map[string]CommitAuthor) *commandLineServerConfig {
+	cfg.commitAuthors = commitAuthors
+	return cfg
+}
+
+// withMetrics updates the host and port of the metrics listener and returns the called `*commandLineServerConfig`,
+// which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withMetrics(host string, port int) *commandLineServerConfig {
+	cfg.metricsHost = host
+	cfg.metricsPort = port
+	return cfg
+}
+
 func (cfg *commandLineServerConfig) withDBNamesAndPaths(dbNamesAndPaths []env.EnvNameAndPath) *commandLineServerConfig {
 	cfg.dbNamesAndPaths = dbNamesAndPaths
 	return cfg
@@ -224,6 +319,7 @@ func DefaultServerConfig() *commandLineServerConfig {
 		logLevel:       defaultLogLevel,
 		autoCommit:     defaultAutoCommit,
 		maxConnections: defaultMaxConnections,
+		metricsHost:    defaultHost,
 	}
 }
 
@@ -244,6 +340,28 @@ func ValidateConfig(config ServerConfig) error {
 	if config.LogLevel().String() == "unknown" {
 		return fmt.Errorf("loglevel is invalid: %v\n", string(config.LogLevel()))
 	}
+	if (config.TLSKey() == "") != (config.TLSCert() == "") {
+		return fmt.Errorf("tls_key and tls_cert must both be provided to enable TLS")
+	}
+	if config.RequireSecureTransport() && config.TLSCert() == "" {
+		return fmt.Errorf("require_secure_transport can only be enabled if tls_key and tls_cert are provided")
+	}
+	for user, author := range config.CommitAuthors() {
+		if author.Name == "" || author.Email == "" {
+			return fmt.Errorf("the commit author of user '%s' must have a name and an email", user)
+		}
+	}
+	if config.MetricsPort() != 0 {
+		if config.MetricsHost() != "localhost" && net.ParseIP(config.MetricsHost()) == nil {
+			return fmt.Errorf("metrics address is not a valid IP: %v", config.MetricsHost())
+		}
+		if config.MetricsPort() < 1024 || config.MetricsPort() > 65535 {
+			return fmt.Errorf("metrics port is not in the range between 1024-65535: %v", config.MetricsPort())
+		}
+		if config.MetricsPort() == config.Port() && config.MetricsHost() == config.Host() {
+			return fmt.Errorf("metrics port must differ from the server's port: %v", config.MetricsPort())
+		}
+	}
 	return nil
 }
 
@@ -32,16 +32,20 @@ import (
 )
 
 const (
-	hostFlag         = "host"
-	portFlag         = "port"
-	userFlag         = "user"
-	passwordFlag     = "password"
-	timeoutFlag      = "timeout"
-	readonlyFlag     = "readonly"
-	logLevelFlag     = "loglevel"
-	multiDBDirFlag   = "multi-db-dir"
-	noAutoCommitFlag = "no-auto-commit"
-	configFileFlag   = "config"
+	hostFlag          = "host"
+	portFlag          = "port"
+	userFlag          = "user"
+	passwordFlag      = "password"
+	timeoutFlag       = "timeout"
+	readonlyFlag      = "readonly"
+	logLevelFlag      = "loglevel"
+	multiDBDirFlag    = "multi-db-dir"
+	noAutoCommitFlag  = "no-auto-commit"
+	configFileFlag    = "config"
+	privilegeFlag     = "privilege-file"
+	tlsKeyFlag        = "tls-key"
+	tlsCertFlag       = "tls-cert"
+	requireSecureFlag = "require-secure-transport"
 )
 
 var sqlServerDocs = cli.CommandDocumentationContent{
@@ -67,6 +71,10 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}user.password{{.EmphasisRight}} - The password that connections should use for authentication.
 
+		{{.EmphasisLeft}}user.privilege_file{{.EmphasisRight}} - The path of a json file storing additional users and their privileges. The user given by {{.EmphasisLeft}}user.name{{.EmphasisRight}} has every privilege, and can manage other users using {{.EmphasisLeft}}CREATE USER{{.EmphasisRight}}, {{.EmphasisLeft}}ALTER USER{{.EmphasisRight}}, {{.EmphasisLeft}}DROP USER{{.EmphasisRight}}, {{.EmphasisLeft}}GRANT{{.EmphasisRight}} and {{.EmphasisLeft}}REVOKE{{.EmphasisRight}}. Privileges may be granted on every database ({{.EmphasisLeft}}*.*{{.EmphasisRight}}), on a database ({{.EmphasisLeft}}db.*{{.EmphasisRight}}) or on a table ({{.EmphasisLeft}}db.table{{.EmphasisRight}}), and are any of {{.EmphasisLeft}}SELECT{{.EmphasisRight}}, {{.EmphasisLeft}}INSERT{{.EmphasisRight}}, {{.EmphasisLeft}}UPDATE{{.EmphasisRight}}, {{.EmphasisLeft}}DELETE{{.EmphasisRight}}, {{.EmphasisLeft}}DDL{{.EmphasisRight}}, {{.EmphasisLeft}}COMMIT{{.EmphasisRight}} or {{.EmphasisLeft}}ALL{{.EmphasisRight}}. {{.EmphasisLeft}}COMMIT{{.EmphasisRight}} is needed on a database to create commits or merge using the {{.EmphasisLeft}}COMMIT(){{.EmphasisRight}} and {{.EmphasisLeft}}MERGE(){{.EmphasisRight}} functions. If no privilege file is given, users created using sql are lost when the server stops.
+
+		{{.EmphasisLeft}}user.commit_authors{{.EmphasisRight}} - A map from user names to the {{.EmphasisLeft}}name{{.EmphasisRight}} and {{.EmphasisLeft}}email{{.EmphasisRight}} of the author of the commits those users make. Users which are not in the map, other than {{.EmphasisLeft}}user.name{{.EmphasisRight}} whose commits are authored by the user configured in the repository, are the authors of their commits under their user name and must set an email before committing. Any session may change the author of its commits by setting {{.EmphasisLeft}}@@dolt_user_name{{.EmphasisRight}} and {{.EmphasisLeft}}@@dolt_user_email{{.EmphasisRight}}.
+
 		{{.EmphasisLeft}}listener.host{{.EmphasisRight}} - The host address that the server will run on.  This may be {{.EmphasisLeft}}localhost{{.EmphasisRight}} or an IPv4 or IPv6 address
 
 		{{.EmphasisLeft}}listener.port{{.EmphasisRight}} - The port that the server should listen on
@@ -77,16 +85,30 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}listener.write_timeout_millis{{.EmphasisRight}} - The number of milliseconds that the server will wait for a write operation
 
+		{{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} - The path of a PEM encoded private key. If it and {{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}} are given, clients may use TLS to connect to the server
+
+		{{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}} - The path of the PEM encoded certificate which matches {{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}}. The key and certificate are read again when the server receives SIGHUP, so that they can be replaced without restarting the server. Connections which are already open are not affected
+
+		{{.EmphasisLeft}}listener.require_secure_transport{{.EmphasisRight}} - If true connections which do not use TLS are refused. This requires {{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} and {{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}}
+
+		{{.EmphasisLeft}}metrics.host{{.EmphasisRight}} - The host address of the HTTP listener which exports the server's metrics in the Prometheus text format at {{.EmphasisLeft}}/metrics{{.EmphasisRight}}. This may be {{.EmphasisLeft}}localhost{{.EmphasisRight}} or an IPv4 or IPv6 address
+
+		{{.EmphasisLeft}}metrics.port{{.EmphasisRight}} - The port that the metrics listener should listen on. If it is not given, metrics are not exported
+
 		{{.EmphasisLeft}}databases{{.EmphasisRight}} - a list of dolt data repositories to make available as SQL databases. If databases is missing or empty then the working directory must be a valid dolt data repository which will be made available as a SQL database
 		
 		{{.EmphasisLeft}}databases[i].path{{.EmphasisRight}} - A path to a dolt data repository
 		
 		{{.EmphasisLeft}}databases[i].name{{.EmphasisRight}} - The name that the database corresponding to the given path should be referenced via SQL
 
-If a config file is not provided many of these settings may be configured on the command line.`,
+If a config file is not provided many of these settings may be configured on the command line.
+
+By default every session reads and writes the working set of the branch checked out in the repository. A session may instead check out another branch by connecting to, or using, a database named {{.EmphasisLeft}}<database>/<branch>{{.EmphasisRight}}, such as {{.EmphasisLeft}}USE ` + "`mydb/feature-x`" + `{{.EmphasisRight}}, or by calling {{.EmphasisLeft}}DOLT_CHECKOUT('feature-x'){{.EmphasisRight}}. A session which has checked out a branch other than the repository's has a working set of its own, which starts at the head of the branch and is lost if it is not committed. The commits made with {{.EmphasisLeft}}COMMIT(){{.EmphasisRight}} and {{.EmphasisLeft}}MERGE(){{.EmphasisRight}} in a session which has checked out a branch are added to that branch.
+
+Each transaction reads the working set as it was when the transaction started. When a transaction is committed, its changes are merged into the working set, which may have been changed by other sessions since. If the same row was changed in both, the transaction is rolled back and fails with a serialization error (1213), and should be restarted. Transactions are started and ended with {{.EmphasisLeft}}START TRANSACTION{{.EmphasisRight}}, {{.EmphasisLeft}}COMMIT{{.EmphasisRight}} and {{.EmphasisLeft}}ROLLBACK{{.EmphasisRight}}. If autocommit is on, every other statement is a transaction of its own.`,
 	Synopsis: []string{
 		"--config {{.LessThan}}file{{.GreaterThan}}",
-		"[-H {{.LessThan}}host{{.GreaterThan}}] [-P {{.LessThan}}port{{.GreaterThan}}] [-u {{.LessThan}}user{{.GreaterThan}}] [-p {{.LessThan}}password{{.GreaterThan}}] [-t {{.LessThan}}timeout{{.GreaterThan}}] [-l {{.LessThan}}loglevel{{.GreaterThan}}] [--multi-db-dir {{.LessThan}}directory{{.GreaterThan}}] [-r]",
+		"[-H {{.LessThan}}host{{.GreaterThan}}] [-P {{.LessThan}}port{{.GreaterThan}}] [-u {{.LessThan}}user{{.GreaterThan}}] [-p {{.LessThan}}password{{.GreaterThan}}] [-t {{.LessThan}}timeout{{.GreaterThan}}] [-l {{.LessThan}}loglevel{{.GreaterThan}}] [--multi-db-dir {{.LessThan}}directory{{.GreaterThan}}] [--privilege-file {{.LessThan}}file{{.GreaterThan}}] [--tls-key {{.LessThan}}file{{.GreaterThan}} --tls-cert {{.LessThan}}file{{.GreaterThan}} [--require-secure-transport]] [-r]",
 	},
 }
 
@@ -124,6 +146,10 @@ func createArgParser() *argparser.ArgParser {
 	ap.SupportsString(logLevelFlag, "l", "Log level", fmt.Sprintf("Defines the level of logging provided\nOptions are: `trace', `debug`, `info`, `warning`, `error`, `fatal` (default `%v`)", serverConfig.LogLevel()))
 	ap.SupportsString(multiDBDirFlag, "", "directory", "Defines a directory whose subdirectories should all be dolt data repositories accessible as independent databases.")
 	ap.SupportsFlag(noAutoCommitFlag, "", "When provided sessions will not automatically commit their changes to the working set. Anything not manually committed will be lost.")
+	ap.SupportsString(privilegeFlag, "", "file", "The json file storing the users created using sql and their privileges. If not provided, such users are lost when the server stops.")
+	ap.SupportsString(tlsKeyFlag, "", "file", "The path of a PEM encoded private key. If it and the certificate are provided, clients may use TLS to connect to the server.")
+	ap.SupportsString(tlsCertFlag, "", "file", "The path of the PEM encoded certificate which matches the private key given by --tls-key. The key and certificate are read again when the server receives SIGHUP.")
+	ap.SupportsFlag(requireSecureFlag, "", "When provided connections which do not use TLS are refused. Requires --tls-key and --tls-cert.")
 	return ap
 }
 
@@ -233,6 +259,8 @@ func getCommandLineServerConfig(dEnv *env.DoltEnv, apr *argparser.ArgParseResult
 	}
 
 	serverConfig.autoCommit = !apr.Contains(noAutoCommitFlag)
+	serverConfig.privilegeFile = apr.GetValueOrDefault(privilegeFlag, "")
+	serverConfig.withTLS(apr.GetValueOrDefault(tlsKeyFlag, ""), apr.GetValueOrDefault(tlsCertFlag, ""), apr.Contains(requireSecureFlag))
 	return serverConfig, nil
 }
 
@@ -0,0 +1,119 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+// certificateReloader holds the TLS certificate of the server, which is read from the key and certificate files given
+// in the server's config. The files are read again when the server receives a reload signal, so that a certificate can
+// be replaced without restarting the server. Connections which are already open keep using the certificate they were
+// opened with.
+type certificateReloader struct {
+	fs       filesys.ReadableFS
+	keyPath  string
+	certPath string
+
+	mu   *sync.RWMutex
+	cert *tls.Certificate
+}
+
+// newCertificateReloader returns a certificateReloader holding the certificate read from the files given, or an error
+// if they cannot be read.
+func newCertificateReloader(fs filesys.ReadableFS, keyPath, certPath string) (*certificateReloader, error) {
+	cr := &certificateReloader{fs: fs, keyPath: keyPath, certPath: certPath, mu: &sync.RWMutex{}}
+
+	if err := cr.reload(); err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// reload reads the key and certificate files again. If they cannot be read the previous certificate is kept.
+func (cr *certificateReloader) reload() error {
+	certPEM, err := cr.fs.ReadFile(cr.certPath)
+	if err != nil {
+		return fmt.Errorf("failed to read tls_cert '%s': %s", cr.certPath, err.Error())
+	}
+
+	keyPEM, err := cr.fs.ReadFile(cr.keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read tls_key '%s': %s", cr.keyPath, err.Error())
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %s", err.Error())
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.cert = &cert
+
+	return nil
+}
+
+// getCertificate returns the current certificate. It is used as tls.Config.GetCertificate.
+func (cr *certificateReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	return cr.cert, nil
+}
+
+// tlsConfig returns the TLS config of a listener which uses the current certificate for each connection.
+func (cr *certificateReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: cr.getCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+// reloadOnSignal reloads the certificate each time the process receives a reload signal, which is SIGHUP on platforms
+// which have it. The returned function stops listening for the signal.
+func (cr *certificateReloader) reloadOnSignal() (stop func()) {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	notifyReload(sigs)
+
+	go func() {
+		for {
+			select {
+			case <-sigs:
+				if err := cr.reload(); err != nil {
+					logrus.Errorf("TLS certificate was not reloaded: %s", err.Error())
+				} else {
+					logrus.Info("TLS certificate reloaded")
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		stopNotifyReload(sigs)
+		close(done)
+	}
+}
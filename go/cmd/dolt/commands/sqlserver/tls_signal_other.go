@@ -0,0 +1,33 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package sqlserver
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReload relays SIGHUP, which asks the server to reload its TLS certificate, to the channel given.
+func notifyReload(sigs chan<- os.Signal) {
+	signal.Notify(sigs, syscall.SIGHUP)
+}
+
+// stopNotifyReload stops relaying signals to the channel given.
+func stopNotifyReload(sigs chan<- os.Signal) {
+	signal.Stop(sigs)
+}
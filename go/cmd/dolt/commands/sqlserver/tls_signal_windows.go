@@ -0,0 +1,25 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package sqlserver
+
+import "os"
+
+// notifyReload does nothing, as there is no signal asking the server to reload its TLS certificate on Windows.
+func notifyReload(sigs chan<- os.Signal) {}
+
+// stopNotifyReload does nothing, as no signals are relayed on Windows.
+func stopNotifyReload(sigs chan<- os.Signal) {}
@@ -0,0 +1,97 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	testKeyPath  = "/server/key.pem"
+	testCertPath = "/server/cert.pem"
+)
+
+// writeTestCert writes a new self-signed certificate for localhost, and its key, to the paths testKeyPath and
+// testCertPath, and returns the DER encoding of the certificate.
+func writeTestCert(t *testing.T, fs filesys.Filesys) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, fs.MkDirs("/server"))
+	require.NoError(t, fs.WriteFile(testCertPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	require.NoError(t, fs.WriteFile(testKeyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+
+	return der
+}
+
+func TestCertificateReloader(t *testing.T) {
+	fs := filesys.NewInMemFS(nil, nil, "/")
+
+	_, err := newCertificateReloader(fs, testKeyPath, testCertPath)
+	assert.Error(t, err)
+
+	first := writeTestCert(t, fs)
+	certs, err := newCertificateReloader(fs, testKeyPath, testCertPath)
+	require.NoError(t, err)
+
+	cert, err := certs.tlsConfig().GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, first, cert.Certificate[0])
+
+	second := writeTestCert(t, fs)
+	require.NoError(t, certs.reload())
+	cert, err = certs.getCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second, cert.Certificate[0])
+
+	// a certificate which cannot be loaded does not replace the current one
+	require.NoError(t, fs.WriteFile(testCertPath, []byte("not a certificate")))
+	assert.Error(t, certs.reload())
+	cert, err = certs.getCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, second, cert.Certificate[0])
+}
@@ -46,10 +46,12 @@ type BehaviorYAMLConfig struct {
 	AutoCommit *bool
 }
 
-// UserYAMLConfig contains server configuration regarding the user account clients must use to connect
+// UserYAMLConfig contains server configuration regarding the user accounts clients must use to connect
 type UserYAMLConfig struct {
-	Name     *string
-	Password *string
+	Name          *string
+	Password      *string
+	PrivilegeFile *string                 `yaml:"privilege_file,omitempty"`
+	CommitAuthors map[string]CommitAuthor `yaml:"commit_authors,omitempty"`
 }
 
 // DatabaseYAMLConfig contains information on a database that this server will provide access to
@@ -65,6 +67,15 @@ type ListenerYAMLConfig struct {
 	MaxConnections     *uint64 `yaml:"max_connections"`
 	ReadTimeoutMillis  *uint64 `yaml:"read_timeout_millis"`
 	WriteTimeoutMillis *uint64 `yaml:"write_timeout_millis"`
+	TLSKey             *string `yaml:"tls_key,omitempty"`
+	TLSCert            *string `yaml:"tls_cert,omitempty"`
+	RequireSecure      *bool   `yaml:"require_secure_transport,omitempty"`
+}
+
+// MetricsYAMLConfig contains information on the HTTP listener which exports the server's metrics
+type MetricsYAMLConfig struct {
+	HostStr    *string `yaml:"host,omitempty"`
+	PortNumber *int    `yaml:"port,omitempty"`
 }
 
 // YAMLConfig is a ServerConfig implementation which is read from a yaml file
@@ -74,19 +85,23 @@ type YAMLConfig struct {
 	UserConfig     UserYAMLConfig       `yaml:"user"`
 	ListenerConfig ListenerYAMLConfig   `yaml:"listener"`
 	DatabaseConfig []DatabaseYAMLConfig `yaml:"databases"`
+	MetricsConfig  MetricsYAMLConfig    `yaml:"metrics,omitempty"`
 }
 
 func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 	return YAMLConfig{
 		LogLevelStr:    strPtr(string(cfg.LogLevel())),
 		BehaviorConfig: BehaviorYAMLConfig{boolPtr(cfg.ReadOnly()), boolPtr(cfg.AutoCommit())},
-		UserConfig:     UserYAMLConfig{strPtr(cfg.User()), strPtr(cfg.Password())},
+		UserConfig:     UserYAMLConfig{strPtr(cfg.User()), strPtr(cfg.Password()), nil, nil},
 		ListenerConfig: ListenerYAMLConfig{
 			strPtr(cfg.Host()),
 			intPtr(cfg.Port()),
 			uint64Ptr(cfg.MaxConnections()),
 			uint64Ptr(cfg.ReadTimeout()),
 			uint64Ptr(cfg.WriteTimeout()),
+			nil,
+			nil,
+			nil,
 		},
 		DatabaseConfig: nil,
 	}
@@ -178,6 +193,67 @@ func (cfg YAMLConfig) Password() string {
 	return *cfg.UserConfig.Password
 }
 
+// PrivilegeFilePath returns the path of the file storing the users created using sql and their privileges.
+func (cfg YAMLConfig) PrivilegeFilePath() string {
+	if cfg.UserConfig.PrivilegeFile == nil {
+		return ""
+	}
+
+	return *cfg.UserConfig.PrivilegeFile
+}
+
+// CommitAuthors returns the authors of the commits made by the users of the server, by user name.
+func (cfg YAMLConfig) CommitAuthors() map[string]CommitAuthor {
+	return cfg.UserConfig.CommitAuthors
+}
+
+// TLSKey returns the path of the PEM encoded private key used for TLS connections. TLS is disabled if it is empty.
+func (cfg YAMLConfig) TLSKey() string {
+	if cfg.ListenerConfig.TLSKey == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSKey
+}
+
+// TLSCert returns the path of the PEM encoded certificate chain used for TLS connections. TLS is disabled if it is
+// empty.
+func (cfg YAMLConfig) TLSCert() string {
+	if cfg.ListenerConfig.TLSCert == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSCert
+}
+
+// RequireSecureTransport returns whether the server rejects connections which do not use TLS.
+func (cfg YAMLConfig) RequireSecureTransport() bool {
+	if cfg.ListenerConfig.RequireSecure == nil {
+		return false
+	}
+
+	return *cfg.ListenerConfig.RequireSecure
+}
+
+// MetricsHost returns the domain of the HTTP listener which exports the server's metrics.
+func (cfg YAMLConfig) MetricsHost() string {
+	if cfg.MetricsConfig.HostStr == nil {
+		return defaultHost
+	}
+
+	return *cfg.MetricsConfig.HostStr
+}
+
+// MetricsPort returns the port of the HTTP listener which exports the server's metrics. The listener is disabled if it
+// is 0.
+func (cfg YAMLConfig) MetricsPort() int {
+	if cfg.MetricsConfig.PortNumber == nil {
+		return 0
+	}
+
+	return *cfg.MetricsConfig.PortNumber
+}
+
 // ReadOnly returns whether the server will only accept read statements or all statements.
 func (cfg YAMLConfig) ReadOnly() bool {
 	if cfg.BehaviorConfig.ReadOnly == nil {
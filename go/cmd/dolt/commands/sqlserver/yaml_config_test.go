@@ -34,6 +34,10 @@ behavior:
 user:
     name: root
     password: ""
+    commit_authors:
+        alice:
+            name: Alice Smith
+            email: alice@example.com
 
 listener:
     host: localhost
@@ -47,9 +51,17 @@ databases:
       path: ./datasets/irs-soi
     - name: noaa
       path: /Users/brian/datasets/noaa
+
+metrics:
+    host: 127.0.0.1
+    port: 9104
 `
 
 	expected := serverConfigAsYAMLConfig(DefaultServerConfig())
+	expected.UserConfig.CommitAuthors = map[string]CommitAuthor{
+		"alice": {Name: "Alice Smith", Email: "alice@example.com"},
+	}
+	expected.MetricsConfig = MetricsYAMLConfig{strPtr("127.0.0.1"), intPtr(9104)}
 	expected.DatabaseConfig = []DatabaseYAMLConfig{
 		{
 			Name: "irs_soi",
@@ -82,4 +94,11 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Equal(t, defaultLogLevel, cfg.LogLevel())
 	assert.Equal(t, defaultAutoCommit, cfg.AutoCommit())
 	assert.Equal(t, uint64(defaultMaxConnections), cfg.MaxConnections())
+	assert.Equal(t, "", cfg.PrivilegeFilePath())
+	assert.Equal(t, "", cfg.TLSKey())
+	assert.Equal(t, "", cfg.TLSCert())
+	assert.False(t, cfg.RequireSecureTransport())
+	assert.Empty(t, cfg.CommitAuthors())
+	assert.Equal(t, defaultHost, cfg.MetricsHost())
+	assert.Equal(t, 0, cfg.MetricsPort())
 }
@@ -0,0 +1,273 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var stashDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Stash the changes in a dirty working set away.",
+	LongDesc: `Use {{.EmphasisLeft}}dolt stash{{.EmphasisRight}} when you want to record the current state of the working set, but want to go back to a clean working set. The command saves your staged and unstaged changes, including new tables and changes to tracked docs, and resets the working set to {{.EmphasisLeft}}HEAD{{.EmphasisRight}}.
+
+Stashes are listed with {{.EmphasisLeft}}dolt stash list{{.EmphasisRight}} and referred to as {{.EmphasisLeft}}stash@{{.LessThan}}n{{.GreaterThan}}{{.EmphasisRight}}, where {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}} is the most recent stash. If no stash is given, {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}} is used.
+
+{{.EmphasisLeft}}push{{.EmphasisRight}}
+Save your local modifications to a new stash. This is the default when no subcommand is given.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+List the stashes that you currently have.
+
+{{.EmphasisLeft}}apply{{.EmphasisRight}}
+Apply the changes of the given stash to the working set using a three-way merge. Conflicts are left in the working set to be resolved using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}pop{{.EmphasisRight}}
+Like {{.EmphasisLeft}}apply{{.EmphasisRight}}, but removes the stash afterwards if it applied without conflicts.
+
+{{.EmphasisLeft}}drop{{.EmphasisRight}}
+Remove the given stash.`,
+	Synopsis: []string{
+		`[push [-m {{.LessThan}}message{{.GreaterThan}}]]`,
+		`list`,
+		`(pop | apply | drop) [{{.LessThan}}stash{{.GreaterThan}}]`,
+	},
+}
+
+const (
+	pushStashId  = "push"
+	listStashId  = "list"
+	popStashId   = "pop"
+	applyStashId = "apply"
+	dropStashId  = "drop"
+
+	stashMessageArg = "message"
+)
+
+var stashSpecRegex = regexp.MustCompile(`^(?:stash@\{(\d+)\}|(\d+))$`)
+
+type StashCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd StashCmd) Name() string {
+	return "stash"
+}
+
+// Description returns a description of the command
+func (cmd StashCmd) Description() string {
+	return stashDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd StashCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+}
+
+func (cmd StashCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"stash", "The stash to apply, pop or drop, in the form stash@{<n>}."})
+	ap.SupportsString(stashMessageArg, "m", "message", "The message describing the stash.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd StashCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_STASH
+}
+
+// Exec executes the command
+func (cmd StashCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	var verr errhand.VerboseError
+
+	switch {
+	case apr.NArg() == 0 || (apr.Arg(0) == pushStashId && apr.NArg() == 1):
+		verr = pushStash(ctx, dEnv, apr.GetValueOrDefault(stashMessageArg, ""))
+	case apr.Arg(0) == listStashId && apr.NArg() == 1:
+		verr = listStashes(ctx, dEnv)
+	case apr.Arg(0) == applyStashId && apr.NArg() <= 2:
+		return applyStash(ctx, dEnv, apr, false, usage)
+	case apr.Arg(0) == popStashId && apr.NArg() <= 2:
+		return applyStash(ctx, dEnv, apr, true, usage)
+	case apr.Arg(0) == dropStashId && apr.NArg() <= 2:
+		verr = dropStash(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func pushStash(ctx context.Context, dEnv *env.DoltEnv, msg string) errhand.VerboseError {
+	if dEnv.IsMergeActive() {
+		return errhand.BuildDError("error: cannot stash changes while merging.").Build()
+	}
+
+	err := actions.StashChanges(ctx, dEnv, msg)
+
+	if err == actions.ErrNoLocalChanges {
+		cli.Println("No local changes to save")
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to stash changes").AddCause(err).Build()
+	}
+
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	meta, err := stashes[0].Commit.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get stash metadata").AddCause(err).Build()
+	}
+
+	cli.Println("Saved working directory and index state", meta.Description)
+	return nil
+}
+
+func listStashes(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	for i, stash := range stashes {
+		meta, err := stash.Commit.GetCommitMeta()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get stash metadata").AddCause(err).Build()
+		}
+
+		cli.Printf("stash@{%d}: %s\n", i, meta.Description)
+	}
+
+	return nil
+}
+
+// resolveStash returns the stash named by the second argument, or the most recent stash if there is no second argument
+func resolveStash(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (*actions.Stash, string, errhand.VerboseError) {
+	idx := 0
+	if apr.NArg() == 2 {
+		matches := stashSpecRegex.FindStringSubmatch(apr.Arg(1))
+
+		if matches == nil {
+			return nil, "", errhand.BuildDError("error: '%s' is not a valid stash", apr.Arg(1)).Build()
+		}
+
+		idxStr := matches[1]
+		if idxStr == "" {
+			idxStr = matches[2]
+		}
+
+		var err error
+		idx, err = strconv.Atoi(idxStr)
+
+		if err != nil {
+			return nil, "", errhand.BuildDError("error: '%s' is not a valid stash", apr.Arg(1)).Build()
+		}
+	}
+
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return nil, "", errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	name := fmt.Sprintf("stash@{%d}", idx)
+	if len(stashes) == 0 {
+		return nil, "", errhand.BuildDError("error: no stash entries found.").Build()
+	} else if idx >= len(stashes) {
+		return nil, "", errhand.BuildDError("error: %s is not a valid stash", name).Build()
+	}
+
+	return stashes[idx], name, nil
+}
+
+func applyStash(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, drop bool, usage cli.UsagePrinter) int {
+	if dEnv.IsMergeActive() {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: cannot apply a stash while merging.").Build(), usage)
+	}
+
+	stash, name, verr := resolveStash(ctx, dEnv, apr)
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	tblToStats, err := actions.ApplyStash(ctx, dEnv, stash)
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to apply %s", name).AddCause(err).Build(), usage)
+	}
+
+	if hasConflicts := printSuccessStats(tblToStats); hasConflicts {
+		if drop {
+			cli.Println("The stash entry is kept in case you need it again.")
+		}
+
+		return 1
+	}
+
+	if drop {
+		return HandleVErrAndExitCode(dropResolvedStash(ctx, dEnv, stash, name), usage)
+	}
+
+	return 0
+}
+
+func dropStash(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	stash, name, verr := resolveStash(ctx, dEnv, apr)
+
+	if verr != nil {
+		return verr
+	}
+
+	return dropResolvedStash(ctx, dEnv, stash, name)
+}
+
+func dropResolvedStash(ctx context.Context, dEnv *env.DoltEnv, stash *actions.Stash, name string) errhand.VerboseError {
+	h, err := stash.Commit.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of stash").AddCause(err).Build()
+	}
+
+	err = actions.DropStash(ctx, dEnv.DoltDB, stash)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to drop %s", name).AddCause(err).Build()
+	}
+
+	cli.Printf("Dropped %s (%s)\n", name, h.String())
+	return nil
+}
@@ -37,8 +37,10 @@ import (
 
 var statusDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Show the working status",
-	LongDesc:  `Displays working tables that differ from the current HEAD commit, tables that differ from the staged tables, and tables that are in the working tree that are not tracked by dolt. The first are what you would commit by running {{.EmphasisLeft}}dolt commit{{.GreaterThan}}; the second and third are what you could commit by running {{.EmphasisLeft}}dolt add .{{.GreaterThan}} before running {{.EmphasisLeft}}dolt commit{{.GreaterThan}}.`,
-	Synopsis:  []string{""},
+	LongDesc: `Displays working tables that differ from the current HEAD commit, tables that differ from the staged tables, and tables that are in the working tree that are not tracked by dolt. The first are what you would commit by running {{.EmphasisLeft}}dolt commit{{.GreaterThan}}; the second and third are what you could commit by running {{.EmphasisLeft}}dolt add .{{.GreaterThan}} before running {{.EmphasisLeft}}dolt commit{{.GreaterThan}}.
+
+Tables renamed with {{.EmphasisLeft}}dolt table mv{{.EmphasisRight}} or {{.EmphasisLeft}}RENAME TABLE{{.EmphasisRight}} are shown as renamed. With {{.EmphasisLeft}}--find-renames[=n]{{.EmphasisRight}}, a table which was copied to a new table and then dropped is also shown as renamed, if the new table has the same columns and at least {{.EmphasisLeft}}n{{.EmphasisRight}} percent of its rows, which defaults to 50%.`,
+	Synopsis: []string{"[--find-renames[={{.LessThan}}n{{.GreaterThan}}]]"},
 }
 
 type StatusCmd struct{}
@@ -61,6 +63,7 @@ func (cmd StatusCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 
 func (cmd StatusCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
+	ap.SupportsFlagWithOptionalValue(FindRenamesFlag, "M", "n", findRenamesDesc)
 	return ap
 }
 
@@ -68,9 +71,21 @@ func (cmd StatusCmd) createArgParser() *argparser.ArgParser {
 func (cmd StatusCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
 	ap := cmd.createArgParser()
 	help, _ := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, statusDocs, ap))
-	cli.ParseArgs(ap, args, help)
+	apr := cli.ParseArgs(ap, args, help)
 
-	stagedTblDiffs, notStagedTblDiffs, err := diff.GetTableDiffs(ctx, dEnv)
+	minSimilarity, findRenames, err := parseFindRenames(apr)
+
+	if err != nil {
+		cli.PrintErrln(errhand.VerboseErrorFromError(err).Verbose())
+		return 1
+	}
+
+	var stagedTblDiffs, notStagedTblDiffs *diff.TableDiffs
+	if findRenames {
+		stagedTblDiffs, notStagedTblDiffs, err = diff.GetTableDiffsWithRenames(ctx, dEnv, minSimilarity)
+	} else {
+		stagedTblDiffs, notStagedTblDiffs, err = diff.GetTableDiffs(ctx, dEnv)
+	}
 
 	if err != nil {
 		cli.PrintErrln(toStatusVErr((err)))
@@ -105,12 +120,33 @@ var tblDiffTypeToLabel = map[diff.TableDiffType]string{
 	diff.ModifiedTable: "modified:",
 	diff.RemovedTable:  "deleted:",
 	diff.AddedTable:    "new table:",
+	diff.RenamedTable:  "renamed:",
 }
 
 var tblDiffTypeToShortLabel = map[diff.TableDiffType]string{
 	diff.ModifiedTable: "M",
 	diff.RemovedTable:  "D",
 	diff.AddedTable:    "N",
+	diff.RenamedTable:  "R",
+}
+
+// colRenameLines returns the lines showing the columns of a table which were renamed, as "table.old -> table.new".
+func colRenameLines(tds *diff.TableDiffs, tblName string) []string {
+	var lines []string
+	for _, cr := range tds.RenamedColumns[tblName] {
+		lines = append(lines, fmt.Sprintf(statusFmt, renamedColumnLabel, tblName+"."+cr.OldName+" -> "+tblName+"."+cr.NewName))
+	}
+
+	return lines
+}
+
+// tblStatusName returns the name of a table as it is shown by status, which is "old -> new" for a renamed table.
+func tblStatusName(tds *diff.TableDiffs, tblName string) string {
+	if oldName, ok := tds.RenamedFrom[tblName]; ok {
+		return oldName + " -> " + tblName
+	}
+
+	return tblName
 }
 
 var docDiffTypeToLabel = map[diff.DocDiffType]string{
@@ -139,6 +175,11 @@ const (
   (use "dolt commit" to conclude merge)
 `
 
+	rebaseHeader = `You are currently rebasing.
+  (fix conflicts and then run "dolt rebase --continue")
+  (use "dolt rebase --abort" to check out the original branch)
+`
+
 	mergedTableHeader = `Unmerged paths:`
 	mergedTableHelp   = `  (use "dolt add <file>..." to mark resolution)`
 
@@ -149,8 +190,9 @@ const (
 	untrackedHeader     = `Untracked files:`
 	untrackedHeaderHelp = `  (use "dolt add <table|doc>" to include in what will be committed)`
 
-	statusFmt         = "\t%-16s%s"
-	bothModifiedLabel = "both modified:"
+	statusFmt          = "\t%-16s%s"
+	bothModifiedLabel  = "both modified:"
+	renamedColumnLabel = "renamed column:"
 )
 
 func printStagedDiffs(wr io.Writer, stagedTbls *diff.TableDiffs, stagedDocs *diff.DocDiffs, printHelp bool) int {
@@ -165,7 +207,8 @@ func printStagedDiffs(wr io.Writer, stagedTbls *diff.TableDiffs, stagedDocs *dif
 		for _, tblName := range stagedTbls.Tables {
 			if !doltdb.IsReadOnlySystemTable(tblName) {
 				tdt := stagedTbls.TableToType[tblName]
-				lines = append(lines, fmt.Sprintf(statusFmt, tblDiffTypeToLabel[tdt], tblName))
+				lines = append(lines, fmt.Sprintf(statusFmt, tblDiffTypeToLabel[tdt], tblStatusName(stagedTbls, tblName)))
+				lines = append(lines, colRenameLines(stagedTbls, tblName)...)
 			}
 		}
 
@@ -260,7 +303,8 @@ func getModifiedAndRemovedNotStaged(notStagedTbls *diff.TableDiffs, notStagedDoc
 		tdt := notStagedTbls.TableToType[tblName]
 
 		if tdt != diff.AddedTable && !inCnfSet.Contains(tblName) && tblName != doltdb.DocTableName {
-			lines = append(lines, fmt.Sprintf(statusFmt, tblDiffTypeToLabel[tdt], tblName))
+			lines = append(lines, fmt.Sprintf(statusFmt, tblDiffTypeToLabel[tdt], tblStatusName(notStagedTbls, tblName)))
+			lines = append(lines, colRenameLines(notStagedTbls, tblName)...)
 		}
 	}
 
@@ -308,6 +352,10 @@ func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTb
 		}
 	}
 
+	if dEnv.RepoState.Rebase != nil {
+		cli.Print(rebaseHeader)
+	}
+
 	n := printStagedDiffs(cli.CliOut, stagedTbls, stagedDocs, true)
 	n = printDiffsNotStaged(ctx, dEnv, cli.CliOut, notStagedTbls, notStagedDocs, true, n, workingTblsInConflict)
 
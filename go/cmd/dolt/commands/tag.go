@@ -0,0 +1,218 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+)
+
+var tagDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Create, list, delete tags.`,
+	LongDesc: `If there are no non-option arguments, existing tags are listed.
+
+The command's second form creates a new tag named {{.LessThan}}tagname{{.GreaterThan}} which points to the current {{.EmphasisLeft}}HEAD{{.EmphasisRight}}, or {{.LessThan}}ref{{.GreaterThan}} if given. Optionally, a tag message can be passed using the {{.EmphasisLeft}}-m{{.EmphasisRight}} option. The tagger name, email and the current time are recorded along with the tag.
+
+With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}tagname{{.GreaterThan}} will be deleted. You may specify more than one tag for deletion.`,
+	Synopsis: []string{
+		`[-v]`,
+		`[-m {{.LessThan}}message{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}]`,
+		`-d {{.LessThan}}tagname{{.GreaterThan}}...`,
+	},
+}
+
+const (
+	tagMessageArg = "message"
+)
+
+type TagCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd TagCmd) Name() string {
+	return "tag"
+}
+
+// Description returns a description of the command
+func (cmd TagCmd) Description() string {
+	return tagDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd TagCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, tagDocs, ap))
+}
+
+func (cmd TagCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A commit ref that the tag should point at."})
+	ap.SupportsString(tagMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the tag message.")
+	ap.SupportsFlag(verboseFlag, "v", "list tags along with their metadata.")
+	ap.SupportsFlag(deleteFlag, "d", "Delete a tag.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd TagCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TAG
+}
+
+// Exec executes the command
+func (cmd TagCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, tagDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	// list tags
+	if len(apr.Args()) == 0 && !apr.Contains(deleteFlag) {
+		verr := listTags(ctx, dEnv, apr.Contains(verboseFlag))
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	// delete tag
+	if apr.Contains(deleteFlag) {
+		if apr.Contains(tagMessageArg) {
+			cli.PrintErrln("delete and tag message options are incompatible")
+			return 1
+		} else if apr.Contains(verboseFlag) {
+			cli.PrintErrln("delete and verbose options are incompatible")
+			return 1
+		} else if apr.NArg() == 0 {
+			usage()
+			return 1
+		}
+
+		verr := deleteTags(ctx, dEnv, apr.Args())
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	// create tag
+	if apr.Contains(verboseFlag) {
+		cli.PrintErrln("verbose flag can only be used with tag listing")
+		return 1
+	} else if len(apr.Args()) > 2 {
+		cli.PrintErrln("create tag takes at most two args")
+		return 1
+	}
+
+	verr := createTag(ctx, dEnv, apr)
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func createTag(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	name, email, err := actions.GetNameAndEmail(dEnv.Config)
+
+	if err == actions.ErrNameNotConfigured {
+		bdr := errhand.BuildDError("Could not determine %s.", env.UserNameKey)
+		bdr.AddDetails("dolt config [-global|local] -add %[1]s:\"FIRST LAST\"", env.UserNameKey)
+		return bdr.Build()
+	} else if err == actions.ErrEmailNotConfigured {
+		bdr := errhand.BuildDError("Could not determine %s.", env.UserEmailKey)
+		bdr.AddDetails("dolt config [-global|local] -add %[1]s:\"EMAIL_ADDRESS\"", env.UserEmailKey)
+		return bdr.Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to read the tagger name and email").AddCause(err).Build()
+	}
+
+	tagName := apr.Arg(0)
+	startPoint := "head"
+	if len(apr.Args()) > 1 {
+		startPoint = apr.Arg(1)
+	}
+
+	msg, _ := apr.GetValue(tagMessageArg)
+
+	props := actions.TagProps{
+		TaggerName:  name,
+		TaggerEmail: email,
+		Description: msg,
+	}
+
+	err = actions.CreateTag(ctx, dEnv, tagName, startPoint, props)
+
+	if err == actions.ErrAlreadyExists || err == datas.ErrTagExists {
+		return errhand.BuildDError("fatal: tag '%s' already exists", tagName).Build()
+	} else if err == doltdb.ErrInvTagName {
+		return errhand.BuildDError("fatal: '%s' is not a valid tag name.", tagName).Build()
+	} else if err == doltdb.ErrBranchNotFound || err == doltdb.ErrHashNotFound || err == doltdb.ErrInvalidBranchOrHash {
+		return errhand.BuildDError("fatal: '%s' is not a valid commit ref.", startPoint).Build()
+	} else if err != nil {
+		return errhand.BuildDError("fatal: failed to create tag '%s'", tagName).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func deleteTags(ctx context.Context, dEnv *env.DoltEnv, tagNames []string) errhand.VerboseError {
+	for _, tn := range tagNames {
+		err := actions.DeleteTags(ctx, dEnv, tn)
+
+		if err == doltdb.ErrTagNotFound {
+			return errhand.BuildDError("error: tag '%s' not found.", tn).Build()
+		} else if err != nil {
+			return errhand.BuildDError("error: failed to delete tag '%s'", tn).AddCause(err).Build()
+		}
+	}
+
+	return nil
+}
+
+func listTags(ctx context.Context, dEnv *env.DoltEnv, verbose bool) errhand.VerboseError {
+	err := actions.IterResolvedTags(ctx, dEnv.DoltDB, func(tag *doltdb.Tag) (bool, error) {
+		if !verbose {
+			cli.Println(tag.Name)
+			return false, nil
+		}
+
+		h, err := tag.Commit.HashOf()
+
+		if err != nil {
+			return false, err
+		}
+
+		cli.Println(fmt.Sprintf("%s\t%s", tag.Name, h.String()))
+		cli.Printf("\tTagger: %s <%s>\n", tag.Meta.Name, tag.Meta.Email)
+		cli.Printf("\tDate:   %s\n", tag.Meta.FormatTS())
+
+		if tag.Meta.Description != "" {
+			lines := strings.Split(tag.Meta.Description, "\n")
+			for _, line := range lines {
+				cli.Println(color.WhiteString("\t%s", line))
+			}
+		}
+
+		cli.Println()
+		return false, nil
+	})
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read tags").AddCause(err).Build()
+	}
+
+	return nil
+}
@@ -232,8 +232,9 @@ func newTableCopyDataMover(ctx context.Context, root *doltdb.RootValue, fs files
 
 	newTblSch := schema.SchemaFromCols(cc)
 	newTblSch.Indexes().Merge(false, oldTblSch.Indexes().AllIndexes()...)
+	newTblSch.Checks().Merge(oldTblSch.Checks().AllChecks()...)
 
-	transforms, err := mvdata.NameMapTransform(oldTblSch, newTblSch, make(rowconv.NameMapper))
+	transforms, err := mvdata.NameMapTransform(ctx, root.VRW(), oldTblSch, newTblSch, make(rowconv.NameMapper))
 
 	if err != nil {
 		return nil, errhand.BuildDError("Error determining the mapping from input fields to output fields.").AddDetails(
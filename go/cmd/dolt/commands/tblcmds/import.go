@@ -55,6 +55,7 @@ const (
 	forceParam       = "force"
 	contOnErrParam   = "continue"
 	primaryKeyParam  = "pk"
+	noPKParam        = "no-pk"
 	fileTypeParam    = "file-type"
 	delimParam       = "delim"
 )
@@ -63,7 +64,7 @@ var importDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Imports data into a dolt table`,
 	LongDesc: `If {{.EmphasisLeft}}--create-table | -c{{.EmphasisRight}} is given the operation will create {{.LessThan}}table{{.GreaterThan}} and import the contents of file into it.  If a table already exists at this location then the operation will fail, unless the {{.EmphasisLeft}}--force | -f{{.EmphasisRight}} flag is provided. The force flag forces the existing table to be overwritten.
 
-The schema for the new table can be specified explicitly by providing a SQL schema definition file, or will be inferred from the imported file.  All schemas, inferred or explicitly defined must define a primary key.  If the file format being imported does not support defining a primary key, then the {{.EmphasisLeft}}--pk{{.EmphasisRight}} parameter must supply the name of the field that should be used as the primary key.
+The schema for the new table can be specified explicitly by providing a SQL schema definition file, or will be inferred from the imported file.  If the file format being imported does not support defining a primary key, then the {{.EmphasisLeft}}--pk{{.EmphasisRight}} parameter should supply the name of the field that should be used as the primary key, otherwise the first field is used.  The {{.EmphasisLeft}}--no-pk{{.EmphasisRight}} flag creates a table without a primary key, into which every row of the file is imported, including duplicate rows.
 
 If {{.EmphasisLeft}}--update-table | -u{{.EmphasisRight}} is given the operation will update {{.LessThan}}table{{.GreaterThan}} with the contents of file. The table's existing schema will be used, and field names will be used to match file fields with table fields unless a mapping file is specified.
 
@@ -78,10 +79,10 @@ A mapping file can be used to map fields between the file being imported and the
 ` + schcmds.MappingFileHelp +
 
 		`
-In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, xlsx).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimeter`,
+In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, jsonl, xlsx, parquet).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimeter`,
 
 	Synopsis: []string{
-		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}|--no-pk] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
 		"-u [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
 		"-r [--map {{.LessThan}}file{{.GreaterThan}}] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
 	},
@@ -103,6 +104,7 @@ type importOptions struct {
 	force       bool
 	schFile     string
 	primaryKeys []string
+	noPK        bool
 	nameMapper  rowconv.NameMapper
 	src         mvdata.DataLocation
 	dest        mvdata.TableDataLocation
@@ -147,6 +149,11 @@ func (m importOptions) srcIsJson() bool {
 	return isJson
 }
 
+func (m importOptions) srcIsParquet() bool {
+	f, isFile := m.src.(mvdata.FileDataLocation)
+	return isFile && f.Format == mvdata.ParquetFile
+}
+
 func (m importOptions) srcIsStream() bool {
 	_, isStream := m.src.(mvdata.StreamDataLocation)
 	return isStream
@@ -178,6 +185,16 @@ func getImportMoveOptions(apr *argparser.ArgParseResults, dEnv *env.DoltEnv) (*i
 		return nil, errhand.VerboseErrorFromError(err)
 	}
 
+	var moveOp tableImportOp
+	switch {
+	case apr.Contains(createParam):
+		moveOp = CreateOp
+	case apr.Contains(replaceParam):
+		moveOp = ReplaceOp
+	default:
+		moveOp = UpdateOp
+	}
+
 	var srcOpts interface{}
 	switch val := srcLoc.(type) {
 	case mvdata.FileDataLocation:
@@ -195,6 +212,8 @@ func getImportMoveOptions(apr *argparser.ArgParseResults, dEnv *env.DoltEnv) (*i
 			srcOpts = mvdata.XlsxOptions{SheetName: tableName}
 		} else if val.Format == mvdata.JsonFile {
 			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
+		} else if val.Format == mvdata.JsonlFile {
+			srcOpts = jsonlImportOptions(tableName, schemaFile, moveOp)
 		}
 
 	case mvdata.StreamDataLocation:
@@ -205,19 +224,11 @@ func getImportMoveOptions(apr *argparser.ArgParseResults, dEnv *env.DoltEnv) (*i
 
 		if hasDelim {
 			srcOpts = mvdata.CsvOptions{Delim: delim}
+		} else if val.Format == mvdata.JsonlFile {
+			srcOpts = jsonlImportOptions(tableName, schemaFile, moveOp)
 		}
 	}
 
-	var moveOp tableImportOp
-	switch {
-	case apr.Contains(createParam):
-		moveOp = CreateOp
-	case apr.Contains(replaceParam):
-		moveOp = ReplaceOp
-	default:
-		moveOp = UpdateOp
-	}
-
 	if moveOp != CreateOp {
 
 		ctx := context.Background()
@@ -244,6 +255,7 @@ func getImportMoveOptions(apr *argparser.ArgParseResults, dEnv *env.DoltEnv) (*i
 		schFile:     schemaFile,
 		nameMapper:  colMapper,
 		primaryKeys: pks,
+		noPK:        apr.Contains(noPKParam),
 		src:         srcLoc,
 		dest:        tableLoc,
 		srcOptions:  srcOpts,
@@ -251,6 +263,16 @@ func getImportMoveOptions(apr *argparser.ArgParseResults, dEnv *env.DoltEnv) (*i
 
 }
 
+// jsonlImportOptions returns the options for reading JSON Lines being imported. Rows imported into an existing table are
+// read with its schema. Those of a new table are read with the schema from the schema file if one is given, and
+// otherwise untyped, so that the table's schema can be inferred from them.
+func jsonlImportOptions(tableName, schemaFile string, moveOp tableImportOp) mvdata.JSONLOptions {
+	if moveOp == CreateOp {
+		return mvdata.JSONLOptions{SchFile: schemaFile}
+	}
+	return mvdata.JSONLOptions{TableName: tableName}
+}
+
 func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 	if apr.NArg() == 0 || apr.NArg() > 2 {
 		return errhand.BuildDError("expected 1 or 2 arguments").SetPrintUsage().Build()
@@ -264,6 +286,14 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		return errhand.BuildDError("Must include '-c' for initial table import or -u to update existing table or -r to replace existing table.").Build()
 	}
 
+	if apr.Contains(noPKParam) {
+		if apr.Contains(schemaParam) || apr.Contains(primaryKeyParam) {
+			return errhand.BuildDError("parameter %s is not supported with %s or %s", noPKParam, schemaParam, primaryKeyParam).Build()
+		} else if !apr.Contains(createParam) {
+			return errhand.BuildDError("fatal: " + noPKParam + " is not supported for update or replace operations").Build()
+		}
+	}
+
 	if apr.Contains(schemaParam) && !apr.Contains(createParam) {
 		return errhand.BuildDError("fatal: " + schemaParam + " is not supported for update or replace operations").Build()
 	}
@@ -309,6 +339,13 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		}
 	}
 
+	if srcStreamLoc, isStream := srcLoc.(mvdata.StreamDataLocation); isStream {
+		_, hasSchema := apr.GetValue(schemaParam)
+		if srcStreamLoc.Format == mvdata.JsonlFile && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .jsonl tables read from stdin.").Build()
+		}
+	}
+
 	return nil
 }
 
@@ -396,6 +433,7 @@ func createArgParser() *argparser.ArgParser {
 	ap.SupportsString(schemaParam, "s", "schema_file", "The schema for the output data.")
 	ap.SupportsString(mappingFileParam, "m", "mapping_file", "A file that lays out how fields should be mapped from input data to output data.")
 	ap.SupportsString(primaryKeyParam, "pk", "primary_key", "Explicitly define the name of the field in the schema which should be used as the primary key.")
+	ap.SupportsFlag(noPKParam, "", "Create a table without a primary key, which may contain duplicate rows.")
 	ap.SupportsString(fileTypeParam, "", "file_type", "Explicitly define the type of the file if it can't be inferred from the file extension.")
 	ap.SupportsString(delimParam, "", "delimiter", "Specify a delimeter for a csv style file with a non-comma delimiter.")
 	return ap
@@ -442,7 +480,8 @@ func newImportDataMover(ctx context.Context, root *doltdb.RootValue, fs filesys.
 	err = wrSch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		preImage := impOpts.nameMapper.PreImage(col.Name)
 		_, found := rd.GetSchema().GetAllCols().GetByName(preImage)
-		if !found {
+		// the values of AUTO_INCREMENT columns are generated when they are not imported
+		if !found && !col.AutoIncrement {
 			err = fmt.Errorf("input primary keys do not match primary keys of existing table")
 		}
 		return err == nil, err
@@ -452,7 +491,7 @@ func newImportDataMover(ctx context.Context, root *doltdb.RootValue, fs filesys.
 		return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
 	}
 
-	transforms, err := mvdata.NameMapTransform(rd.GetSchema(), wrSch, impOpts.nameMapper)
+	transforms, err := mvdata.NameMapTransform(ctx, root.VRW(), rd.GetSchema(), wrSch, impOpts.nameMapper)
 
 	if err != nil {
 		return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.CreateMapperErr, Cause: err}
@@ -512,7 +551,12 @@ func getImportSchema(ctx context.Context, root *doltdb.RootValue, fs filesys.Fil
 			return rd.GetSchema(), nil
 		}
 
-		outSch, err := inferSchema(ctx, root, rd, impOpts)
+		var outSch schema.Schema
+		if impOpts.srcIsParquet() {
+			outSch, err = parquetSchema(ctx, root, rd, impOpts)
+		} else {
+			outSch, err = inferSchema(ctx, root, rd, impOpts)
+		}
 
 		if err != nil {
 			return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
@@ -535,7 +579,7 @@ func inferSchema(ctx context.Context, root *doltdb.RootValue, rd table.TableRead
 	var err error
 
 	pks := impOpts.primaryKeys
-	if len(pks) == 0 {
+	if len(pks) == 0 && !impOpts.noPK {
 		pks = rd.GetSchema().GetPKCols().GetColumnNames()
 	}
 
@@ -559,6 +603,40 @@ func inferSchema(ctx context.Context, root *doltdb.RootValue, rd table.TableRead
 	return schema.SchemaFromCols(newCols), nil
 }
 
+// parquetSchema returns the schema for a table created from a parquet file. Parquet files carry their column types, so
+// the reader's schema is used as is, with the primary key taken from the import options.
+func parquetSchema(ctx context.Context, root *doltdb.RootValue, rd table.TableReadCloser, impOpts *importOptions) (schema.Schema, error) {
+	pks := impOpts.primaryKeys
+	if len(pks) == 0 && !impOpts.noPK {
+		pks = rd.GetSchema().GetPKCols().GetColumnNames()
+	}
+
+	pkSet := set.NewStrSet(pks)
+	for _, pk := range pks {
+		if _, ok := rd.GetSchema().GetAllCols().GetByName(pk); !ok {
+			return nil, fmt.Errorf("primary key '%s' is not a column of the parquet file", pk)
+		}
+	}
+
+	newCols, err := schema.MapColCollection(rd.GetSchema().GetAllCols(), func(col schema.Column) (schema.Column, error) {
+		col.IsPartOfPK = pkSet.Contains(col.Name)
+		if col.IsPartOfPK && col.IsNullable() {
+			col.Constraints = append(col.Constraints, schema.NotNullConstraint{})
+		}
+		return col, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newCols, err = root.GenerateTagsForNewColColl(ctx, impOpts.tableName, newCols)
+	if err != nil {
+		return nil, errhand.BuildDError("failed to generate new schema").AddCause(err).Build()
+	}
+
+	return schema.SchemaFromCols(newCols), nil
+}
+
 func newDataMoverErrToVerr(mvOpts *importOptions, err *mvdata.DataMoverCreationError) errhand.VerboseError {
 	switch err.ErrType {
 	case mvdata.CreateReaderErr:
@@ -593,17 +671,9 @@ func newDataMoverErrToVerr(mvOpts *importOptions, err *mvdata.DataMoverCreationE
 		return bdr.AddCause(err.Cause).Build()
 
 	case mvdata.CreateWriterErr:
-		if err.Cause == mvdata.ErrNoPK {
-			builder := errhand.BuildDError("Attempting to write to %s with a schema that does not contain a primary key.", mvOpts.dest.String())
-			builder.AddDetails("A primary key is required and can be specified by:\n" +
-				"\tusing -pk option to designate a field as the primary key by name.\n" +
-				"\tusing -schema to provide a schema descriptor file.")
-			return builder.Build()
-		} else {
-			bdr := errhand.BuildDError("Error creating writer for %s.\n", mvOpts.dest.String())
-			bdr.AddDetails("When attempting to move data from %s to %s, could not open a writer.", mvOpts.src.String(), mvOpts.dest.String())
-			return bdr.AddCause(err.Cause).Build()
-		}
+		bdr := errhand.BuildDError("Error creating writer for %s.\n", mvOpts.dest.String())
+		bdr.AddDetails("When attempting to move data from %s to %s, could not open a writer.", mvOpts.src.String(), mvOpts.dest.String())
+		return bdr.AddCause(err.Cause).Build()
 
 	case mvdata.CreateSorterErr:
 		bdr := errhand.BuildDError("Error creating sorting reader.")
@@ -28,6 +28,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands/cnfcmds"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands/cnstcmds"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands/credcmds"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands/indexcmds"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/commands/schcmds"
@@ -77,6 +78,14 @@ var doltCommand = cli.NewSubCommandHandler("dolt", "it's git for data", []cli.Co
 	dumpDocsCommand,
 	commands.MigrateCmd{},
 	indexcmds.Commands,
+	cnstcmds.Commands,
+	commands.GarbageCollectionCmd{},
+	commands.TagCmd{},
+	commands.CherryPickCmd{},
+	commands.RevertCmd{},
+	commands.RebaseCmd{},
+	commands.StashCmd{},
+	commands.MergeStrategyCmd{},
 })
 
 func init() {
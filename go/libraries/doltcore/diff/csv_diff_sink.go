@@ -0,0 +1,202 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	csvTableTag uint64 = iota
+	csvDiffTypeTag
+	csvFirstValTag
+)
+
+// CSVDiffSink writes the row diffs of a table as CSV. Each line has the name of the table, the diff_type of the change,
+// and the values of the row before and after it in from_ and to_ columns, which are empty for added and removed rows.
+// A header line is written when the sink is created.
+//
+// The rows received must be split by a DiffSplitter which converts old rows to fromSch and new rows to toSch.
+type CSVDiffSink struct {
+	csvWr     *csv.CSVWriter
+	sch       schema.Schema
+	fromTags  map[uint64]uint64
+	toTags    map[uint64]uint64
+	tableName string
+	oldRow    row.Row
+}
+
+// NewCSVDiffSink creates a CSVDiffSink for a diff pipeline, and writes its header line.
+func NewCSVDiffSink(wr io.WriteCloser, fromSch, toSch schema.Schema, tableName string) (*CSVDiffSink, error) {
+	cols := []schema.Column{
+		schema.NewColumn("table", csvTableTag, types.StringKind, true),
+		schema.NewColumn("diff_type", csvDiffTypeTag, types.StringKind, true),
+	}
+
+	// the columns of both versions are renamed and given new tags, as a column may be in both
+	outTag := csvFirstValTag
+	addCols := func(prefix string, sch schema.Schema) map[uint64]uint64 {
+		tags := make(map[uint64]uint64)
+		_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			tags[tag] = outTag
+			col.Name = prefix + "_" + col.Name
+			col.Tag = outTag
+			col.IsPartOfPK = false
+			col.Constraints = nil
+			cols = append(cols, col)
+			outTag++
+			return false, nil
+		})
+		return tags
+	}
+
+	fromTags := addCols(From, fromSch)
+	toTags := addCols(To, toSch)
+
+	colColl, err := schema.NewColCollection(cols...)
+	if err != nil {
+		return nil, err
+	}
+
+	sch := schema.SchemaFromCols(colColl)
+	csvWr, err := csv.NewCSVWriter(wr, sch, csv.NewCSVInfo())
+	if err != nil {
+		return nil, err
+	}
+
+	return &CSVDiffSink{csvWr: csvWr, sch: sch, fromTags: fromTags, toTags: toTags, tableName: tableName}, nil
+}
+
+// GetSchema gets the schema of the lines that the CSVDiffSink writes.
+func (cds *CSVDiffSink) GetSchema() schema.Schema {
+	return cds.sch
+}
+
+// ProcRowWithProps satisfies pipeline.SinkFunc; it writes a row diff as a line of CSV. The old row of a modified row is
+// held until the new row is received, so that both are written on a single line.
+func (cds *CSVDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap) error {
+	dt, err := rowDiffType(props)
+	if err != nil {
+		return err
+	}
+
+	var from, to row.Row
+	switch dt {
+	case DiffModifiedOld:
+		cds.oldRow = r
+		return nil
+	case DiffModifiedNew:
+		from, to = cds.oldRow, r
+		cds.oldRow = nil
+	case DiffAdded:
+		to = r
+	case DiffRemoved:
+		from = r
+	}
+
+	taggedVals := row.TaggedValues{
+		csvTableTag:    types.String(cds.tableName),
+		csvDiffTypeTag: types.String(diffTypeName(dt)),
+	}
+
+	for _, side := range []struct {
+		r    row.Row
+		tags map[uint64]uint64
+	}{{from, cds.fromTags}, {to, cds.toTags}} {
+		if side.r == nil {
+			continue
+		}
+
+		_, err = side.r.IterCols(func(tag uint64, val types.Value) (stop bool, err error) {
+			if outTag, ok := side.tags[tag]; ok {
+				taggedVals[outTag] = val
+			}
+			return false, nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	outRow, err := row.New(types.Format_Default, cds.sch, taggedVals)
+	if err != nil {
+		return err
+	}
+
+	return cds.csvWr.WriteRow(context.TODO(), outRow)
+}
+
+// Close should release resources being held
+func (cds *CSVDiffSink) Close() error {
+	return cds.csvWr.Close(context.TODO())
+}
+
+// WriteCSVSchemaDiff writes the columns which differ between two schemas of a table as CSV, with a header line. Each
+// line has the name of the table, the diff_type and tag of a column, and its definition before and after the change,
+// which is empty for added and removed columns.
+func WriteCSVSchemaDiff(wr io.WriteCloser, fromSch, toSch schema.Schema, tableName string) error {
+	_, sch := untyped.NewUntypedSchema("table", "diff_type", "tag",
+		"from_name", "from_type", "from_primary_key", "from_nullable",
+		"to_name", "to_type", "to_primary_key", "to_nullable")
+
+	csvWr, err := csv.NewCSVWriter(wr, sch, csv.NewCSVInfo())
+	if err != nil {
+		return err
+	}
+
+	diffs, unionTags := DiffSchemas(fromSch, toSch)
+	for _, tag := range unionTags {
+		dff := diffs[tag]
+		if dff.DiffType == SchDiffNone {
+			continue
+		}
+
+		// the definition of a missing column is left null, rather than written as empty strings
+		vals := map[uint64]string{0: tableName, 1: schDiffTypeName(dff.DiffType), 2: strconv.FormatUint(tag, 10)}
+		for i, col := range []*schema.Column{dff.Old, dff.New} {
+			if col != nil {
+				jc := newJSONColumn(col)
+				firstTag := uint64(3 + 4*i)
+				vals[firstTag] = jc.Name
+				vals[firstTag+1] = jc.Type
+				vals[firstTag+2] = strconv.FormatBool(jc.PrimaryKey)
+				vals[firstTag+3] = strconv.FormatBool(jc.Nullable)
+			}
+		}
+
+		r, err := untyped.NewRowFromTaggedStrings(types.Format_Default, sch, vals)
+		if err != nil {
+			csvWr.Close(context.TODO())
+			return err
+		}
+
+		if err = csvWr.WriteRow(context.TODO(), r); err != nil {
+			csvWr.Close(context.TODO())
+			return err
+		}
+	}
+
+	return csvWr.Close(context.TODO())
+}
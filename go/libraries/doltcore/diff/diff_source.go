@@ -36,14 +36,17 @@ type RowDiffSource struct {
 	joiner     *rowconv.Joiner
 	oldRowConv *rowconv.RowConverter
 	newRowConv *rowconv.RowConverter
+
+	// pending holds the remaining diff rows for a change to the number of copies of a row of a keyless table
+	pending []row.Row
 }
 
 func NewRowDiffSource(ad *AsyncDiffer, joiner *rowconv.Joiner) *RowDiffSource {
 	return &RowDiffSource{
-		ad,
-		joiner,
-		rowconv.IdentityConverter,
-		rowconv.IdentityConverter,
+		ad:         ad,
+		joiner:     joiner,
+		oldRowConv: rowconv.IdentityConverter,
+		newRowConv: rowconv.IdentityConverter,
 	}
 }
 
@@ -60,6 +63,12 @@ func (rdRd *RowDiffSource) GetSchema() schema.Schema {
 // NextDiff reads a row from a table.  If there is a bad row the returned error will be non nil, and callin IsBadRow(err)
 // will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row, or fail.
 func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, error) {
+	if len(rdRd.pending) > 0 {
+		r := rdRd.pending[0]
+		rdRd.pending = rdRd.pending[1:]
+		return r, pipeline.ImmutableProperties{}, nil
+	}
+
 	if rdRd.ad.isDone {
 		return nil, pipeline.NoProps, io.EOF
 	}
@@ -84,6 +93,8 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 
 	d := diffs[0]
 	rows := make(map[string]row.Row)
+	var oldCard, newCard uint64
+	keyless := false
 	if d.OldValue != nil {
 		sch := rdRd.joiner.SchemaForName(From)
 		if !rdRd.oldRowConv.IdentityConverter {
@@ -96,6 +107,8 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 			return nil, pipeline.ImmutableProperties{}, err
 		}
 
+		oldCard = row.Cardinality(oldRow)
+		keyless = keyless || schema.IsKeyless(sch)
 		rows[From], err = rdRd.oldRowConv.Convert(oldRow)
 
 		if err != nil {
@@ -115,6 +128,8 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 			return nil, pipeline.ImmutableProperties{}, err
 		}
 
+		newCard = row.Cardinality(newRow)
+		keyless = keyless || schema.IsKeyless(sch)
 		rows[To], err = rdRd.newRowConv.Convert(newRow)
 
 		if err != nil {
@@ -122,6 +137,10 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 		}
 	}
 
+	if keyless {
+		return rdRd.keylessDiff(rows, oldCard, newCard)
+	}
+
 	joinedRow, err := rdRd.joiner.Join(rows)
 
 	if err != nil {
@@ -131,6 +150,31 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 	return joinedRow, pipeline.ImmutableProperties{}, nil
 }
 
+// keylessDiff returns the diff of a row of a keyless table as one added or removed row for each copy of the row which
+// was added or removed. The values of a keyless row are part of its key, so a row can't be modified.
+func (rdRd *RowDiffSource) keylessDiff(rows map[string]row.Row, oldCard, newCard uint64) (row.Row, pipeline.ImmutableProperties, error) {
+	side, n := To, newCard-oldCard
+	if oldCard > newCard {
+		side, n = From, oldCard-newCard
+	}
+
+	if n == 0 {
+		return rdRd.NextDiff()
+	}
+
+	joinedRow, err := rdRd.joiner.Join(map[string]row.Row{side: rows[side]})
+
+	if err != nil {
+		return nil, pipeline.ImmutableProperties{}, err
+	}
+
+	for i := uint64(1); i < n; i++ {
+		rdRd.pending = append(rdRd.pending, joinedRow)
+	}
+
+	return joinedRow, pipeline.ImmutableProperties{}, nil
+}
+
 // Close should release resources being held
 func (rdRd *RowDiffSource) Close() error {
 	rdRd.ad.Close()
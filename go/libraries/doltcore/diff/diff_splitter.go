@@ -15,11 +15,15 @@
 package diff
 
 import (
+	"fmt"
+
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/rowconv"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/valutil"
+	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 const (
@@ -30,6 +34,9 @@ const (
 	// CollChangesProp is the name of a property added to each modified row which is a map from collumn name to the
 	// type of change.
 	CollChangesProp = "collchanges"
+
+	// maxBlobCellLen is the length above which TEXT and BLOB values are summarized rather than printed in full
+	maxBlobCellLen = 128
 )
 
 // DiffChType is an enum that represents the type of change
@@ -70,14 +77,23 @@ func (dr *DiffRow) DiffType() DiffChType {
 // version, and a column for every field in the new version and split it into two rows with properties which annotate
 // what each row is.  This is used to show diffs as 2 lines, instead of 1.
 type DiffSplitter struct {
-	joiner  *rowconv.Joiner
-	oldConv *rowconv.RowConverter
-	newConv *rowconv.RowConverter
+	joiner        *rowconv.Joiner
+	oldConv       *rowconv.RowConverter
+	newConv       *rowconv.RowConverter
+	cellSummaries bool
 }
 
 // NewDiffSplitter creates a DiffSplitter
 func NewDiffSplitter(joiner *rowconv.Joiner, oldConv, newConv *rowconv.RowConverter) *DiffSplitter {
-	return &DiffSplitter{joiner, oldConv, newConv}
+	return &DiffSplitter{joiner: joiner, oldConv: oldConv, newConv: newConv}
+}
+
+// ShowCellDiffSummaries makes the splitter replace cell values which are too large to read in a diff with summaries of
+// them. Modified JSON cells are replaced with the values at only the paths which differ between them, and long TEXT and
+// BLOB values are replaced with their length and hash. The rows must be converted to an untyped schema for this to be
+// used.
+func (ds *DiffSplitter) ShowCellDiffSummaries() {
+	ds.cellSummaries = true
 }
 
 func convertNamedRow(rows map[string]row.Row, name string, rc *rowconv.RowConverter) (row.Row, error) {
@@ -128,6 +144,13 @@ func (ds *DiffSplitter) SplitDiffIntoOldAndNew(inRow row.Row, props pipeline.Rea
 				if !valutil.NilSafeEqCheck(oldVal, newVal) {
 					newColDiffs[col.Name] = DiffModifiedNew
 					oldColDiffs[col.Name] = DiffModifiedOld
+
+					if ds.cellSummaries && isJSONCol(originalOldSch, tag) && isJSONCol(originalNewSch, tag) {
+						mappedOld, mappedNew, err = setJSONPathDiffs(outSch, tag, oldVal, newVal, mappedOld, mappedNew)
+						if err != nil {
+							return true, err
+						}
+					}
 				}
 			} else if inOld {
 				oldColDiffs[col.Name] = DiffRemoved
@@ -146,6 +169,17 @@ func (ds *DiffSplitter) SplitDiffIntoOldAndNew(inRow row.Row, props pipeline.Rea
 		newProps = map[string]interface{}{DiffTypeProp: DiffModifiedNew, CollChangesProp: newColDiffs}
 	}
 
+	if ds.cellSummaries {
+		mappedOld, err = summarizeBlobCells(ds.oldConv.DestSch, ds.joiner.SchemaForName(From), rows[From], mappedOld)
+		if err != nil {
+			return nil, err.Error()
+		}
+		mappedNew, err = summarizeBlobCells(ds.newConv.DestSch, ds.joiner.SchemaForName(To), rows[To], mappedNew)
+		if err != nil {
+			return nil, err.Error()
+		}
+	}
+
 	var results []*pipeline.TransformedRowResult
 	if mappedOld != nil {
 		results = append(results, &pipeline.TransformedRowResult{RowData: mappedOld, PropertyUpdates: oldProps})
@@ -157,3 +191,76 @@ func (ds *DiffSplitter) SplitDiffIntoOldAndNew(inRow row.Row, props pipeline.Rea
 
 	return results, ""
 }
+
+func isJSONCol(sch schema.Schema, tag uint64) bool {
+	col, ok := sch.GetAllCols().GetByTag(tag)
+	return ok && col.TypeInfo.GetTypeIdentifier() == typeinfo.JSONTypeIdentifier
+}
+
+// setJSONPathDiffs sets the values of the JSON column with the given tag to the values at the paths which differ between
+// the old and new documents.
+func setJSONPathDiffs(sch schema.Schema, tag uint64, oldVal, newVal types.Value, oldRow, newRow row.Row) (row.Row, row.Row, error) {
+	oldStr, oldOk := oldVal.(types.String)
+	newStr, newOk := newVal.(types.String)
+	if !oldOk || !newOk {
+		// one of the documents is NULL
+		return oldRow, newRow, nil
+	}
+
+	jsonDiffs, err := DiffJSON(string(oldStr), string(newStr))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldPaths, newPaths := FormatJSONDiffs(jsonDiffs)
+	oldRow, err = oldRow.SetColVal(tag, types.String(oldPaths), sch)
+	if err != nil {
+		return nil, nil, err
+	}
+	newRow, err = newRow.SetColVal(tag, types.String(newPaths), sch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return oldRow, newRow, nil
+}
+
+// summarizeBlobCells replaces the values of the TEXT and BLOB columns of a converted row which are longer than
+// maxBlobCellLen with a summary of their type, length and hash. The summaries are computed from the original row, so
+// that they are the same for equal values.
+func summarizeBlobCells(outSch, origSch schema.Schema, origRow, r row.Row) (row.Row, error) {
+	if r == nil || origRow == nil {
+		return r, nil
+	}
+
+	err := origSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if _, ok := outSch.GetAllCols().GetByTag(tag); !ok || !typeinfo.IsBlobType(col.TypeInfo) {
+			return false, nil
+		}
+
+		val, _ := origRow.GetColVal(tag)
+		b, ok := val.(types.Blob)
+		if !ok || b.Len() <= maxBlobCellLen {
+			return false, nil
+		}
+
+		h, err := b.Hash(origRow.Format())
+		if err != nil {
+			return true, err
+		}
+
+		typeName := "BLOB"
+		if col.TypeInfo.GetTypeIdentifier() == typeinfo.BlobStringTypeIdentifier {
+			typeName = "TEXT"
+		}
+
+		summary := fmt.Sprintf("<%s %d bytes #%s>", typeName, b.Len(), h.String()[:8])
+		r, err = r.SetColVal(tag, types.String(summary), outSch)
+		return false, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
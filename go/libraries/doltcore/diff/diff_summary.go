@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/store/diff"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -33,7 +34,19 @@ func Summary(ctx context.Context, ch chan DiffSummaryProgress, from, to types.Ma
 	ad.Start(ctx, from, to)
 	defer ad.Close()
 
-	ch <- DiffSummaryProgress{OldSize: from.Len(), NewSize: to.Len()}
+	oldSize, err := rowCount(ctx, from)
+
+	if err != nil {
+		return err
+	}
+
+	newSize, err := rowCount(ctx, to)
+
+	if err != nil {
+		return err
+	}
+
+	ch <- DiffSummaryProgress{OldSize: oldSize, NewSize: newSize}
 
 	for !ad.IsDone() {
 		diffs, err := ad.GetDiffs(100, time.Millisecond)
@@ -55,7 +68,55 @@ func Summary(ctx context.Context, ch chan DiffSummaryProgress, from, to types.Ma
 	return nil
 }
 
+// rowCount returns the number of rows in a table's row data. Each entry of the row data of a keyless table holds a
+// number of copies of the same row.
+func rowCount(ctx context.Context, m types.Map) (uint64, error) {
+	if m.Empty() {
+		return 0, nil
+	}
+
+	_, v, err := m.First(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if _, keyless, err := row.NomsCardinality(v.(types.Tuple)); err != nil {
+		return 0, err
+	} else if !keyless {
+		return m.Len(), nil
+	}
+
+	var count uint64
+	err = m.IterAll(ctx, func(_, v types.Value) error {
+		card, _, err := row.NomsCardinality(v.(types.Tuple))
+		count += card
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func reportChanges(change *diff.Difference, ch chan<- DiffSummaryProgress) error {
+	oldCard, newCard, keyless, err := cardinalities(change)
+
+	if err != nil {
+		return err
+	} else if keyless {
+		// the values of a keyless row are part of its key, so only the number of copies of the row can change
+		if newCard > oldCard {
+			ch <- DiffSummaryProgress{Adds: newCard - oldCard}
+		} else {
+			ch <- DiffSummaryProgress{Removes: oldCard - newCard}
+		}
+
+		return nil
+	}
+
 	switch change.ChangeType {
 	case types.DiffChangeAdded:
 		ch <- DiffSummaryProgress{Adds: 1}
@@ -75,3 +136,31 @@ func reportChanges(change *diff.Difference, ch chan<- DiffSummaryProgress) error
 
 	return nil
 }
+
+// cardinalities returns the number of copies of a row before and after a change, and whether the row belongs to a
+// keyless table.
+func cardinalities(change *diff.Difference) (oldCard, newCard uint64, keyless bool, err error) {
+	if change.OldValue != nil {
+		var k bool
+		oldCard, k, err = row.NomsCardinality(change.OldValue.(types.Tuple))
+
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		keyless = keyless || k
+	}
+
+	if change.NewValue != nil {
+		var k bool
+		newCard, k, err = row.NomsCardinality(change.NewValue.(types.Tuple))
+
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		keyless = keyless || k
+	}
+
+	return oldCard, newCard, keyless, nil
+}
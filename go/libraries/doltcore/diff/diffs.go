@@ -31,6 +31,7 @@ const (
 	AddedTable TableDiffType = iota
 	ModifiedTable
 	RemovedTable
+	RenamedTable
 )
 
 type TableDiffs struct {
@@ -39,6 +40,16 @@ type TableDiffs struct {
 	NumRemoved  int
 	TableToType map[string]TableDiffType
 	Tables      []string
+	// RenamedFrom maps the new name of each renamed table to its old name. Renamed tables are counted in NumModified.
+	RenamedFrom map[string]string
+	// RenamedColumns maps the name of each modified table to the columns of the table which were renamed.
+	RenamedColumns map[string][]ColumnRename
+}
+
+// ColumnRename is a column of a table which was renamed.
+type ColumnRename struct {
+	OldName string
+	NewName string
 }
 
 type DocDiffType int
@@ -93,25 +104,60 @@ func (rvu RootValueUnreadable) Error() string {
 
 // NewTableDiffs returns the TableDiffs between two roots.
 func NewTableDiffs(ctx context.Context, newer, older *doltdb.RootValue) (*TableDiffs, error) {
+	return newTableDiffs(ctx, newer, older, false, 0)
+}
+
+// NewTableDiffsWithRenames returns the TableDiffs between two roots, with the tables which were dropped and added with
+// at least |minSimilarity| percent of their rows in common found to be renamed. See FindRenamedTables.
+func NewTableDiffsWithRenames(ctx context.Context, newer, older *doltdb.RootValue, minSimilarity int) (*TableDiffs, error) {
+	return newTableDiffs(ctx, newer, older, true, minSimilarity)
+}
+
+func newTableDiffs(ctx context.Context, newer, older *doltdb.RootValue, findRenames bool, minSimilarity int) (*TableDiffs, error) {
 	deltas, err := GetTableDeltas(ctx, older, newer)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if findRenames {
+		deltas, err = FindRenamedTables(ctx, deltas, minSimilarity)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var added []string
 	var modified []string
 	var removed []string
+	renamedFrom := make(map[string]string)
+	renamedCols := make(map[string][]ColumnRename)
 
 	for _, d := range deltas {
 		switch {
 		case d.IsAdd():
 			added = append(added, d.ToName)
+			continue
 		case d.IsDrop():
 			removed = append(removed, d.FromName)
+			continue
+		case d.IsRename():
+			modified = append(modified, d.ToName)
+			renamedFrom[d.ToName] = d.FromName
 		default:
 			modified = append(modified, d.ToName)
 		}
+
+		renames, err := d.RenamedColumns(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(renames) > 0 {
+			renamedCols[d.ToName] = renames
+		}
 	}
 
 	var tbls []string
@@ -125,7 +171,11 @@ func NewTableDiffs(ctx context.Context, newer, older *doltdb.RootValue) (*TableD
 	}
 
 	for _, tbl := range modified {
-		tblToType[tbl] = ModifiedTable
+		if _, ok := renamedFrom[tbl]; ok {
+			tblToType[tbl] = RenamedTable
+		} else {
+			tblToType[tbl] = ModifiedTable
+		}
 	}
 
 	for _, tbl := range removed {
@@ -134,7 +184,7 @@ func NewTableDiffs(ctx context.Context, newer, older *doltdb.RootValue) (*TableD
 
 	sort.Strings(tbls)
 
-	return &TableDiffs{len(added), len(modified), len(removed), tblToType, tbls}, err
+	return &TableDiffs{len(added), len(modified), len(removed), tblToType, tbls, renamedFrom, renamedCols}, err
 }
 
 func (td *TableDiffs) Len() int {
@@ -143,6 +193,16 @@ func (td *TableDiffs) Len() int {
 
 // GetTableDiffs returns the staged and unstaged TableDiffs for the repo.
 func GetTableDiffs(ctx context.Context, dEnv *env.DoltEnv) (*TableDiffs, *TableDiffs, error) {
+	return getTableDiffs(ctx, dEnv, false, 0)
+}
+
+// GetTableDiffsWithRenames returns the staged and unstaged TableDiffs for the repo, with the tables which were dropped
+// and added with at least |minSimilarity| percent of their rows in common found to be renamed.
+func GetTableDiffsWithRenames(ctx context.Context, dEnv *env.DoltEnv, minSimilarity int) (*TableDiffs, *TableDiffs, error) {
+	return getTableDiffs(ctx, dEnv, true, minSimilarity)
+}
+
+func getTableDiffs(ctx context.Context, dEnv *env.DoltEnv, findRenames bool, minSimilarity int) (*TableDiffs, *TableDiffs, error) {
 	headRoot, err := dEnv.HeadRoot(ctx)
 
 	if err != nil {
@@ -161,13 +221,13 @@ func GetTableDiffs(ctx context.Context, dEnv *env.DoltEnv) (*TableDiffs, *TableD
 		return nil, nil, RootValueUnreadable{WorkingRoot, err}
 	}
 
-	stagedDiffs, err := NewTableDiffs(ctx, stagedRoot, headRoot)
+	stagedDiffs, err := newTableDiffs(ctx, stagedRoot, headRoot, findRenames, minSimilarity)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	notStagedDiffs, err := NewTableDiffs(ctx, workingRoot, stagedRoot)
+	notStagedDiffs, err := newTableDiffs(ctx, workingRoot, stagedRoot, findRenames, minSimilarity)
 
 	if err != nil {
 		return nil, nil, err
@@ -268,6 +328,20 @@ type TableDelta struct {
 	FromTable     *doltdb.Table
 	ToTable       *doltdb.Table
 	ToForeignKeys []*doltdb.DisplayForeignKey // In the event that a table is an add, we'll display the FKs as well
+
+	// fromToTags maps the tags of the columns of FromTable to those of ToTable for a table which was found to be renamed
+	// by FindRenamedTables, whose columns were given new tags.
+	fromToTags map[uint64]uint64
+}
+
+// tableIdentityTag returns the tag used to match a table across roots, which is the tag of its first primary key
+// column, or of its first column if the table has no primary key.
+func tableIdentityTag(sch schema.Schema) uint64 {
+	if schema.IsKeyless(sch) {
+		return sch.GetAllCols().GetByIndex(0).Tag
+	}
+
+	return sch.GetPKCols().GetByIndex(0).Tag
 }
 
 // GetTableDeltas returns a list of TableDelta objects for each table that changed between fromRoot and toRoot.
@@ -288,7 +362,7 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) ([]
 			return true, err
 		}
 
-		pkTag := sch.GetPKCols().GetColumns()[0].Tag
+		pkTag := tableIdentityTag(sch)
 		fromTable[pkTag] = table
 		fromTableNames[pkTag] = name
 		fromTableHashes[pkTag] = th
@@ -310,7 +384,7 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) ([]
 			return true, err
 		}
 
-		pkTag := sch.GetPKCols().GetColumns()[0].Tag
+		pkTag := tableIdentityTag(sch)
 		oldName, ok := fromTableNames[pkTag]
 
 		fkc, err := toRoot.GetForeignKeyCollection(ctx)
@@ -364,7 +438,33 @@ func (td TableDelta) IsDrop() bool {
 	return td.FromTable != nil && td.ToTable == nil
 }
 
-// GetSchemas returns the table's schema at the fromRoot and toRoot, or schema.Empty if the table did not exist.
+// RenamedColumns returns the columns of the table which were renamed between the fromRoot and toRoot, in the order
+// of their tags.
+func (td TableDelta) RenamedColumns(ctx context.Context) ([]ColumnRename, error) {
+	if td.IsAdd() || td.IsDrop() {
+		return nil, nil
+	}
+
+	fromSch, toSch, err := td.GetSchemas(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var renames []ColumnRename
+	colDiffs, unionTags := DiffSchemas(fromSch, toSch)
+	for _, tag := range unionTags {
+		cd := colDiffs[tag]
+		if (cd.DiffType == SchDiffColRenamed || cd.DiffType == SchDiffColModified) && cd.Old.Name != cd.New.Name {
+			renames = append(renames, ColumnRename{cd.Old.Name, cd.New.Name})
+		}
+	}
+
+	return renames, nil
+}
+
+// GetSchemas returns the table's schema at the fromRoot and toRoot, or schema.Empty if the table did not exist. The
+// columns of a table found to be renamed by FindRenamedTables are given the tags of its columns at the toRoot.
 func (td TableDelta) GetSchemas(ctx context.Context) (from, to schema.Schema, err error) {
 	if td.FromTable != nil {
 		from, err = td.FromTable.GetSchema(ctx)
@@ -372,6 +472,12 @@ func (td TableDelta) GetSchemas(ctx context.Context) (from, to schema.Schema, er
 		if err != nil {
 			return nil, nil, err
 		}
+
+		if td.fromToTags != nil {
+			if from, err = td.retagSchema(from); err != nil {
+				return nil, nil, err
+			}
+		}
 	} else {
 		from = schema.EmptySchema
 	}
@@ -389,9 +495,15 @@ func (td TableDelta) GetSchemas(ctx context.Context) (from, to schema.Schema, er
 	return from, to, nil
 }
 
-// GetMaps returns the table's row map at the fromRoot and toRoot, or and empty map if the table did not exist.
+// GetMaps returns the table's row map at the fromRoot and toRoot, or and empty map if the table did not exist. The rows
+// of a table found to be renamed by FindRenamedTables are given the tags of its columns at the toRoot.
 func (td TableDelta) GetMaps(ctx context.Context) (from, to types.Map, err error) {
-	if td.FromTable != nil {
+	if td.fromToTags != nil {
+		from, err = td.retagRows(ctx)
+		if err != nil {
+			return from, to, err
+		}
+	} else if td.FromTable != nil {
 		from, err = td.FromTable.GetRowData(ctx)
 		if err != nil {
 			return from, to, err
@@ -0,0 +1,164 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+var jsonPathKeyRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// JSONDiff is a difference between two JSON documents at a single path.
+type JSONDiff struct {
+	// Path is the path of the difference, such as $.a[1].b
+	Path string
+	// From is the value at Path in the old document, or nil if the path was added
+	From *string
+	// To is the value at Path in the new document, or nil if the path was removed
+	To *string
+}
+
+// DiffJSON returns the differences between two JSON documents, as the values at the deepest paths which differ,
+// ordered by path.
+func DiffJSON(from, to string) ([]JSONDiff, error) {
+	fromDoc, err := typeinfo.UnmarshalJSON([]byte(from))
+	if err != nil {
+		return nil, err
+	}
+	toDoc, err := typeinfo.UnmarshalJSON([]byte(to))
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []JSONDiff
+	err = diffJSONValues("$", fromDoc, toDoc, &diffs)
+	if err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+func diffJSONValues(path string, from, to interface{}, diffs *[]JSONDiff) error {
+	fromObj, fromIsObj := from.(map[string]interface{})
+	toObj, toIsObj := to.(map[string]interface{})
+	if fromIsObj && toIsObj {
+		keys := make([]string, 0, len(fromObj)+len(toObj))
+		for k := range fromObj {
+			keys = append(keys, k)
+		}
+		for k := range toObj {
+			if _, ok := fromObj[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fromVal, inFrom := fromObj[k]
+			toVal, inTo := toObj[k]
+			err := diffJSONMembers(JSONObjectKeyPath(path, k), fromVal, inFrom, toVal, inTo, diffs)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	fromArr, fromIsArr := from.([]interface{})
+	toArr, toIsArr := to.([]interface{})
+	if fromIsArr && toIsArr {
+		for i := 0; i < len(fromArr) || i < len(toArr); i++ {
+			var fromVal, toVal interface{}
+			if i < len(fromArr) {
+				fromVal = fromArr[i]
+			}
+			if i < len(toArr) {
+				toVal = toArr[i]
+			}
+			err := diffJSONMembers(path+"["+strconv.Itoa(i)+"]", fromVal, i < len(fromArr), toVal, i < len(toArr), diffs)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return diffJSONMembers(path, from, true, to, true, diffs)
+}
+
+func diffJSONMembers(path string, from interface{}, inFrom bool, to interface{}, inTo bool, diffs *[]JSONDiff) error {
+	if inFrom && inTo {
+		if reflect.DeepEqual(from, to) {
+			return nil
+		}
+
+		_, fromIsObj := from.(map[string]interface{})
+		_, toIsObj := to.(map[string]interface{})
+		_, fromIsArr := from.([]interface{})
+		_, toIsArr := to.([]interface{})
+		if (fromIsObj && toIsObj) || (fromIsArr && toIsArr) {
+			return diffJSONValues(path, from, to, diffs)
+		}
+	}
+
+	d := JSONDiff{Path: path}
+	if inFrom {
+		str, err := typeinfo.MarshalJSON(from)
+		if err != nil {
+			return err
+		}
+		d.From = &str
+	}
+	if inTo {
+		str, err := typeinfo.MarshalJSON(to)
+		if err != nil {
+			return err
+		}
+		d.To = &str
+	}
+	*diffs = append(*diffs, d)
+	return nil
+}
+
+// JSONObjectKeyPath returns the path of the member with the given key of the object at the given path.
+func JSONObjectKeyPath(path, key string) string {
+	if jsonPathKeyRegex.MatchString(key) {
+		return path + "." + key
+	}
+	quoted, _ := json.Marshal(key)
+	return path + "." + string(quoted)
+}
+
+// FormatJSONDiffs returns the old and new sides of the given differences, as the values at each path separated by
+// commas. Paths which are missing from a side are omitted from it.
+func FormatJSONDiffs(diffs []JSONDiff) (from, to string) {
+	var fromParts, toParts []string
+	for _, d := range diffs {
+		if d.From != nil {
+			fromParts = append(fromParts, d.Path+": "+*d.From)
+		}
+		if d.To != nil {
+			toParts = append(toParts, d.Path+": "+*d.To)
+		}
+	}
+	return strings.Join(fromParts, ", "), strings.Join(toParts, ", ")
+}
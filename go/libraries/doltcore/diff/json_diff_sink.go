@@ -0,0 +1,235 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
+	dtjson "github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
+)
+
+const (
+	// DiffTypeAdded is the diff_type of rows and columns which were added
+	DiffTypeAdded = "added"
+	// DiffTypeRemoved is the diff_type of rows and columns which were removed
+	DiffTypeRemoved = "removed"
+	// DiffTypeModified is the diff_type of rows and columns which were modified
+	DiffTypeModified = "modified"
+	// DiffTypeRenamed is the diff_type of renamed tables, and of columns which were renamed and not otherwise changed
+	DiffTypeRenamed = "renamed"
+)
+
+// diffTypeName returns the diff_type written for rows with the given DiffTypeProp.
+func diffTypeName(dt DiffChType) string {
+	switch dt {
+	case DiffAdded:
+		return DiffTypeAdded
+	case DiffRemoved:
+		return DiffTypeRemoved
+	default:
+		return DiffTypeModified
+	}
+}
+
+// rowDiffType returns the DiffTypeProp of a row, which must be set by a DiffSplitter.
+func rowDiffType(props pipeline.ReadableMap) (DiffChType, error) {
+	if prop, ok := props.Get(DiffTypeProp); ok {
+		if dt, ok := prop.(DiffChType); ok {
+			return dt, nil
+		}
+	}
+
+	return 0, errors.New("row is missing its diff type")
+}
+
+type jsonRowDiff struct {
+	DiffType string                 `json:"diff_type"`
+	From     map[string]interface{} `json:"from"`
+	To       map[string]interface{} `json:"to"`
+}
+
+// JSONDiffSink writes the row diffs of a table as a JSON array. Each element has the diff_type of the change, and the
+// values of the row before and after it as the objects from and to, which are null for added and removed rows. Values
+// are written as they are by `dolt table export`. Rows are written as they are received, so a diff of any size can be
+// written without holding it in memory.
+//
+// The rows received must be split by a DiffSplitter which converts old rows to fromSch and new rows to toSch.
+type JSONDiffSink struct {
+	wr      io.WriteCloser
+	bWr     *bufio.Writer
+	fromSch schema.Schema
+	toSch   schema.Schema
+	oldRow  row.Row
+	numRows int
+}
+
+// NewJSONDiffSink creates a JSONDiffSink for a diff pipeline, and writes the start of the array.
+func NewJSONDiffSink(wr io.WriteCloser, fromSch, toSch schema.Schema) (*JSONDiffSink, error) {
+	bWr := bufio.NewWriter(wr)
+	if err := iohelp.WriteAll(bWr, []byte("[")); err != nil {
+		return nil, err
+	}
+
+	return &JSONDiffSink{wr: wr, bWr: bWr, fromSch: fromSch, toSch: toSch}, nil
+}
+
+// GetSchema gets the schema of the new rows that the JSONDiffSink writes.
+func (jds *JSONDiffSink) GetSchema() schema.Schema {
+	return jds.toSch
+}
+
+// ProcRowWithProps satisfies pipeline.SinkFunc; it writes a row diff to the array. The old row of a modified row is
+// held until the new row is received, so that both are written as a single element.
+func (jds *JSONDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap) error {
+	dt, err := rowDiffType(props)
+	if err != nil {
+		return err
+	}
+
+	var from, to row.Row
+	switch dt {
+	case DiffModifiedOld:
+		jds.oldRow = r
+		return nil
+	case DiffModifiedNew:
+		from, to = jds.oldRow, r
+		jds.oldRow = nil
+	case DiffAdded:
+		to = r
+	case DiffRemoved:
+		from = r
+	}
+
+	rd := jsonRowDiff{DiffType: diffTypeName(dt)}
+	if from != nil {
+		if rd.From, err = dtjson.RowToJSONMap(jds.fromSch, from); err != nil {
+			return err
+		}
+	}
+	if to != nil {
+		if rd.To, err = dtjson.RowToJSONMap(jds.toSch, to); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(rd)
+	if err != nil {
+		return err
+	}
+
+	if jds.numRows > 0 {
+		data = append([]byte(","), data...)
+	}
+	jds.numRows++
+
+	return iohelp.WriteAll(jds.bWr, data)
+}
+
+// Close writes the end of the array and releases resources being held
+func (jds *JSONDiffSink) Close() error {
+	if jds.wr == nil {
+		return errors.New("Already closed.")
+	}
+
+	errWr := iohelp.WriteAll(jds.bWr, []byte("]"))
+	errFl := jds.bWr.Flush()
+	errCl := jds.wr.Close()
+	jds.wr = nil
+
+	if errWr != nil {
+		return errWr
+	} else if errFl != nil {
+		return errFl
+	}
+
+	return errCl
+}
+
+type jsonColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key"`
+	Nullable   bool   `json:"nullable"`
+}
+
+type jsonColumnDiff struct {
+	DiffType string      `json:"diff_type"`
+	Tag      uint64      `json:"tag"`
+	From     *jsonColumn `json:"from"`
+	To       *jsonColumn `json:"to"`
+}
+
+func newJSONColumn(col *schema.Column) *jsonColumn {
+	if col == nil {
+		return nil
+	}
+
+	return &jsonColumn{
+		Name:       col.Name,
+		Type:       col.TypeInfo.ToSqlType().String(),
+		PrimaryKey: col.IsPartOfPK,
+		Nullable:   col.IsNullable(),
+	}
+}
+
+// WriteJSONSchemaDiff writes the columns which differ between two schemas as a JSON array. Each element has the
+// diff_type and tag of a column, and its definition before and after the change as the objects from and to, which are
+// null for added and removed columns.
+func WriteJSONSchemaDiff(wr io.Writer, fromSch, toSch schema.Schema) error {
+	diffs, unionTags := DiffSchemas(fromSch, toSch)
+
+	colDiffs := make([]jsonColumnDiff, 0, len(diffs))
+	for _, tag := range unionTags {
+		dff := diffs[tag]
+		if dff.DiffType == SchDiffNone {
+			continue
+		}
+
+		colDiffs = append(colDiffs, jsonColumnDiff{
+			DiffType: schDiffTypeName(dff.DiffType),
+			Tag:      tag,
+			From:     newJSONColumn(dff.Old),
+			To:       newJSONColumn(dff.New),
+		})
+	}
+
+	data, err := json.Marshal(colDiffs)
+	if err != nil {
+		return err
+	}
+
+	return iohelp.WriteAll(wr, data)
+}
+
+// schDiffTypeName returns the diff_type written for columns with the given SchemaChangeType.
+func schDiffTypeName(dt SchemaChangeType) string {
+	switch dt {
+	case SchDiffColAdded:
+		return DiffTypeAdded
+	case SchDiffColRemoved:
+		return DiffTypeRemoved
+	case SchDiffColRenamed:
+		return DiffTypeRenamed
+	default:
+		return DiffTypeModified
+	}
+}
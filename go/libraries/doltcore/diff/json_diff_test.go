@@ -0,0 +1,51 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		from         string
+		to           string
+		expectedFrom string
+		expectedTo   string
+	}{
+		{`{"a":1,"b":2}`, `{"a":1,"b":2}`, "", ""},
+		{`{"a":1,"b":2}`, `{"a":1,"b":3}`, "$.b: 2", "$.b: 3"},
+		{`{"a":{"x":[1,2]}}`, `{"a":{"x":[1,3,4]}}`, "$.a.x[1]: 2", "$.a.x[1]: 3, $.a.x[2]: 4"},
+		{`{"a":1}`, `{"b":1,"my key":null}`, "$.a: 1", "$.b: 1, $.\"my key\": null"},
+		{`{"a":{"x":1}}`, `{"a":[1]}`, "$.a: {\"x\":1}", "$.a: [1]"},
+		{`"abc"`, `123`, "$: \"abc\"", "$: 123"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.from+" "+test.to, func(t *testing.T) {
+			diffs, err := DiffJSON(test.from, test.to)
+			require.NoError(t, err)
+			from, to := FormatJSONDiffs(diffs)
+			assert.Equal(t, test.expectedFrom, from)
+			assert.Equal(t, test.expectedTo, to)
+		})
+	}
+
+	_, err := DiffJSON(`{"a":1}`, `{"a":`)
+	assert.Error(t, err)
+}
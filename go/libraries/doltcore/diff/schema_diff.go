@@ -29,6 +29,8 @@ const (
 	SchDiffColRemoved
 	// SchDiffModified is the SchemaChangeType for two columns with the same tag that are different
 	SchDiffColModified
+	// SchDiffColRenamed is the SchemaChangeType for two columns with the same tag that differ only in their names
+	SchDiffColRenamed
 )
 
 // SchemaDifference is the result of comparing two columns from two schemas.
@@ -41,7 +43,8 @@ type SchemaDifference struct {
 
 type columnPair [2]*schema.Column
 
-// DiffSchemas compares two schemas by looking at columns with the same tag.
+// DiffSchemas compares two schemas by looking at columns with the same tag. A column which keeps its tag but changes
+// its name is a rename, rather than a removed and an added column.
 func DiffSchemas(fromSch, toSch schema.Schema) (map[uint64]SchemaDifference, []uint64) {
 	colPairMap, unionTags := pairColumns(fromSch, toSch)
 
@@ -52,16 +55,28 @@ func DiffSchemas(fromSch, toSch schema.Schema) (map[uint64]SchemaDifference, []u
 			diffs[tag] = SchemaDifference{SchDiffColAdded, tag, nil, colPair[1]}
 		} else if colPair[1] == nil {
 			diffs[tag] = SchemaDifference{SchDiffColRemoved, tag, colPair[0], nil}
-		} else if !colPair[0].Equals(*colPair[1]) {
-			diffs[tag] = SchemaDifference{SchDiffColModified, tag, colPair[0], colPair[1]}
-		} else {
+		} else if colPair[0].Equals(*colPair[1]) {
 			diffs[tag] = SchemaDifference{SchDiffNone, tag, colPair[0], colPair[1]}
+		} else if isColRename(*colPair[0], *colPair[1]) {
+			diffs[tag] = SchemaDifference{SchDiffColRenamed, tag, colPair[0], colPair[1]}
+		} else {
+			diffs[tag] = SchemaDifference{SchDiffColModified, tag, colPair[0], colPair[1]}
 		}
 	}
 
 	return diffs, unionTags
 }
 
+// isColRename returns whether two columns are the same but for their names.
+func isColRename(oldCol, newCol schema.Column) bool {
+	if oldCol.Name == newCol.Name {
+		return false
+	}
+
+	oldCol.Name = newCol.Name
+	return oldCol.Equals(newCol)
+}
+
 // pairColumns loops over both sets of columns pairing columns with the same tag.
 func pairColumns(fromSch, toSch schema.Schema) (map[uint64]columnPair, []uint64) {
 	// collect the tag union of the two schemas, ordering fromSch before toSch
@@ -30,6 +30,7 @@ func TestDiffSchemas(t *testing.T) {
 		schema.NewColumn("type_changed", 3, types.StringKind, false),
 		schema.NewColumn("moved_to_pk", 4, types.StringKind, false),
 		schema.NewColumn("contraint_added", 5, types.StringKind, false),
+		schema.NewColumn("renamed_and_type_changed", 7, types.StringKind, false),
 	}
 
 	newCols := []schema.Column{
@@ -39,6 +40,7 @@ func TestDiffSchemas(t *testing.T) {
 		schema.NewColumn("moved_to_pk", 4, types.StringKind, true),
 		schema.NewColumn("contraint_added", 5, types.StringKind, false, schema.NotNullConstraint{}),
 		schema.NewColumn("added", 6, types.StringKind, false),
+		schema.NewColumn("renamed_and_type_changed_new", 7, types.IntKind, false),
 	}
 
 	oldColColl, _ := schema.NewColCollection(oldCols...)
@@ -51,11 +53,12 @@ func TestDiffSchemas(t *testing.T) {
 	expected := map[uint64]SchemaDifference{
 		0: {SchDiffNone, 0, &oldCols[0], &newCols[0]},
 		1: {SchDiffColRemoved, 1, &oldCols[1], nil},
-		2: {SchDiffColModified, 2, &oldCols[2], &newCols[1]},
+		2: {SchDiffColRenamed, 2, &oldCols[2], &newCols[1]},
 		3: {SchDiffColModified, 3, &oldCols[3], &newCols[2]},
 		4: {SchDiffColModified, 4, &oldCols[4], &newCols[3]},
 		5: {SchDiffColModified, 5, &oldCols[5], &newCols[4]},
 		6: {SchDiffColAdded, 6, nil, &newCols[5]},
+		7: {SchDiffColModified, 7, &oldCols[6], &newCols[6]},
 	}
 
 	if !reflect.DeepEqual(diffs, expected) {
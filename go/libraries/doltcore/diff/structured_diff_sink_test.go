@@ -0,0 +1,139 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// the new schema renames name to full_name, drops age and adds email
+func structuredDiffSchemas(t *testing.T) (fromSch, toSch schema.Schema) {
+	fromCols, err := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		schema.NewColumn("age", 2, types.UintKind, false),
+	)
+	require.NoError(t, err)
+
+	toCols, err := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("full_name", 1, types.StringKind, false),
+		schema.NewColumn("email", 3, types.StringKind, false),
+	)
+	require.NoError(t, err)
+
+	return schema.SchemaFromCols(fromCols), schema.SchemaFromCols(toCols)
+}
+
+// writeRowDiffs sends a modified, a removed and an added row to the sink in the order a DiffSplitter does.
+func writeRowDiffs(t *testing.T, sink interface {
+	ProcRowWithProps(r row.Row, props pipeline.ReadableMap) error
+	Close() error
+}, fromSch, toSch schema.Schema) {
+	newRow := func(sch schema.Schema, vals row.TaggedValues) row.Row {
+		r, err := row.New(types.Format_Default, sch, vals)
+		require.NoError(t, err)
+		return r
+	}
+
+	rowDiffs := []struct {
+		r  row.Row
+		dt DiffChType
+	}{
+		{newRow(fromSch, row.TaggedValues{0: types.Int(1), 1: types.String("bill"), 2: types.Uint(40)}), DiffModifiedOld},
+		{newRow(toSch, row.TaggedValues{0: types.Int(1), 1: types.String("bill, jr"), 3: types.String("b@x.com")}), DiffModifiedNew},
+		{newRow(fromSch, row.TaggedValues{0: types.Int(2), 1: types.String("ted")}), DiffRemoved},
+		{newRow(toSch, row.TaggedValues{0: types.Int(3), 1: types.String("\"rufus\"")}), DiffAdded},
+	}
+
+	for _, rd := range rowDiffs {
+		props := pipeline.NoProps.Set(map[string]interface{}{DiffTypeProp: rd.dt})
+		require.NoError(t, sink.ProcRowWithProps(rd.r, props))
+	}
+
+	require.NoError(t, sink.Close())
+}
+
+func TestJSONDiffSink(t *testing.T) {
+	fromSch, toSch := structuredDiffSchemas(t)
+
+	out := &StringBuilderCloser{}
+	sink, err := NewJSONDiffSink(out, fromSch, toSch)
+	require.NoError(t, err)
+	writeRowDiffs(t, sink, fromSch, toSch)
+
+	expected := `[` +
+		`{"diff_type":"modified","from":{"age":40,"id":1,"name":"bill"},"to":{"email":"b@x.com","full_name":"bill, jr","id":1}},` +
+		`{"diff_type":"removed","from":{"id":2,"name":"ted"},"to":null},` +
+		`{"diff_type":"added","from":null,"to":{"full_name":"\"rufus\"","id":3}}` +
+		`]`
+	assert.Equal(t, expected, out.String())
+
+	out = &StringBuilderCloser{}
+	sink, err = NewJSONDiffSink(out, fromSch, toSch)
+	require.NoError(t, err)
+	require.NoError(t, sink.Close())
+	assert.Equal(t, "[]", out.String())
+}
+
+func TestCSVDiffSink(t *testing.T) {
+	fromSch, toSch := structuredDiffSchemas(t)
+
+	out := &StringBuilderCloser{}
+	sink, err := NewCSVDiffSink(out, fromSch, toSch, "people")
+	require.NoError(t, err)
+	writeRowDiffs(t, sink, fromSch, toSch)
+
+	expected := `table,diff_type,from_id,from_name,from_age,to_id,to_full_name,to_email
+people,modified,1,bill,40,1,"bill, jr",b@x.com
+people,removed,2,ted,,,,
+people,added,,,,3,"""rufus""",
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestStructuredSchemaDiffs(t *testing.T) {
+	fromSch, toSch := structuredDiffSchemas(t)
+
+	out := &StringBuilderCloser{}
+	require.NoError(t, WriteJSONSchemaDiff(out, fromSch, toSch))
+	expected := `[` +
+		`{"diff_type":"renamed","tag":1,"from":{"name":"name","type":"LONGTEXT","primary_key":false,"nullable":true},"to":{"name":"full_name","type":"LONGTEXT","primary_key":false,"nullable":true}},` +
+		`{"diff_type":"removed","tag":2,"from":{"name":"age","type":"BIGINT UNSIGNED","primary_key":false,"nullable":true},"to":null},` +
+		`{"diff_type":"added","tag":3,"from":null,"to":{"name":"email","type":"LONGTEXT","primary_key":false,"nullable":true}}` +
+		`]`
+	assert.Equal(t, expected, out.String())
+
+	out = &StringBuilderCloser{}
+	require.NoError(t, WriteCSVSchemaDiff(out, fromSch, toSch, "people"))
+	expected = `table,diff_type,tag,from_name,from_type,from_primary_key,from_nullable,to_name,to_type,to_primary_key,to_nullable
+people,renamed,1,name,LONGTEXT,false,true,full_name,LONGTEXT,false,true
+people,removed,2,age,BIGINT UNSIGNED,false,true,,,,
+people,added,3,,,,,email,LONGTEXT,false,true
+`
+	assert.Equal(t, expected, out.String())
+
+	out = &StringBuilderCloser{}
+	require.NoError(t, WriteJSONSchemaDiff(out, fromSch, fromSch))
+	assert.Equal(t, "[]", out.String())
+}
@@ -0,0 +1,286 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"sort"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// DefaultRenameSimilarity is the percentage of rows which a dropped and an added table must have in common for the
+// added table to be found to be a rename of the dropped one, when no other threshold is given.
+const DefaultRenameSimilarity = 50
+
+// IsRename returns true if the table exists at both the fromRoot and toRoot under different names.
+func (td TableDelta) IsRename() bool {
+	return td.FromTable != nil && td.ToTable != nil && td.FromName != td.ToName
+}
+
+type renameCandidate struct {
+	dropIdx    int
+	addIdx     int
+	rename     TableDelta
+	similarity int
+}
+
+// FindRenamedTables finds the tables which were dropped and added in |deltas| with the same columns and at least
+// |minSimilarity| percent of their rows in common, and replaces each such pair with a single TableDelta for the rename
+// of the table. Renames made with `dolt table mv` keep the tags of the table's columns, and are found by
+// GetTableDeltas. This finds tables which were copied to a new table and then dropped, whose columns have new tags.
+//
+// Columns are matched by tag, and then by name and type. The similarity of two tables is the percentage of rows of
+// the larger table which are in the other table with the same values. When a dropped table is similar enough to more
+// than one added table, it is paired with the most similar one.
+func FindRenamedTables(ctx context.Context, deltas []TableDelta, minSimilarity int) ([]TableDelta, error) {
+	var candidates []renameCandidate
+	for i, drop := range deltas {
+		if !drop.IsDrop() {
+			continue
+		}
+
+		for j, add := range deltas {
+			if !add.IsAdd() {
+				continue
+			}
+
+			rename := TableDelta{
+				FromName:      drop.FromName,
+				ToName:        add.ToName,
+				FromTable:     drop.FromTable,
+				ToTable:       add.ToTable,
+				ToForeignKeys: add.ToForeignKeys,
+			}
+
+			fromSch, toSch, err := rename.GetSchemas(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			var ok bool
+			if rename.fromToTags, ok = matchRenamedColumns(fromSch, toSch); !ok {
+				continue
+			}
+
+			similarity, err := rename.similarity(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if similarity >= minSimilarity {
+				candidates = append(candidates, renameCandidate{i, j, rename, similarity})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.similarity != cj.similarity {
+			return ci.similarity > cj.similarity
+		} else if ci.rename.FromName != cj.rename.FromName {
+			return ci.rename.FromName < cj.rename.FromName
+		}
+
+		return ci.rename.ToName < cj.rename.ToName
+	})
+
+	paired := make(map[int]bool)
+	renames := make(map[int]TableDelta)
+	for _, c := range candidates {
+		if paired[c.dropIdx] || paired[c.addIdx] {
+			continue
+		}
+
+		// the rename takes the place of the added table, and the dropped table is removed
+		paired[c.dropIdx], paired[c.addIdx] = true, true
+		renames[c.addIdx] = c.rename
+	}
+
+	var results []TableDelta
+	for i, td := range deltas {
+		if rename, ok := renames[i]; ok {
+			results = append(results, rename)
+		} else if !paired[i] {
+			results = append(results, td)
+		}
+	}
+
+	return results, nil
+}
+
+// matchRenamedColumns returns a mapping from the tags of the columns of |fromSch| to the tags of the same columns of
+// |toSch|, and whether every column of each schema was matched. Columns are matched by tag, and then by name, and must
+// have the same type and be part of the primary key in both schemas or neither.
+func matchRenamedColumns(fromSch, toSch schema.Schema) (map[uint64]uint64, bool) {
+	fromCols, toCols := fromSch.GetAllCols(), toSch.GetAllCols()
+	if fromCols.Size() == 0 || fromCols.Size() != toCols.Size() {
+		return nil, false
+	}
+
+	tags := make(map[uint64]uint64)
+	matched := make(map[uint64]bool)
+	allMatched := true
+	_ = fromCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		toCol, ok := toCols.GetByTag(tag)
+		if !ok {
+			toCol, ok = toCols.GetByName(col.Name)
+		}
+
+		if !ok || matched[toCol.Tag] || toCol.Kind != col.Kind || toCol.IsPartOfPK != col.IsPartOfPK {
+			allMatched = false
+			return true, nil
+		}
+
+		tags[tag] = toCol.Tag
+		matched[toCol.Tag] = true
+		return false, nil
+	})
+
+	return tags, allMatched
+}
+
+// retagSchema returns the from schema of a renamed table with the tags of the columns of its to schema.
+func (td TableDelta) retagSchema(fromSch schema.Schema) (schema.Schema, error) {
+	var cols []schema.Column
+	err := fromSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		col.Tag = td.fromToTags[tag]
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.SchemaFromCols(colColl), nil
+}
+
+// iterRetaggedRows calls |cb| with each row of the from table of a renamed table, with the tags of the columns of its to
+// schema.
+func (td TableDelta) iterRetaggedRows(ctx context.Context, cb func(r row.Row, sch schema.Schema) error) error {
+	fromSch, err := td.FromTable.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	retaggedSch, err := td.retagSchema(fromSch)
+	if err != nil {
+		return err
+	}
+
+	rowData, err := td.FromTable.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	return rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(fromSch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return err
+		}
+
+		vals := make(row.TaggedValues)
+		_, err = r.IterCols(func(tag uint64, val types.Value) (stop bool, err error) {
+			vals[td.fromToTags[tag]] = val
+			return false, nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		retagged, err := row.New(rowData.Format(), retaggedSch, vals)
+		if err != nil {
+			return err
+		}
+
+		return cb(row.WithCardinality(retagged, row.Cardinality(r)), retaggedSch)
+	})
+}
+
+// retagRows returns the row data of the from table of a renamed table, with the tags of the columns of its to schema.
+func (td TableDelta) retagRows(ctx context.Context) (types.Map, error) {
+	m, err := types.NewMap(ctx, td.FromTable.ValueReadWriter())
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	me := m.Edit()
+	err = td.iterRetaggedRows(ctx, func(r row.Row, sch schema.Schema) error {
+		me.Set(r.NomsMapKey(sch), r.NomsMapValue(sch))
+		return nil
+	})
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	return me.Map(ctx)
+}
+
+// similarity returns the percentage of the rows of the larger of the from and to tables of a rename which are in the
+// other table with the same values.
+func (td TableDelta) similarity(ctx context.Context) (int, error) {
+	toData, err := td.ToTable.GetRowData(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var fromLen, common uint64
+	err = td.iterRetaggedRows(ctx, func(r row.Row, sch schema.Schema) error {
+		key, err := r.NomsMapKey(sch).Value(ctx)
+		if err != nil {
+			return err
+		}
+
+		val, err := r.NomsMapValue(sch).Value(ctx)
+		if err != nil {
+			return err
+		}
+
+		fromLen++
+		toVal, ok, err := toData.MaybeGet(ctx, key)
+		if err != nil {
+			return err
+		} else if ok && toVal.Equals(val) {
+			common++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	total := fromLen
+	if toData.Len() > total {
+		total = toData.Len()
+	}
+
+	if total == 0 {
+		return 100, nil
+	}
+
+	return int(common * 100 / total), nil
+}
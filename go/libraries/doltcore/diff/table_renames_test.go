@@ -0,0 +1,178 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const copyTagOffset = 100
+
+// copyTable returns the schema and rows of dtestutils.TypedSchema and dtestutils.TypedRows with new tags, as a table
+// which was copied from another would have. The name of the first row is changed if |changeRow| is set.
+func copyTable(t *testing.T, changeRow bool) (schema.Schema, []row.Row) {
+	var cols []schema.Column
+	_ = dtestutils.TypedSchema.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		col.Tag += copyTagOffset
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	colColl, err := schema.NewColCollection(cols...)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	var rows []row.Row
+	for i, r := range dtestutils.TypedRows {
+		vals := make(row.TaggedValues)
+		_, err = r.IterCols(func(tag uint64, val types.Value) (stop bool, err error) {
+			vals[tag+copyTagOffset] = val
+			return false, nil
+		})
+		require.NoError(t, err)
+
+		if changeRow && i == 0 {
+			vals[dtestutils.NameTag+copyTagOffset] = types.String("Changed Name")
+		}
+
+		copied, err := row.New(types.Format_7_18, sch, vals)
+		require.NoError(t, err)
+		rows = append(rows, copied)
+	}
+
+	return sch, rows
+}
+
+// copyAndDropTable returns the roots before and after the table people is copied to persons and dropped.
+func copyAndDropTable(t *testing.T, changeRow bool) (ctx context.Context, oldRoot, newRoot *doltdb.RootValue) {
+	ctx, sch, dEnv := setupSchema()
+	dtestutils.CreateTestTable(t, dEnv, "people", sch, dtestutils.TypedRows...)
+	oldRoot, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+
+	copySch, copyRows := copyTable(t, changeRow)
+	dtestutils.CreateTestTable(t, dEnv, "persons", copySch, copyRows...)
+	newRoot, err = dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	newRoot, err = newRoot.RemoveTables(ctx, "people")
+	require.NoError(t, err)
+
+	return ctx, oldRoot, newRoot
+}
+
+func TestFindRenamedTables(t *testing.T) {
+	ctx, oldRoot, newRoot := copyAndDropTable(t, false)
+	deltas, err := GetTableDeltas(ctx, oldRoot, newRoot)
+	require.NoError(t, err)
+	require.Len(t, deltas, 2)
+
+	renamed, err := FindRenamedTables(ctx, deltas, DefaultRenameSimilarity)
+	require.NoError(t, err)
+	require.Len(t, renamed, 1)
+
+	td := renamed[0]
+	assert.True(t, td.IsRename())
+	assert.Equal(t, "people", td.FromName)
+	assert.Equal(t, "persons", td.ToName)
+
+	fromSch, toSch, err := td.GetSchemas(ctx)
+	require.NoError(t, err)
+	eq, err := schema.SchemasAreEqual(fromSch, toSch)
+	require.NoError(t, err)
+	assert.True(t, eq)
+
+	fromMap, toMap, err := td.GetMaps(ctx)
+	require.NoError(t, err)
+	assert.True(t, fromMap.Equals(toMap))
+
+	tds, err := NewTableDiffsWithRenames(ctx, newRoot, oldRoot, DefaultRenameSimilarity)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"persons"}, tds.Tables)
+	assert.Equal(t, RenamedTable, tds.TableToType["persons"])
+	assert.Equal(t, map[string]string{"persons": "people"}, tds.RenamedFrom)
+	assert.Equal(t, 1, tds.NumModified)
+}
+
+func TestFindRenamedTablesSimilarity(t *testing.T) {
+	// one of the three rows is changed, so the tables have 66% of their rows in common
+	ctx, oldRoot, newRoot := copyAndDropTable(t, true)
+	deltas, err := GetTableDeltas(ctx, oldRoot, newRoot)
+	require.NoError(t, err)
+
+	renamed, err := FindRenamedTables(ctx, deltas, 66)
+	require.NoError(t, err)
+	require.Len(t, renamed, 1)
+	assert.True(t, renamed[0].IsRename())
+
+	fromMap, toMap, err := renamed[0].GetMaps(ctx)
+	require.NoError(t, err)
+	var changed int
+	err = toMap.IterAll(ctx, func(key, value types.Value) error {
+		fromVal, ok, err := fromMap.MaybeGet(ctx, key)
+		require.True(t, ok)
+		if !fromVal.Equals(value) {
+			changed++
+		}
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+
+	renamed, err = FindRenamedTables(ctx, deltas, 67)
+	require.NoError(t, err)
+	require.Len(t, renamed, 2)
+	for _, td := range renamed {
+		assert.False(t, td.IsRename())
+	}
+
+	tds, err := NewTableDiffs(ctx, newRoot, oldRoot)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"people", "persons"}, tds.Tables)
+	assert.Empty(t, tds.RenamedFrom)
+}
+
+func TestMatchRenamedColumns(t *testing.T) {
+	copySch, _ := copyTable(t, false)
+	tags, ok := matchRenamedColumns(dtestutils.TypedSchema, copySch)
+	require.True(t, ok)
+	assert.Equal(t, map[uint64]uint64{0: 100, 1: 101, 2: 102, 3: 103, 4: 104}, tags)
+
+	// a column which is removed, or whose type is changed, means the tables do not match
+	removed := dtestutils.RemoveColumnFromSchema(copySch, dtestutils.TitleTag+copyTagOffset)
+	_, ok = matchRenamedColumns(dtestutils.TypedSchema, removed)
+	assert.False(t, ok)
+
+	retyped := dtestutils.RemoveColumnFromSchema(copySch, dtestutils.AgeTag+copyTagOffset)
+	retyped = dtestutils.AddColumnToSchema(retyped, schema.NewColumn("age", dtestutils.AgeTag+copyTagOffset, types.StringKind, false))
+	_, ok = matchRenamedColumns(dtestutils.TypedSchema, retyped)
+	assert.False(t, ok)
+
+	// columns with the same tags are matched even if they were renamed
+	renamedCol := dtestutils.RemoveColumnFromSchema(dtestutils.TypedSchema, dtestutils.TitleTag)
+	renamedCol = dtestutils.AddColumnToSchema(renamedCol, schema.NewColumn("job", dtestutils.TitleTag, types.StringKind, false))
+	tags, ok = matchRenamedColumns(dtestutils.TypedSchema, renamedCol)
+	require.True(t, ok)
+	assert.Equal(t, dtestutils.TitleTag, tags[dtestutils.TitleTag])
+}
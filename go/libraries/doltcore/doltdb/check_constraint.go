@@ -0,0 +1,261 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/liquidata-inc/go-mysql-server/sql/expression"
+	"github.com/liquidata-inc/go-mysql-server/sql/expression/function"
+	"github.com/liquidata-inc/go-mysql-server/sql/parse"
+	"github.com/liquidata-inc/go-mysql-server/sql/plan"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ErrCheckConstraintViolation is returned when a row does not satisfy a CHECK constraint of its table.
+var ErrCheckConstraintViolation = errors.New("check constraint violation")
+
+var checkFunctions = func() sql.FunctionRegistry {
+	registry := sql.NewFunctionRegistry()
+	registry.MustRegister(function.Defaults...)
+	return registry
+}()
+
+// CheckConstraint is a CHECK constraint of a table, with its expression resolved against the table's schema so that it
+// may be evaluated against the table's rows.
+type CheckConstraint struct {
+	TableName string
+	Check     schema.Check
+
+	sch  schema.Schema
+	expr sql.Expression
+}
+
+// NewCheckConstraint resolves the expression of the given check against the schema given. An error is returned if the
+// expression cannot be parsed, or references anything other than the columns of the table and built-in functions.
+func NewCheckConstraint(tableName string, sch schema.Schema, check schema.Check) (*CheckConstraint, error) {
+	expr, err := parseCheckExpression(check.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid check constraint `%s`: %v", check.Name, err)
+	}
+
+	allCols := sch.GetAllCols()
+	expr, err = expression.TransformUp(expr, func(e sql.Expression) (sql.Expression, error) {
+		switch e := e.(type) {
+		case *expression.UnresolvedColumn:
+			col, ok := allCols.GetByNameCaseInsensitive(e.Name())
+			if !ok || (e.Table() != "" && !strings.EqualFold(e.Table(), tableName)) {
+				return nil, fmt.Errorf("column `%s` does not exist on table `%s`", e.String(), tableName)
+			}
+			idx := 0
+			for allCols.Tags[idx] != col.Tag {
+				idx++
+			}
+			return expression.NewGetField(idx, col.TypeInfo.ToSqlType(), col.Name, col.IsNullable()), nil
+		case *expression.UnresolvedFunction:
+			f, err := checkFunctions.Function(e.Name())
+			if err != nil {
+				return nil, err
+			}
+			return f.Call(e.Arguments...)
+		}
+		return e, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid check constraint `%s`: %v", check.Name, err)
+	}
+	if !expr.Resolved() {
+		return nil, fmt.Errorf("invalid check constraint `%s`: `%s` may only reference the columns of the table", check.Name, check.Expression)
+	}
+
+	return &CheckConstraint{
+		TableName: tableName,
+		Check:     check,
+		sch:       sch,
+		expr:      expr,
+	}, nil
+}
+
+// CheckConstraintsForSchema returns the CHECK constraints of the table with the schema given.
+func CheckConstraintsForSchema(tableName string, sch schema.Schema) ([]*CheckConstraint, error) {
+	checks := sch.Checks().AllChecks()
+	constraints := make([]*CheckConstraint, len(checks))
+	for i, check := range checks {
+		var err error
+		constraints[i], err = NewCheckConstraint(tableName, sch, check)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return constraints, nil
+}
+
+// Satisfied returns whether the given row satisfies the check. A row only violates a check when the expression evaluates
+// to false, so checks on NULL values are always satisfied.
+func (cc *CheckConstraint) Satisfied(ctx context.Context, r row.Row) (bool, error) {
+	allCols := cc.sch.GetAllCols()
+	sqlRow := make(sql.Row, allCols.Size())
+	for i, tag := range allCols.Tags {
+		val, ok := r.GetColVal(tag)
+		if !ok {
+			continue
+		}
+		var err error
+		sqlRow[i], err = allCols.TagToCol[tag].TypeInfo.ConvertNomsValueToValue(val)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	res, err := cc.expr.Eval(sql.NewContext(ctx), sqlRow)
+	if err != nil {
+		return false, err
+	}
+	if res == nil {
+		return true, nil
+	}
+	return sql.ConvertToBool(res)
+}
+
+// ValidateRow returns an error if the given row violates the check.
+func (cc *CheckConstraint) ValidateRow(ctx context.Context, r row.Row) error {
+	ok, err := cc.Satisfied(ctx, r)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		rowStr, err := cc.formatRow(r)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w on `%s`.`%s`: %s", ErrCheckConstraintViolation, cc.TableName, cc.Check.Name, rowStr)
+	}
+	return nil
+}
+
+// formatRow returns the values of the given row by column name, in the order of the columns of the table.
+func (cc *CheckConstraint) formatRow(r row.Row) (string, error) {
+	allCols := cc.sch.GetAllCols()
+	vals := make([]string, 0, allCols.Size())
+	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		valStr := "NULL"
+		if val, ok := r.GetColVal(tag); ok && !types.IsNull(val) {
+			str, err := col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return true, err
+			}
+			if str != nil {
+				valStr = *str
+			}
+		}
+		vals = append(vals, col.Name+": "+valStr)
+		return false, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return "(" + strings.Join(vals, ", ") + ")", nil
+}
+
+// ValidateData ensures that every row of the given table data satisfies the check.
+func (cc *CheckConstraint) ValidateData(ctx context.Context, rowData types.Map) error {
+	return rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(cc.sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+		err = cc.ValidateRow(ctx, r)
+		if err != nil {
+			return true, err
+		}
+		return false, nil
+	})
+}
+
+// CheckReferencesColumn returns whether the expression of the given check references the named column.
+func CheckReferencesColumn(check schema.Check, colName string) (bool, error) {
+	expr, err := parseCheckSqlExpr(check.Expression)
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		if colNameNode, ok := node.(*sqlparser.ColName); ok && colNameNode.Name.EqualString(colName) {
+			found = true
+		}
+		return !found, nil
+	}, expr)
+	return found, err
+}
+
+// RenameCheckColumn returns the given check, with all references to the column named oldName in its expression replaced
+// with newName.
+func RenameCheckColumn(check schema.Check, oldName, newName string) (schema.Check, error) {
+	expr, err := parseCheckSqlExpr(check.Expression)
+	if err != nil {
+		return schema.Check{}, err
+	}
+
+	renamed := false
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		if colNameNode, ok := node.(*sqlparser.ColName); ok && colNameNode.Name.EqualString(oldName) {
+			colNameNode.Name = sqlparser.NewColIdent(newName)
+			renamed = true
+		}
+		return true, nil
+	}, expr)
+	if err != nil || !renamed {
+		return check, err
+	}
+
+	return schema.Check{Name: check.Name, Expression: sqlparser.String(expr)}, nil
+}
+
+func parseCheckExpression(checkExpr string) (sql.Expression, error) {
+	node, err := parse.Parse(sql.NewEmptyContext(), "SELECT "+checkExpr)
+	if err != nil {
+		return nil, err
+	}
+	proj, ok := node.(*plan.Project)
+	if !ok || len(proj.Projections) != 1 {
+		return nil, fmt.Errorf("`%s` is not a valid check expression", checkExpr)
+	}
+	return proj.Projections[0], nil
+}
+
+func parseCheckSqlExpr(checkExpr string) (sqlparser.Expr, error) {
+	stmt, err := sqlparser.Parse("SELECT " + checkExpr)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return nil, fmt.Errorf("`%s` is not a valid check expression", checkExpr)
+	}
+	aliased, ok := sel.SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, fmt.Errorf("`%s` is not a valid check expression", checkExpr)
+	}
+	return aliased.Expr, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestCheckConstraint(t *testing.T) {
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true),
+		schema.NewColumn("v1", 1, types.IntKind, false),
+		schema.NewColumn("v2", 2, types.IntKind, false))
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	newRow := func(vals ...types.Value) row.Row {
+		taggedVals := make(row.TaggedValues)
+		for i, val := range vals {
+			if val != nil {
+				taggedVals[uint64(i)] = val
+			}
+		}
+		r, err := row.New(types.Format_Default, sch, taggedVals)
+		require.NoError(t, err)
+		return r
+	}
+
+	tests := []struct {
+		expr      string
+		r         row.Row
+		satisfied bool
+	}{
+		{"v1 < v2", newRow(types.Int(1), types.Int(1), types.Int(2)), true},
+		{"v1 < v2", newRow(types.Int(1), types.Int(2), types.Int(1)), false},
+		{"v1 < v2", newRow(types.Int(1), types.Int(2)), true},
+		{"test.V1 + v2 <= 10 AND pk > 0", newRow(types.Int(1), types.Int(4), types.Int(6)), true},
+		{"test.V1 + v2 <= 10 AND pk > 0", newRow(types.Int(1), types.Int(5), types.Int(6)), false},
+		{"ABS(v1) < 5", newRow(types.Int(1), types.Int(-4)), true},
+		{"ABS(v1) < 5", newRow(types.Int(1), types.Int(-5)), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			cc, err := NewCheckConstraint("test", sch, schema.Check{Name: "chk", Expression: test.expr})
+			require.NoError(t, err)
+			satisfied, err := cc.Satisfied(context.Background(), test.r)
+			require.NoError(t, err)
+			assert.Equal(t, test.satisfied, satisfied)
+			if satisfied {
+				assert.NoError(t, cc.ValidateRow(context.Background(), test.r))
+			} else {
+				assert.Error(t, cc.ValidateRow(context.Background(), test.r))
+			}
+		})
+	}
+
+	cc, err := NewCheckConstraint("test", sch, schema.Check{Name: "chk", Expression: "v1 < v2 OR v2 IS NULL AND v1 > 0"})
+	require.NoError(t, err)
+	err = cc.ValidateRow(context.Background(), newRow(types.Int(1), types.Int(2), types.Int(1)))
+	assert.EqualError(t, err, "check constraint violation on `test`.`chk`: (pk: 1, v1: 2, v2: 1)")
+	assert.True(t, errors.Is(err, ErrCheckConstraintViolation))
+	err = cc.ValidateRow(context.Background(), newRow(types.Int(1), types.Int(-2)))
+	assert.EqualError(t, err, "check constraint violation on `test`.`chk`: (pk: 1, v1: -2, v2: NULL)")
+
+	for _, expr := range []string{"v3 > 0", "other.v1 > 0", "v1 >", "NOT_A_FUNCTION(v1)"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := NewCheckConstraint("test", sch, schema.Check{Name: "chk", Expression: expr})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheckColumnReferences(t *testing.T) {
+	check := schema.Check{Name: "chk", Expression: "v1 < v2 AND ABS(v1) < 10"}
+
+	ok, err := CheckReferencesColumn(check, "V1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = CheckReferencesColumn(check, "v3")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	renamed, err := RenameCheckColumn(check, "v1", "v3")
+	require.NoError(t, err)
+	assert.Equal(t, "chk", renamed.Name)
+	ok, err = CheckReferencesColumn(renamed, "v1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = CheckReferencesColumn(renamed, "v3")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	unchanged, err := RenameCheckColumn(check, "v4", "v5")
+	require.NoError(t, err)
+	assert.Equal(t, check, unchanged)
+}
@@ -24,9 +24,10 @@ import (
 )
 
 const (
-	metaField      = "meta"
-	parentsField   = "parents"
-	rootValueField = "value"
+	metaField        = "meta"
+	parentsField     = "parents"
+	parentsListField = "parents_list"
+	rootValueField   = "value"
 )
 
 var errCommitHasNoMeta = errors.New("commit has no metadata")
@@ -79,22 +80,18 @@ func (c *Commit) GetCommitMeta() (*CommitMeta, error) {
 }
 
 func (c *Commit) ParentHashes(ctx context.Context) ([]hash.Hash, error) {
-	parentSet, err := c.getParents()
+	parentRefs, err := c.parentRefs(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	hashes := make([]hash.Hash, 0, parentSet.Len())
-	err = parentSet.IterAll(ctx, func(parentVal types.Value) error {
-		parentRef := parentVal.(types.Ref)
-		parentHash := parentRef.TargetHash()
-		hashes = append(hashes, parentHash)
-
-		return nil
-	})
+	hashes := make([]hash.Hash, len(parentRefs))
+	for i, parentRef := range parentRefs {
+		hashes[i] = parentRef.TargetHash()
+	}
 
-	return hashes, err
+	return hashes, nil
 }
 
 func (c *Commit) getParents() (types.Set, error) {
@@ -107,6 +104,39 @@ func (c *Commit) getParents() (types.Set, error) {
 	return types.EmptySet, nil
 }
 
+// parentRefs returns the refs of the commit's parents in the order they were recorded when the commit was made, with
+// the commit it was built upon first. Commits made before the order was recorded list their parents in hash order.
+func (c *Commit) parentRefs(ctx context.Context) ([]types.Ref, error) {
+	var parentRefs []types.Ref
+	appendRef := func(parentVal types.Value) error {
+		parentRefs = append(parentRefs, parentVal.(types.Ref))
+		return nil
+	}
+
+	parListVal, found, err := c.commitSt.MaybeGet(parentsListField)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if found && parListVal != nil {
+		err = parListVal.(types.List).IterAll(ctx, func(parentVal types.Value, _ uint64) error {
+			return appendRef(parentVal)
+		})
+
+		return parentRefs, err
+	}
+
+	parentSet, err := c.getParents()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = parentSet.IterAll(ctx, appendRef)
+	return parentRefs, err
+}
+
 // NumParents gets the number of parents a commit has.
 func (c *Commit) NumParents() (int, error) {
 	parents, err := c.getParents()
@@ -127,30 +157,17 @@ func (c *Commit) Height() (uint64, error) {
 }
 
 func (c *Commit) getParent(ctx context.Context, idx int) (*types.Struct, error) {
-	parentSet, err := c.getParents()
-
-	if err != nil {
-		return nil, err
-	}
-
-	itr, err := parentSet.IteratorAt(ctx, uint64(idx))
-
-	if err != nil {
-		return nil, err
-	}
-
-	parentVal, err := itr.Next(ctx)
+	parentRefs, err := c.parentRefs(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if parentVal == nil {
+	if idx < 0 || idx >= len(parentRefs) {
 		return nil, nil
 	}
 
-	parentRef := parentVal.(types.Ref)
-	targVal, err := parentRef.TargetValue(ctx, c.vrw)
+	targVal, err := parentRefs[idx].TargetValue(ctx, c.vrw)
 
 	if err != nil {
 		return nil, err
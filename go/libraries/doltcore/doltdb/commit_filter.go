@@ -0,0 +1,151 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// AllFilters returns a CommitFilter which filters out a commit if any of the given filters filters it out.
+func AllFilters(filters ...CommitFilter) CommitFilter {
+	return func(ctx context.Context, h hash.Hash, cm *Commit) (bool, error) {
+		for _, filter := range filters {
+			if filterOut, err := filter(ctx, h, cm); err != nil || filterOut {
+				return filterOut, err
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// AuthorFilter returns a CommitFilter which keeps the commits whose author, formatted as "name <email>", matches
+// |pattern|.
+func AuthorFilter(pattern *regexp.Regexp) CommitFilter {
+	return func(ctx context.Context, h hash.Hash, cm *Commit) (bool, error) {
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return false, err
+		}
+
+		return !pattern.MatchString(meta.Name + " <" + meta.Email + ">"), nil
+	}
+}
+
+// SinceFilter returns a CommitFilter which keeps the commits made at or after |t|.
+func SinceFilter(t time.Time) CommitFilter {
+	return func(ctx context.Context, h hash.Hash, cm *Commit) (bool, error) {
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return false, err
+		}
+
+		return meta.Time().Before(t), nil
+	}
+}
+
+// UntilFilter returns a CommitFilter which keeps the commits made at or before |t|.
+func UntilFilter(t time.Time) CommitFilter {
+	return func(ctx context.Context, h hash.Hash, cm *Commit) (bool, error) {
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return false, err
+		}
+
+		return meta.Time().After(t), nil
+	}
+}
+
+// MergeFilter returns a CommitFilter which keeps only merge commits if |merges| is true, and only commits which are not
+// merges otherwise.
+func MergeFilter(merges bool) CommitFilter {
+	return func(ctx context.Context, h hash.Hash, cm *Commit) (bool, error) {
+		numParents, err := cm.NumParents()
+
+		if err != nil {
+			return false, err
+		}
+
+		return (numParents > 1) != merges, nil
+	}
+}
+
+// TablesFilter returns a CommitFilter which keeps the commits that changed any of the tables given. As with git, a
+// merge commit is only considered to have changed a table if the table differs from the table in each of its parents.
+func TablesFilter(ddb *DoltDB, tblNames []string) CommitFilter {
+	return func(ctx context.Context, h hash.Hash, cm *Commit) (bool, error) {
+		root, err := cm.GetRootValue()
+
+		if err != nil {
+			return false, err
+		}
+
+		parents, err := ddb.ResolveAllParents(ctx, cm)
+
+		if err != nil {
+			return false, err
+		}
+
+		parentRoots := make([]*RootValue, len(parents))
+		for i, parent := range parents {
+			parentRoots[i], err = parent.GetRootValue()
+
+			if err != nil {
+				return false, err
+			}
+		}
+
+		for _, tblName := range tblNames {
+			if changed, err := tableChangedFromParents(ctx, tblName, root, parentRoots); err != nil || changed {
+				return !changed, err
+			}
+		}
+
+		return true, nil
+	}
+}
+
+func tableChangedFromParents(ctx context.Context, tblName string, root *RootValue, parentRoots []*RootValue) (bool, error) {
+	h, ok, err := root.GetTableHash(ctx, tblName)
+
+	if err != nil {
+		return false, err
+	}
+
+	if len(parentRoots) == 0 {
+		return ok, nil
+	}
+
+	for _, parentRoot := range parentRoots {
+		parentH, parentOk, err := parentRoot.GetTableHash(ctx, tblName)
+
+		if err != nil {
+			return false, err
+		}
+
+		if ok == parentOk && h == parentH {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -31,6 +31,11 @@ func IsValidUserBranchName(name string) bool {
 	return name != head && !hashRegex.MatchString(name) && ref.IsValidBranchName(name)
 }
 
+// IsValidUserTagName returns true if name isn't a valid commit hash, it is not named "head" and it is a valid ref name
+func IsValidUserTagName(name string) bool {
+	return name != head && !hashRegex.MatchString(name) && ref.IsValidTagName(name)
+}
+
 func IsValidBranchRef(dref ref.DoltRef) bool {
 	return dref.GetType() == ref.BranchRefType && IsValidUserBranchName(dref.GetPath())
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/store/datas"
 	"github.com/liquidata-inc/dolt/go/store/hash"
 	"github.com/liquidata-inc/dolt/go/store/types"
+	"github.com/liquidata-inc/dolt/go/store/util/random"
 )
 
 func init() {
@@ -99,6 +100,12 @@ func (ddb *DoltDB) CSMetricsSummary() string {
 	return datas.GetCSStatSummaryForDB(ddb.db)
 }
 
+// CSMetrics returns the statistics of the chunk store backing this database, such as nbs.Stats. Their type depends on
+// the chunk store, and may be nil.
+func (ddb *DoltDB) CSMetrics() interface{} {
+	return datas.GetCSStatsForDB(ddb.db)
+}
+
 // WriteEmptyRepo will create initialize the given db with a master branch which points to a commit which has valid
 // metadata for the creation commit, and an empty RootValue.
 func (ddb *DoltDB) WriteEmptyRepo(ctx context.Context, name, email string) error {
@@ -195,11 +202,23 @@ func getCommitStForRefStr(ctx context.Context, db datas.Database, ref string) (t
 	}
 
 	dsHead, hasHead := ds.MaybeHead()
-	if hasHead {
+
+	if !hasHead {
+		return types.EmptyStruct(db.Format()), ErrBranchNotFound
+	}
+
+	if dsHead.Name() == CommitStructName {
 		return dsHead, nil
 	}
 
-	return types.EmptyStruct(db.Format()), ErrBranchNotFound
+	// tags resolve to the commit they reference
+	if is, err := datas.IsTag(dsHead); err != nil {
+		return types.EmptyStruct(db.Format()), err
+	} else if is {
+		return getCommitStForTagSt(ctx, db, dsHead)
+	}
+
+	return types.EmptyStruct(db.Format()), ErrFoundHashNotACommit
 }
 
 func getCommitStForHash(ctx context.Context, db datas.Database, c string) (types.Struct, error) {
@@ -280,11 +299,12 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec, cwb ref.DoltRef)
 		// For a ref in a CommitSpec, we have the following behavior.
 		// If it starts with `refs/`, we look for an exact match before
 		// we try any suffix matches. After that, we try a match on the
-		// user supplied input, with the following three prefixes, in
-		// order: `refs/`, `refs/heads/`, `refs/remotes/`.
+		// user supplied input, with the following four prefixes, in
+		// order: `refs/`, `refs/heads/`, `refs/tags/`, `refs/remotes/`.
 		candidates := []string{
 			"refs/" + cs.baseSpec,
 			"refs/heads/" + cs.baseSpec,
+			"refs/tags/" + cs.baseSpec,
 			"refs/remotes/" + cs.baseSpec,
 		}
 		if strings.HasPrefix(cs.baseSpec, "refs/") {
@@ -292,6 +312,7 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec, cwb ref.DoltRef)
 				cs.baseSpec,
 				"refs/" + cs.baseSpec,
 				"refs/heads/" + cs.baseSpec,
+				"refs/tags/" + cs.baseSpec,
 				"refs/remotes/" + cs.baseSpec,
 			}
 		}
@@ -453,29 +474,7 @@ func (ddb *DoltDB) WriteDanglingCommit(ctx context.Context, valHash hash.Hash, p
 		return nil, errors.New("can't commit a value that is not a valid root value")
 	}
 
-	s, err := types.NewSet(ctx, ddb.db)
-
-	if err != nil {
-		return nil, err
-	}
-
-	parentEditor := s.Edit()
-
-	for _, cm := range parentCommits {
-		rf, err := types.NewRef(cm.commitSt, ddb.db.Format())
-
-		if err != nil {
-			return nil, err
-		}
-
-		_, err = parentEditor.Insert(rf)
-
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	parents, err := parentEditor.Set(ctx)
+	parents, parentsList, err := ddb.newParents(ctx, nil, parentCommits)
 
 	if err != nil {
 		return nil, err
@@ -487,7 +486,7 @@ func (ddb *DoltDB) WriteDanglingCommit(ctx context.Context, valHash hash.Hash, p
 		return nil, err
 	}
 
-	commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil}
+	commitOpts := datas.CommitOptions{Parents: parents, ParentsList: parentsList, Meta: st, Policy: nil}
 	commitSt, err = ddb.db.CommitDangling(ctx, val, commitOpts)
 
 	if err != nil {
@@ -515,14 +514,7 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 		return nil, err
 	}
 
-	s, err := types.NewSet(ctx, ddb.db)
-
-	if err != nil {
-		return nil, err
-	}
-
-	parentEditor := s.Edit()
-
+	var parentRefs []types.Ref
 	headRef, hasHead, err := ds.MaybeHeadRef()
 
 	if err != nil {
@@ -530,28 +522,10 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 	}
 
 	if hasHead {
-		_, err := parentEditor.Insert(headRef)
-
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	for _, cm := range parentCommits {
-		rf, err := types.NewRef(cm.commitSt, ddb.db.Format())
-
-		if err != nil {
-			return nil, err
-		}
-
-		_, err = parentEditor.Insert(rf)
-
-		if err != nil {
-			return nil, err
-		}
+		parentRefs = append(parentRefs, headRef)
 	}
 
-	parents, err := parentEditor.Set(ctx)
+	parents, parentsList, err := ddb.newParents(ctx, parentRefs, parentCommits)
 
 	if err != nil {
 		return nil, err
@@ -563,7 +537,7 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 		return nil, err
 	}
 
-	commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil}
+	commitOpts := datas.CommitOptions{Parents: parents, ParentsList: parentsList, Meta: st, Policy: nil}
 	ds, err = ddb.db.GetDataset(ctx, dref.String())
 
 	if err != nil {
@@ -585,6 +559,45 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 	return &Commit{ddb.db, commitSt}, nil
 }
 
+// newParents returns the set of parents of a new commit, and the list of the same parents in the order given: the refs
+// given followed by the commits given, without duplicates. The list is recorded in the commit so that its parents are
+// numbered in the order they were merged.
+func (ddb *DoltDB) newParents(ctx context.Context, parentRefs []types.Ref, parentCommits []*Commit) (types.Set, types.List, error) {
+	for _, cm := range parentCommits {
+		rf, err := types.NewRef(cm.commitSt, ddb.db.Format())
+
+		if err != nil {
+			return types.EmptySet, types.EmptyList, err
+		}
+
+		parentRefs = append(parentRefs, rf)
+	}
+
+	seen := make(map[hash.Hash]bool)
+	var parentVals []types.Value
+	for _, rf := range parentRefs {
+		if !seen[rf.TargetHash()] {
+			seen[rf.TargetHash()] = true
+			parentVals = append(parentVals, rf)
+		}
+	}
+
+	// the list is made first, as making the set sorts the values given
+	parentsList, err := types.NewList(ctx, ddb.db, parentVals...)
+
+	if err != nil {
+		return types.EmptySet, types.EmptyList, err
+	}
+
+	parents, err := types.NewSet(ctx, ddb.db, parentVals...)
+
+	if err != nil {
+		return types.EmptySet, types.EmptyList, err
+	}
+
+	return parents, parentsList, nil
+}
+
 // dangling commits are unreferenced by any branch or ref. They are created in the course of programmatic updates
 // such as rebase. You must create a ref to a dangling commit for it to be reachable
 func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash hash.Hash, parentCommits []*Commit, cm *CommitMeta) (*Commit, error) {
@@ -600,30 +613,8 @@ func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash
 			return errors.New("can't commit a value that is not a valid root value")
 		}
 
-		s, err := types.NewSet(ctx, ddb.db)
-
-		if err != nil {
-			return err
-		}
-
-		parentEditor := s.Edit()
-
-		for _, cm := range parentCommits {
-			rf, err := types.NewRef(cm.commitSt, ddb.db.Format())
-
-			if err != nil {
-				return err
-			}
-
-			_, err = parentEditor.Insert(rf)
-
-			if err != nil {
-				return err
-			}
-		}
-
 		// even orphans have parents
-		parents, err := parentEditor.Set(ctx)
+		parents, parentsList, err := ddb.newParents(ctx, nil, parentCommits)
 
 		if err != nil {
 			return err
@@ -635,7 +626,7 @@ func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash
 			return err
 		}
 
-		commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil}
+		commitOpts := datas.CommitOptions{Parents: parents, ParentsList: parentsList, Meta: st, Policy: nil}
 
 		commitSt, err = ddb.db.CommitDangling(ctx, val, commitOpts)
 
@@ -686,66 +677,42 @@ func writeValAndGetRef(ctx context.Context, vrw types.ValueReadWriter, val types
 // non-nil in the case that the commit cannot be resolved, there aren't as many ancestors as requested, or the
 // underlying storage cannot be accessed.
 func (ddb *DoltDB) ResolveParent(ctx context.Context, commit *Commit, parentIdx int) (*Commit, error) {
-	var parentCommitSt types.Struct
-	parentSet, err := commit.getParents()
-
-	if err != nil {
-		return nil, err
-	}
-
-	itr, err := parentSet.IteratorAt(ctx, uint64(parentIdx))
+	parentRefs, err := commit.parentRefs(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	parentCommRef, err := itr.Next(ctx)
-
-	if err != nil {
-		return nil, err
+	if parentIdx < 0 || parentIdx >= len(parentRefs) {
+		return nil, ErrInvalidAncestorSpec
 	}
 
-	parentVal, err := parentCommRef.(types.Ref).TargetValue(ctx, ddb.ValueReadWriter())
+	parentVal, err := parentRefs[parentIdx].TargetValue(ctx, ddb.ValueReadWriter())
 
 	if err != nil {
 		return nil, err
 	}
 
-	parentCommitSt = parentVal.(types.Struct)
-
-	return &Commit{ddb.ValueReadWriter(), parentCommitSt}, nil
+	return &Commit{ddb.ValueReadWriter(), parentVal.(types.Struct)}, nil
 }
 
+// ResolveAllParents returns the parents of a given commit, in the order they were recorded when it was made.
 func (ddb *DoltDB) ResolveAllParents(ctx context.Context, commit *Commit) ([]*Commit, error) {
-	var parentCommitSt types.Struct
-	parentSet, err := commit.getParents()
-
-	if err != nil {
-		return nil, err
-	}
-
-	itr, err := parentSet.IteratorAt(ctx, 0)
+	parentRefs, err := commit.parentRefs(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
 	var allParents []*Commit
-	for i := 0; i < int(parentSet.Len()); i++ {
-		parentCommRef, err := itr.Next(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		parentVal, err := parentCommRef.(types.Ref).TargetValue(ctx, ddb.ValueReadWriter())
+	for _, parentRef := range parentRefs {
+		parentVal, err := parentRef.TargetValue(ctx, ddb.ValueReadWriter())
 
 		if err != nil {
 			return nil, err
 		}
 
-		parentCommitSt = parentVal.(types.Struct)
-
-		allParents = append(allParents, &Commit{ddb.ValueReadWriter(), parentCommitSt})
+		allParents = append(allParents, &Commit{ddb.ValueReadWriter(), parentVal.(types.Struct)})
 	}
 	return allParents, nil
 }
@@ -841,6 +808,135 @@ func (ddb *DoltDB) DeleteBranch(ctx context.Context, dref ref.DoltRef) error {
 	return err
 }
 
+var tagRefFilter = map[ref.RefType]struct{}{ref.TagRefType: {}}
+
+// GetTags returns a list of all tags in the database.
+func (ddb *DoltDB) GetTags(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, tagRefFilter)
+}
+
+// NewTagAtCommit creates a new tag named by |tagRef| that points at the commit given. Returns datas.ErrTagExists if
+// the tag already exists.
+func (ddb *DoltDB) NewTagAtCommit(ctx context.Context, tagRef ref.DoltRef, commit *Commit, meta *TagMeta) error {
+	if tagRef.GetType() != ref.TagRefType {
+		panic(fmt.Sprintf("invalid tag name %s, use IsValidUserTagName check", tagRef.String()))
+	}
+
+	ds, err := ddb.db.GetDataset(ctx, tagRef.String())
+
+	if err != nil {
+		return err
+	}
+
+	if ds.HasHead() {
+		return datas.ErrTagExists
+	}
+
+	rf, err := types.NewRef(commit.commitSt, ddb.db.Format())
+
+	if err != nil {
+		return err
+	}
+
+	st, err := meta.toNomsStruct(ddb.db.Format())
+
+	if err != nil {
+		return err
+	}
+
+	_, err = ddb.db.Tag(ctx, ds, rf, datas.TagOptions{Meta: st})
+
+	return err
+}
+
+// ResolveTag takes a TagRef and returns the corresponding Tag object, or ErrTagNotFound if it doesn't exist.
+func (ddb *DoltDB) ResolveTag(ctx context.Context, tagRef ref.TagRef) (*Tag, error) {
+	ds, err := ddb.db.GetDataset(ctx, tagRef.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	tagSt, hasHead := ds.MaybeHead()
+
+	if !hasHead {
+		return nil, ErrTagNotFound
+	}
+
+	if is, err := datas.IsTag(tagSt); err != nil {
+		return nil, err
+	} else if !is {
+		return nil, fmt.Errorf("tagRef head is not a tag")
+	}
+
+	return NewTag(ctx, tagRef.GetPath(), ddb.db, tagSt)
+}
+
+// DeleteTag deletes the tag given, returning ErrTagNotFound if it doesn't exist.
+func (ddb *DoltDB) DeleteTag(ctx context.Context, tagRef ref.DoltRef) error {
+	ds, err := ddb.db.GetDataset(ctx, tagRef.String())
+
+	if err != nil {
+		return err
+	}
+
+	if !ds.HasHead() {
+		return ErrTagNotFound
+	}
+
+	_, err = ddb.db.Delete(ctx, ds)
+	return err
+}
+
+var stashRefFilter = map[ref.RefType]struct{}{ref.StashRefType: {}}
+
+// GetStashes returns a list of all stashes in the database.
+func (ddb *DoltDB) GetStashes(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, stashRefFilter)
+}
+
+// NewStashAtCommit creates a new stash named by |stashRef| that points at the commit given.
+func (ddb *DoltDB) NewStashAtCommit(ctx context.Context, stashRef ref.DoltRef, commit *Commit) error {
+	if stashRef.GetType() != ref.StashRefType {
+		panic(fmt.Sprintf("invalid stash name %s", stashRef.String()))
+	}
+
+	return ddb.SetHead(ctx, stashRef, commit)
+}
+
+// ResolveStash returns the commit of the stash given, or ErrStashNotFound if it doesn't exist.
+func (ddb *DoltDB) ResolveStash(ctx context.Context, stashRef ref.DoltRef) (*Commit, error) {
+	ds, err := ddb.db.GetDataset(ctx, stashRef.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	commitSt, hasHead := ds.MaybeHead()
+
+	if !hasHead {
+		return nil, ErrStashNotFound
+	}
+
+	return &Commit{ddb.db, commitSt}, nil
+}
+
+// DeleteStash deletes the stash given, returning ErrStashNotFound if it doesn't exist.
+func (ddb *DoltDB) DeleteStash(ctx context.Context, stashRef ref.DoltRef) error {
+	ds, err := ddb.db.GetDataset(ctx, stashRef.String())
+
+	if err != nil {
+		return err
+	}
+
+	if !ds.HasHead() {
+		return ErrStashNotFound
+	}
+
+	_, err = ddb.db.Delete(ctx, ds)
+	return err
+}
+
 // PushChunks initiates a push into a database from the source database given, at the commit given. Pull progress is
 // communicated over the provided channel.
 func (ddb *DoltDB) PushChunks(ctx context.Context, tempDir string, srcDB *DoltDB, cm *Commit, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
@@ -892,3 +988,65 @@ func (ddb *DoltDB) PullChunks(ctx context.Context, tempDir string, srcDB *DoltDB
 func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return datas.Clone(ctx, ddb.db, destDB.db, eventCh)
 }
+
+// GC performs garbage collection on this ddb. Values passed in |uncommitedVals| will be temporarily saved during
+// the GC process, so that values which are only referenced outside of the commit graph, such as the working and
+// staged roots of a repository, are not collected.
+func (ddb *DoltDB) GC(ctx context.Context, uncommitedVals ...hash.Hash) (err error) {
+	var tmpDatasets []datas.Dataset
+
+	// the temporary datasets keep their values alive, so they are deleted whether or not the collection succeeds
+	defer func() {
+		for _, ds := range tmpDatasets {
+			_, delErr := ddb.db.Delete(ctx, ds)
+
+			if err == nil {
+				err = delErr
+			}
+		}
+	}()
+
+	for _, h := range uncommitedVals {
+		v, err := ddb.db.ReadValue(ctx, h)
+
+		if err != nil {
+			return err
+		}
+
+		if v == nil {
+			return fmt.Errorf("cannot garbage collect, uncommitted value %s does not exist", h.String())
+		}
+
+		ds, err := ddb.db.GetDataset(ctx, fmt.Sprintf("-/gc/%s", random.Id()))
+
+		if err != nil {
+			return err
+		}
+
+		r, err := writeValAndGetRef(ctx, ddb.db, v)
+
+		if err != nil {
+			return err
+		}
+
+		ds, err = ddb.db.CommitValue(ctx, ds, r)
+
+		if err != nil {
+			return err
+		}
+
+		tmpDatasets = append(tmpDatasets, ds)
+	}
+
+	return ddb.db.GC(ctx)
+}
+
+// Size returns the number of bytes used by the table files backing this database.
+func (ddb *DoltDB) Size(ctx context.Context) (uint64, error) {
+	return datas.GetCSSizeForDB(ctx, ddb.db)
+}
+
+// TableFileCount returns the number of table files backing this database.
+func (ddb *DoltDB) TableFileCount(ctx context.Context) (int, error) {
+	return datas.GetCSTableFileCountForDB(ctx, ddb.db)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/test"
+	"github.com/liquidata-inc/dolt/go/store/datas"
 	"github.com/liquidata-inc/dolt/go/store/hash"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -225,3 +227,272 @@ func TestLDNoms(t *testing.T) {
 		}
 	}
 }
+
+func TestGarbageCollection(t *testing.T) {
+	ctx := context.Background()
+	testDir, err := test.ChangeToTestDir("TestGarbageCollection")
+	require.NoError(t, err)
+
+	err = filesys.LocalFS.MkDirs(filepath.Join(testDir, dbfactory.DoltDataDir))
+	require.NoError(t, err)
+
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, LocalDirDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	commit, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	root, err := commit.GetRootValue()
+	require.NoError(t, err)
+
+	tSchema := createTestSchema(t)
+	rowData, _ := createTestRowData(t, ddb.db, tSchema)
+	tbl, err := createTestTable(ddb.db, tSchema, rowData)
+	require.NoError(t, err)
+
+	keptRoot, err := root.PutTable(ctx, "kept", tbl)
+	require.NoError(t, err)
+	keptHash, err := ddb.WriteRootValue(ctx, keptRoot)
+	require.NoError(t, err)
+
+	droppedRoot, err := root.PutTable(ctx, "dropped", tbl)
+	require.NoError(t, err)
+	droppedHash, err := ddb.WriteRootValue(ctx, droppedRoot)
+	require.NoError(t, err)
+
+	err = ddb.GC(ctx, keptHash)
+	require.NoError(t, err)
+
+	// reopen the db to make sure nothing is served from memory
+	ddb, err = LoadDoltDB(ctx, types.Format_7_18, LocalDirDoltDB)
+	require.NoError(t, err)
+
+	commit, err = ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	_, err = commit.GetRootValue()
+	require.NoError(t, err)
+
+	readRoot, err := ddb.ReadRootValue(ctx, keptHash)
+	require.NoError(t, err)
+	has, err := readRoot.HasTable(ctx, "kept")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	_, err = ddb.ReadRootValue(ctx, droppedHash)
+	assert.Error(t, err)
+}
+
+func TestFailedGarbageCollectionRemovesTempDatasets(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	commit, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	root, err := commit.GetRootValue()
+	require.NoError(t, err)
+	rootHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	// in memory databases do not support garbage collection
+	err = ddb.GC(ctx, rootHash)
+	assert.Error(t, err)
+
+	dss, err := ddb.db.Datasets(ctx)
+	require.NoError(t, err)
+	err = dss.IterAll(ctx, func(key, _ types.Value) error {
+		assert.False(t, strings.HasPrefix(string(key.(types.String)), "-/gc/"), "temporary dataset %s was not removed", key)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	commit, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+
+	meta, err := NewTagMeta("Bill Billerson", "bigbillieb@fake.horse", "the first release")
+	require.NoError(t, err)
+
+	tagRef := ref.NewTagRef("v1")
+	err = ddb.NewTagAtCommit(ctx, tagRef, commit, meta)
+	require.NoError(t, err)
+
+	err = ddb.NewTagAtCommit(ctx, tagRef, commit, meta)
+	assert.Equal(t, datas.ErrTagExists, err)
+
+	tags, err := ddb.GetTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ref.DoltRef{tagRef}, tags)
+
+	tag, err := ddb.ResolveTag(ctx, tagRef)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", tag.Name)
+	assert.Equal(t, meta, tag.Meta)
+
+	expected, err := commit.HashOf()
+	require.NoError(t, err)
+	actual, err := tag.Commit.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	for _, spec := range []string{"v1", "tags/v1", "refs/tags/v1"} {
+		cs, err := NewCommitSpec(spec)
+		require.NoError(t, err)
+		cm, err := ddb.Resolve(ctx, cs, nil)
+		require.NoError(t, err)
+		h, err := cm.HashOf()
+		require.NoError(t, err)
+		assert.Equal(t, expected, h, spec)
+	}
+
+	err = ddb.DeleteTag(ctx, tagRef)
+	require.NoError(t, err)
+	_, err = ddb.ResolveTag(ctx, tagRef)
+	assert.Equal(t, ErrTagNotFound, err)
+	assert.Equal(t, ErrTagNotFound, ddb.DeleteTag(ctx, tagRef))
+}
+
+func TestStashes(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	commit, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+
+	root, err := commit.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "WIP on master")
+	require.NoError(t, err)
+	stashCm, err := ddb.WriteDanglingCommit(ctx, valHash, []*Commit{commit}, meta)
+	require.NoError(t, err)
+
+	stashRef := ref.NewStashRef("0")
+	err = ddb.NewStashAtCommit(ctx, stashRef, stashCm)
+	require.NoError(t, err)
+
+	stashes, err := ddb.GetStashes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ref.DoltRef{stashRef}, stashes)
+
+	branches, err := ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ref.DoltRef{ref.NewBranchRef("master")}, branches)
+
+	resolved, err := ddb.ResolveStash(ctx, stashRef)
+	require.NoError(t, err)
+	expected, err := stashCm.HashOf()
+	require.NoError(t, err)
+	actual, err := resolved.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	err = ddb.DeleteStash(ctx, stashRef)
+	require.NoError(t, err)
+	_, err = ddb.ResolveStash(ctx, stashRef)
+	assert.Equal(t, ErrStashNotFound, err)
+	assert.Equal(t, ErrStashNotFound, ddb.DeleteStash(ctx, stashRef))
+}
+
+func TestParentOrder(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	initial, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	root, err := initial.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	newCommit := func(desc string, parents ...*Commit) *Commit {
+		meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", desc)
+		require.NoError(t, err)
+		cm, err := ddb.CommitDanglingWithParentCommits(ctx, valHash, parents, meta)
+		require.NoError(t, err)
+		return cm
+	}
+
+	hashesOf := func(cms ...*Commit) []hash.Hash {
+		var hashes []hash.Hash
+		for _, cm := range cms {
+			h, err := cm.HashOf()
+			require.NoError(t, err)
+			hashes = append(hashes, h)
+		}
+		return hashes
+	}
+
+	c1 := newCommit("first", initial)
+	c2 := newCommit("second", initial)
+
+	// parents are numbered in the order they were given, whichever has the lower hash
+	for _, parents := range [][]*Commit{{c1, c2}, {c2, c1}} {
+		merge := newCommit("merge", parents...)
+
+		parentHashes, err := merge.ParentHashes(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, hashesOf(parents...), parentHashes)
+
+		for i, expected := range parents {
+			parent, err := ddb.ResolveParent(ctx, merge, i)
+			require.NoError(t, err)
+			assert.Equal(t, hashesOf(expected), hashesOf(parent))
+		}
+
+		allParents, err := ddb.ResolveAllParents(ctx, merge)
+		require.NoError(t, err)
+		assert.Equal(t, hashesOf(parents...), hashesOf(allParents...))
+
+		_, err = ddb.ResolveParent(ctx, merge, 2)
+		assert.Equal(t, ErrInvalidAncestorSpec, err)
+	}
+
+	// a commit to a branch lists the head of the branch first
+	branch := ref.NewBranchRef("branch")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, branch, c2))
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "merge c1 into branch")
+	require.NoError(t, err)
+	merge, err := ddb.CommitWithParentCommits(ctx, valHash, branch, []*Commit{c1}, meta)
+	require.NoError(t, err)
+
+	parentHashes, err := merge.ParentHashes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, hashesOf(c2, c1), parentHashes)
+
+	// commits made before the order of parents was recorded list their parents in the order of the set holding them
+	parents, _, err := ddb.newParents(ctx, nil, []*Commit{c2, c1})
+	require.NoError(t, err)
+	val, err := ddb.db.ReadValue(ctx, valHash)
+	require.NoError(t, err)
+	legacySt, err := ddb.db.CommitDangling(ctx, val, datas.CommitOptions{Parents: parents})
+	require.NoError(t, err)
+	legacy := NewCommit(ddb.db, legacySt)
+
+	parentHashes, err = legacy.ParentHashes(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, hashesOf(c1, c2), parentHashes)
+}
@@ -17,6 +17,7 @@ package doltdb
 import "errors"
 
 var ErrInvBranchName = errors.New("not a valid user branch name")
+var ErrInvTagName = errors.New("not a valid user tag name")
 var ErrInvTableName = errors.New("not a valid table name")
 var ErrInvHash = errors.New("not a valid hash")
 var ErrInvalidAncestorSpec = errors.New("invalid ancestor spec")
@@ -27,6 +28,8 @@ var ErrFoundHashNotACommit = errors.New("the value retrieved for this hash is no
 
 var ErrHashNotFound = errors.New("could not find a value for this hash")
 var ErrBranchNotFound = errors.New("branch not found")
+var ErrTagNotFound = errors.New("tag not found")
+var ErrStashNotFound = errors.New("stash not found")
 var ErrTableNotFound = errors.New("table not found")
 var ErrTableExists = errors.New("table already exists")
 var ErrAlreadyOnBranch = errors.New("Already on branch")
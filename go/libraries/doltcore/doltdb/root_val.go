@@ -1234,6 +1234,35 @@ func (root *RootValue) ValidateForeignKeys(ctx context.Context) (*RootValue, err
 	return root.PutForeignKeyCollection(ctx, fkCollection)
 }
 
+// ValidateCheckConstraints ensures that every row of every table satisfies the CHECK constraints of its table, returning
+// an error describing the first violation found.
+func (root *RootValue) ValidateCheckConstraints(ctx context.Context) error {
+	return root.IterTables(ctx, func(name string, table *Table) (stop bool, err error) {
+		sch, err := table.GetSchema(ctx)
+		if err != nil {
+			return true, err
+		}
+		if sch.Checks().Count() == 0 {
+			return false, nil
+		}
+		checks, err := CheckConstraintsForSchema(name, sch)
+		if err != nil {
+			return true, err
+		}
+		rowData, err := table.GetRowData(ctx)
+		if err != nil {
+			return true, err
+		}
+		for _, check := range checks {
+			err = check.ValidateData(ctx, rowData)
+			if err != nil {
+				return true, err
+			}
+		}
+		return false, nil
+	})
+}
+
 func getDocDetailsBtwnRoots(ctx context.Context, newTbl *Table, newSch schema.Schema, newTblFound bool, oldTbl *Table, oldSch schema.Schema, oldTblFound bool) ([]DocDetails, error) {
 	var docDetailsBtwnRoots []DocDetails
 	if newTblFound {
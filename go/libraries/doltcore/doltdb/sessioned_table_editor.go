@@ -27,8 +27,9 @@ import (
 type SessionedTableEditor struct {
 	tableEditSession  *TableEditSession
 	tableEditor       *TableEditor
-	referencedTables  []*ForeignKey // The tables that we reference to ensure an insert or update is valid
-	referencingTables []*ForeignKey // The tables that reference us to ensure their inserts and updates are valid
+	referencedTables  []*ForeignKey      // The tables that we reference to ensure an insert or update is valid
+	referencingTables []*ForeignKey      // The tables that reference us to ensure their inserts and updates are valid
+	checks            []*CheckConstraint // The CHECK constraints that every inserted or updated row must satisfy
 }
 
 // ContainsIndexedKey returns whether the given key is contained within the index. The key is assumed to be in the
@@ -59,7 +60,18 @@ func (ste *SessionedTableEditor) InsertRow(ctx context.Context, dRow row.Row) er
 	ste.tableEditSession.writeMutex.RLock()
 	defer ste.tableEditSession.writeMutex.RUnlock()
 
-	err := ste.validateForInsert(ctx, dRow)
+	// the AUTO_INCREMENT value is generated before validating so that the checks see the row that is written
+	dRow, err := ste.tableEditor.setAutoIncrement(dRow)
+	if err != nil {
+		return err
+	}
+
+	err = ste.validateChecks(ctx, dRow)
+	if err != nil {
+		return err
+	}
+
+	err = ste.validateForInsert(ctx, dRow)
 	if err != nil {
 		return err
 	}
@@ -294,6 +306,16 @@ func (ste *SessionedTableEditor) reduceRowAndConvert(nbf *types.NomsBinFormat, o
 }
 
 func (ste *SessionedTableEditor) updateRow(ctx context.Context, dOldRow row.Row, dNewRow row.Row, checkReferences bool) error {
+	dNewRow, err := ste.tableEditor.setAutoIncrement(dNewRow)
+	if err != nil {
+		return err
+	}
+
+	err = ste.validateChecks(ctx, dNewRow)
+	if err != nil {
+		return err
+	}
+
 	if checkReferences {
 		err := ste.validateForInsert(ctx, dNewRow)
 		if err != nil {
@@ -301,7 +323,7 @@ func (ste *SessionedTableEditor) updateRow(ctx context.Context, dOldRow row.Row,
 		}
 	}
 
-	err := ste.handleReferencingRowsOnUpdate(ctx, dOldRow, dNewRow)
+	err = ste.handleReferencingRowsOnUpdate(ctx, dOldRow, dNewRow)
 	if err != nil {
 		return err
 	}
@@ -309,6 +331,20 @@ func (ste *SessionedTableEditor) updateRow(ctx context.Context, dOldRow row.Row,
 	return ste.tableEditor.UpdateRow(ctx, dOldRow, dNewRow)
 }
 
+// validateChecks returns an error if the given row violates any of the table's CHECK constraints.
+func (ste *SessionedTableEditor) validateChecks(ctx context.Context, dRow row.Row) error {
+	if ste.tableEditSession.Props.CheckConstraintsDisabled {
+		return nil
+	}
+	for _, check := range ste.checks {
+		err := check.ValidateRow(ctx, dRow)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateForInsert returns whether the given row is able to be inserted into the target table.
 func (ste *SessionedTableEditor) validateForInsert(ctx context.Context, dRow row.Row) error {
 	if ste.tableEditSession.Props.ForeignKeyChecksDisabled {
@@ -124,18 +124,21 @@ func GetAllTableNames(ctx context.Context, root *RootValue) ([]string, error) {
 var writeableSystemTables = []string{
 	DoltQueryCatalogTableName,
 	SchemasTableName,
+	MergeStrategiesTableName,
 }
 
 var persistedSystemTables = []string{
 	DocTableName,
 	DoltQueryCatalogTableName,
 	SchemasTableName,
+	MergeStrategiesTableName,
 }
 
 var generatedSystemTables = []string{
 	BranchesTableName,
 	LogTableName,
 	TableOfTablesInConflictName,
+	TagsTableName,
 }
 
 var generatedSystemTablePrefixes = []string{
@@ -223,6 +226,38 @@ const (
 	DoltSchemasFragmentTag
 )
 
+const (
+	// MergeStrategiesTableName is the name of the table declaring how conflicting cell changes are resolved by merges
+	MergeStrategiesTableName = "dolt_merge_strategies"
+
+	// MergeStrategiesTableCol is the name of the column containing the table a merge strategy applies to
+	MergeStrategiesTableCol = "table_name"
+
+	// MergeStrategiesColumnCol is the name of the column containing the column a merge strategy applies to. An empty
+	// column name applies the strategy to every column of the table.
+	MergeStrategiesColumnCol = "column_name"
+
+	// MergeStrategiesStrategyCol is the name of the column containing the name of the merge strategy
+	MergeStrategiesStrategyCol = "strategy"
+
+	// MergeStrategiesTimestampCol is the name of the column containing the column used to order changes by the
+	// latest strategy
+	MergeStrategiesTimestampCol = "timestamp_column"
+)
+
+// Tags for dolt_merge_strategies table
+// for info on unaligned constant: https://github.com/liquidata-inc/dolt/pull/663
+const (
+	// MergeStrategiesTableTag is the tag of the table name column in the merge strategies table
+	MergeStrategiesTableTag = iota + SystemTableReservedMin + uint64(5005)
+	// MergeStrategiesColumnTag is the tag of the column name column in the merge strategies table
+	MergeStrategiesColumnTag
+	// MergeStrategiesStrategyTag is the tag of the strategy column in the merge strategies table
+	MergeStrategiesStrategyTag
+	// MergeStrategiesTimestampTag is the tag of the timestamp column in the merge strategies table
+	MergeStrategiesTimestampTag
+)
+
 const (
 	// DoltHistoryTablePrefix is the prefix assigned to all the generated history tables
 	DoltHistoryTablePrefix = "dolt_history_"
@@ -254,4 +289,7 @@ const (
 
 	// BranchesTableName is the system table name
 	BranchesTableName = "dolt_branches"
+
+	// TagsTableName is the tags system table name
+	TagsTableName = "dolt_tags"
 )
@@ -38,6 +38,7 @@ type TableEditSession struct {
 // TableEditSessionProps are properties that define different functionality for the TableEditSession.
 type TableEditSessionProps struct {
 	ForeignKeyChecksDisabled bool // If true, then ALL foreign key checks AND updates (through CASCADE, etc.) are skipped
+	CheckConstraintsDisabled bool // If true, then rows are not checked against CHECK constraints as they are written
 }
 
 // CreateTableEditSession creates and returns a TableEditSession. Inserting a nil root is not an error, as there are
@@ -147,6 +148,29 @@ func (tes *TableEditSession) ValidateForeignKeys(ctx context.Context) error {
 	return nil
 }
 
+// ValidateCheckConstraints ensures that all rows of the tables with open table editors satisfy their CHECK constraints.
+// This does not consider any tables that do not have open editors.
+func (tes *TableEditSession) ValidateCheckConstraints(ctx context.Context) error {
+	tes.writeMutex.Lock()
+	defer tes.writeMutex.Unlock()
+
+	_, err := tes.flush(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ste := range tes.tables {
+		for _, check := range ste.checks {
+			err = check.ValidateData(ctx, ste.tableEditor.rowData)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // flush is the inner implementation for Flush that does not acquire any locks
 func (tes *TableEditSession) flush(ctx context.Context) (*RootValue, error) {
 	rootMutex := &sync.Mutex{}
@@ -235,6 +259,10 @@ func (tes *TableEditSession) getTableEditor(ctx context.Context, tableName strin
 		return nil, err
 	}
 	localTableEditor.tableEditor = tableEditor
+	localTableEditor.checks, err = CheckConstraintsForSchema(tableName, tableSch)
+	if err != nil {
+		return nil, err
+	}
 	if tes.Props.ForeignKeyChecksDisabled {
 		return localTableEditor, nil
 	}
@@ -301,6 +329,10 @@ func (tes *TableEditSession) setRoot(ctx context.Context, root *RootValue) error
 			return err
 		}
 		localTableEditor.tableEditor = newTableEditor
+		localTableEditor.checks, err = CheckConstraintsForSchema(tableName, tSch)
+		if err != nil {
+			return err
+		}
 		localTableEditor.referencedTables, localTableEditor.referencingTables = fkCollection.KeysForTable(tableName)
 		err = tes.loadForeignKeys(ctx, localTableEditor)
 		if err != nil {
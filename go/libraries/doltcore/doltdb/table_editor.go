@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"sync"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
@@ -47,6 +48,12 @@ type TableEditor struct {
 
 	rowData types.Map // cached for GetRow and ContainsKey operations
 
+	// aiCol is the AUTO_INCREMENT column of the table, if it has one. aiVal is the greatest value of the column in the
+	// table or written by this editor, and is guarded by aiMutex.
+	aiCol   *schema.Column
+	aiVal   types.Value
+	aiMutex *sync.Mutex
+
 	// This mutex blocks on each operation, so that map reads and updates are serialized
 	writeMutex *sync.Mutex
 	// This mutex ensures that Flush is only called once all current write operations have completed
@@ -60,6 +67,20 @@ type tableEditAccumulator struct {
 	addedKeys    map[hash.Hash]types.Value
 	removedKeys  map[hash.Hash]types.Value
 	affectedKeys map[hash.Hash]types.Value
+
+	// keylessDeltas holds the changes to the number of copies of each row of a keyless table, which are applied to the
+	// existing rows when the edits are flushed.
+	keylessDeltas map[hash.Hash]*keylessDelta
+}
+
+// keylessDelta is a change to the number of copies of a row of a keyless table
+type keylessDelta struct {
+	key types.Tuple
+	r   row.Row
+	// delta is the number of copies added, or removed if negative
+	delta int64
+	// deleted is true if every existing copy of the row was removed before delta was applied
+	deleted bool
 }
 
 const tableEditorMaxOps = 16384
@@ -81,6 +102,15 @@ func NewTableEditor(ctx context.Context, t *Table, tableSch schema.Schema) (*Tab
 	}
 	te.aq = async.NewActionExecutor(ctx, te.flushEditAccumulator, 1, 1)
 
+	if aiCol, ok := schema.AutoIncrementCol(tableSch); ok {
+		te.aiCol = &aiCol
+		te.aiMutex = &sync.Mutex{}
+		te.aiVal, err = lastAutoIncrementValue(ctx, te.rowData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for i, index := range tableSch.Indexes().AllIndexes() {
 		indexData, err := t.GetIndexRowData(ctx, index.Name())
 		if err != nil {
@@ -98,6 +128,8 @@ func newTableEditAcc(nbf *types.NomsBinFormat) *tableEditAccumulator {
 		addedKeys:    make(map[hash.Hash]types.Value),
 		removedKeys:  make(map[hash.Hash]types.Value),
 		affectedKeys: make(map[hash.Hash]types.Value),
+
+		keylessDeltas: make(map[hash.Hash]*keylessDelta),
 	}
 }
 
@@ -203,12 +235,22 @@ func (te *TableEditor) GetRow(ctx context.Context, key types.Tuple) (row.Row, bo
 	return r, true, nil
 }
 
-// InsertRow adds the given row to the table. If the row already exists, use UpdateRow.
+// InsertRow adds the given row to the table. If the row already exists, use UpdateRow. Inserting a row into a keyless
+// table adds as many copies of the row as its cardinality, whether or not the row already exists.
 func (te *TableEditor) InsertRow(ctx context.Context, dRow row.Row) error {
 	defer te.autoFlush()
 	te.flushMutex.RLock()
 	defer te.flushMutex.RUnlock()
 
+	if schema.IsKeyless(te.tSch) {
+		return te.addKeylessDelta(ctx, dRow, int64(row.Cardinality(dRow)))
+	}
+
+	dRow, err := te.setAutoIncrement(dRow)
+	if err != nil {
+		return err
+	}
+
 	key, err := dRow.NomsMapKey(te.tSch).Value(ctx)
 	if err != nil {
 		return errhand.BuildDError("failed to get row key").AddCause(err).Build()
@@ -260,12 +302,16 @@ func (te *TableEditor) DeleteKey(ctx context.Context, key types.Tuple) error {
 }
 
 // DeleteRow removes the given row from the table. This essentially acts as a convenience function for DeleteKey, while
-// ensuring proper thread safety.
+// ensuring proper thread safety. Deleting a row of a keyless table removes as many copies of the row as its cardinality.
 func (te *TableEditor) DeleteRow(ctx context.Context, dRow row.Row) error {
 	defer te.autoFlush()
 	te.flushMutex.RLock()
 	defer te.flushMutex.RUnlock()
 
+	if schema.IsKeyless(te.tSch) {
+		return te.addKeylessDelta(ctx, dRow, -int64(row.Cardinality(dRow)))
+	}
+
 	key, err := dRow.NomsMapKey(te.tSch).Value(ctx)
 	if err != nil {
 		return errhand.BuildDError("failed to get row key").AddCause(err).Build()
@@ -280,6 +326,19 @@ func (te *TableEditor) UpdateRow(ctx context.Context, dOldRow row.Row, dNewRow r
 	te.flushMutex.RLock()
 	defer te.flushMutex.RUnlock()
 
+	if schema.IsKeyless(te.tSch) {
+		err := te.addKeylessDelta(ctx, dOldRow, -int64(row.Cardinality(dOldRow)))
+		if err != nil {
+			return err
+		}
+		return te.addKeylessDelta(ctx, dNewRow, int64(row.Cardinality(dNewRow)))
+	}
+
+	dNewRow, err := te.setAutoIncrement(dNewRow)
+	if err != nil {
+		return err
+	}
+
 	dOldKey := dOldRow.NomsMapKey(te.tSch)
 	dOldKeyVal, err := dOldKey.Value(ctx)
 	if err != nil {
@@ -366,6 +425,12 @@ func (te *TableEditor) delete(key types.Tuple) error {
 	te.writeMutex.Lock()
 	defer te.writeMutex.Unlock()
 
+	if schema.IsKeyless(te.tSch) {
+		te.tea.keylessDeltas[keyHash] = &keylessDelta{key: key, deleted: true}
+		te.tea.opCount++
+		return nil
+	}
+
 	delete(te.tea.addedKeys, keyHash)
 	te.tea.removedKeys[keyHash] = key
 	te.tea.affectedKeys[keyHash] = key
@@ -375,10 +440,70 @@ func (te *TableEditor) delete(key types.Tuple) error {
 	return nil
 }
 
+// addKeylessDelta adds |delta| copies of a row of a keyless table, or removes them if |delta| is negative
+func (te *TableEditor) addKeylessDelta(ctx context.Context, dRow row.Row, delta int64) error {
+	key, err := dRow.NomsMapKey(te.tSch).Value(ctx)
+	if err != nil {
+		return errhand.BuildDError("failed to get row key").AddCause(err).Build()
+	}
+	keyHash, err := key.Hash(dRow.Format())
+	if err != nil {
+		return err
+	}
+
+	// Regarding the lock's position here, refer to the comment in InsertRow
+	te.writeMutex.Lock()
+	defer te.writeMutex.Unlock()
+
+	kd, ok := te.tea.keylessDeltas[keyHash]
+	if !ok {
+		kd = &keylessDelta{key: key.(types.Tuple)}
+		te.tea.keylessDeltas[keyHash] = kd
+	}
+	kd.r = dRow
+	kd.delta += delta
+
+	te.tea.opCount++
+	return nil
+}
+
+// applyKeylessDeltas adds the edits which change the number of copies of each row of a keyless table
+func (te *TableEditor) applyKeylessDeltas(ctx context.Context, tea *tableEditAccumulator) error {
+	for _, kd := range tea.keylessDeltas {
+		card := kd.delta
+		if !kd.deleted {
+			val, ok, err := te.rowData.MaybeGet(ctx, kd.key)
+			if err != nil {
+				return errhand.BuildDError("failed to read table").AddCause(err).Build()
+			}
+			if ok {
+				r, err := row.FromNoms(te.tSch, kd.key, val.(types.Tuple))
+				if err != nil {
+					return err
+				}
+				card += int64(row.Cardinality(r))
+			}
+		}
+
+		if card > 0 {
+			tea.ed.AddEdit(kd.key, row.WithCardinality(kd.r, uint64(card)).NomsMapValue(te.tSch))
+		} else {
+			tea.ed.AddEdit(kd.key, nil)
+		}
+	}
+
+	return nil
+}
+
 func (te *TableEditor) flushEditAccumulator(ctx context.Context, teaInterface interface{}) error {
 	// We don't call any locks here since this is called from an ActionExecutor with a concurrency of 1
 	tea := teaInterface.(*tableEditAccumulator)
 
+	err := te.applyKeylessDeltas(ctx, tea)
+	if err != nil {
+		return err
+	}
+
 	// For all added keys, check for and report a collision
 	for keyHash, addedKey := range tea.addedKeys {
 		if _, ok := tea.removedKeys[keyHash]; !ok {
@@ -527,3 +652,77 @@ func (te *TableEditor) updateIndexes(ctx context.Context, tea *tableEditAccumula
 
 	return tbl, nil
 }
+
+// setAutoIncrement returns the row given with a generated value for the table's AUTO_INCREMENT column if the row does
+// not have one, and records the greatest value of the column that has been written.
+func (te *TableEditor) setAutoIncrement(dRow row.Row) (row.Row, error) {
+	if te.aiCol == nil {
+		return dRow, nil
+	}
+
+	te.aiMutex.Lock()
+	defer te.aiMutex.Unlock()
+
+	val, _ := dRow.GetColVal(te.aiCol.Tag)
+	if types.IsNull(val) {
+		next, err := nextAutoIncrementValue(*te.aiCol, te.aiVal)
+		if err != nil {
+			return nil, err
+		}
+		te.aiVal = next
+		return dRow.SetColVal(te.aiCol.Tag, next, te.tSch)
+	}
+
+	if te.aiVal == nil {
+		te.aiVal = val
+		return dRow, nil
+	}
+
+	isLess, err := te.aiVal.Less(te.nbf, val)
+	if err != nil {
+		return nil, err
+	}
+	if isLess {
+		te.aiVal = val
+	}
+
+	return dRow, nil
+}
+
+// lastAutoIncrementValue returns the greatest value of the AUTO_INCREMENT column of a table, or nil if the table is
+// empty. The AUTO_INCREMENT column is always the first primary key column, so its greatest value is in the last key.
+func lastAutoIncrementValue(ctx context.Context, rowData types.Map) (types.Value, error) {
+	if rowData.Empty() {
+		return nil, nil
+	}
+
+	key, _, err := rowData.Last(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.(types.Tuple).Get(1)
+}
+
+// nextAutoIncrementValue returns the value that follows the last value generated for the AUTO_INCREMENT column given.
+// Values start at 1. An error is returned if the value is out of the range of the column's type.
+func nextAutoIncrementValue(col schema.Column, last types.Value) (types.Value, error) {
+	var next types.Value
+	if col.Kind == types.UintKind {
+		if last == nil {
+			next = types.Uint(1)
+		} else if uint64(last.(types.Uint)) < math.MaxUint64 {
+			next = types.Uint(uint64(last.(types.Uint)) + 1)
+		}
+	} else if last == nil || int64(last.(types.Int)) < 1 {
+		next = types.Int(1)
+	} else if int64(last.(types.Int)) < math.MaxInt64 {
+		next = types.Int(int64(last.(types.Int)) + 1)
+	}
+
+	if next == nil || !col.TypeInfo.IsValid(next) {
+		return nil, fmt.Errorf("generated value of AUTO_INCREMENT column `%s` is out of range for %s", col.Name, col.TypeInfo.ToSqlType().String())
+	}
+
+	return next, nil
+}
@@ -16,6 +16,7 @@ package doltdb
 
 import (
 	"context"
+	"math"
 	"sync"
 	"testing"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -295,3 +297,99 @@ func TestTableEditorWriteAfterFlush(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, sameTableData.Equals(newTableData))
 }
+
+func TestTableEditorKeyless(t *testing.T) {
+	ctx := context.Background()
+	format := types.Format_7_18
+	db, err := dbfactory.MemFactory{}.CreateDB(ctx, format, nil, nil)
+	require.NoError(t, err)
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("v1", 0, types.IntKind, false),
+		schema.NewColumn("v2", 1, types.IntKind, false))
+	require.NoError(t, err)
+	tableSch := schema.SchemaFromCols(colColl)
+	tableSchVal, err := encoding.MarshalSchemaAsNomsValue(ctx, db, tableSch)
+	require.NoError(t, err)
+	emptyMap, err := types.NewMap(ctx, db)
+	require.NoError(t, err)
+	table, err := NewTable(ctx, db, tableSchVal, emptyMap, nil)
+	require.NoError(t, err)
+
+	newRow := func(v1, v2 int) row.Row {
+		r, err := row.New(format, tableSch, row.TaggedValues{0: types.Int(v1), 1: types.Int(v2)})
+		require.NoError(t, err)
+		return r
+	}
+
+	cardinalities := func(table *Table) map[int]uint64 {
+		rowData, err := table.GetRowData(ctx)
+		require.NoError(t, err)
+		cards := make(map[int]uint64)
+		_ = rowData.IterAll(ctx, func(key, value types.Value) error {
+			r, err := row.FromNoms(tableSch, key.(types.Tuple), value.(types.Tuple))
+			require.NoError(t, err)
+			v1, _ := r.GetColVal(0)
+			cards[int(v1.(types.Int))] = row.Cardinality(r)
+			return nil
+		})
+		return cards
+	}
+
+	tableEditor, err := NewTableEditor(ctx, table, tableSch)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tableEditor.InsertRow(ctx, newRow(1, 1)))
+	}
+	require.NoError(t, tableEditor.InsertRow(ctx, newRow(2, 2)))
+	require.NoError(t, tableEditor.InsertRow(ctx, newRow(3, 3)))
+	table, err = tableEditor.Table()
+	require.NoError(t, err)
+	assert.Equal(t, map[int]uint64{1: 3, 2: 1, 3: 1}, cardinalities(table))
+
+	tableEditor, err = NewTableEditor(ctx, table, tableSch)
+	require.NoError(t, err)
+	require.NoError(t, tableEditor.DeleteRow(ctx, newRow(1, 1)))
+	require.NoError(t, tableEditor.UpdateRow(ctx, newRow(2, 2), newRow(3, 3)))
+	require.NoError(t, tableEditor.InsertRow(ctx, row.WithCardinality(newRow(4, 4), 2)))
+	table, err = tableEditor.Table()
+	require.NoError(t, err)
+	assert.Equal(t, map[int]uint64{1: 2, 3: 2, 4: 2}, cardinalities(table))
+}
+
+func TestNextAutoIncrementValue(t *testing.T) {
+	newCol := func(ti typeinfo.TypeInfo) schema.Column {
+		col, err := schema.NewColumnWithTypeInfo("pk", 0, ti, true)
+		require.NoError(t, err)
+		col.AutoIncrement = true
+		return col
+	}
+
+	tests := []struct {
+		name string
+		col  schema.Column
+		last types.Value
+		next types.Value
+	}{
+		{"empty", newCol(typeinfo.Int8Type), nil, types.Int(1)},
+		{"negative", newCol(typeinfo.Int8Type), types.Int(-5), types.Int(1)},
+		{"tinyint", newCol(typeinfo.Int8Type), types.Int(126), types.Int(127)},
+		{"tinyint max", newCol(typeinfo.Int8Type), types.Int(math.MaxInt8), nil},
+		{"bigint max", newCol(typeinfo.Int64Type), types.Int(math.MaxInt64), nil},
+		{"bigint unsigned empty", newCol(typeinfo.Uint64Type), nil, types.Uint(1)},
+		{"bigint unsigned", newCol(typeinfo.Uint64Type), types.Uint(math.MaxUint64 - 1), types.Uint(math.MaxUint64)},
+		{"bigint unsigned max", newCol(typeinfo.Uint64Type), types.Uint(math.MaxUint64), nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next, err := nextAutoIncrementValue(test.col, test.last)
+			if test.next == nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "out of range")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.next, next)
+			}
+		})
+	}
+}
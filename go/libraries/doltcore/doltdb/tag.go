@@ -0,0 +1,220 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	tagMetaNameKey      = "name"
+	tagMetaEmailKey     = "email"
+	tagMetaDescKey      = "desc"
+	tagMetaTimestampKey = "timestamp"
+	tagMetaUserTSKey    = "user_timestamp"
+	tagMetaVersionKey   = "metaversion"
+
+	tagMetaVersion = "1.0"
+)
+
+var errTagHasNoMeta = errors.New("tag has no metadata")
+var errTagHasNoCommit = errors.New("tag does not reference a commit")
+
+// Tag contains information on a tag that was written to noms
+type Tag struct {
+	Name   string
+	vrw    types.ValueReadWriter
+	tagSt  types.Struct
+	Meta   *TagMeta
+	Commit *Commit
+}
+
+// NewTag creates a Tag from the noms struct |tagSt| stored in the dataset refs/tags/|name|
+func NewTag(ctx context.Context, name string, vrw types.ValueReadWriter, tagSt types.Struct) (*Tag, error) {
+	metaVal, ok, err := tagSt.MaybeGet(datas.TagMetaField)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errTagHasNoMeta
+	}
+
+	meta, err := tagMetaFromNomsSt(metaVal.(types.Struct))
+
+	if err != nil {
+		return nil, err
+	}
+
+	commitSt, err := getCommitStForTagSt(ctx, vrw, tagSt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tag{
+		Name:   name,
+		vrw:    vrw,
+		tagSt:  tagSt,
+		Meta:   meta,
+		Commit: NewCommit(vrw, commitSt),
+	}, nil
+}
+
+// HashOf returns the hash of the tag
+func (t *Tag) HashOf() (hash.Hash, error) {
+	return t.tagSt.Hash(t.vrw.Format())
+}
+
+// GetDoltRef returns a DoltRef for this tag.
+func (t *Tag) GetDoltRef() ref.DoltRef {
+	return ref.NewTagRef(t.Name)
+}
+
+func getCommitStForTagSt(ctx context.Context, vr types.ValueReader, tagSt types.Struct) (types.Struct, error) {
+	commitRef, ok, err := tagSt.MaybeGet(datas.TagCommitRefField)
+
+	if err != nil {
+		return types.EmptyStruct(vr.Format()), err
+	} else if !ok {
+		return types.EmptyStruct(vr.Format()), errTagHasNoCommit
+	}
+
+	commitVal, err := commitRef.(types.Ref).TargetValue(ctx, vr)
+
+	if err != nil {
+		return types.EmptyStruct(vr.Format()), err
+	}
+
+	commitSt, ok := commitVal.(types.Struct)
+
+	if !ok || commitSt.Name() != CommitStructName {
+		return types.EmptyStruct(vr.Format()), errTagHasNoCommit
+	}
+
+	return commitSt, nil
+}
+
+// TagMeta contains all the metadata that is associated with a tag within a data repo.
+type TagMeta struct {
+	Name          string
+	Email         string
+	Timestamp     uint64
+	Description   string
+	UserTimestamp int64
+}
+
+// NewTagMeta creates a TagMeta instance from a name, email, and description and uses the current time for the
+// timestamp
+func NewTagMeta(name, email, desc string) (*TagMeta, error) {
+	return NewTagMetaWithUserTS(name, email, desc, CommitNowFunc())
+}
+
+// NewTagMetaWithUserTS creates a user metadata
+func NewTagMetaWithUserTS(name, email, desc string, userTS time.Time) (*TagMeta, error) {
+	n := strings.TrimSpace(name)
+	e := strings.TrimSpace(email)
+	d := strings.TrimSpace(desc)
+
+	if n == "" || e == "" {
+		return nil, errors.New("Aborting tag due to missing tagger name or email.")
+	}
+
+	ns := uint64(CommitNowFunc().UnixNano())
+	ms := ns / uMilliToNano
+
+	userMS := userTS.UnixNano() / milliToNano
+
+	return &TagMeta{n, e, ms, d, userMS}, nil
+}
+
+func tagMetaFromNomsSt(st types.Struct) (*TagMeta, error) {
+	e, err := getRequiredFromSt(st, tagMetaEmailKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := getRequiredFromSt(st, tagMetaNameKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := getRequiredFromSt(st, tagMetaDescKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := getRequiredFromSt(st, tagMetaTimestampKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userTS, err := getRequiredFromSt(st, tagMetaUserTSKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TagMeta{
+		string(n.(types.String)),
+		string(e.(types.String)),
+		uint64(ts.(types.Uint)),
+		string(d.(types.String)),
+		int64(userTS.(types.Int)),
+	}, nil
+}
+
+func (tm *TagMeta) toNomsStruct(nbf *types.NomsBinFormat) (types.Struct, error) {
+	metadata := types.StructData{
+		tagMetaNameKey:      types.String(tm.Name),
+		tagMetaEmailKey:     types.String(tm.Email),
+		tagMetaDescKey:      types.String(tm.Description),
+		tagMetaTimestampKey: types.Uint(tm.Timestamp),
+		tagMetaVersionKey:   types.String(tagMetaVersion),
+		tagMetaUserTSKey:    types.Int(tm.UserTimestamp),
+	}
+
+	return types.NewStruct(nbf, "metadata", metadata)
+}
+
+// Time returns the time at which the tag was created
+func (tm *TagMeta) Time() time.Time {
+	seconds := tm.UserTimestamp / secToMilli
+	nanos := (tm.UserTimestamp % secToMilli) * milliToNano
+	return time.Unix(seconds, nanos)
+}
+
+// FormatTS takes the internal timestamp and turns it into a human readable string in the time.RubyDate format
+// which looks like: "Mon Jan 02 15:04:05 -0700 2006"
+func (tm *TagMeta) FormatTS() string {
+	return tm.Time().In(CommitLoc).Format(time.RubyDate)
+}
+
+// String returns the human readable string representation of the tag data
+func (tm *TagMeta) String() string {
+	return fmt.Sprintf("name: %s, email: %s, timestamp: %s, description: %s", tm.Name, tm.Email, tm.FormatTS(), tm.Description)
+}
@@ -61,6 +61,7 @@ func (c CommitStaged) Exec(t *testing.T, dEnv *env.DoltEnv) error {
 		Date:             time.Now(),
 		AllowEmpty:       false,
 		CheckForeignKeys: true,
+		CheckConstraints: true,
 	})
 }
 
@@ -81,6 +82,7 @@ func (c CommitAll) Exec(t *testing.T, dEnv *env.DoltEnv) error {
 		Date:             time.Now(),
 		AllowEmpty:       false,
 		CheckForeignKeys: true,
+		CheckConstraints: true,
 	})
 }
 
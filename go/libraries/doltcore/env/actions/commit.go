@@ -36,6 +36,7 @@ type CommitStagedProps struct {
 	Date             time.Time
 	AllowEmpty       bool
 	CheckForeignKeys bool
+	CheckConstraints bool
 }
 
 // GetNameAndEmail returns the name and email from the supplied config
@@ -117,6 +118,14 @@ func CommitStaged(ctx context.Context, dEnv *env.DoltEnv, props CommitStagedProp
 		}
 	}
 
+	if props.CheckConstraints {
+		err = srt.ValidateCheckConstraints(ctx)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	h, err := dEnv.UpdateStagedRoot(ctx, srt)
 
 	if err != nil {
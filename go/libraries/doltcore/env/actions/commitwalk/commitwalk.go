@@ -142,14 +142,15 @@ func newQueue(ddb *doltdb.DoltDB) *q {
 // GetDotDotRevisions returns the commits reachable from commit at hash
 // `includedHead` that are not reachable from hash `excludedHead`.
 // `includedHead` and `excludedHead` must be commits in `ddb`. Returns up
-// to `num` commits, in reverse topological order starting at `includedHead`,
-// with tie breaking based on the height of commit graph between
-// concurrent commits --- higher commits appear first. Remaining
-// ties are broken by timestamp; newer commits appear first.
+// to `num` commits, or all of them if `num` is negative, in reverse
+// topological order starting at `includedHead`, with tie breaking
+// based on the height of commit graph between concurrent commits ---
+// higher commits appear first. Remaining ties are broken by timestamp;
+// newer commits appear first.
 //
 // Roughly mimics `git log master..feature`.
 func GetDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead hash.Hash, excludedHead hash.Hash, num int) ([]*doltdb.Commit, error) {
-	commitList := make([]*doltdb.Commit, 0, num)
+	var commitList []*doltdb.Commit
 	q := newQueue(ddb)
 	if err := q.SetInvisible(ctx, excludedHead); err != nil {
 		return nil, err
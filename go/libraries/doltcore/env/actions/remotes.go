@@ -67,6 +67,79 @@ func Push(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, destRe
 	return err
 }
 
+// PushTag pushes a commit tag and all underlying data from a local source database to a remote destination database.
+// Tags are immutable, so if the tag already exists in the destination database it is left untouched.  In that case
+// doltdb.ErrUpToDate is returned if the remote tag matches the local one and datas.ErrTagExists is returned otherwise.
+func PushTag(ctx context.Context, dEnv *env.DoltEnv, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	hasRef, err := destDB.HasRef(ctx, destRef)
+
+	if err != nil {
+		return err
+	}
+
+	if hasRef {
+		return checkTagsMatch(ctx, destDB, destRef, tag)
+	}
+
+	err = destDB.PushChunks(ctx, dEnv.TempTableFilesDir(), srcDB, tag.Commit, progChan, pullerEventCh)
+
+	if err != nil {
+		return err
+	}
+
+	return destDB.NewTagAtCommit(ctx, destRef, tag.Commit, tag.Meta)
+}
+
+// FetchTag fetches a commit tag and all underlying data from a remote source database to the local destination
+// database.  Returns doltdb.ErrUpToDate if the tag already exists locally and matches the remote tag, and
+// datas.ErrTagExists if a different tag with the same name exists locally.
+func FetchTag(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBTag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	tagRef := ref.NewTagRef(srcDBTag.Name)
+	hasRef, err := destDB.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	if hasRef {
+		return checkTagsMatch(ctx, destDB, tagRef, srcDBTag)
+	}
+
+	err = destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, srcDBTag.Commit, progChan, pullerEventCh)
+
+	if err != nil {
+		return err
+	}
+
+	return destDB.NewTagAtCommit(ctx, tagRef, srcDBTag.Commit, srcDBTag.Meta)
+}
+
+func checkTagsMatch(ctx context.Context, ddb *doltdb.DoltDB, tagRef ref.TagRef, tag *doltdb.Tag) error {
+	existing, err := ddb.ResolveTag(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	existingHash, err := existing.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	h, err := tag.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	if existingHash != h {
+		return datas.ErrTagExists
+	}
+
+	return doltdb.ErrUpToDate
+}
+
 // DeleteRemoteBranch validates targetRef is a branch on the remote database, and then deletes it, then deletes the
 // remote tracking branch from the local database.
 func DeleteRemoteBranch(ctx context.Context, targetRef ref.BranchRef, remoteRef ref.RemoteRef, localDB, remoteDB *doltdb.DoltDB) error {
@@ -0,0 +1,372 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+var ErrNoLocalChanges = errors.New("no local changes to save")
+var ErrStashWithConflicts = errors.New("cannot apply a stash while there are unresolved conflicts")
+
+// Stash is a stash of the uncommitted changes of a working set. A stash is a dangling commit of the working root whose
+// parent is a dangling commit of the staged root, which in turn has the commit that was HEAD when the changes were
+// stashed as its parent. Parents of a commit are unordered, so the roots are chained rather than being siblings.
+type Stash struct {
+	Ref    ref.StashRef
+	Commit *doltdb.Commit
+}
+
+// StashChanges records the staged and working roots, including changes to tracked docs on the filesystem, as a new
+// stash and resets the working set to HEAD. If |msg| is empty a message describing HEAD is used.
+func StashChanges(ctx context.Context, dEnv *env.DoltEnv, msg string) error {
+	headCm, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec(), dEnv.RepoState.CWBHeadRef())
+
+	if err != nil {
+		return err
+	}
+
+	headRoot, err := headCm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	stagedRoot, err := dEnv.StagedRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	workingRoot, err := getWorkingRootWithTrackedDocs(ctx, dEnv)
+
+	if err != nil {
+		return err
+	}
+
+	headHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	stagedHash, err := dEnv.DoltDB.WriteRootValue(ctx, stagedRoot)
+
+	if err != nil {
+		return err
+	}
+
+	workingHash, err := dEnv.DoltDB.WriteRootValue(ctx, workingRoot)
+
+	if err != nil {
+		return err
+	}
+
+	if stagedHash == headHash && workingHash == headHash {
+		return ErrNoLocalChanges
+	}
+
+	name, email, err := GetNameAndEmail(dEnv.Config)
+
+	if err != nil {
+		return err
+	}
+
+	branch := dEnv.RepoState.CWBHeadRef().GetPath()
+	if msg == "" {
+		headMeta, err := headCm.GetCommitMeta()
+
+		if err != nil {
+			return err
+		}
+
+		cmHash, err := headCm.HashOf()
+
+		if err != nil {
+			return err
+		}
+
+		msg = fmt.Sprintf("WIP on %s: %s %s", branch, cmHash.String(), strings.SplitN(headMeta.Description, "\n", 2)[0])
+	} else {
+		msg = fmt.Sprintf("On %s: %s", branch, msg)
+	}
+
+	stagedMeta, err := doltdb.NewCommitMeta(name, email, "index "+msg)
+
+	if err != nil {
+		return err
+	}
+
+	stagedCm, err := dEnv.DoltDB.WriteDanglingCommit(ctx, stagedHash, []*doltdb.Commit{headCm}, stagedMeta)
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := doltdb.NewCommitMeta(name, email, msg)
+
+	if err != nil {
+		return err
+	}
+
+	stashCm, err := dEnv.DoltDB.WriteDanglingCommit(ctx, workingHash, []*doltdb.Commit{stagedCm}, meta)
+
+	if err != nil {
+		return err
+	}
+
+	stashes, err := GetStashes(ctx, dEnv.DoltDB)
+
+	if err != nil {
+		return err
+	}
+
+	next := 0
+	if len(stashes) > 0 {
+		latest, err := strconv.Atoi(stashes[0].Ref.GetPath())
+
+		if err != nil {
+			return err
+		}
+
+		next = latest + 1
+	}
+
+	err = dEnv.DoltDB.NewStashAtCommit(ctx, ref.NewStashRef(strconv.Itoa(next)), stashCm)
+
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, headRoot)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = dEnv.UpdateStagedRoot(ctx, headRoot)
+
+	if err != nil {
+		return err
+	}
+
+	return SaveTrackedDocsFromWorking(ctx, dEnv)
+}
+
+// getWorkingRootWithTrackedDocs returns the working root updated with the changes to tracked docs on the filesystem
+func getWorkingRootWithTrackedDocs(ctx context.Context, dEnv *env.DoltEnv) (*doltdb.RootValue, error) {
+	workingRoot, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, notStagedDocs, err := diff.GetDocDiffs(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []doltdb.DocDetails
+	for _, docName := range notStagedDocs.Docs {
+		if notStagedDocs.DocToType[docName] == diff.AddedDoc {
+			continue
+		}
+
+		doc, err := dEnv.GetOneDocDetail(docName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	if len(docs) == 0 {
+		return workingRoot, nil
+	}
+
+	return dEnv.GetUpdatedRootWithDocs(ctx, workingRoot, docs)
+}
+
+// GetStashes returns the stashes in |ddb| ordered from the most recent to the oldest
+func GetStashes(ctx context.Context, ddb *doltdb.DoltDB) ([]*Stash, error) {
+	stashRefs, err := ddb.GetStashes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stashes []*Stash
+	ids := make(map[*Stash]int)
+	for _, r := range stashRefs {
+		sr, ok := r.(ref.StashRef)
+
+		if !ok {
+			continue
+		}
+
+		id, err := strconv.Atoi(sr.GetPath())
+
+		if err != nil {
+			// not a stash created by StashChanges
+			continue
+		}
+
+		cm, err := ddb.ResolveStash(ctx, sr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stash := &Stash{sr, cm}
+		ids[stash] = id
+		stashes = append(stashes, stash)
+	}
+
+	sort.Slice(stashes, func(i, j int) bool {
+		return ids[stashes[i]] > ids[stashes[j]]
+	})
+
+	return stashes, nil
+}
+
+// ApplyStash merges the changes of |stash| into the working set using a three-way merge with the commit the changes
+// were stashed on as the common ancestor. Conflicts are left in the working set. If the working root merges cleanly,
+// the staged changes of the stash are restored as well, otherwise they are left unstaged.
+func ApplyStash(ctx context.Context, dEnv *env.DoltEnv, stash *Stash) (map[string]*merge.MergeStats, error) {
+	workingRoot, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if has, err := workingRoot.HasConflicts(ctx); err != nil {
+		return nil, err
+	} else if has {
+		return nil, ErrStashWithConflicts
+	}
+
+	stagedCm, err := dEnv.DoltDB.ResolveParent(ctx, stash.Commit, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	baseCm, err := dEnv.DoltDB.ResolveParent(ctx, stagedCm, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	baseRoot, err := baseCm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashStagedRoot, err := stagedCm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashWorkingRoot, err := stash.Commit.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	vrw := dEnv.DoltDB.ValueReadWriter()
+	mergedWorking, tblToStats, err := merge.MergeRoots(ctx, workingRoot, stashWorkingRoot, baseRoot, vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mergedStaged *doltdb.RootValue
+	if !hasConflicts(tblToStats) {
+		stagedRoot, err := dEnv.StagedRoot(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		root, stagedStats, err := merge.MergeRoots(ctx, stagedRoot, stashStagedRoot, baseRoot, vrw)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasConflicts(stagedStats) {
+			mergedStaged = root
+		}
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, mergedWorking)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mergedStaged != nil {
+		_, err = dEnv.UpdateStagedRoot(ctx, mergedStaged)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = SaveTrackedDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// unstaged doc changes live on the filesystem rather than in the working root
+	err = dEnv.ResetWorkingDocsToStagedDocs(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tblToStats, nil
+}
+
+func hasConflicts(tblToStats map[string]*merge.MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.Conflicts > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DropStash deletes |stash|
+func DropStash(ctx context.Context, ddb *doltdb.DoltDB, stash *Stash) error {
+	return ddb.DeleteStash(ctx, stash.Ref)
+}
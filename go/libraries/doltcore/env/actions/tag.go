@@ -0,0 +1,128 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"sort"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+type TagProps struct {
+	TaggerName  string
+	TaggerEmail string
+	Description string
+}
+
+func CreateTag(ctx context.Context, dEnv *env.DoltEnv, tagName, startPoint string, props TagProps) error {
+	tagRef := ref.NewTagRef(tagName)
+
+	hasRef, err := dEnv.DoltDB.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	if hasRef {
+		return ErrAlreadyExists
+	}
+
+	if !doltdb.IsValidUserTagName(tagName) {
+		return doltdb.ErrInvTagName
+	}
+
+	cs, err := doltdb.NewCommitSpec(startPoint)
+
+	if err != nil {
+		return err
+	}
+
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoState.CWBHeadRef())
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := doltdb.NewTagMeta(props.TaggerName, props.TaggerEmail, props.Description)
+
+	if err != nil {
+		return err
+	}
+
+	return dEnv.DoltDB.NewTagAtCommit(ctx, tagRef, cm, meta)
+}
+
+func DeleteTags(ctx context.Context, dEnv *env.DoltEnv, tagNames ...string) error {
+	for _, tn := range tagNames {
+		dref := ref.NewTagRef(tn)
+
+		hasRef, err := dEnv.DoltDB.HasRef(ctx, dref)
+
+		if err != nil {
+			return err
+		} else if !hasRef {
+			return doltdb.ErrTagNotFound
+		}
+
+		err = dEnv.DoltDB.DeleteTag(ctx, dref)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IterResolvedTags iterates over the tags in |ddb| in lexicographic order, calling |cb| with each resolved tag.
+// Iteration stops early if |cb| returns true or an error.
+func IterResolvedTags(ctx context.Context, ddb *doltdb.DoltDB, cb func(tag *doltdb.Tag) (stop bool, err error)) error {
+	tagRefs, err := ddb.GetTags(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(tagRefs, func(i, j int) bool {
+		return tagRefs[i].String() < tagRefs[j].String()
+	})
+
+	for _, r := range tagRefs {
+		tr, ok := r.(ref.TagRef)
+
+		if !ok {
+			continue
+		}
+
+		tag, err := ddb.ResolveTag(ctx, tr)
+
+		if err != nil {
+			return err
+		}
+
+		stop, err := cb(tag)
+
+		if err != nil {
+			return err
+		} else if stop {
+			break
+		}
+	}
+
+	return nil
+}
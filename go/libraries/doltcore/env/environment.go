@@ -377,6 +377,17 @@ func (r *repoStateWriter) SetWorkingHash(ctx context.Context, h hash.Hash) error
 	return nil
 }
 
+func (r *repoStateWriter) SetStagedHash(ctx context.Context, h hash.Hash) error {
+	r.dEnv.RepoState.Staged = h.String()
+	err := r.dEnv.RepoState.Save(r.dEnv.FS)
+
+	if err != nil {
+		return ErrStateUpdate
+	}
+
+	return nil
+}
+
 func (dEnv *DoltEnv) RepoStateWriter() RepoStateWriter {
 	return &repoStateWriter{dEnv}
 }
@@ -457,6 +468,10 @@ func (dEnv *DoltEnv) IsMergeActive() bool {
 	return dEnv.RepoState.Merge != nil
 }
 
+func (dEnv *DoltEnv) IsRebaseActive() bool {
+	return dEnv.RepoState.Rebase != nil
+}
+
 func (dEnv *DoltEnv) GetTablesWithConflicts(ctx context.Context) ([]string, error) {
 	root, err := dEnv.WorkingRoot(ctx)
 
@@ -35,7 +35,7 @@ type RepoStateWriter interface {
 	// SetCWBHeadRef(context.Context, ref.DoltRef) error
 	// SetCWBHeadSpec(context.Context, *doltdb.CommitSpec) error
 	SetWorkingHash(context.Context, hash.Hash) error
-	//	SetStagedHash(context.Context, hash.Hash) error
+	SetStagedHash(context.Context, hash.Hash) error
 }
 
 type BranchConfig struct {
@@ -48,11 +48,21 @@ type MergeState struct {
 	PreMergeWorking string `json:"working_pre_merge"`
 }
 
+// RebaseState tracks the progress of a rebase of the current branch. |Todo| holds the remaining steps of the rebase
+// and |Current| the step that stopped due to conflicts, both in the format of the rebase todo list.
+type RebaseState struct {
+	OrigHead string   `json:"orig_head"`
+	Onto     string   `json:"onto"`
+	Todo     []string `json:"todo"`
+	Current  string   `json:"current"`
+}
+
 type RepoState struct {
 	Head     ref.MarshalableRef      `json:"head"`
 	Staged   string                  `json:"staged"`
 	Working  string                  `json:"working"`
 	Merge    *MergeState             `json:"merge"`
+	Rebase   *RebaseState            `json:"rebase,omitempty"`
 	Remotes  map[string]Remote       `json:"remotes"`
 	Branches map[string]BranchConfig `json:"branches"`
 }
@@ -83,6 +93,7 @@ func CloneRepoState(fs filesys.ReadWriteFS, r Remote) (*RepoState, error) {
 		hashStr,
 		hashStr,
 		nil,
+		nil,
 		map[string]Remote{r.Name: r},
 		make(map[string]BranchConfig),
 	}
@@ -109,6 +120,7 @@ func CreateRepoState(fs filesys.ReadWriteFS, br string, rootHash hash.Hash) (*Re
 		hashStr,
 		hashStr,
 		nil,
+		nil,
 		make(map[string]Remote),
 		make(map[string]BranchConfig),
 	}
@@ -158,6 +170,16 @@ func (rs *RepoState) ClearMerge(fs filesys.Filesys) error {
 	return rs.Save(fs)
 }
 
+func (rs *RepoState) StartRebase(origHead, onto string, todo []string, fs filesys.Filesys) error {
+	rs.Rebase = &RebaseState{origHead, onto, todo, ""}
+	return rs.Save(fs)
+}
+
+func (rs *RepoState) ClearRebase(fs filesys.Filesys) error {
+	rs.Rebase = nil
+	return rs.Save(fs)
+}
+
 func (rs *RepoState) AddRemote(r Remote) {
 	rs.Remotes[r.Name] = r
 }
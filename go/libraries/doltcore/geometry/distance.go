@@ -0,0 +1,131 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import "math"
+
+// Distance returns the minimum distance between two shapes on a flat plane, in the units of their coordinates. Shapes
+// which touch or overlap, including a shape inside a polygon, are a distance of 0 apart.
+func Distance(a, b Shape) float64 {
+	if inside(a, b) || inside(b, a) {
+		return 0
+	}
+
+	segsA, segsB := segments(a), segments(b)
+	dist := math.Inf(1)
+	for _, sa := range segsA {
+		for _, sb := range segsB {
+			dist = math.Min(dist, segmentDistance(sa, sb))
+		}
+	}
+	return dist
+}
+
+// segment is a straight line between two points. A point is a segment whose ends are the same.
+type segment struct {
+	a, b Point
+}
+
+func segments(shape Shape) []segment {
+	switch s := shape.(type) {
+	case Point:
+		return []segment{{s, s}}
+	case LineString:
+		segs := make([]segment, 0, len(s)-1)
+		for i := 1; i < len(s); i++ {
+			segs = append(segs, segment{s[i-1], s[i]})
+		}
+		return segs
+	case Polygon:
+		var segs []segment
+		for _, ring := range s {
+			segs = append(segs, segments(ring)...)
+		}
+		return segs
+	}
+	return nil
+}
+
+// inside returns whether any point of the shape is inside the polygon given. Shapes which cross a polygon's boundary
+// are found by segment intersection instead.
+func inside(shape, polygon Shape) bool {
+	poly, ok := polygon.(Polygon)
+	if !ok {
+		return false
+	}
+
+	switch s := shape.(type) {
+	case Point:
+		return poly.contains(s)
+	case LineString:
+		return poly.contains(s[0])
+	case Polygon:
+		return poly.contains(s[0][0])
+	}
+	return false
+}
+
+// contains returns whether the point is inside the polygon, using the even-odd rule so that points in holes are outside.
+func (poly Polygon) contains(p Point) bool {
+	in := false
+	for _, ring := range poly {
+		for i := 1; i < len(ring); i++ {
+			a, b := ring[i-1], ring[i]
+			if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+				in = !in
+			}
+		}
+	}
+	return in
+}
+
+func segmentDistance(s1, s2 segment) float64 {
+	if segmentsIntersect(s1, s2) {
+		return 0
+	}
+
+	return math.Min(
+		math.Min(pointSegmentDistance(s1.a, s2), pointSegmentDistance(s1.b, s2)),
+		math.Min(pointSegmentDistance(s2.a, s1), pointSegmentDistance(s2.b, s1)),
+	)
+}
+
+func pointSegmentDistance(p Point, s segment) float64 {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return math.Hypot(p.X-s.a.X, p.Y-s.a.Y)
+	}
+
+	// the position of the closest point along the segment, from 0 at a to 1 at b
+	t := ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / lenSq
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(s.a.X+t*dx), p.Y-(s.a.Y+t*dy))
+}
+
+func segmentsIntersect(s1, s2 segment) bool {
+	d1 := cross(s2.a, s2.b, s1.a)
+	d2 := cross(s2.a, s2.b, s1.b)
+	d3 := cross(s1.a, s1.b, s2.a)
+	d4 := cross(s1.a, s1.b, s2.b)
+	// segments which touch or are collinear are found by pointSegmentDistance
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+// cross returns the cross product of (b - a) and (c - a), whose sign is the side of the line through a and b that c is
+// on.
+func cross(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
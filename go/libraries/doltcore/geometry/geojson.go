@@ -0,0 +1,133 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GeoJSONSRID is the SRID of GeoJSON coordinates, which are always WGS 84 longitudes and latitudes.
+const GeoJSONSRID = 4326
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+var geoJSONTypes = map[ShapeType]string{
+	PointShape:      "Point",
+	LineStringShape: "LineString",
+	PolygonShape:    "Polygon",
+}
+
+// MarshalGeoJSON returns the shape as a GeoJSON geometry object, such as {"type":"Point","coordinates":[1,2]}.
+func MarshalGeoJSON(shape Shape) ([]byte, error) {
+	coords, err := json.Marshal(shape.geoJSONCoordinates())
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(geoJSONGeometry{Type: geoJSONTypes[shape.Type()], Coordinates: coords})
+}
+
+func (p Point) geoJSONCoordinates() interface{} {
+	return []float64{p.X, p.Y}
+}
+
+func (ls LineString) geoJSONCoordinates() interface{} {
+	coords := make([]interface{}, len(ls))
+	for i, p := range ls {
+		coords[i] = p.geoJSONCoordinates()
+	}
+	return coords
+}
+
+func (poly Polygon) geoJSONCoordinates() interface{} {
+	coords := make([]interface{}, len(poly))
+	for i, ring := range poly {
+		coords[i] = ring.geoJSONCoordinates()
+	}
+	return coords
+}
+
+// UnmarshalGeoJSON reads a Point, LineString or Polygon GeoJSON geometry object. Positions may have an altitude, which
+// is discarded.
+func UnmarshalGeoJSON(data []byte) (Shape, error) {
+	var obj geoJSONGeometry
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %v", err)
+	}
+
+	if obj.Coordinates == nil {
+		return nil, errors.New(`invalid GeoJSON: missing "coordinates"`)
+	}
+
+	var shape Shape
+	var err error
+	switch obj.Type {
+	case geoJSONTypes[PointShape]:
+		var coords []float64
+		if err = json.Unmarshal(obj.Coordinates, &coords); err == nil {
+			shape, err = geoJSONPoint(coords)
+		}
+	case geoJSONTypes[LineStringShape]:
+		var coords [][]float64
+		if err = json.Unmarshal(obj.Coordinates, &coords); err == nil {
+			shape, err = geoJSONLineString(coords)
+		}
+	case geoJSONTypes[PolygonShape]:
+		var coords [][][]float64
+		if err = json.Unmarshal(obj.Coordinates, &coords); err == nil {
+			poly := make(Polygon, len(coords))
+			for i := 0; i < len(coords) && err == nil; i++ {
+				poly[i], err = geoJSONLineString(coords[i])
+			}
+			shape = poly
+		}
+	default:
+		return nil, fmt.Errorf("invalid GeoJSON: unsupported geometry type %q", obj.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON %s: %v", obj.Type, err)
+	}
+
+	if err = validate(shape); err != nil {
+		return nil, err
+	}
+
+	return shape, nil
+}
+
+func geoJSONLineString(coords [][]float64) (LineString, error) {
+	ls := make(LineString, len(coords))
+	for i, pos := range coords {
+		p, err := geoJSONPoint(pos)
+		if err != nil {
+			return nil, err
+		}
+		ls[i] = p
+	}
+	return ls, nil
+}
+
+func geoJSONPoint(pos []float64) (Point, error) {
+	if len(pos) < 2 {
+		return Point{}, errors.New("positions must have at least 2 coordinates")
+	}
+	return Point{X: pos[0], Y: pos[1]}, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geometry implements the spatial values stored in POINT, LINESTRING, POLYGON and GEOMETRY columns, along
+// with their text (WKT), binary (WKB) and GeoJSON representations.
+package geometry
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ShapeType is the type of a shape. The values are the geometry type codes used by WKB.
+type ShapeType uint32
+
+const (
+	// AnyShape is the type of GEOMETRY columns, which may hold shapes of any other type.
+	AnyShape        ShapeType = 0
+	PointShape      ShapeType = 1
+	LineStringShape ShapeType = 2
+	PolygonShape    ShapeType = 3
+)
+
+// String returns the SQL name of the shape type.
+func (st ShapeType) String() string {
+	switch st {
+	case PointShape:
+		return "POINT"
+	case LineStringShape:
+		return "LINESTRING"
+	case PolygonShape:
+		return "POLYGON"
+	default:
+		return "GEOMETRY"
+	}
+}
+
+// ShapeTypeFromString returns the shape type with the given SQL name.
+func ShapeTypeFromString(str string) (ShapeType, error) {
+	for _, st := range []ShapeType{AnyShape, PointShape, LineStringShape, PolygonShape} {
+		if strings.EqualFold(str, st.String()) {
+			return st, nil
+		}
+	}
+	return AnyShape, fmt.Errorf("unknown spatial type: %s", str)
+}
+
+// Shape is a point, line string or polygon.
+type Shape interface {
+	// Type returns the type of the shape.
+	Type() ShapeType
+	// writeWKT writes the shape as well-known text, without the type name.
+	writeWKT(b []byte) []byte
+	// writeWKB writes the shape as well-known binary, without the byte order and type.
+	writeWKB(b []byte) []byte
+	// geoJSONCoordinates returns the coordinates of the shape as nested GeoJSON positions.
+	geoJSONCoordinates() interface{}
+}
+
+// Point is a single position.
+type Point struct {
+	X, Y float64
+}
+
+// LineString is a sequence of at least two points, joined by straight lines.
+type LineString []Point
+
+// Polygon is an area bounded by an exterior ring, with holes cut out by its other rings. Each ring is a closed line
+// string of at least four points whose first and last points are the same.
+type Polygon []LineString
+
+// Type implements Shape.
+func (Point) Type() ShapeType {
+	return PointShape
+}
+
+// Type implements Shape.
+func (LineString) Type() ShapeType {
+	return LineStringShape
+}
+
+// Type implements Shape.
+func (Polygon) Type() ShapeType {
+	return PolygonShape
+}
+
+// Geometry is a shape along with the identifier of the spatial reference system (SRID) of its coordinates. An SRID of
+// 0 is a flat plane with no units.
+type Geometry struct {
+	SRID  uint32
+	Shape Shape
+}
+
+// sridLen is the length of the SRID which precedes the WKB of a serialized geometry
+const sridLen = 4
+
+// Serialize returns the geometry in the format in which it is stored: its SRID as a 4 byte little endian integer,
+// followed by the shape as WKB. This is the same format MySQL uses, so values are returned to clients as is.
+func (g Geometry) Serialize() []byte {
+	b := make([]byte, sridLen, 64)
+	binary.LittleEndian.PutUint32(b, g.SRID)
+	return appendWKB(b, g.Shape)
+}
+
+// Deserialize reads a geometry in the format written by Serialize.
+func Deserialize(b []byte) (Geometry, error) {
+	if len(b) < sridLen {
+		return Geometry{}, errors.New("invalid geometry: too short")
+	}
+
+	shape, err := UnmarshalWKB(b[sridLen:])
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	return Geometry{SRID: binary.LittleEndian.Uint32(b), Shape: shape}, nil
+}
+
+// String returns the geometry as well-known text. A non-zero SRID is written before the text, as in
+// "SRID=4326;POINT(1 2)". Parse reads this format.
+func (g Geometry) String() string {
+	wkt := MarshalWKT(g.Shape)
+	if g.SRID == 0 {
+		return wkt
+	}
+	return fmt.Sprintf("SRID=%d;%s", g.SRID, wkt)
+}
+
+// validate returns an error if the shape is not well formed.
+func validate(shape Shape) error {
+	switch s := shape.(type) {
+	case Point:
+		return nil
+	case LineString:
+		if len(s) < 2 {
+			return errors.New("invalid LINESTRING: must have at least 2 points")
+		}
+	case Polygon:
+		if len(s) == 0 {
+			return errors.New("invalid POLYGON: must have at least 1 ring")
+		}
+		for _, ring := range s {
+			if len(ring) < 4 {
+				return errors.New("invalid POLYGON: rings must have at least 4 points")
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return errors.New("invalid POLYGON: rings must be closed")
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported shape %T", shape)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testShapes = []struct {
+	wkt     string
+	shape   Shape
+	geoJSON string
+}{
+	{
+		"POINT(1 2)",
+		Point{1, 2},
+		`{"type":"Point","coordinates":[1,2]}`,
+	},
+	{
+		"POINT(-122.4194 37.7749)",
+		Point{-122.4194, 37.7749},
+		`{"type":"Point","coordinates":[-122.4194,37.7749]}`,
+	},
+	{
+		"LINESTRING(0 0,1 1,2 0.5)",
+		LineString{{0, 0}, {1, 1}, {2, 0.5}},
+		`{"type":"LineString","coordinates":[[0,0],[1,1],[2,0.5]]}`,
+	},
+	{
+		"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))",
+		Polygon{{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}, {{1, 1}, {2, 1}, {2, 2}, {1, 1}}},
+		`{"type":"Polygon","coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]],[[1,1],[2,1],[2,2],[1,1]]]}`,
+	},
+}
+
+func TestWKTRoundTrip(t *testing.T) {
+	for _, test := range testShapes {
+		t.Run(test.wkt, func(t *testing.T) {
+			assert.Equal(t, test.wkt, MarshalWKT(test.shape))
+			shape, err := ParseWKT(test.wkt)
+			require.NoError(t, err)
+			assert.Equal(t, test.shape, shape)
+		})
+	}
+}
+
+func TestParseWKT(t *testing.T) {
+	shape, err := ParseWKT("  point ( 1.5e1  -2 ) ")
+	require.NoError(t, err)
+	assert.Equal(t, Point{15, -2}, shape)
+
+	invalid := []string{
+		"",
+		"POINT",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT(1 2) POINT(3 4)",
+		"LINESTRING(1 2)",
+		"POLYGON((0 0,1 0,1 1,0 1))",
+		"POLYGON((0 0,1 0,0 0))",
+		"MULTIPOINT((1 2))",
+		"POINT(a b)",
+	}
+	for _, wkt := range invalid {
+		t.Run(wkt, func(t *testing.T) {
+			_, err := ParseWKT(wkt)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	g, err := Parse("SRID=4326;POINT(1 2)")
+	require.NoError(t, err)
+	assert.Equal(t, Geometry{SRID: 4326, Shape: Point{1, 2}}, g)
+	assert.Equal(t, "SRID=4326;POINT(1 2)", g.String())
+
+	g, err = Parse("POINT(1 2)")
+	require.NoError(t, err)
+	assert.Equal(t, Geometry{Shape: Point{1, 2}}, g)
+	assert.Equal(t, "POINT(1 2)", g.String())
+
+	_, err = Parse("SRID=x;POINT(1 2)")
+	assert.Error(t, err)
+	_, err = Parse("4326;POINT(1 2)")
+	assert.Error(t, err)
+}
+
+func TestWKBRoundTrip(t *testing.T) {
+	for _, test := range testShapes {
+		t.Run(test.wkt, func(t *testing.T) {
+			shape, err := UnmarshalWKB(MarshalWKB(test.shape))
+			require.NoError(t, err)
+			assert.Equal(t, test.shape, shape)
+		})
+	}
+}
+
+func TestUnmarshalWKB(t *testing.T) {
+	// POINT(1 2) in big endian
+	b, err := hex.DecodeString("00000000013ff00000000000004000000000000000")
+	require.NoError(t, err)
+	shape, err := UnmarshalWKB(b)
+	require.NoError(t, err)
+	assert.Equal(t, Point{1, 2}, shape)
+
+	le := MarshalWKB(LineString{{0, 0}, {1, 1}})
+	_, err = UnmarshalWKB(le[:len(le)-1])
+	assert.Error(t, err)
+	_, err = UnmarshalWKB(append(le, 0))
+	assert.Error(t, err)
+
+	// a line string claiming to have far more points than there is data for
+	_, err = UnmarshalWKB([]byte{1, 2, 0, 0, 0, 255, 255, 255, 255})
+	assert.Error(t, err)
+}
+
+func TestSerialize(t *testing.T) {
+	g := Geometry{SRID: 4326, Shape: Point{1, 2}}
+	b := g.Serialize()
+	// the SRID and the little endian WKB, as MySQL stores POINT(1 2) with SRID 4326
+	assert.Equal(t, "e61000000101000000000000000000f03f0000000000000040", hex.EncodeToString(b))
+
+	g2, err := Deserialize(b)
+	require.NoError(t, err)
+	assert.Equal(t, g, g2)
+
+	_, err = Deserialize(b[:3])
+	assert.Error(t, err)
+}
+
+func TestGeoJSONRoundTrip(t *testing.T) {
+	for _, test := range testShapes {
+		t.Run(test.wkt, func(t *testing.T) {
+			data, err := MarshalGeoJSON(test.shape)
+			require.NoError(t, err)
+			assert.Equal(t, test.geoJSON, string(data))
+
+			shape, err := UnmarshalGeoJSON(data)
+			require.NoError(t, err)
+			assert.Equal(t, test.shape, shape)
+		})
+	}
+}
+
+func TestUnmarshalGeoJSON(t *testing.T) {
+	shape, err := UnmarshalGeoJSON([]byte(`{"type": "Point", "coordinates": [1, 2, 100]}`))
+	require.NoError(t, err)
+	assert.Equal(t, Point{1, 2}, shape)
+
+	invalid := []string{
+		`{"type": "Point"}`,
+		`{"type": "Point", "coordinates": [1]}`,
+		`{"type": "Point", "coordinates": [[1, 2]]}`,
+		`{"type": "LineString", "coordinates": [[1, 2]]}`,
+		`{"type": "MultiPoint", "coordinates": [[1, 2]]}`,
+		`[1, 2]`,
+	}
+	for _, data := range invalid {
+		t.Run(data, func(t *testing.T) {
+			_, err := UnmarshalGeoJSON([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	square := Polygon{{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}}
+	withHole := Polygon{{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}, {{1, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 1}}}
+
+	tests := []struct {
+		name     string
+		a, b     Shape
+		expected float64
+	}{
+		{"points", Point{0, 0}, Point{3, 4}, 5},
+		{"same point", Point{1, 1}, Point{1, 1}, 0},
+		{"point to line end", Point{-3, 4}, LineString{{0, 0}, {5, 0}}, 5},
+		{"point to line middle", Point{2, 3}, LineString{{0, 0}, {5, 0}}, 3},
+		{"point on line", Point{2, 0}, LineString{{0, 0}, {5, 0}}, 0},
+		{"crossing lines", LineString{{0, 0}, {2, 2}}, LineString{{0, 2}, {2, 0}}, 0},
+		{"parallel lines", LineString{{0, 0}, {2, 0}}, LineString{{0, 1}, {2, 1}}, 1},
+		{"point in polygon", Point{2, 2}, square, 0},
+		{"point outside polygon", Point{7, 8}, square, 5},
+		{"polygon to point", square, Point{2, 6}, 2},
+		{"point in hole", Point{2, 2}, withHole, 1},
+		{"line in polygon", LineString{{1, 1}, {2, 2}}, square, 0},
+		{"polygon in polygon", Polygon{{{1, 1}, {2, 1}, {2, 2}, {1, 1}}}, square, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.expected, Distance(test.a, test.b), 1e-9)
+			assert.InDelta(t, test.expected, Distance(test.b, test.a), 1e-9)
+		})
+	}
+
+	// polygons which share a boundary
+	assert.Equal(t, 0.0, Distance(square, withHole))
+}
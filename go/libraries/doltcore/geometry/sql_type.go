@@ -0,0 +1,142 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/proto/query"
+)
+
+// SqlType is the SQL type of a spatial column. Values are held by the engine as Geometry, and are sent to clients in
+// the serialized form MySQL uses.
+type SqlType struct {
+	ShapeType ShapeType
+}
+
+var _ sql.Type = SqlType{}
+
+var (
+	GeometrySqlType   = SqlType{AnyShape}
+	PointSqlType      = SqlType{PointShape}
+	LineStringSqlType = SqlType{LineStringShape}
+	PolygonSqlType    = SqlType{PolygonShape}
+)
+
+// Compare implements sql.Type. Geometries are ordered by their serialized form, which is only meaningful for equality.
+func (t SqlType) Compare(a interface{}, b interface{}) (int, error) {
+	if hasNulls, res := compareNulls(a, b); hasNulls {
+		return res, nil
+	}
+
+	ga, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	gb, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return bytes.Compare(ga.(Geometry).Serialize(), gb.(Geometry).Serialize()), nil
+}
+
+// Convert implements sql.Type. Strings and byte slices must be serialized geometries, as returned to clients.
+func (t SqlType) Convert(v interface{}) (interface{}, error) {
+	var g Geometry
+	var err error
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case Geometry:
+		g = val
+	case []byte:
+		g, err = Deserialize(val)
+	case string:
+		g, err = Deserialize([]byte(val))
+	default:
+		return nil, fmt.Errorf("cannot convert value of type %T to %s", v, t.String())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert value to %s: %v", t.String(), err)
+	}
+
+	if t.ShapeType != AnyShape && g.Shape.Type() != t.ShapeType {
+		return nil, fmt.Errorf("cannot store a %s in a %s column", g.Shape.Type(), t.String())
+	}
+
+	return g, nil
+}
+
+// MustConvert implements sql.Type.
+func (t SqlType) MustConvert(v interface{}) interface{} {
+	value, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Promote implements sql.Type.
+func (t SqlType) Promote() sql.Type {
+	return GeometrySqlType
+}
+
+// SQL implements sql.Type.
+func (t SqlType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+
+	g, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Geometry, g.(Geometry).Serialize()), nil
+}
+
+// Type implements sql.Type.
+func (t SqlType) Type() query.Type {
+	return sqltypes.Geometry
+}
+
+// Zero implements sql.Type.
+func (t SqlType) Zero() interface{} {
+	return nil
+}
+
+// String implements sql.Type.
+func (t SqlType) String() string {
+	return t.ShapeType.String()
+}
+
+// compareNulls returns true if either value is null, along with their order. Nulls are ordered before non-nulls, as
+// they are by the SQL engine.
+func compareNulls(a, b interface{}) (bool, int) {
+	switch {
+	case a == nil && b == nil:
+		return true, 0
+	case a == nil:
+		return true, -1
+	case b == nil:
+		return true, 1
+	}
+	return false, 0
+}
@@ -0,0 +1,190 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+)
+
+var errShortWKB = errors.New("invalid WKB: unexpected end of data")
+
+// MarshalWKB returns the shape as little endian well-known binary.
+func MarshalWKB(shape Shape) []byte {
+	return appendWKB(nil, shape)
+}
+
+func appendWKB(b []byte, shape Shape) []byte {
+	b = append(b, wkbLittleEndian)
+	b = appendUint32(b, uint32(shape.Type()))
+	return shape.writeWKB(b)
+}
+
+func (p Point) writeWKB(b []byte) []byte {
+	b = appendUint64(b, math.Float64bits(p.X))
+	return appendUint64(b, math.Float64bits(p.Y))
+}
+
+func (ls LineString) writeWKB(b []byte) []byte {
+	b = appendUint32(b, uint32(len(ls)))
+	for _, p := range ls {
+		b = p.writeWKB(b)
+	}
+	return b
+}
+
+func (poly Polygon) writeWKB(b []byte) []byte {
+	b = appendUint32(b, uint32(len(poly)))
+	for _, ring := range poly {
+		b = ring.writeWKB(b)
+	}
+	return b
+}
+
+func appendUint32(b []byte, n uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], n)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, n uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	return append(b, buf[:]...)
+}
+
+// UnmarshalWKB reads a POINT, LINESTRING or POLYGON written as well-known binary of either byte order.
+func UnmarshalWKB(b []byte) (Shape, error) {
+	r := &wkbReader{b: b}
+	shape, err := r.readShape()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.b) != 0 {
+		return nil, fmt.Errorf("invalid WKB: %d unexpected bytes after the %s", len(r.b), shape.Type())
+	}
+
+	if err = validate(shape); err != nil {
+		return nil, err
+	}
+
+	return shape, nil
+}
+
+type wkbReader struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readShape() (Shape, error) {
+	if len(r.b) < 1 {
+		return nil, errShortWKB
+	}
+
+	switch r.b[0] {
+	case wkbLittleEndian:
+		r.order = binary.LittleEndian
+	case wkbBigEndian:
+		r.order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid WKB: unknown byte order %d", r.b[0])
+	}
+	r.b = r.b[1:]
+
+	typ, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	switch ShapeType(typ) {
+	case PointShape:
+		return r.readPoint()
+	case LineStringShape:
+		return r.readLineString()
+	case PolygonShape:
+		n, err := r.readCount()
+		if err != nil {
+			return nil, err
+		}
+
+		poly := make(Polygon, n)
+		for i := range poly {
+			if poly[i], err = r.readLineString(); err != nil {
+				return nil, err
+			}
+		}
+		return poly, nil
+	default:
+		return nil, fmt.Errorf("invalid WKB: unsupported geometry type %d", typ)
+	}
+}
+
+func (r *wkbReader) readLineString() (LineString, error) {
+	n, err := r.readCount()
+	if err != nil {
+		return nil, err
+	}
+
+	ls := make(LineString, n)
+	for i := range ls {
+		if ls[i], err = r.readPoint(); err != nil {
+			return nil, err
+		}
+	}
+	return ls, nil
+}
+
+func (r *wkbReader) readPoint() (Point, error) {
+	if len(r.b) < 16 {
+		return Point{}, errShortWKB
+	}
+
+	x := math.Float64frombits(r.order.Uint64(r.b))
+	y := math.Float64frombits(r.order.Uint64(r.b[8:]))
+	r.b = r.b[16:]
+	return Point{X: x, Y: y}, nil
+}
+
+// readCount reads the number of points or rings which follow, checking that there is enough data for them so that a
+// corrupt count cannot cause a huge allocation. Points and rings are both at least 16 bytes long.
+func (r *wkbReader) readCount() (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+
+	if uint64(n)*16 > uint64(len(r.b)) {
+		return 0, errShortWKB
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if len(r.b) < 4 {
+		return 0, errShortWKB
+	}
+
+	n := r.order.Uint32(r.b)
+	r.b = r.b[4:]
+	return n, nil
+}
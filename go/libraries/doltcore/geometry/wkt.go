@@ -0,0 +1,235 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalWKT returns the shape as well-known text, such as "POLYGON((0 0,1 0,1 1,0 0))".
+func MarshalWKT(shape Shape) string {
+	b := []byte(shape.Type().String())
+	return string(shape.writeWKT(b))
+}
+
+func (p Point) writeWKT(b []byte) []byte {
+	b = append(b, '(')
+	b = appendCoords(b, p)
+	return append(b, ')')
+}
+
+func (ls LineString) writeWKT(b []byte) []byte {
+	b = append(b, '(')
+	for i, p := range ls {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendCoords(b, p)
+	}
+	return append(b, ')')
+}
+
+func (poly Polygon) writeWKT(b []byte) []byte {
+	b = append(b, '(')
+	for i, ring := range poly {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = ring.writeWKT(b)
+	}
+	return append(b, ')')
+}
+
+func appendCoords(b []byte, p Point) []byte {
+	b = strconv.AppendFloat(b, p.X, 'f', -1, 64)
+	b = append(b, ' ')
+	return strconv.AppendFloat(b, p.Y, 'f', -1, 64)
+}
+
+// Parse reads a geometry written by Geometry.String: well-known text, optionally preceded by an SRID, as in
+// "SRID=4326;POINT(1 2)".
+func Parse(str string) (Geometry, error) {
+	var srid uint32
+	if i := strings.IndexByte(str, ';'); i >= 0 {
+		prefix := strings.TrimSpace(str[:i])
+		if len(prefix) < 5 || !strings.EqualFold(prefix[:5], "SRID=") {
+			return Geometry{}, fmt.Errorf("invalid geometry: %s", str)
+		}
+
+		n, err := strconv.ParseUint(prefix[5:], 10, 32)
+		if err != nil {
+			return Geometry{}, fmt.Errorf("invalid SRID: %s", prefix[5:])
+		}
+
+		srid = uint32(n)
+		str = str[i+1:]
+	}
+
+	shape, err := ParseWKT(str)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	return Geometry{SRID: srid, Shape: shape}, nil
+}
+
+// ParseWKT reads a POINT, LINESTRING or POLYGON written as well-known text.
+func ParseWKT(wkt string) (Shape, error) {
+	p := &wktParser{str: wkt}
+	shape, err := p.parseShape()
+	if err != nil {
+		return nil, fmt.Errorf("invalid WKT %q: %v", wkt, err)
+	}
+
+	if p.skipSpace(); p.pos != len(p.str) {
+		return nil, fmt.Errorf("invalid WKT %q: unexpected text after the %s", wkt, shape.Type())
+	}
+
+	if err = validate(shape); err != nil {
+		return nil, err
+	}
+
+	return shape, nil
+}
+
+type wktParser struct {
+	str string
+	pos int
+}
+
+func (p *wktParser) parseShape() (Shape, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.str) && isLetter(p.str[p.pos]) {
+		p.pos++
+	}
+
+	name := p.str[start:p.pos]
+	st, err := ShapeTypeFromString(name)
+	if err != nil || st == AnyShape {
+		return nil, fmt.Errorf("unsupported shape %q", name)
+	}
+
+	switch st {
+	case PointShape:
+		if err = p.expect('('); err != nil {
+			return nil, err
+		}
+		pt, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		return pt, p.expect(')')
+	case LineStringShape:
+		return p.parseLineString()
+	default:
+		return p.parsePolygon()
+	}
+}
+
+func (p *wktParser) parsePolygon() (Polygon, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	var poly Polygon
+	for {
+		ring, err := p.parseLineString()
+		if err != nil {
+			return nil, err
+		}
+		poly = append(poly, ring)
+
+		if !p.accept(',') {
+			return poly, p.expect(')')
+		}
+	}
+}
+
+func (p *wktParser) parseLineString() (LineString, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	var ls LineString
+	for {
+		pt, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		ls = append(ls, pt)
+
+		if !p.accept(',') {
+			return ls, p.expect(')')
+		}
+	}
+}
+
+func (p *wktParser) parsePoint() (Point, error) {
+	x, err := p.parseFloat()
+	if err != nil {
+		return Point{}, err
+	}
+
+	y, err := p.parseFloat()
+	if err != nil {
+		return Point{}, err
+	}
+
+	return Point{X: x, Y: y}, nil
+}
+
+func (p *wktParser) parseFloat() (float64, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.str) && strings.IndexByte("+-.0123456789eE", p.str[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	if start == p.pos {
+		return 0, fmt.Errorf("expected a number at position %d", start)
+	}
+
+	return strconv.ParseFloat(p.str[start:p.pos], 64)
+}
+
+// accept consumes the next character if it is c, and returns whether it was.
+func (p *wktParser) accept(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.str) && p.str[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(c byte) error {
+	if !p.accept(c) {
+		return fmt.Errorf("expected '%c' at position %d", c, p.pos)
+	}
+	return nil
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.str) && strings.IndexByte(" \t\r\n", p.str[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func isLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// mergeJSONCell performs a three-way merge of the JSON documents of a cell which was modified on both sides of a merge.
+// Objects are merged key by key, so edits to different keys do not conflict. Any other values conflict unless only one
+// side changed them. Returns false if the documents conflict.
+func mergeJSONCell(ctx context.Context, baseVal, val, mergeVal types.Value) (types.Value, bool, error) {
+	baseStr, baseOk := baseVal.(types.String)
+	str, ok := val.(types.String)
+	mergeStr, mergeOk := mergeVal.(types.String)
+	if !baseOk || !ok || !mergeOk {
+		return nil, false, nil
+	}
+
+	baseDoc, err := typeinfo.UnmarshalJSON([]byte(baseStr))
+	if err != nil {
+		return nil, false, err
+	}
+	doc, err := typeinfo.UnmarshalJSON([]byte(str))
+	if err != nil {
+		return nil, false, err
+	}
+	mergeDoc, err := typeinfo.UnmarshalJSON([]byte(mergeStr))
+	if err != nil {
+		return nil, false, err
+	}
+
+	merged, ok := mergeJSONValues(baseDoc, doc, mergeDoc)
+	if !ok {
+		return nil, false, nil
+	}
+
+	// JSON documents are stored in the row itself, so no ValueReadWriter is needed
+	mergedVal, err := typeinfo.JSONType.ConvertValueToNomsValue(ctx, nil, merged)
+	if err != nil {
+		return nil, false, err
+	}
+	return mergedVal, true, nil
+}
+
+func mergeJSONValues(base, val, mergeVal interface{}) (interface{}, bool) {
+	switch {
+	case reflect.DeepEqual(val, mergeVal):
+		return val, true
+	case reflect.DeepEqual(base, val):
+		return mergeVal, true
+	case reflect.DeepEqual(base, mergeVal):
+		return val, true
+	}
+
+	baseObj, baseIsObj := base.(map[string]interface{})
+	obj, isObj := val.(map[string]interface{})
+	mergeObj, mergeIsObj := mergeVal.(map[string]interface{})
+	if !baseIsObj || !isObj || !mergeIsObj {
+		return nil, false
+	}
+
+	merged := make(map[string]interface{})
+	keys := make(map[string]struct{})
+	for _, m := range []map[string]interface{}{baseObj, obj, mergeObj} {
+		for k := range m {
+			keys[k] = struct{}{}
+		}
+	}
+
+	for k := range keys {
+		baseMember, inBase := baseObj[k]
+		member, inVal := obj[k]
+		mergeMember, inMerge := mergeObj[k]
+
+		switch {
+		case inVal == inMerge && (!inVal || reflect.DeepEqual(member, mergeMember)):
+			// unchanged, or changed identically on both sides
+		case inBase == inVal && (!inBase || reflect.DeepEqual(baseMember, member)):
+			// only changed by the merge side
+			member, inVal = mergeMember, inMerge
+		case inBase == inMerge && (!inBase || reflect.DeepEqual(baseMember, mergeMember)):
+			// only changed by our side
+		case inBase && inVal && inMerge:
+			var ok bool
+			member, ok = mergeJSONValues(baseMember, member, mergeMember)
+			if !ok {
+				return nil, false
+			}
+		default:
+			// added on both sides with different values, or removed on one side and modified on the other
+			return nil, false
+		}
+
+		if inVal {
+			merged[k] = member
+		}
+	}
+
+	return merged, true
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestMergeJSONCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		val      string
+		mergeVal string
+		expected string
+		conflict bool
+	}{
+		{"different keys", `{"a":1,"b":2}`, `{"a":3,"b":2}`, `{"a":1,"b":4}`, `{"a":3,"b":4}`, false},
+		{"nested keys", `{"a":{"x":1,"y":1}}`, `{"a":{"x":2,"y":1}}`, `{"a":{"x":1,"y":2}}`, `{"a":{"x":2,"y":2}}`, false},
+		{"added keys", `{}`, `{"a":1}`, `{"b":2}`, `{"a":1,"b":2}`, false},
+		{"removed and modified keys", `{"a":1,"b":2}`, `{"b":2}`, `{"a":1,"b":3}`, `{"b":3}`, false},
+		{"same edit", `{"a":1}`, `{"a":2,"b":1}`, `{"a":2,"c":1}`, `{"a":2,"b":1,"c":1}`, false},
+		{"same key", `{"a":1}`, `{"a":2}`, `{"a":3}`, "", true},
+		{"added key with different values", `{}`, `{"a":1}`, `{"a":2}`, "", true},
+		{"removed and modified key", `{"a":1}`, `{}`, `{"a":2}`, "", true},
+		{"arrays", `[1,2]`, `[1,3]`, `[0,2]`, "", true},
+		{"object replaced", `{"a":{"x":1}}`, `{"a":[1]}`, `{"a":{"x":2}}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, ok, err := mergeJSONCell(context.Background(), types.String(test.base), types.String(test.val), types.String(test.mergeVal))
+			require.NoError(t, err)
+			assert.Equal(t, !test.conflict, ok)
+			if !test.conflict {
+				assert.Equal(t, types.String(test.expected), merged)
+			}
+		})
+	}
+
+	_, ok, err := mergeJSONCell(context.Background(), types.NullValue, types.String(`{"a":1}`), types.String(`{"b":1}`))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
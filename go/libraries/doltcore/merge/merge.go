@@ -28,6 +28,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/valutil"
 	"github.com/liquidata-inc/dolt/go/store/types"
@@ -35,6 +36,7 @@ import (
 
 var ErrFastForward = errors.New("fast forward")
 var ErrSameTblAddedTwice = errors.New("table with same name added in 2 commits can't be merged")
+var ErrTableDeletedAndModified = errors.New("conflict: table with same name deleted and modified")
 
 type Merger struct {
 	root      *doltdb.RootValue
@@ -110,9 +112,17 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, tableEditS
 	}
 
 	if h == anch {
+		if !mergeOk {
+			return nil, &MergeStats{Operation: TableRemoved}, nil
+		}
+
 		ms := MergeStats{Operation: TableModified}
 		if h != mh {
 			ms, err = calcTableMergeStats(ctx, tbl, mergeTbl)
+
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 		// force load the table editor since this counts as a change
 		_, err := tableEditSession.GetTableEditor(ctx, tblName, nil)
@@ -121,9 +131,17 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, tableEditS
 		}
 		return mergeTbl, &ms, nil
 	} else if mh == anch {
+		if !ok {
+			return nil, &MergeStats{Operation: TableRemoved}, nil
+		}
+
 		return tbl, &MergeStats{Operation: TableUnmodified}, nil
 	}
 
+	if !ok || !mergeOk {
+		return nil, nil, ErrTableDeletedAndModified
+	}
+
 	tblSchema, err := tbl.GetSchema(ctx)
 
 	if err != nil {
@@ -177,6 +195,7 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, tableEditS
 	}
 
 	postMergeSchema.Indexes().AddIndex(tblSchema.Indexes().AllIndexes()...)
+	postMergeSchema.Checks().Merge(tblSchema.Checks().AllChecks()...)
 
 	rows, err := tbl.GetRowData(ctx)
 
@@ -212,7 +231,23 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, tableEditS
 		return nil, nil, err
 	}
 
-	mergedTable, conflicts, stats, err := mergeTableData(ctx, tblName, postMergeSchema, rows, mergeRows, ancRows, merger.vrw, updatedTblEditor)
+	if schema.IsKeyless(postMergeSchema) {
+		return mergeKeylessTableData(ctx, tblName, postMergeSchema, mergeRows, ancRows, updatedTblEditor)
+	}
+
+	strategyDecls, err := GetStrategyDecls(ctx, merger.root)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolver, err := newCellResolver(strategyDecls, tblName, postMergeSchema)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mergedTable, conflicts, stats, err := mergeTableData(ctx, tblName, postMergeSchema, rows, mergeRows, ancRows, merger.vrw, updatedTblEditor, resolver)
 
 	if err != nil {
 		return nil, nil, err
@@ -342,7 +377,7 @@ func mergeTableSchema(sch, mergeSch, ancSch schema.Schema) (schema.Schema, error
 	return schema.SchemaFromCols(union), nil
 }
 
-func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, vrw types.ValueReadWriter, tblEdit *doltdb.SessionedTableEditor) (*doltdb.Table, types.Map, *MergeStats, error) {
+func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, vrw types.ValueReadWriter, tblEdit *doltdb.SessionedTableEditor, resolver *cellResolver) (*doltdb.Table, types.Map, *MergeStats, error) {
 	//changeChan1, changeChan2 := make(chan diff.Difference, 32), make(chan diff.Difference, 32)
 	ae := atomicerr.New()
 	changeChan, mergeChangeChan := make(chan types.ValueChanged, 32), make(chan types.ValueChanged, 32)
@@ -432,7 +467,7 @@ func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows
 
 			if !processed {
 				r, mergeRow, ancRow := change.NewValue, mergeChange.NewValue, change.OldValue
-				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow)
+				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow, resolver)
 
 				if err != nil {
 					return err
@@ -448,7 +483,15 @@ func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows
 
 					addConflict(conflictValChan, key, conflictTuple)
 				} else {
-					err = applyChange(ctx, tblEdit, rows, sch, stats, types.ValueChanged{ChangeType: change.ChangeType, Key: key, OldValue: r, NewValue: mergedRow})
+					// a merge strategy may keep a row that was deleted on our side or delete a row we modified
+					changeType := change.ChangeType
+					if mergedRow == nil {
+						changeType = types.DiffChangeRemoved
+					} else if r == nil {
+						changeType = types.DiffChangeAdded
+					}
+
+					err = applyChange(ctx, tblEdit, rows, sch, stats, types.ValueChanged{ChangeType: changeType, Key: key, OldValue: r, NewValue: mergedRow})
 					if err != nil {
 						return err
 					}
@@ -486,9 +529,95 @@ func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows
 		return nil, types.EmptyMap, nil, fmt.Errorf("updated mergedTable `%s` has disappeared", tblName)
 	}
 
+	stats.AutoResolutions = resolver.autoResolutions()
+
 	return mergedTable, conflicts, stats, nil
 }
 
+// mergeKeylessTableData merges the rows of a keyless table. Rows of a keyless table are identified by their values,
+// so the changes on both sides of the merge never conflict. The number of copies of each row in the merged table is
+// the number in our table, plus the number added or minus the number removed in their table.
+func mergeKeylessTableData(ctx context.Context, tblName string, sch schema.Schema, mergeRows, ancRows types.Map, tblEdit *doltdb.SessionedTableEditor) (*doltdb.Table, *MergeStats, error) {
+	ae := atomicerr.New()
+	mergeChangeChan := make(chan types.ValueChanged, 32)
+	mergeStopChan := make(chan struct{}, 1)
+
+	go func() {
+		mergeRows.Diff(ctx, ancRows, ae, mergeChangeChan, mergeStopChan)
+		close(mergeChangeChan)
+	}()
+
+	defer stopAndDrain(mergeStopChan, mergeChangeChan)
+
+	stats := &MergeStats{Operation: TableModified}
+	for change := range mergeChangeChan {
+		if ae.IsSet() {
+			break
+		}
+
+		var ancRow, mergeRow row.Row
+		var err error
+		if change.OldValue != nil {
+			ancRow, err = row.FromNoms(sch, change.Key.(types.Tuple), change.OldValue.(types.Tuple))
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if change.NewValue != nil {
+			mergeRow, err = row.FromNoms(sch, change.Key.(types.Tuple), change.NewValue.(types.Tuple))
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		switch {
+		case ancRow == nil:
+			err = tblEdit.InsertRow(ctx, mergeRow)
+		case mergeRow == nil:
+			err = tblEdit.DeleteRow(ctx, ancRow)
+		default:
+			err = tblEdit.UpdateRow(ctx, ancRow, mergeRow)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ancCard, mergeCard := uint64(0), uint64(0)
+		if ancRow != nil {
+			ancCard = row.Cardinality(ancRow)
+		}
+		if mergeRow != nil {
+			mergeCard = row.Cardinality(mergeRow)
+		}
+
+		if mergeCard > ancCard {
+			stats.Adds += int(mergeCard - ancCard)
+		} else {
+			stats.Deletes += int(ancCard - mergeCard)
+		}
+	}
+
+	if err := ae.Get(); err != nil {
+		return nil, nil, err
+	}
+
+	newRoot, err := tblEdit.Flush(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mergedTable, ok, err := newRoot.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("updated mergedTable `%s` has disappeared", tblName)
+	}
+
+	return mergedTable, stats, nil
+}
+
 func addConflict(conflictChan chan types.Value, key types.Value, value types.Tuple) {
 	conflictChan <- key
 	conflictChan <- value
@@ -545,7 +674,9 @@ func applyChange(ctx context.Context, tableEditor *doltdb.SessionedTableEditor,
 	return nil
 }
 
-func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+// rowMerge merges the changes made to a row on both sides of a merge. Conflicting changes to the same cell are
+// resolved using the merge strategies of |resolver|, which may be nil.
+func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value, resolver *cellResolver) (types.Value, bool, error) {
 	var baseVals row.TaggedValues
 	if baseRow == nil {
 		if r.Equals(mergeRow) {
@@ -557,6 +688,10 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 		return nil, false, nil
 	} else if r == nil || mergeRow == nil {
 		// removed from one and modified in another
+		if resolved, ok := resolver.resolveRow(r, mergeRow); ok {
+			return resolved, false, nil
+		}
+
 		return nil, true, nil
 	} else {
 		var err error
@@ -579,24 +714,48 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 		return nil, false, err
 	}
 
-	processTagFunc := func(tag uint64) (resultVal types.Value, isConflict bool) {
+	// when the row was added on both sides every differing value is a change made by both, so the strategy of the
+	// column decides between them even when one side left the value null
+	rowAdded := baseRow == nil
+
+	var resolved []tagStrategy
+	processTagFunc := func(col schema.Column) (resultVal types.Value, isConflict bool, err error) {
+		tag := col.Tag
 		baseVal, _ := baseVals.Get(tag)
 		val, _ := rowVals.Get(tag)
 		mergeVal, _ := mergeVals.Get(tag)
 
 		if valutil.NilSafeEqCheck(val, mergeVal) {
-			return val, false
+			return val, false, nil
 		} else {
 			modified := !valutil.NilSafeEqCheck(val, baseVal)
 			mergeModified := !valutil.NilSafeEqCheck(mergeVal, baseVal)
-			switch {
-			case modified && mergeModified:
-				return nil, true
-			case modified:
-				return val, false
-			default:
-				return mergeVal, false
+
+			if modified && mergeModified && col.TypeInfo.GetTypeIdentifier() == typeinfo.JSONTypeIdentifier {
+				mergedVal, ok, err := mergeJSONCell(ctx, baseVal, val, mergeVal)
+				if err != nil || ok {
+					return mergedVal, false, err
+				}
+			}
+
+			if (modified && mergeModified) || rowAdded {
+				resolvedVal, ts, ok, err := resolver.resolveCell(nbf, col, baseVal, val, mergeVal, rowVals, mergeVals)
+
+				if err != nil {
+					return nil, true, err
+				} else if ok {
+					resolved = append(resolved, ts)
+					return resolvedVal, false, nil
+				} else if modified && mergeModified {
+					return nil, true, nil
+				}
 			}
+
+			if modified {
+				return val, false, nil
+			}
+
+			return mergeVal, false, nil
 		}
 
 	}
@@ -604,12 +763,12 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 	resultVals := make(row.TaggedValues)
 
 	var isConflict bool
-	err = sch.GetNonPKCols().Iter(func(tag uint64, _ schema.Column) (stop bool, err error) {
+	err = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		var val types.Value
-		val, isConflict = processTagFunc(tag)
+		val, isConflict, err = processTagFunc(col)
 		resultVals[tag] = val
 
-		return isConflict, nil
+		return isConflict, err
 	})
 
 	if err != nil {
@@ -620,6 +779,10 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 		return nil, true, nil
 	}
 
+	if len(resolved) > 0 {
+		resolver.record(resolved...)
+	}
+
 	tpl := resultVals.NomsTupleForNonPKCols(nbf, sch.GetNonPKCols())
 	v, err := tpl.Value(ctx)
 
@@ -655,7 +818,13 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit *
 		return nil, nil, err
 	}
 
-	merger := NewMerger(ctx, root, mergeRoot, ancRoot, ddb.ValueReadWriter())
+	return MergeRoots(ctx, root, mergeRoot, ancRoot, ddb.ValueReadWriter())
+}
+
+// MergeRoots performs a three-way merge of the changes between |ancRoot| and |mergeRoot| into |root|.  Conflicts are
+// written into the conflict structures of the returned root's tables, and are counted in the returned stats.
+func MergeRoots(ctx context.Context, root, mergeRoot, ancRoot *doltdb.RootValue, vrw types.ValueReadWriter) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	merger := NewMerger(ctx, root, mergeRoot, ancRoot, vrw)
 
 	tblNames, err := doltdb.UnionTableNames(ctx, root, mergeRoot)
 
@@ -668,6 +837,7 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit *
 	newRoot := root
 	tableEditSession := doltdb.CreateTableEditSession(root, doltdb.TableEditSessionProps{
 		ForeignKeyChecksDisabled: true,
+		CheckConstraintsDisabled: true,
 	})
 	var unconflicted []string
 	// need to validate merges can be done on all tables before starting the actual merges.
@@ -706,8 +876,6 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit *
 			if err != nil {
 				return nil, nil, err
 			}
-		} else {
-			panic("?")
 		}
 	}
 
@@ -716,6 +884,11 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit *
 		return nil, nil, err
 	}
 
+	err = tableEditSession.ValidateCheckConstraints(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	newRoot, err = newRoot.UpdateSuperSchemasFromOther(ctx, unconflicted, mergeRoot)
 
 	if err != nil {
@@ -29,4 +29,6 @@ type MergeStats struct {
 	Deletes       int
 	Modifications int
 	Conflicts     int
+	// AutoResolutions are the conflicts that were resolved automatically using merge strategies
+	AutoResolutions []AutoResolution
 }
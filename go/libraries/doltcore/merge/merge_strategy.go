@@ -0,0 +1,558 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/valutil"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// Strategy is the name of a strategy used to automatically resolve conflicting changes made to the same cell by both
+// sides of a merge.
+type Strategy string
+
+const (
+	// StrategyOurs resolves conflicts using the value of the current branch
+	StrategyOurs Strategy = "ours"
+	// StrategyTheirs resolves conflicts using the value of the branch being merged
+	StrategyTheirs Strategy = "theirs"
+	// StrategyMax resolves conflicts using the larger of the two values
+	StrategyMax Strategy = "max"
+	// StrategyMin resolves conflicts using the smaller of the two values
+	StrategyMin Strategy = "min"
+	// StrategyLatest resolves conflicts using the value of the row with the latest value in a designated timestamp column
+	StrategyLatest Strategy = "latest"
+	// StrategySum resolves conflicts by applying the changes of both sides to the ancestor's value, as is needed for
+	// counters
+	StrategySum Strategy = "sum"
+)
+
+// Strategies is the list of all supported merge strategies
+var Strategies = []Strategy{StrategyOurs, StrategyTheirs, StrategyMax, StrategyMin, StrategyLatest, StrategySum}
+
+var ErrInvalidStrategy = errors.New("invalid merge strategy")
+var ErrTimestampColumnRequired = errors.New("the latest merge strategy requires a timestamp column")
+var ErrStrategyNotFound = errors.New("merge strategy not found")
+
+// ParseStrategy returns the Strategy with the given name.
+func ParseStrategy(str string) (Strategy, error) {
+	strategy := Strategy(strings.ToLower(strings.TrimSpace(str)))
+
+	for _, s := range Strategies {
+		if s == strategy {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: '%s'", ErrInvalidStrategy, str)
+}
+
+// StrategyDecl is a merge strategy declared for a table or one of its columns in the dolt_merge_strategies table.
+type StrategyDecl struct {
+	// TableName is the name of the table the strategy applies to
+	TableName string
+	// ColumnName is the name of the column the strategy applies to. If empty, the strategy applies to every column of
+	// the table which does not have a strategy of its own, and to rows deleted on one side and modified on the other.
+	ColumnName string
+	// Strategy is the strategy used to resolve conflicts
+	Strategy Strategy
+	// TimestampColumn is the name of the column used to order the changes of both sides by the latest strategy
+	TimestampColumn string
+}
+
+var mergeStrategiesCols, _ = schema.NewColCollection(
+	// MergeStrategiesTableCol is the name of the table the strategy applies to
+	schema.NewColumn(doltdb.MergeStrategiesTableCol, doltdb.MergeStrategiesTableTag, types.StringKind, true, schema.NotNullConstraint{}),
+	// MergeStrategiesColumnCol is the name of the column the strategy applies to, or empty for the whole table
+	schema.NewColumn(doltdb.MergeStrategiesColumnCol, doltdb.MergeStrategiesColumnTag, types.StringKind, true, schema.NotNullConstraint{}),
+	// MergeStrategiesStrategyCol is the name of the strategy
+	schema.NewColumn(doltdb.MergeStrategiesStrategyCol, doltdb.MergeStrategiesStrategyTag, types.StringKind, false, schema.NotNullConstraint{}),
+	// MergeStrategiesTimestampCol is the column used by the latest strategy
+	schema.NewColumn(doltdb.MergeStrategiesTimestampCol, doltdb.MergeStrategiesTimestampTag, types.StringKind, false),
+)
+
+// MergeStrategiesSchema is the schema of the dolt_merge_strategies table
+var MergeStrategiesSchema = schema.SchemaFromCols(mergeStrategiesCols)
+
+// GetStrategyDecls returns the merge strategies declared in the dolt_merge_strategies table of |root|, ordered by table
+// and column name.
+func GetStrategyDecls(ctx context.Context, root *doltdb.RootValue) ([]StrategyDecl, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.MergeStrategiesTableName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var decls []StrategyDecl
+	err = rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(MergeStrategiesSchema, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		decl := StrategyDecl{
+			TableName:       getStringColVal(r, doltdb.MergeStrategiesTableTag),
+			ColumnName:      getStringColVal(r, doltdb.MergeStrategiesColumnTag),
+			Strategy:        Strategy(getStringColVal(r, doltdb.MergeStrategiesStrategyTag)),
+			TimestampColumn: getStringColVal(r, doltdb.MergeStrategiesTimestampTag),
+		}
+
+		decl.Strategy, err = ParseStrategy(string(decl.Strategy))
+
+		if err != nil {
+			return fmt.Errorf("merge strategy declared for table '%s' is not valid: %w", decl.TableName, err)
+		}
+
+		decls = append(decls, decl)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(decls, func(i, j int) bool {
+		if decls[i].TableName != decls[j].TableName {
+			return decls[i].TableName < decls[j].TableName
+		}
+
+		return decls[i].ColumnName < decls[j].ColumnName
+	})
+
+	return decls, nil
+}
+
+func getStringColVal(r row.Row, tag uint64) string {
+	val, ok := r.GetColVal(tag)
+
+	if !ok || types.IsNull(val) {
+		return ""
+	}
+
+	return string(val.(types.String))
+}
+
+// SetStrategy declares |decl| in the dolt_merge_strategies table of |root|, creating the table if it does not exist.
+// Any strategy previously declared for the same table and column is replaced. The new root value is returned.
+func SetStrategy(ctx context.Context, root *doltdb.RootValue, decl StrategyDecl) (*doltdb.RootValue, error) {
+	var err error
+	decl.Strategy, err = ParseStrategy(string(decl.Strategy))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if decl.Strategy == StrategyLatest && decl.TimestampColumn == "" {
+		return nil, ErrTimestampColumnRequired
+	}
+
+	tbl, ok, err := root.GetTable(ctx, doltdb.MergeStrategiesTableName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		root, err = root.CreateEmptyTable(ctx, doltdb.MergeStrategiesTableName, MergeStrategiesSchema)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tbl, _, err = root.GetTable(ctx, doltdb.MergeStrategiesTableName)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	taggedVals := row.TaggedValues{
+		doltdb.MergeStrategiesTableTag:    types.String(decl.TableName),
+		doltdb.MergeStrategiesColumnTag:   types.String(decl.ColumnName),
+		doltdb.MergeStrategiesStrategyTag: types.String(decl.Strategy),
+	}
+
+	if decl.TimestampColumn != "" {
+		taggedVals[doltdb.MergeStrategiesTimestampTag] = types.String(decl.TimestampColumn)
+	}
+
+	r, err := row.New(root.VRW().Format(), MergeStrategiesSchema, taggedVals)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	me := rowData.Edit()
+	me.Set(r.NomsMapKey(MergeStrategiesSchema), r.NomsMapValue(MergeStrategiesSchema))
+
+	return putStrategiesRowData(ctx, root, tbl, me)
+}
+
+// RemoveStrategy removes the strategy declared for |tableName| and |columnName| from the dolt_merge_strategies table
+// of |root| and returns the new root value. ErrStrategyNotFound is returned if no such strategy was declared.
+func RemoveStrategy(ctx context.Context, root *doltdb.RootValue, tableName, columnName string) (*doltdb.RootValue, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.MergeStrategiesTableName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrStrategyNotFound
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := types.NewTuple(root.VRW().Format(),
+		types.Uint(doltdb.MergeStrategiesTableTag), types.String(tableName),
+		types.Uint(doltdb.MergeStrategiesColumnTag), types.String(columnName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if has, err := rowData.Has(ctx, key); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrStrategyNotFound
+	}
+
+	me := rowData.Edit()
+	me.Remove(key)
+
+	return putStrategiesRowData(ctx, root, tbl, me)
+}
+
+func putStrategiesRowData(ctx context.Context, root *doltdb.RootValue, tbl *doltdb.Table, me *types.MapEditor) (*doltdb.RootValue, error) {
+	updatedRows, err := me.Map(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err = tbl.UpdateRows(ctx, updatedRows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return root.PutTable(ctx, doltdb.MergeStrategiesTableName, tbl)
+}
+
+// AutoResolution counts the conflicts in a column of a table which were resolved automatically by a merge strategy.
+type AutoResolution struct {
+	// Column is the name of the column whose conflicts were resolved. It is empty for rows that were deleted on one
+	// side of the merge and modified on the other.
+	Column string
+	// Strategy is the strategy used to resolve the conflicts
+	Strategy Strategy
+	// Count is the number of conflicts resolved
+	Count int
+}
+
+type tagStrategy struct {
+	colName  string
+	strategy Strategy
+	tsTag    uint64
+}
+
+// cellResolver resolves conflicting changes to the cells of a table using the merge strategies declared for it, and
+// keeps count of the conflicts it resolved.
+type cellResolver struct {
+	tblStrategy   *tagStrategy
+	tagStrategies map[uint64]tagStrategy
+	counts        map[AutoResolution]int
+}
+
+// newCellResolver returns a cellResolver for the strategies declared for |tblName|, or nil if there are none.
+// Strategies declared for columns which do not exist in |sch| are ignored.
+func newCellResolver(decls []StrategyDecl, tblName string, sch schema.Schema) (*cellResolver, error) {
+	var cr *cellResolver
+	for _, decl := range decls {
+		if decl.TableName != tblName {
+			continue
+		}
+
+		ts := tagStrategy{colName: decl.ColumnName, strategy: decl.Strategy}
+		if decl.Strategy == StrategyLatest {
+			tsCol, ok := sch.GetAllCols().GetByName(decl.TimestampColumn)
+
+			if !ok {
+				return nil, fmt.Errorf("timestamp column '%s' of the merge strategy declared for table '%s' does not exist", decl.TimestampColumn, tblName)
+			}
+
+			ts.tsTag = tsCol.Tag
+		}
+
+		if cr == nil {
+			cr = &cellResolver{tagStrategies: make(map[uint64]tagStrategy), counts: make(map[AutoResolution]int)}
+		}
+
+		if decl.ColumnName == "" {
+			cr.tblStrategy = &ts
+		} else if col, ok := sch.GetNonPKCols().GetByName(decl.ColumnName); ok {
+			cr.tagStrategies[col.Tag] = ts
+		}
+	}
+
+	return cr, nil
+}
+
+func (cr *cellResolver) strategyFor(tag uint64) (tagStrategy, bool) {
+	if cr == nil {
+		return tagStrategy{}, false
+	}
+
+	if ts, ok := cr.tagStrategies[tag]; ok {
+		return ts, true
+	} else if cr.tblStrategy != nil {
+		return *cr.tblStrategy, true
+	}
+
+	return tagStrategy{}, false
+}
+
+// resolveRow resolves a row which was deleted on one side of the merge and modified on the other using the strategy
+// declared for the whole table. Only the ours and theirs strategies can resolve such conflicts.
+func (cr *cellResolver) resolveRow(r, mergeRow types.Value) (types.Value, bool) {
+	if cr == nil || cr.tblStrategy == nil {
+		return nil, false
+	}
+
+	switch cr.tblStrategy.strategy {
+	case StrategyOurs:
+		cr.record(*cr.tblStrategy)
+		return r, true
+	case StrategyTheirs:
+		cr.record(*cr.tblStrategy)
+		return mergeRow, true
+	}
+
+	return nil, false
+}
+
+// resolveCell resolves conflicting changes to the cell of the column |col|. |rowVals| and |mergeVals| are the values of
+// the conflicting rows, which are used by the latest strategy. The strategy used is returned along with the resolved
+// value, and false is returned if the conflict could not be resolved, which includes resolving it to a value that does
+// not fit the type of the column.
+func (cr *cellResolver) resolveCell(nbf *types.NomsBinFormat, col schema.Column, baseVal, val, mergeVal types.Value, rowVals, mergeVals row.TaggedValues) (types.Value, tagStrategy, bool, error) {
+	ts, ok := cr.strategyFor(col.Tag)
+
+	if !ok {
+		return nil, tagStrategy{}, false, nil
+	}
+
+	// strategies declared for the whole table are reported for the column they were applied to
+	ts.colName = col.Name
+
+	resolved, ok, err := applyStrategy(nbf, ts, baseVal, val, mergeVal, rowVals, mergeVals)
+
+	if err != nil {
+		return nil, tagStrategy{}, false, err
+	} else if !ok || (!types.IsNull(resolved) && !col.TypeInfo.IsValid(resolved)) {
+		return nil, tagStrategy{}, false, nil
+	}
+
+	return resolved, ts, true, nil
+}
+
+// applyStrategy returns the value of a conflicting cell resolved with the strategy |ts|, or false if the strategy
+// cannot resolve the conflict.
+func applyStrategy(nbf *types.NomsBinFormat, ts tagStrategy, baseVal, val, mergeVal types.Value, rowVals, mergeVals row.TaggedValues) (types.Value, bool, error) {
+	switch ts.strategy {
+	case StrategyOurs:
+		return val, true, nil
+
+	case StrategyTheirs:
+		return mergeVal, true, nil
+
+	case StrategyMax, StrategyMin:
+		if types.IsNull(val) {
+			return mergeVal, true, nil
+		} else if types.IsNull(mergeVal) {
+			return val, true, nil
+		}
+
+		less, err := val.Less(nbf, mergeVal)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if less == (ts.strategy == StrategyMax) {
+			return mergeVal, true, nil
+		}
+
+		return val, true, nil
+
+	case StrategyLatest:
+		tsVal, _ := rowVals.Get(ts.tsTag)
+		mergeTsVal, _ := mergeVals.Get(ts.tsTag)
+
+		if valutil.NilSafeEqCheck(tsVal, mergeTsVal) {
+			return nil, false, nil
+		} else if types.IsNull(tsVal) {
+			return mergeVal, true, nil
+		} else if types.IsNull(mergeTsVal) {
+			return val, true, nil
+		}
+
+		less, err := tsVal.Less(nbf, mergeTsVal)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if less {
+			return mergeVal, true, nil
+		}
+
+		return val, true, nil
+
+	case StrategySum:
+		sum, ok := sumDeltas(baseVal, val, mergeVal)
+		return sum, ok, nil
+	}
+
+	return nil, false, nil
+}
+
+// sumDeltas returns the result of applying the changes from |baseVal| to both |val| and |mergeVal| to |baseVal|. A
+// missing |baseVal| counts as zero. False is returned if the values are not numbers of the same kind, or if the result
+// overflows.
+func sumDeltas(baseVal, val, mergeVal types.Value) (types.Value, bool) {
+	if types.IsNull(val) || types.IsNull(mergeVal) || val.Kind() != mergeVal.Kind() {
+		return nil, false
+	}
+
+	if !types.IsNull(baseVal) && baseVal.Kind() != val.Kind() {
+		return nil, false
+	}
+
+	switch v := val.(type) {
+	case types.Int:
+		var base types.Int
+		if !types.IsNull(baseVal) {
+			base = baseVal.(types.Int)
+		}
+
+		sum := new(big.Int).SetInt64(int64(v))
+		sum.Add(sum, big.NewInt(int64(mergeVal.(types.Int))))
+		sum.Sub(sum, big.NewInt(int64(base)))
+		if !sum.IsInt64() {
+			return nil, false
+		}
+
+		return types.Int(sum.Int64()), true
+
+	case types.Uint:
+		var base types.Uint
+		if !types.IsNull(baseVal) {
+			base = baseVal.(types.Uint)
+		}
+
+		sum := new(big.Int).SetUint64(uint64(v))
+		sum.Add(sum, new(big.Int).SetUint64(uint64(mergeVal.(types.Uint))))
+		sum.Sub(sum, new(big.Int).SetUint64(uint64(base)))
+		if !sum.IsUint64() {
+			return nil, false
+		}
+
+		return types.Uint(sum.Uint64()), true
+
+	case types.Float:
+		var base types.Float
+		if !types.IsNull(baseVal) {
+			base = baseVal.(types.Float)
+		}
+
+		sum := v + mergeVal.(types.Float) - base
+		if math.IsInf(float64(sum), 0) || math.IsNaN(float64(sum)) {
+			return nil, false
+		}
+
+		return sum, true
+
+	case types.Decimal:
+		base := decimal.Zero
+		if !types.IsNull(baseVal) {
+			base = decimal.Decimal(baseVal.(types.Decimal))
+		}
+
+		sum := decimal.Decimal(v).Add(decimal.Decimal(mergeVal.(types.Decimal))).Sub(base)
+		return types.Decimal(sum), true
+	}
+
+	return nil, false
+}
+
+func (cr *cellResolver) record(resolved ...tagStrategy) {
+	for _, ts := range resolved {
+		cr.counts[AutoResolution{Column: ts.colName, Strategy: ts.strategy}]++
+	}
+}
+
+// autoResolutions returns the conflicts resolved by |cr| ordered by column name.
+func (cr *cellResolver) autoResolutions() []AutoResolution {
+	if cr == nil || len(cr.counts) == 0 {
+		return nil
+	}
+
+	var resolutions []AutoResolution
+	for res, count := range cr.counts {
+		res.Count = count
+		resolutions = append(resolutions, res)
+	}
+
+	sort.Slice(resolutions, func(i, j int) bool {
+		if resolutions[i].Column != resolutions[j].Column {
+			return resolutions[i].Column < resolutions[j].Column
+		}
+
+		return resolutions[i].Strategy < resolutions[j].Strategy
+	})
+
+	return resolutions
+}
@@ -0,0 +1,357 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestParseStrategy(t *testing.T) {
+	for _, s := range Strategies {
+		parsed, err := ParseStrategy(string(s))
+		assert.NoError(t, err)
+		assert.Equal(t, s, parsed)
+	}
+
+	parsed, err := ParseStrategy(" Theirs ")
+	assert.NoError(t, err)
+	assert.Equal(t, StrategyTheirs, parsed)
+
+	_, err = ParseStrategy("newest")
+	assert.True(t, errors.Is(err, ErrInvalidStrategy))
+}
+
+func TestStrategyDecls(t *testing.T) {
+	ctx := context.Background()
+	ddb, _ := doltdb.LoadDoltDB(ctx, types.Format_7_18, doltdb.InMemDoltDB)
+	err := ddb.WriteEmptyRepo(ctx, name, email)
+	require.NoError(t, err)
+
+	cs, _ := doltdb.NewCommitSpec("master")
+	cm, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	root, err := cm.GetRootValue()
+	require.NoError(t, err)
+
+	decls, err := GetStrategyDecls(ctx, root)
+	require.NoError(t, err)
+	assert.Empty(t, decls)
+
+	_, err = SetStrategy(ctx, root, StrategyDecl{TableName: "t", Strategy: StrategyLatest})
+	assert.Equal(t, ErrTimestampColumnRequired, err)
+
+	_, err = SetStrategy(ctx, root, StrategyDecl{TableName: "t", Strategy: "newest"})
+	assert.True(t, errors.Is(err, ErrInvalidStrategy))
+
+	root, err = SetStrategy(ctx, root, StrategyDecl{TableName: "t", ColumnName: "c1", Strategy: "MAX"})
+	require.NoError(t, err)
+	root, err = SetStrategy(ctx, root, StrategyDecl{TableName: "t", Strategy: StrategyOurs})
+	require.NoError(t, err)
+	root, err = SetStrategy(ctx, root, StrategyDecl{TableName: "a", Strategy: StrategyLatest, TimestampColumn: "ts"})
+	require.NoError(t, err)
+	root, err = SetStrategy(ctx, root, StrategyDecl{TableName: "t", Strategy: StrategyTheirs})
+	require.NoError(t, err)
+
+	decls, err = GetStrategyDecls(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, []StrategyDecl{
+		{TableName: "a", Strategy: StrategyLatest, TimestampColumn: "ts"},
+		{TableName: "t", Strategy: StrategyTheirs},
+		{TableName: "t", ColumnName: "c1", Strategy: StrategyMax},
+	}, decls)
+
+	root, err = RemoveStrategy(ctx, root, "t", "")
+	require.NoError(t, err)
+	_, err = RemoveStrategy(ctx, root, "t", "")
+	assert.Equal(t, ErrStrategyNotFound, err)
+
+	decls, err = GetStrategyDecls(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, []StrategyDecl{
+		{TableName: "a", Strategy: StrategyLatest, TimestampColumn: "ts"},
+		{TableName: "t", ColumnName: "c1", Strategy: StrategyMax},
+	}, decls)
+}
+
+func TestRowMergeWithStrategies(t *testing.T) {
+	// columns of the rows created by createRowMergeStruct are named after their tags
+	tests := []struct {
+		RowMergeTest
+		decls       []StrategyDecl
+		resolutions []AutoResolution
+	}{
+		{
+			createRowMergeStruct(
+				"no strategy",
+				[]types.Value{types.Int(2)},
+				[]types.Value{types.Int(3)},
+				[]types.Value{types.Int(1)},
+				nil,
+				true,
+			),
+			[]StrategyDecl{{TableName: "other", Strategy: StrategyOurs}},
+			nil,
+		},
+		{
+			createRowMergeStruct(
+				"ours",
+				[]types.Value{types.String("a"), types.Int(2)},
+				[]types.Value{types.String("b"), types.Int(3)},
+				[]types.Value{types.String("c"), types.Int(1)},
+				[]types.Value{types.String("a"), types.Int(2)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategyOurs}},
+			[]AutoResolution{{"1", StrategyOurs, 1}, {"2", StrategyOurs, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"column strategy overrides table strategy",
+				[]types.Value{types.String("a"), types.Int(2)},
+				[]types.Value{types.String("b"), types.Int(3)},
+				[]types.Value{types.String("c"), types.Int(1)},
+				[]types.Value{types.String("b"), types.Int(2)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategyOurs}, {TableName: "t", ColumnName: "1", Strategy: StrategyTheirs}},
+			[]AutoResolution{{"1", StrategyTheirs, 1}, {"2", StrategyOurs, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"unresolved column is a conflict",
+				[]types.Value{types.String("a"), types.Int(2)},
+				[]types.Value{types.String("b"), types.Int(3)},
+				[]types.Value{types.String("c"), types.Int(1)},
+				nil,
+				true,
+			),
+			[]StrategyDecl{{TableName: "t", ColumnName: "2", Strategy: StrategyMax}},
+			nil,
+		},
+		{
+			createRowMergeStruct(
+				"max and min",
+				[]types.Value{types.Int(2), types.Float(2.5)},
+				[]types.Value{types.Int(3), types.Float(1.5)},
+				[]types.Value{types.Int(1), types.Float(1)},
+				[]types.Value{types.Int(3), types.Float(1.5)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", ColumnName: "1", Strategy: StrategyMax}, {TableName: "t", ColumnName: "2", Strategy: StrategyMin}},
+			[]AutoResolution{{"1", StrategyMax, 1}, {"2", StrategyMin, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"sum of deltas",
+				[]types.Value{types.Int(15), types.Uint(7), types.Float(0.5)},
+				[]types.Value{types.Int(8), types.Uint(4), types.Float(2)},
+				[]types.Value{types.Int(10), types.Uint(5), types.Float(1)},
+				[]types.Value{types.Int(13), types.Uint(6), types.Float(1.5)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategySum}},
+			[]AutoResolution{{"1", StrategySum, 1}, {"2", StrategySum, 1}, {"3", StrategySum, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"sum of deltas of strings is a conflict",
+				[]types.Value{types.String("a")},
+				[]types.Value{types.String("b")},
+				[]types.Value{types.String("c")},
+				nil,
+				true,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategySum}},
+			nil,
+		},
+		{
+			createRowMergeStruct(
+				"latest",
+				[]types.Value{types.String("a"), types.Int(5)},
+				[]types.Value{types.String("b"), types.Int(7)},
+				[]types.Value{types.String("c"), types.Int(1)},
+				[]types.Value{types.String("b"), types.Int(7)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategyLatest, TimestampColumn: "2"}},
+			[]AutoResolution{{"1", StrategyLatest, 1}, {"2", StrategyLatest, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"latest with equal timestamps is a conflict",
+				[]types.Value{types.String("a"), types.Int(5)},
+				[]types.Value{types.String("b"), types.Int(5)},
+				[]types.Value{types.String("c"), types.Int(1)},
+				nil,
+				true,
+			),
+			[]StrategyDecl{{TableName: "t", ColumnName: "1", Strategy: StrategyLatest, TimestampColumn: "2"}},
+			nil,
+		},
+		{
+			createRowMergeStruct(
+				"theirs resolves a row added on both sides",
+				[]types.Value{types.String("a"), types.Int(2)},
+				[]types.Value{types.NullValue, types.Int(3)},
+				nil,
+				[]types.Value{types.NullValue, types.Int(3)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategyTheirs}},
+			[]AutoResolution{{"1", StrategyTheirs, 1}, {"2", StrategyTheirs, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"ours resolves a row added on both sides",
+				[]types.Value{types.String("a"), types.Int(2)},
+				[]types.Value{types.String("b"), types.Int(3)},
+				nil,
+				[]types.Value{types.String("a"), types.Int(2)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategyOurs}},
+			[]AutoResolution{{"1", StrategyOurs, 1}, {"2", StrategyOurs, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"column strategy for a row added on both sides",
+				[]types.Value{types.String("a"), types.Int(2)},
+				[]types.Value{types.NullValue, types.Int(3)},
+				nil,
+				[]types.Value{types.String("a"), types.Int(3)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", ColumnName: "2", Strategy: StrategyMax}},
+			[]AutoResolution{{"2", StrategyMax, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"sum of a row added on both sides",
+				[]types.Value{types.Int(2)},
+				[]types.Value{types.Int(3)},
+				nil,
+				[]types.Value{types.Int(5)},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategySum}},
+			[]AutoResolution{{"1", StrategySum, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"theirs resolves a delete and modify",
+				nil,
+				[]types.Value{types.String("b")},
+				[]types.Value{types.String("c")},
+				[]types.Value{types.String("b")},
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategyTheirs}},
+			[]AutoResolution{{"", StrategyTheirs, 1}},
+		},
+		{
+			createRowMergeStruct(
+				"ours resolves a delete and modify",
+				nil,
+				[]types.Value{types.String("b")},
+				[]types.Value{types.String("c")},
+				nil,
+				false,
+			),
+			[]StrategyDecl{{TableName: "t", Strategy: StrategyOurs}},
+			[]AutoResolution{{"", StrategyOurs, 1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver, err := newCellResolver(test.decls, "t", test.sch)
+			require.NoError(t, err)
+
+			actualResult, isConflict, err := rowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow, resolver)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResult, actualResult, "expected "+mustString(types.EncodedValue(context.Background(), test.expectedResult))+"got "+mustString(types.EncodedValue(context.Background(), actualResult)))
+			assert.Equal(t, test.expectConflict, isConflict)
+			assert.Equal(t, test.resolutions, resolver.autoResolutions())
+		})
+	}
+}
+
+func TestResolveCellChecksColumnType(t *testing.T) {
+	decimalTi, err := typeinfo.FromSqlType(sql.MustCreateDecimalType(4, 2))
+	require.NoError(t, err)
+	tinyIntCol, err := schema.NewColumnWithTypeInfo("tiny", 1, typeinfo.Int8Type, false)
+	require.NoError(t, err)
+	bigIntCol, err := schema.NewColumnWithTypeInfo("big", 2, typeinfo.Int64Type, false)
+	require.NoError(t, err)
+	unsignedCol, err := schema.NewColumnWithTypeInfo("unsigned", 3, typeinfo.Uint64Type, false)
+	require.NoError(t, err)
+	decimalCol, err := schema.NewColumnWithTypeInfo("dec", 4, decimalTi, false)
+	require.NoError(t, err)
+	colColl, err := schema.NewColCollection(tinyIntCol, bigIntCol, unsignedCol, decimalCol)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	newDecimal := func(str string) types.Decimal {
+		return types.Decimal(decimal.RequireFromString(str))
+	}
+
+	tests := []struct {
+		name                   string
+		strategy               Strategy
+		col                    schema.Column
+		baseVal, val, mergeVal types.Value
+		expected               types.Value
+	}{
+		{"tinyint sum", StrategySum, tinyIntCol, types.Int(50), types.Int(100), types.Int(70), types.Int(120)},
+		{"tinyint sum out of range", StrategySum, tinyIntCol, types.Int(50), types.Int(100), types.Int(150), nil},
+		{"bigint sum overflow", StrategySum, bigIntCol, types.Int(0), types.Int(math.MaxInt64), types.Int(1), nil},
+		{"bigint sum underflow", StrategySum, bigIntCol, types.Int(1), types.Int(math.MinInt64), types.Int(0), nil},
+		{"unsigned sum overflow", StrategySum, unsignedCol, types.Uint(0), types.Uint(math.MaxUint64), types.Uint(1), nil},
+		{"unsigned sum below zero", StrategySum, unsignedCol, types.Uint(5), types.Uint(1), types.Uint(1), nil},
+		{"unsigned sum with a large ancestor", StrategySum, unsignedCol, types.Uint(math.MaxUint64 - 1), types.Uint(math.MaxUint64), types.Uint(math.MaxUint64 - 1), types.Uint(math.MaxUint64)},
+		{"decimal sum", StrategySum, decimalCol, newDecimal("1"), newDecimal("50.25"), newDecimal("40"), newDecimal("89.25")},
+		{"decimal sum exceeds precision", StrategySum, decimalCol, newDecimal("1"), newDecimal("90"), newDecimal("20"), nil},
+		{"tinyint max", StrategyMax, tinyIntCol, types.Int(1), types.Int(2), types.Int(3), types.Int(3)},
+		{"tinyint max out of range", StrategyMax, tinyIntCol, types.Int(1), types.Int(2), types.Int(200), nil},
+		{"tinyint min out of range", StrategyMin, tinyIntCol, types.Int(1), types.Int(-200), types.Int(3), nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver, err := newCellResolver([]StrategyDecl{{TableName: "t", Strategy: test.strategy}}, "t", sch)
+			require.NoError(t, err)
+
+			resolved, _, ok, err := resolver.resolveCell(types.Format_7_18, test.col, test.baseVal, test.val, test.mergeVal, nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected != nil, ok)
+			if test.expected != nil {
+				assert.True(t, test.expected.Equals(resolved), "expected %v, got %v", test.expected, resolved)
+			}
+		})
+	}
+}
@@ -61,6 +61,12 @@ const (
 
 	// SqlFile is the format of a data location that is a .sql file
 	SqlFile DataFormat = ".sql"
+
+	// ParquetFile is the format of a data location that is a .parquet file
+	ParquetFile DataFormat = ".parquet"
+
+	// JsonlFile is the format of a data location that is a JSON Lines file, with a JSON object on each line
+	JsonlFile DataFormat = ".jsonl"
 )
 
 // ReadableStr returns a human readable string for a DataFormat
@@ -78,6 +84,10 @@ func (df DataFormat) ReadableStr() string {
 		return "json file"
 	case SqlFile:
 		return "sql file"
+	case ParquetFile:
+		return "parquet file"
+	case JsonlFile:
+		return "jsonl file"
 	default:
 		return "invalid"
 	}
@@ -130,6 +140,10 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 				dataFmt = JsonFile
 			case string(SqlFile):
 				dataFmt = SqlFile
+			case string(ParquetFile):
+				dataFmt = ParquetFile
+			case string(JsonlFile), ".ndjson":
+				dataFmt = JsonlFile
 			}
 		}
 	}
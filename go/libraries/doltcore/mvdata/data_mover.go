@@ -30,6 +30,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 type CsvOptions struct {
@@ -45,6 +46,14 @@ type JSONOptions struct {
 	SchFile   string
 }
 
+// JSONLOptions are the options for reading JSON Lines. If SchFile is given the rows are read with the schema it
+// defines. Otherwise, if TableName names an existing table the rows are read with its schema, and if not they are read
+// untyped, which is only possible for files.
+type JSONLOptions struct {
+	TableName string
+	SchFile   string
+}
+
 type DataMoverOptions interface {
 	WritesToTable() bool
 	SrcName() string
@@ -183,14 +192,15 @@ func MoveData(ctx context.Context, dEnv *env.DoltEnv, mover *DataMover, mvOpts D
 }
 
 // NameMapTransform creates a pipeline transform that converts rows from inSch to outSch based on a name mapping.
-func NameMapTransform(inSch schema.Schema, outSch schema.Schema, mapper rowconv.NameMapper) (*pipeline.TransformCollection, error) {
+// Converted values which are stored out of row are written to the ValueReadWriter given.
+func NameMapTransform(ctx context.Context, vrw types.ValueReadWriter, inSch schema.Schema, outSch schema.Schema, mapper rowconv.NameMapper) (*pipeline.TransformCollection, error) {
 	mapping, err := rowconv.NameMapping(inSch, outSch, mapper)
 
 	if err != nil {
 		return nil, err
 	}
 
-	rconv, err := rowconv.NewImportRowConverter(mapping)
+	rconv, err := rowconv.NewImportRowConverter(ctx, vrw, mapping)
 
 	if err != nil {
 		return nil, err
@@ -205,6 +215,26 @@ func NameMapTransform(inSch schema.Schema, outSch schema.Schema, mapper rowconv.
 	return transforms, nil
 }
 
+// jsonlReaderSchema returns the schema with which JSON Lines are read given the options, or nil if they are read untyped.
+func jsonlReaderSchema(ctx context.Context, root *doltdb.RootValue, fs filesys.ReadableFS, opts interface{}) (schema.Schema, error) {
+	jsonlOpts, _ := opts.(JSONLOptions)
+	if jsonlOpts.SchFile != "" {
+		_, sch, err := SchAndTableNameFromFile(ctx, jsonlOpts.SchFile, fs, root)
+		return sch, err
+	}
+
+	if jsonlOpts.TableName == "" {
+		return nil, nil
+	}
+
+	tbl, exists, err := root.GetTable(ctx, jsonlOpts.TableName)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return tbl.GetSchema(ctx)
+}
+
 // SchAndTableNameFromFile reads a SQL schema file and creates a Dolt schema from it.
 func SchAndTableNameFromFile(ctx context.Context, path string, fs filesys.ReadableFS, root *doltdb.RootValue) (string, schema.Schema, error) {
 	if path != "" {
@@ -28,6 +28,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/noms"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/sqlexport"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/xlsx"
@@ -47,6 +48,10 @@ func DFFromString(dfStr string) DataFormat {
 		return JsonFile
 	case "sql", ".sql":
 		return SqlFile
+	case "parquet", ".parquet":
+		return ParquetFile
+	case "jsonl", ".jsonl", "ndjson", ".ndjson":
+		return JsonlFile
 	default:
 		return InvalidDataFormat
 	}
@@ -136,7 +141,20 @@ func (dl FileDataLocation) NewReader(ctx context.Context, root *doltdb.RootValue
 			}
 		}
 
-		rd, err := json.OpenJSONReader(root.VRW().Format(), dl.Path, fs, sch)
+		rd, err := json.OpenJSONReader(root.VRW(), dl.Path, fs, sch)
+		return rd, false, err
+
+	case ParquetFile:
+		rd, err := parquet.OpenParquetReader(root.VRW(), dl.Path, fs)
+		return rd, false, err
+
+	case JsonlFile:
+		sch, err := jsonlReaderSchema(ctx, root, fs, opts)
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := json.OpenJSONLReader(root.VRW(), dl.Path, fs, sch)
 		return rd, false, err
 	}
 
@@ -155,6 +173,10 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMov
 		panic("writing to xlsx files is not supported yet")
 	case JsonFile:
 		return json.OpenJSONWriter(dl.Path, fs, outSch)
+	case ParquetFile:
+		return parquet.OpenParquetWriter(dl.Path, fs, outSch)
+	case JsonlFile:
+		return json.OpenJSONLWriter(dl.Path, fs, outSch)
 	case SqlFile:
 		fkc, err := root.GetForeignKeyCollection(ctx)
 		if err != nil {
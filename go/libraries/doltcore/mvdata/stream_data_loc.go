@@ -23,6 +23,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
@@ -67,6 +68,17 @@ func (dl StreamDataLocation) NewReader(ctx context.Context, root *doltdb.RootVal
 	case PsvFile:
 		rd, err := csv.NewCSVReader(root.VRW().Format(), ioutil.NopCloser(dl.Reader), csv.NewCSVInfo().SetDelim("|"))
 		return rd, false, err
+
+	case JsonlFile:
+		sch, err := jsonlReaderSchema(ctx, root, fs, opts)
+		if err != nil {
+			return nil, false, err
+		} else if sch == nil {
+			return nil, false, errors.New("a schema file or an existing table is required to read jsonl from stdin")
+		}
+
+		rd, err := json.NewJSONLReader(root.VRW(), ioutil.NopCloser(dl.Reader), sch)
+		return rd, false, err
 	}
 
 	return nil, false, errors.New(string(dl.Format) + "is an unsupported format to read from stdin")
@@ -81,6 +93,9 @@ func (dl StreamDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataM
 
 	case PsvFile:
 		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, csv.NewCSVInfo().SetDelim("|"))
+
+	case JsonlFile:
+		return json.NewJSONLWriter(iohelp.NopWrCloser(dl.Writer), outSch)
 	}
 
 	return nil, errors.New(string(dl.Format) + "is an unsupported format to write to stdout")
@@ -33,9 +33,6 @@ import (
 // TableDataLocationUpdateRate is the number of writes that will process before the updated stats are displayed.
 const TableDataLocationUpdateRate = 32768
 
-// ErrNoPK is an error returned if a schema is missing a required primary key
-var ErrNoPK = errors.New("schema does not contain a primary key")
-
 // TableDataLocation is a dolt table that that can be imported from or exported to.
 type TableDataLocation struct {
 	// Name the name of a table
@@ -88,10 +85,6 @@ func (dl TableDataLocation) NewReader(ctx context.Context, root *doltdb.RootValu
 // NewCreatingWriter will create a TableWriteCloser for a DataLocation that will create a new table, or overwrite
 // an existing table.
 func (dl TableDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMoverOptions, root *doltdb.RootValue, fs filesys.WritableFS, sortedInput bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error) {
-	if outSch.GetPKCols().Size() == 0 {
-		return nil, ErrNoPK
-	}
-
 	m, err := types.NewMap(ctx, root.VRW())
 	if err != nil {
 		return nil, err
@@ -125,7 +118,7 @@ func (dl TableDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMo
 }
 
 // NewUpdatingWriter will create a TableWriteCloser for a DataLocation that will update and append rows based on
-// their primary key.
+// their primary key. Rows are always appended to tables without a primary key.
 func (dl TableDataLocation) NewUpdatingWriter(ctx context.Context, mvOpts DataMoverOptions, root *doltdb.RootValue, fs filesys.WritableFS, srcIsSorted bool, outSch schema.Schema, statsCB noms.StatsCB) (table.TableWriteCloser, error) {
 	tbl, ok, err := root.GetTable(ctx, dl.Name)
 	if err != nil {
@@ -150,7 +143,7 @@ func (dl TableDataLocation) NewUpdatingWriter(ctx context.Context, mvOpts DataMo
 	}
 
 	return &tableEditorWriteCloser{
-		insertOnly:  false,
+		insertOnly:  schema.IsKeyless(tblSch),
 		initialData: m,
 		statsCB:     statsCB,
 		tableEditor: tableEditor,
@@ -233,10 +226,24 @@ func (te *tableEditorWriteCloser) WriteRow(ctx context.Context, r row.Row) error
 		atomic.StoreInt64(&te.opsSoFar, 0)
 		te.statsCB(te.stats)
 	}
+
+	err := te.writeRow(ctx, r)
+	if errors.Is(err, doltdb.ErrCheckConstraintViolation) {
+		// like rows violating any other constraint, rows violating a check are bad rows, which may be skipped
+		return table.NewBadRow(r, err.Error())
+	}
+	return err
+}
+
+func (te *tableEditorWriteCloser) writeRow(ctx context.Context, r row.Row) error {
 	if te.insertOnly {
+		err := te.tableEditor.InsertRow(ctx, r)
+		if err != nil {
+			return err
+		}
 		_ = atomic.AddInt64(&te.opsSoFar, 1)
 		te.stats.Additions++
-		return te.tableEditor.InsertRow(ctx, r)
+		return nil
 	} else {
 		pkTuple, err := r.NomsMapKey(te.tableSch).Value(ctx)
 		if err != nil {
@@ -247,9 +254,13 @@ func (te *tableEditorWriteCloser) WriteRow(ctx context.Context, r row.Row) error
 			return err
 		}
 		if !ok {
+			err = te.tableEditor.InsertRow(ctx, r)
+			if err != nil {
+				return err
+			}
 			_ = atomic.AddInt64(&te.opsSoFar, 1)
 			te.stats.Additions++
-			return te.tableEditor.InsertRow(ctx, r)
+			return nil
 		}
 		oldRow, err := row.FromNoms(te.tableSch, pkTuple.(types.Tuple), val.(types.Tuple))
 		if err != nil {
@@ -259,9 +270,13 @@ func (te *tableEditorWriteCloser) WriteRow(ctx context.Context, r row.Row) error
 			te.stats.SameVal++
 			return nil
 		}
+		err = te.tableEditor.UpdateRow(ctx, oldRow, r)
+		if err != nil {
+			return err
+		}
 		_ = atomic.AddInt64(&te.opsSoFar, 1)
 		te.stats.Modifications++
-		return te.tableEditor.UpdateRow(ctx, oldRow, r)
+		return nil
 	}
 }
 
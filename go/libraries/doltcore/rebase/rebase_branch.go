@@ -0,0 +1,295 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// Action is the action taken for a single commit of a branch rebase
+type Action string
+
+const (
+	// Pick replays the commit as is
+	Pick Action = "pick"
+	// Reword replays the commit and allows the user to edit its message
+	Reword Action = "reword"
+	// Squash melds the commit into the previous commit, combining their messages
+	Squash Action = "squash"
+	// Fixup melds the commit into the previous commit, discarding its message
+	Fixup Action = "fixup"
+	// Drop removes the commit
+	Drop Action = "drop"
+)
+
+var actionAbbrevs = map[string]Action{
+	"p": Pick,
+	"r": Reword,
+	"s": Squash,
+	"f": Fixup,
+	"d": Drop,
+}
+
+var ErrInvalidTodoItem = errors.New("invalid rebase todo item")
+var ErrNothingToSquash = errors.New("cannot squash or fixup without a previous commit")
+
+// TodoHelp describes the format of a todo list and is appended to the todo list presented to the user
+const TodoHelp = `
+# Commands:
+# p, pick <commit> = use commit
+# r, reword <commit> = use commit, but edit the commit message
+# s, squash <commit> = use commit, but meld into previous commit
+# f, fixup <commit> = like "squash", but discard this commit's log message
+# d, drop <commit> = remove commit
+#
+# These lines can be re-ordered; they are executed from top to bottom.
+#
+# If you remove a line here THAT COMMIT WILL BE LOST.
+#
+# However, if you remove everything, the rebase will be aborted.
+#
+`
+
+// TodoItem is a single step of a branch rebase
+type TodoItem struct {
+	Action  Action
+	Commit  hash.Hash
+	Summary string
+}
+
+// String returns the todo list line for the item
+func (ti TodoItem) String() string {
+	if ti.Summary == "" {
+		return fmt.Sprintf("%s %s", ti.Action, ti.Commit.String())
+	}
+
+	return fmt.Sprintf("%s %s %s", ti.Action, ti.Commit.String(), ti.Summary)
+}
+
+// ParseTodoItem parses a single line of a todo list
+func ParseTodoItem(line string) (TodoItem, error) {
+	fields := strings.Fields(line)
+
+	if len(fields) < 2 {
+		return TodoItem{}, fmt.Errorf("%w: '%s'", ErrInvalidTodoItem, line)
+	}
+
+	action := Action(strings.ToLower(fields[0]))
+	if abbrev, ok := actionAbbrevs[string(action)]; ok {
+		action = abbrev
+	}
+
+	switch action {
+	case Pick, Reword, Squash, Fixup, Drop:
+	default:
+		return TodoItem{}, fmt.Errorf("%w: unknown action '%s'", ErrInvalidTodoItem, fields[0])
+	}
+
+	h, ok := hash.MaybeParse(fields[1])
+
+	if !ok {
+		return TodoItem{}, fmt.Errorf("%w: invalid commit hash '%s'", ErrInvalidTodoItem, fields[1])
+	}
+
+	return TodoItem{action, h, strings.Join(fields[2:], " ")}, nil
+}
+
+// ParseTodoList parses a todo list as edited by the user, ignoring blank lines and lines starting with '#'
+func ParseTodoList(todo string) ([]TodoItem, error) {
+	var items []TodoItem
+	for _, line := range strings.Split(todo, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		item, err := ParseTodoItem(line)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	for _, item := range items {
+		if item.Action == Drop {
+			continue
+		} else if item.Action == Squash || item.Action == Fixup {
+			return nil, ErrNothingToSquash
+		}
+
+		break
+	}
+
+	return items, nil
+}
+
+// FormatTodoList returns the todo list lines for the items given
+func FormatTodoList(items []TodoItem) []string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = item.String()
+	}
+
+	return lines
+}
+
+// NewTodoList creates a todo list which picks each of the commits given
+func NewTodoList(commits []*doltdb.Commit) ([]TodoItem, error) {
+	items := make([]TodoItem, len(commits))
+	for i, cm := range commits {
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = TodoItem{Pick, h, summary(meta.Description)}
+	}
+
+	return items, nil
+}
+
+func summary(desc string) string {
+	desc = strings.TrimSpace(desc)
+	if i := strings.IndexByte(desc, '\n'); i != -1 {
+		return desc[:i]
+	}
+
+	return desc
+}
+
+// CommitsToRebase returns the commits reachable from |head| that are not reachable from |mergeBase|, the merge base of
+// |head| and the upstream being rebased onto, with parents ordered before their children. Merge commits are not
+// included as the rebased history is linear.
+func CommitsToRebase(ctx context.Context, ddb *doltdb.DoltDB, head, mergeBase *doltdb.Commit) ([]*doltdb.Commit, error) {
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBaseHash, err := mergeBase.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := commitwalk.GetDotDotRevisions(ctx, ddb, headHash, mergeBaseHash, -1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*doltdb.Commit
+	for i := len(revisions) - 1; i >= 0; i-- {
+		numParents, err := revisions[i].NumParents()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if numParents == 1 {
+			commits = append(commits, revisions[i])
+		}
+	}
+
+	return commits, nil
+}
+
+// ReplayCommit replays the changes between |cm| and its parent onto |onto| using a three-way merge, and returns the
+// rebased commit, which keeps the metadata of |cm| and is not referenced by any branch. If the changes conflict with
+// |onto| no commit is written, and the merged root holding the conflicts is returned instead.
+func ReplayCommit(ctx context.Context, ddb *doltdb.DoltDB, cm, onto *doltdb.Commit) (*doltdb.Commit, *doltdb.RootValue, map[string]*merge.MergeStats, error) {
+	cmHash, err := cm.HashOf()
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	parentHash, err := parent.HashOf()
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var mergedRoot *doltdb.RootValue
+	var tblToStats map[string]*merge.MergeStats
+	replay := func(ctx context.Context, root, parentRoot, rebasedParentRoot *doltdb.RootValue) (*doltdb.RootValue, error) {
+		var err error
+		mergedRoot, tblToStats, err = merge.MergeRoots(ctx, rebasedParentRoot, root, parentRoot, ddb.ValueReadWriter())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if hasConflicts(tblToStats) {
+			return nil, errReplayConflicts
+		}
+
+		return mergedRoot, nil
+	}
+
+	// only |cm| is replayed, onto |onto| in place of its parent
+	replayOnly := func(ctx context.Context, c *doltdb.Commit) (bool, error) {
+		h, err := c.HashOf()
+		return h == cmHash, err
+	}
+
+	vs := visitedSet{parentHash: onto}
+	rebasedCm, err := rebaseRecursive(ctx, ddb, replay, replayOnly, vs, cm)
+
+	if err == errReplayConflicts {
+		return nil, mergedRoot, tblToStats, nil
+	} else if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return rebasedCm, nil, tblToStats, nil
+}
+
+var errReplayConflicts = errors.New("conflicts replaying commit")
+
+func hasConflicts(tblToStats map[string]*merge.MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
+			return true
+		}
+	}
+
+	return false
+}
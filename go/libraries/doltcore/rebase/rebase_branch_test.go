@@ -0,0 +1,96 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+func TestParseTodoList(t *testing.T) {
+	h1 := hash.Of([]byte("one"))
+	h2 := hash.Of([]byte("two"))
+	h3 := hash.Of([]byte("three"))
+
+	tests := []struct {
+		name     string
+		todo     string
+		expected []TodoItem
+		expErr   error
+	}{
+		{
+			"empty",
+			"\n# comment\n\n",
+			nil,
+			nil,
+		},
+		{
+			"actions and abbreviations",
+			"pick " + h1.String() + " first commit\n" +
+				"s " + h2.String() + " second\n" +
+				"# comment\n" +
+				"  Drop " + h3.String() + "\n",
+			[]TodoItem{
+				{Pick, h1, "first commit"},
+				{Squash, h2, "second"},
+				{Drop, h3, ""},
+			},
+			nil,
+		},
+		{
+			"squash first",
+			"drop " + h1.String() + "\nfixup " + h2.String() + "\npick " + h3.String(),
+			nil,
+			ErrNothingToSquash,
+		},
+		{
+			"unknown action",
+			"edit " + h1.String(),
+			nil,
+			ErrInvalidTodoItem,
+		},
+		{
+			"invalid hash",
+			"pick abc",
+			nil,
+			ErrInvalidTodoItem,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := ParseTodoList(test.todo)
+
+			if test.expErr != nil {
+				assert.True(t, errors.Is(err, test.expErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, items)
+
+			for _, item := range items {
+				parsed, err := ParseTodoItem(item.String())
+				require.NoError(t, err)
+				assert.Equal(t, item, parsed)
+			}
+		})
+	}
+}
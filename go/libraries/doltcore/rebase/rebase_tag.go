@@ -369,6 +369,7 @@ func replayCommitWithNewTag(ctx context.Context, root, parentRoot, rebasedParent
 				return nil, err
 			}
 		}
+		rebasedSch.Checks().Merge(sch.Checks().AllChecks()...)
 
 		// super schema rebase
 		ss, _, err := root.GetSuperSchema(ctx, tblName)
@@ -45,11 +45,17 @@ const (
 
 	// InternalRefType is a reference to a dolt internal commit
 	InternalRefType RefType = "internal"
+
+	// TagRefType is a reference to commit tag
+	TagRefType RefType = "tags"
+
+	// StashRefType is a reference to a stash of working set changes
+	StashRefType RefType = "stashes"
 )
 
 // RefTypes is the set of all supported reference types.  External RefTypes can be added to this map in order to add
 // RefTypes for external tooling
-var RefTypes = map[RefType]struct{}{BranchRefType: {}, RemoteRefType: {}, InternalRefType: {}}
+var RefTypes = map[RefType]struct{}{BranchRefType: {}, RemoteRefType: {}, InternalRefType: {}, TagRefType: {}, StashRefType: {}}
 
 // PrefixForType returns what a reference string for a given type should start with
 func PrefixForType(refType RefType) string {
@@ -137,6 +143,10 @@ func Parse(str string) (DoltRef, error) {
 				return NewRemoteRefFromPathStr(str)
 			case InternalRefType:
 				return NewInternalRef(str), nil
+			case TagRefType:
+				return NewTagRef(str), nil
+			case StashRefType:
+				return NewStashRef(str), nil
 			default:
 				panic("unknown type " + rType)
 			}
@@ -40,6 +40,10 @@ func TestJsonMarshalAndUnmarshal(t *testing.T) {
 			NewInternalRef("create"),
 			`{"test":"refs/internal/create"}`,
 		},
+		{
+			NewTagRef("v1"),
+			`{"test":"refs/tags/v1"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -134,6 +138,31 @@ func TestEqualsStr(t *testing.T) {
 			"refs/internal/create",
 			true,
 		},
+		{
+			NewTagRef("v1"),
+			"refs/tags/v1",
+			true,
+		},
+		{
+			NewTagRef("refs/tags/v1"),
+			"refs/tags/v1",
+			true,
+		},
+		{
+			NewTagRef("v1"),
+			"refs/heads/v1",
+			false,
+		},
+		{
+			NewStashRef("abc"),
+			"refs/stashes/abc",
+			true,
+		},
+		{
+			NewStashRef("refs/stashes/abc"),
+			"refs/stashes/abc",
+			true,
+		},
 	}
 
 	for _, test := range tests {
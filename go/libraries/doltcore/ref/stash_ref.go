@@ -0,0 +1,53 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import "strings"
+
+// StashRef is a reference to a stash, a dangling commit recording the uncommitted changes of a working set
+type StashRef struct {
+	stash string
+}
+
+var _ DoltRef = StashRef{}
+
+// NewStashRef creates a reference to the given stash.  The name may optionally start with "refs/stashes/".
+func NewStashRef(stashName string) StashRef {
+	if IsRef(stashName) {
+		prefix := PrefixForType(StashRefType)
+		if strings.HasPrefix(stashName, prefix) {
+			stashName = stashName[len(prefix):]
+		} else {
+			panic(stashName + " is a ref that is not of type " + prefix)
+		}
+	}
+
+	return StashRef{stashName}
+}
+
+// GetType returns StashRefType
+func (sr StashRef) GetType() RefType {
+	return StashRefType
+}
+
+// GetPath returns the name of the stash
+func (sr StashRef) GetPath() string {
+	return sr.stash
+}
+
+// String returns the fully qualified reference name e.g. refs/stashes/...
+func (sr StashRef) String() string {
+	return String(sr)
+}
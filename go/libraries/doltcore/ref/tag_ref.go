@@ -0,0 +1,58 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import "strings"
+
+// TagRef is a reference to a tag, which points to a commit and carries a tagger and a message
+type TagRef struct {
+	tag string
+}
+
+var _ DoltRef = TagRef{}
+
+// NewTagRef creates a reference to the given tag.  The name may optionally start with "refs/tags/".
+func NewTagRef(tagName string) TagRef {
+	if IsRef(tagName) {
+		prefix := PrefixForType(TagRefType)
+		if strings.HasPrefix(tagName, prefix) {
+			tagName = tagName[len(prefix):]
+		} else {
+			panic(tagName + " is a ref that is not of type " + prefix)
+		}
+	}
+
+	return TagRef{tagName}
+}
+
+// GetType returns TagRefType
+func (tr TagRef) GetType() RefType {
+	return TagRefType
+}
+
+// GetPath returns the name of the tag
+func (tr TagRef) GetPath() string {
+	return tr.tag
+}
+
+// String returns the fully qualified reference name e.g. refs/tags/v1
+func (tr TagRef) String() string {
+	return String(tr)
+}
+
+// IsValidTagName returns true if |s| is a valid tag name.  Tag names follow the same rules as branch names.
+func IsValidTagName(s string) bool {
+	return IsValidBranchName(s)
+}
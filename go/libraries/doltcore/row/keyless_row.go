@@ -0,0 +1,229 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package row
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// keylessRow is a row of a table without a primary key. Keyless tables are stored as a map from the hash of each
+// distinct row's values to the number of copies of the row in the table, followed by the row's values.
+type keylessRow struct {
+	vals TaggedValues
+	card uint64
+	nbf  *types.NomsBinFormat
+}
+
+var _ Row = keylessRow{}
+
+// keylessRowKey is the key of a row of a keyless table, or the error encountered hashing the row's values.
+type keylessRowKey struct {
+	tvs TupleVals
+	err error
+}
+
+func (k keylessRowKey) Kind() types.NomsKind {
+	return types.TupleKind
+}
+
+func (k keylessRowKey) Value(ctx context.Context) (types.Value, error) {
+	if k.err != nil {
+		return nil, k.err
+	}
+
+	return k.tvs.Value(ctx)
+}
+
+func (k keylessRowKey) Less(nbf *types.NomsBinFormat, other types.LesserValuable) (bool, error) {
+	if k.err != nil {
+		return false, k.err
+	}
+
+	return k.tvs.Less(nbf, other)
+}
+
+func newKeylessRow(nbf *types.NomsBinFormat, sch schema.Schema, vals TaggedValues, card uint64) (Row, error) {
+	allCols := sch.GetAllCols()
+	filteredVals := make(TaggedValues, len(vals))
+
+	_, err := vals.Iter(func(tag uint64, val types.Value) (stop bool, err error) {
+		col, ok := allCols.GetByTag(tag)
+
+		if !ok {
+			return false, nil
+		} else if !types.IsNull(val) && col.Kind != val.Kind() {
+			return false, errors.New("bug.  Setting a value to an incorrect kind. col:" + col.Name)
+		}
+
+		filteredVals[tag] = val
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keylessRow{filteredVals, card, nbf}, nil
+}
+
+func keylessRowFromNoms(sch schema.Schema, nomsVal types.Tuple) (Row, error) {
+	vals, err := ParseTaggedValues(nomsVal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	card := uint64(1)
+	if c, ok := vals[schema.KeylessRowCardinalityTag]; ok {
+		card = uint64(c.(types.Uint))
+		delete(vals, schema.KeylessRowCardinalityTag)
+	}
+
+	return newKeylessRow(nomsVal.Format(), sch, vals, card)
+}
+
+// Cardinality returns the number of copies of a row in its table. This is always 1 for the rows of tables with a
+// primary key.
+func Cardinality(r Row) uint64 {
+	if kr, ok := r.(keylessRow); ok {
+		return kr.card
+	}
+
+	return 1
+}
+
+// WithCardinality returns a copy of the row of a keyless table with the given number of copies of the row. Rows of
+// tables with a primary key are returned unchanged.
+func WithCardinality(r Row, card uint64) Row {
+	if kr, ok := r.(keylessRow); ok {
+		kr.card = card
+		return kr
+	}
+
+	return r
+}
+
+// NomsCardinality returns the number of copies of a row given the noms tuple of its values, and whether the tuple is
+// the value of a row of a keyless table.
+func NomsCardinality(nomsVal types.Tuple) (card uint64, keyless bool, err error) {
+	if nomsVal.Len() < 2 {
+		return 1, false, nil
+	}
+
+	tag, err := nomsVal.Get(0)
+
+	if err != nil {
+		return 0, false, err
+	} else if tag != types.Uint(schema.KeylessRowCardinalityTag) {
+		return 1, false, nil
+	}
+
+	c, err := nomsVal.Get(1)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uint64(c.(types.Uint)), true, nil
+}
+
+// KeylessRowHash returns the hash identifying the values of a row of a keyless table, which is used as its key.
+func KeylessRowHash(ctx context.Context, r Row, sch schema.Schema) (types.Value, error) {
+	var vals TaggedValues
+	if kr, ok := r.(keylessRow); ok {
+		vals = kr.vals
+	} else {
+		var err error
+		vals, err = GetTaggedVals(r)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tpl, err := vals.NomsTupleForNonPKCols(r.Format(), sch.GetAllCols()).Value(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := tpl.Hash(r.Format())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return types.InlineBlob(h[:]), nil
+}
+
+func (kr keylessRow) IterSchema(sch schema.Schema, cb func(tag uint64, val types.Value) (stop bool, err error)) (bool, error) {
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (bool, error) {
+		value, _ := kr.GetColVal(tag)
+		return cb(tag, value)
+	})
+
+	return false, err
+}
+
+func (kr keylessRow) IterCols(cb func(tag uint64, val types.Value) (bool, error)) (bool, error) {
+	return kr.vals.Iter(cb)
+}
+
+func (kr keylessRow) GetColVal(tag uint64) (types.Value, bool) {
+	return kr.vals.Get(tag)
+}
+
+func (kr keylessRow) SetColVal(tag uint64, val types.Value, sch schema.Schema) (Row, error) {
+	if _, ok := sch.GetAllCols().GetByTag(tag); !ok {
+		panic("can't set a column whose tag isn't in the schema.  verify before calling this function.")
+	}
+
+	return keylessRow{kr.vals.Set(tag, val), kr.card, kr.nbf}, nil
+}
+
+func (kr keylessRow) ReduceToIndex(idx schema.Index) (Row, error) {
+	return nil, schema.ErrKeylessIndex
+}
+
+func (kr keylessRow) ReduceToIndexPartialKey(idx schema.Index) (types.Tuple, error) {
+	return types.EmptyTuple(kr.nbf), schema.ErrKeylessIndex
+}
+
+// NomsMapKey returns a tuple of the hash of the row's values. If the hash can't be computed, the error is returned when
+// the key is converted to a value or compared, as it is for the keys of rows with a primary key.
+func (kr keylessRow) NomsMapKey(sch schema.Schema) types.LesserValuable {
+	h, err := KeylessRowHash(context.Background(), kr, sch)
+
+	if err != nil {
+		return keylessRowKey{err: err}
+	}
+
+	return keylessRowKey{tvs: TupleVals{[]types.Value{types.Uint(schema.KeylessRowIdTag), h}, kr.nbf}}
+}
+
+// NomsMapValue returns a tuple of the number of copies of the row, followed by the row's values
+func (kr keylessRow) NomsMapValue(sch schema.Schema) types.Valuable {
+	vals := kr.vals.NomsTupleForNonPKCols(kr.nbf, sch.GetAllCols())
+	vals.vs = append([]types.Value{types.Uint(schema.KeylessRowCardinalityTag), types.Uint(kr.card)}, vals.vs...)
+	return vals
+}
+
+func (kr keylessRow) Format() *types.NomsBinFormat {
+	return kr.nbf
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package row
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var keylessColColl, _ = schema.NewColCollection(testCols...)
+var keylessSch = schema.SchemaFromCols(keylessColColl)
+
+func newKeylessTestRow(t *testing.T, addr string) Row {
+	r, err := New(types.Format_Default, keylessSch, TaggedValues{
+		addrColTag: types.String(addr),
+		ageColTag:  ageVal,
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestKeylessRow(t *testing.T) {
+	require.True(t, schema.IsKeyless(keylessSch))
+
+	r := newKeylessTestRow(t, "123 Fake St")
+	assert.Equal(t, uint64(1), Cardinality(r))
+
+	val, ok := r.GetColVal(addrColTag)
+	assert.True(t, ok)
+	assert.Equal(t, types.String("123 Fake St"), val)
+
+	_, err := r.ReduceToIndex(index)
+	assert.Equal(t, schema.ErrKeylessIndex, err)
+}
+
+func TestKeylessRowRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	r := WithCardinality(newKeylessTestRow(t, "123 Fake St"), 3)
+
+	key, err := r.NomsMapKey(keylessSch).Value(ctx)
+	require.NoError(t, err)
+	val, err := r.NomsMapValue(keylessSch).Value(ctx)
+	require.NoError(t, err)
+
+	card, keyless, err := NomsCardinality(val.(types.Tuple))
+	require.NoError(t, err)
+	assert.True(t, keyless)
+	assert.Equal(t, uint64(3), card)
+
+	fromNoms, err := FromNoms(keylessSch, key.(types.Tuple), val.(types.Tuple))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), Cardinality(fromNoms))
+	assert.True(t, AreEqual(r, fromNoms, keylessSch))
+}
+
+func TestKeylessRowHash(t *testing.T) {
+	ctx := context.Background()
+	r1 := newKeylessTestRow(t, "123 Fake St")
+	r2 := newKeylessTestRow(t, "456 Real St")
+
+	h1, err := KeylessRowHash(ctx, r1, keylessSch)
+	require.NoError(t, err)
+	h1Dup, err := KeylessRowHash(ctx, WithCardinality(r1, 5), keylessSch)
+	require.NoError(t, err)
+	h2, err := KeylessRowHash(ctx, r2, keylessSch)
+	require.NoError(t, err)
+
+	assert.True(t, h1.Equals(h1Dup), "the hash of a row should not depend on its cardinality")
+	assert.False(t, h1.Equals(h2), "rows with different values should have different hashes")
+}
+
+func TestKeylessRowKey(t *testing.T) {
+	ctx := context.Background()
+	k1 := newKeylessTestRow(t, "123 Fake St").NomsMapKey(keylessSch)
+	k2 := newKeylessTestRow(t, "456 Real St").NomsMapKey(keylessSch)
+
+	v1, err := k1.Value(ctx)
+	require.NoError(t, err)
+	v2, err := k2.Value(ctx)
+	require.NoError(t, err)
+
+	less, err := k1.Less(types.Format_Default, k2)
+	require.NoError(t, err)
+	expected, err := v1.Less(types.Format_Default, v2)
+	require.NoError(t, err)
+	assert.Equal(t, expected, less)
+
+	hashErr := errors.New("hash failed")
+	failed := keylessRowKey{err: hashErr}
+
+	_, err = failed.Value(ctx)
+	assert.Equal(t, hashErr, err)
+	_, err = failed.Less(types.Format_Default, k1)
+	assert.Equal(t, hashErr, err)
+	_, err = k1.Less(types.Format_Default, failed)
+	assert.Equal(t, hashErr, err)
+}
+
+func TestNomsCardinalityWithPrimaryKey(t *testing.T) {
+	r, err := newTestRow()
+	require.NoError(t, err)
+
+	val, err := r.NomsMapValue(sch).Value(context.Background())
+	require.NoError(t, err)
+
+	card, keyless, err := NomsCardinality(val.(types.Tuple))
+	require.NoError(t, err)
+	assert.False(t, keyless)
+	assert.Equal(t, uint64(1), card)
+	assert.Equal(t, uint64(1), Cardinality(r))
+}
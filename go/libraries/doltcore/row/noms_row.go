@@ -15,7 +15,9 @@
 package row
 
 import (
+	"context"
 	"errors"
+	"strings"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/store/types"
@@ -89,6 +91,10 @@ func (nr nomsRow) Format() *types.NomsBinFormat {
 }
 
 func New(nbf *types.NomsBinFormat, sch schema.Schema, colVals TaggedValues) (Row, error) {
+	if schema.IsKeyless(sch) {
+		return newKeylessRow(nbf, sch, colVals, 1)
+	}
+
 	allCols := sch.GetAllCols()
 
 	keyVals := make(TaggedValues)
@@ -165,6 +171,10 @@ func fromTaggedVals(nbf *types.NomsBinFormat, sch schema.Schema, keyVals, nonKey
 }
 
 func FromNoms(sch schema.Schema, nomsKey, nomsVal types.Tuple) (Row, error) {
+	if schema.IsKeyless(sch) {
+		return keylessRowFromNoms(sch, nomsVal)
+	}
+
 	key, err := ParseTaggedValues(nomsKey)
 
 	if err != nil {
@@ -195,6 +205,10 @@ func (nr nomsRow) ReduceToIndex(idx schema.Index) (Row, error) {
 				continue
 			}
 		}
+		val, err := indexValue(val)
+		if err != nil {
+			return nil, err
+		}
 		newRow.key[tag] = val
 	}
 
@@ -211,11 +225,30 @@ func (nr nomsRow) ReduceToIndexPartialKey(idx schema.Index) (types.Tuple, error)
 				val = types.NullValue
 			}
 		}
+		val, err := indexValue(val)
+		if err != nil {
+			return types.EmptyTuple(nr.nbf), err
+		}
 		vals = append(vals, types.Uint(tag), val)
 	}
 	return types.NewTuple(nr.nbf, vals...)
 }
 
+// indexValue returns the value stored in an index for the given row value. TEXT values are stored outside of the row as
+// blobs, but are stored inline in indexes so that index entries are ordered by their contents.
+func indexValue(val types.Value) (types.Value, error) {
+	b, ok := val.(types.Blob)
+	if !ok {
+		return val, nil
+	}
+	str := &strings.Builder{}
+	_, err := b.Copy(context.Background(), str)
+	if err != nil {
+		return nil, err
+	}
+	return types.String(str.String()), nil
+}
+
 func (nr nomsRow) NomsMapKey(sch schema.Schema) types.LesserValuable {
 	return nr.key.NomsTupleForPKCols(nr.nbf, sch.GetPKCols())
 }
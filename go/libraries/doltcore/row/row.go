@@ -127,6 +127,11 @@ func findInvalidCol(r Row, sch schema.Schema) (*schema.Column, schema.ColConstra
 			return true, fmt.Errorf(`"%v" is not valid for "%v"`, val, col.TypeInfo.String())
 		}
 
+		// null values of AUTO_INCREMENT columns are generated when the row is inserted
+		if col.AutoIncrement && types.IsNull(val) {
+			return false, nil
+		}
+
 		if len(col.Constraints) > 0 {
 			for _, cnst := range col.Constraints {
 				if !cnst.SatisfiesConstraint(val) {
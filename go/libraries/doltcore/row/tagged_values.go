@@ -38,6 +38,14 @@ func (tvs TupleVals) Value(ctx context.Context) (types.Value, error) {
 }
 
 func (tvs TupleVals) Less(nbf *types.NomsBinFormat, other types.LesserValuable) (bool, error) {
+	if otherKey, ok := other.(keylessRowKey); ok {
+		if otherKey.err != nil {
+			return false, otherKey.err
+		}
+
+		other = otherKey.tvs
+	}
+
 	if other.Kind() == types.TupleKind {
 		if otherTVs, ok := other.(TupleVals); ok {
 			for i, val := range tvs.vs {
@@ -15,16 +15,18 @@
 package rowconv
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
-var IdentityConverter = &RowConverter{nil, true, nil}
+var IdentityConverter = &RowConverter{nil, true, nil, nil}
 
 // RowConverter converts rows from one schema to another
 type RowConverter struct {
@@ -33,14 +35,17 @@ type RowConverter struct {
 	// IdentityConverter is a bool which is true if the converter is doing nothing.
 	IdentityConverter bool
 	ConvFuncs         map[uint64]types.MarshalCallback
+	// Defaults are the default values of the destination columns that no source column is mapped to
+	Defaults row.TaggedValues
 }
 
 func newIdentityConverter(mapping *FieldMapping) *RowConverter {
-	return &RowConverter{mapping, true, nil}
+	return &RowConverter{mapping, true, nil, nil}
 }
 
-// NewRowConverter creates a row converter from a given FieldMapping.
-func NewRowConverter(mapping *FieldMapping) (*RowConverter, error) {
+// NewRowConverter creates a row converter from a given FieldMapping. Converted values which are stored out of row are
+// written to the given ValueReadWriter.
+func NewRowConverter(ctx context.Context, vrw types.ValueReadWriter, mapping *FieldMapping) (*RowConverter, error) {
 	if nec, err := isNecessary(mapping.SrcSch, mapping.DestSch, mapping.SrcToDest); err != nil {
 		return nil, err
 	} else if !nec {
@@ -74,16 +79,16 @@ func NewRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 			}
 		} else {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				return typeinfo.Convert(v, srcCol.TypeInfo, destCol.TypeInfo)
+				return typeinfo.Convert(ctx, vrw, v, srcCol.TypeInfo, destCol.TypeInfo)
 			}
 		}
 	}
 
-	return &RowConverter{mapping, false, convFuncs}, nil
+	return &RowConverter{mapping, false, convFuncs, nil}, nil
 }
 
 // NewImportRowConverter creates a row converter from a given FieldMapping specifically for importing.
-func NewImportRowConverter(mapping *FieldMapping) (*RowConverter, error) {
+func NewImportRowConverter(ctx context.Context, vrw types.ValueReadWriter, mapping *FieldMapping) (*RowConverter, error) {
 	if nec, err := isNecessary(mapping.SrcSch, mapping.DestSch, mapping.SrcToDest); err != nil {
 		return nil, err
 	} else if !nec {
@@ -118,20 +123,25 @@ func NewImportRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 		} else if destCol.TypeInfo.Equals(typeinfo.PseudoBoolType) || destCol.TypeInfo.Equals(typeinfo.Int8Type) {
 			// BIT(1) and BOOLEAN (MySQL alias for TINYINT or Int8) are both logical stand-ins for a bool type
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				intermediateVal, err := typeinfo.Convert(v, srcCol.TypeInfo, typeinfo.BoolType)
+				intermediateVal, err := typeinfo.Convert(ctx, vrw, v, srcCol.TypeInfo, typeinfo.BoolType)
 				if err != nil {
 					return nil, err
 				}
-				return typeinfo.Convert(intermediateVal, typeinfo.BoolType, destCol.TypeInfo)
+				return typeinfo.Convert(ctx, vrw, intermediateVal, typeinfo.BoolType, destCol.TypeInfo)
 			}
 		} else {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				return typeinfo.Convert(v, srcCol.TypeInfo, destCol.TypeInfo)
+				return typeinfo.Convert(ctx, vrw, v, srcCol.TypeInfo, destCol.TypeInfo)
 			}
 		}
 	}
 
-	return &RowConverter{mapping, false, convFuncs}, nil
+	defaults, err := unmappedDefaults(ctx, vrw, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RowConverter{mapping, false, convFuncs, defaults}, nil
 }
 
 // Convert takes a row maps its columns to their destination columns, and performs any type conversion needed to create
@@ -163,9 +173,40 @@ func (rc *RowConverter) Convert(inRow row.Row) (row.Row, error) {
 		return nil, err
 	}
 
+	for tag, val := range rc.Defaults {
+		outTaggedVals[tag] = val
+	}
+
 	return row.New(inRow.Format(), rc.DestSch, outTaggedVals)
 }
 
+// unmappedDefaults returns the default values of the destination columns of a mapping that no source column is mapped to.
+func unmappedDefaults(ctx context.Context, vrw types.ValueReadWriter, mapping *FieldMapping) (row.TaggedValues, error) {
+	mapped := make(map[uint64]bool, len(mapping.SrcToDest))
+	for _, destTag := range mapping.SrcToDest {
+		mapped[destTag] = true
+	}
+
+	defaults := make(row.TaggedValues)
+	err := mapping.DestSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if mapped[tag] || !col.HasDefault() {
+			return false, nil
+		}
+
+		val, err := sqlfmt.SqlStringAsValue(ctx, vrw, col.TypeInfo, col.Default)
+		if err != nil {
+			return true, fmt.Errorf("invalid default value for column %s: %v", col.Name, err)
+		}
+
+		if !types.IsNull(val) {
+			defaults[tag] = val
+		}
+		return false, nil
+	})
+
+	return defaults, err
+}
+
 func isNecessary(srcSch, destSch schema.Schema, destToSrc map[uint64]uint64) (bool, error) {
 	srcCols := srcSch.GetAllCols()
 	destCols := destSch.GetAllCols()
@@ -48,7 +48,7 @@ func TestRowConverter(t *testing.T) {
 
 	assert.NoError(t, err)
 
-	rConv, err := NewRowConverter(mapping)
+	rConv, err := NewRowConverter(context.Background(), types.NewMemoryValueStore(), mapping)
 
 	if err != nil {
 		t.Fatal("Error creating row converter")
@@ -95,7 +95,7 @@ func TestUnneccessaryConversion(t *testing.T) {
 		t.Error(err)
 	}
 
-	rconv, err := NewRowConverter(mapping)
+	rconv, err := NewRowConverter(context.Background(), types.NewMemoryValueStore(), mapping)
 
 	if !rconv.IdentityConverter {
 		t.Error("expected identity converter")
@@ -114,7 +114,7 @@ func TestSpecialBoolHandling(t *testing.T) {
 
 	mapping, err := TagMapping(untypedSch, sch)
 	require.NoError(t, err)
-	rconv, err := NewImportRowConverter(mapping)
+	rconv, err := NewImportRowConverter(context.Background(), types.NewMemoryValueStore(), mapping)
 	require.NoError(t, err)
 	inRow, err := row.New(types.Format_7_18, untypedSch, row.TaggedValues{
 		0: types.String("76"),
@@ -133,7 +133,7 @@ func TestSpecialBoolHandling(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, row.AreEqual(outData, expected, mapping.DestSch))
 
-	rconvNoHandle, err := NewRowConverter(mapping)
+	rconvNoHandle, err := NewRowConverter(context.Background(), types.NewMemoryValueStore(), mapping)
 	require.NoError(t, err)
 	results, errStr = GetRowConvTransformFunc(rconvNoHandle)(inRow, pipeline.ImmutableProperties{})
 	assert.Nil(t, results)
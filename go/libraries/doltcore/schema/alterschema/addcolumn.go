@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
@@ -45,21 +46,37 @@ type ColumnOrder struct {
 //
 // Returns an error if the column added conflicts with the existing schema in tag or name.
 func AddColumnToTable(ctx context.Context, root *doltdb.RootValue, tbl *doltdb.Table, tblName string, tag uint64, newColName string, typeInfo typeinfo.TypeInfo, nullable Nullable, defaultVal types.Value, order *ColumnOrder) (*doltdb.Table, error) {
+	if typeInfo == nil {
+		return nil, fmt.Errorf(`typeinfo may not be nil`)
+	}
+
+	newCol, err := createColumn(nullable, newColName, tag, typeInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return AddColumnDefToTable(ctx, root, tbl, tblName, newCol, defaultVal, order)
+}
+
+// AddColumnDefToTable follows the same logic as AddColumnToTable, but adds the column given with all of its
+// attributes, such as its default value and comment.
+func AddColumnDefToTable(ctx context.Context, root *doltdb.RootValue, tbl *doltdb.Table, tblName string, newCol schema.Column, defaultVal types.Value, order *ColumnOrder) (*doltdb.Table, error) {
 	sch, err := tbl.GetSchema(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := validateNewColumn(ctx, root, tbl, tblName, tag, newColName, typeInfo, nullable, defaultVal); err != nil {
+	nullable := Nullable(newCol.IsNullable())
+	if err := validateNewColumn(ctx, root, tbl, tblName, newCol.Tag, newCol.Name, newCol.TypeInfo, nullable, defaultVal); err != nil {
 		return nil, err
 	}
 
-	newSchema, err := addColumnToSchema(sch, tag, newColName, typeInfo, nullable, order)
+	newSchema, err := addColumnToSchema(sch, newCol, order)
 	if err != nil {
 		return nil, err
 	}
 
-	return updateTableWithNewSchema(ctx, tbl, tag, newSchema, defaultVal)
+	return updateTableWithNewSchema(ctx, tbl, newCol.Tag, newSchema, defaultVal)
 }
 
 // updateTableWithNewSchema updates the existing table with a new schema and new values for the new column as necessary,
@@ -87,6 +104,18 @@ func updateTableWithNewSchema(ctx context.Context, tbl *doltdb.Table, tag uint64
 		return doltdb.NewTable(ctx, vrw, newSchemaVal, rowData, &indexData)
 	}
 
+	if schema.IsKeyless(newSchema) {
+		m, err := rehashKeylessRows(ctx, vrw, newSchema, rowData, func(r row.Row) (row.Row, error) {
+			return r.SetColVal(tag, defaultVal, newSchema)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return doltdb.NewTable(ctx, vrw, newSchemaVal, m, &indexData)
+	}
+
 	me := rowData.Edit()
 
 	err = rowData.Iter(ctx, func(k, v types.Value) (stop bool, err error) {
@@ -117,13 +146,8 @@ func updateTableWithNewSchema(ctx context.Context, tbl *doltdb.Table, tag uint64
 	return doltdb.NewTable(ctx, vrw, newSchemaVal, m, &indexData)
 }
 
-// addColumnToSchema creates a new schema with a column as specified by the params.
-func addColumnToSchema(sch schema.Schema, tag uint64, newColName string, typeInfo typeinfo.TypeInfo, nullable Nullable, order *ColumnOrder) (schema.Schema, error) {
-	newCol, err := createColumn(nullable, newColName, tag, typeInfo)
-	if err != nil {
-		return nil, err
-	}
-
+// addColumnToSchema creates a new schema with the column given added at the position specified by order.
+func addColumnToSchema(sch schema.Schema, newCol schema.Column, order *ColumnOrder) (schema.Schema, error) {
 	var newCols []schema.Column
 	if order != nil && order.First {
 		newCols = append(newCols, newCol)
@@ -145,6 +169,7 @@ func addColumnToSchema(sch schema.Schema, tag uint64, newColName string, typeInf
 	}
 	newSch := schema.SchemaFromCols(collection)
 	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	newSch.Checks().Merge(sch.Checks().AllChecks()...)
 
 	return newSch, nil
 }
@@ -62,6 +62,14 @@ func DropColumn(ctx context.Context, tbl *doltdb.Table, colName string, foreignK
 		}
 	}
 
+	for _, check := range tblSch.Checks().AllChecks() {
+		if ok, err := doltdb.CheckReferencesColumn(check, colName); err != nil {
+			return nil, err
+		} else if ok {
+			return nil, fmt.Errorf("cannot drop column `%s` as it is used in check constraint `%s`", colName, check.Name)
+		}
+	}
+
 	for _, index := range tblSch.Indexes().IndexesWithColumn(colName) {
 		_, err = tblSch.Indexes().RemoveIndex(index.Name())
 		if err != nil {
@@ -92,6 +100,7 @@ func DropColumn(ctx context.Context, tbl *doltdb.Table, colName string, foreignK
 
 	newSch := schema.SchemaFromCols(colColl)
 	newSch.Indexes().AddIndex(tblSch.Indexes().AllIndexes()...)
+	newSch.Checks().Merge(tblSch.Checks().AllChecks()...)
 
 	vrw := tbl.ValueReadWriter()
 	schemaVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, newSch)
@@ -102,7 +111,12 @@ func DropColumn(ctx context.Context, tbl *doltdb.Table, colName string, foreignK
 
 	rd, err := tbl.GetRowData(ctx)
 
-	prunedRowData, err := dropColumnValuesForTag(ctx, tbl.Format(), newSch, rd, dropTag)
+	var prunedRowData types.Map
+	if schema.IsKeyless(newSch) {
+		prunedRowData, err = rehashKeylessRows(ctx, vrw, newSch, rd, nil)
+	} else {
+		prunedRowData, err = dropColumnValuesForTag(ctx, tbl.Format(), newSch, rd, dropTag)
+	}
 
 	if err != nil {
 		return nil, err
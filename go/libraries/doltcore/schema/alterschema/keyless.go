@@ -0,0 +1,82 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// rehashKeylessRows returns the row data of a keyless table with the schema |newSch|, after each row is changed by
+// |update|, which may be nil. The rows of keyless tables are keyed by the hash of their values, so each row is stored
+// under a new key, and the copies of rows which become equal are combined.
+func rehashKeylessRows(ctx context.Context, vrw types.ValueReadWriter, newSch schema.Schema, rowData types.Map, update func(r row.Row) (row.Row, error)) (types.Map, error) {
+	type keyedRow struct {
+		key types.Value
+		r   row.Row
+	}
+
+	rows := make(map[hash.Hash]keyedRow)
+	err := rowData.Iter(ctx, func(k, v types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(newSch, k.(types.Tuple), v.(types.Tuple))
+		if err != nil {
+			return false, err
+		}
+
+		if update != nil {
+			r, err = update(r)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		key, err := r.NomsMapKey(newSch).Value(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		h, err := key.Hash(vrw.Format())
+		if err != nil {
+			return false, err
+		}
+
+		if existing, ok := rows[h]; ok {
+			r = row.WithCardinality(r, row.Cardinality(r)+row.Cardinality(existing.r))
+		}
+
+		rows[h] = keyedRow{key, r}
+		return false, nil
+	})
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	m, err := types.NewMap(ctx, vrw)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	me := m.Edit()
+	for _, kr := range rows {
+		me.Set(kr.key, kr.r.NomsMapValue(newSch))
+	}
+
+	return me.Map(ctx)
+}
@@ -202,5 +202,14 @@ func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schem
 
 	newSch := schema.SchemaFromCols(collection)
 	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	for _, check := range sch.Checks().AllChecks() {
+		if oldCol.Name != newCol.Name {
+			check, err = doltdb.RenameCheckColumn(check, oldCol.Name, newCol.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		newSch.Checks().Merge(check)
+	}
 	return newSch, nil
 }
@@ -0,0 +1,129 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Check is a table-level CHECK constraint. Expression is a SQL boolean expression over the columns of the table. A row
+// violates the check when the expression evaluates to false, while NULL results are allowed, as in MySQL.
+type Check struct {
+	Name       string
+	Expression string
+}
+
+type CheckCollection interface {
+	// AddCheck adds a check with the given name and expression. Check names are unique within a table, ignoring case.
+	AddCheck(name, expression string) (Check, error)
+	// AllChecks returns a slice containing all of the checks in this collection, in the order they were added.
+	AllChecks() []Check
+	// Contains returns whether a check with the given name exists for this table.
+	Contains(name string) bool
+	// Count returns the number of checks in this collection.
+	Count() int
+	// DropCheck removes the check with the given name.
+	DropCheck(name string) (Check, error)
+	// Equals returns whether this check collection is equivalent to another.
+	Equals(other CheckCollection) bool
+	// Get returns the check with the given name, or false if it does not exist.
+	Get(name string) (Check, bool)
+	// Merge adds the given checks, skipping any check whose name already exists in this collection.
+	Merge(checks ...Check)
+}
+
+type checkCollectionImpl struct {
+	checks []Check
+}
+
+func NewCheckCollection() CheckCollection {
+	return &checkCollectionImpl{}
+}
+
+func (cc *checkCollectionImpl) AddCheck(name, expression string) (Check, error) {
+	if name == "" {
+		return Check{}, fmt.Errorf("check constraints must have a name")
+	}
+	if strings.TrimSpace(expression) == "" {
+		return Check{}, fmt.Errorf("check constraint `%s` must have an expression", name)
+	}
+	if cc.Contains(name) {
+		return Check{}, fmt.Errorf("`%s` already exists as a check constraint for this table", name)
+	}
+
+	check := Check{Name: name, Expression: expression}
+	cc.checks = append(cc.checks, check)
+	return check, nil
+}
+
+func (cc *checkCollectionImpl) AllChecks() []Check {
+	checks := make([]Check, len(cc.checks))
+	copy(checks, cc.checks)
+	return checks
+}
+
+func (cc *checkCollectionImpl) Contains(name string) bool {
+	_, ok := cc.Get(name)
+	return ok
+}
+
+func (cc *checkCollectionImpl) Count() int {
+	return len(cc.checks)
+}
+
+func (cc *checkCollectionImpl) DropCheck(name string) (Check, error) {
+	for i, check := range cc.checks {
+		if strings.EqualFold(check.Name, name) {
+			cc.checks = append(cc.checks[:i:i], cc.checks[i+1:]...)
+			if len(cc.checks) == 0 {
+				cc.checks = nil
+			}
+			return check, nil
+		}
+	}
+	return Check{}, fmt.Errorf("`%s` does not exist as a check constraint for this table", name)
+}
+
+func (cc *checkCollectionImpl) Equals(other CheckCollection) bool {
+	otherChecks := other.AllChecks()
+	if len(cc.checks) != len(otherChecks) {
+		return false
+	}
+	for _, check := range otherChecks {
+		ourCheck, ok := cc.Get(check.Name)
+		if !ok || ourCheck != check {
+			return false
+		}
+	}
+	return true
+}
+
+func (cc *checkCollectionImpl) Get(name string) (Check, bool) {
+	for _, check := range cc.checks {
+		if strings.EqualFold(check.Name, name) {
+			return check, true
+		}
+	}
+	return Check{}, false
+}
+
+func (cc *checkCollectionImpl) Merge(checks ...Check) {
+	for _, check := range checks {
+		if !cc.Contains(check.Name) {
+			cc.checks = append(cc.checks, check)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCollection(t *testing.T) {
+	checkColl := NewCheckCollection()
+
+	check, err := checkColl.AddCheck("chk_v1", "v1 > 0")
+	require.NoError(t, err)
+	assert.Equal(t, Check{Name: "chk_v1", Expression: "v1 > 0"}, check)
+	_, err = checkColl.AddCheck("chk_v1v2", "v1 < v2")
+	require.NoError(t, err)
+
+	_, err = checkColl.AddCheck("CHK_V1", "v1 > 1")
+	assert.Error(t, err)
+	_, err = checkColl.AddCheck("", "v1 > 1")
+	assert.Error(t, err)
+	_, err = checkColl.AddCheck("chk_empty", " ")
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, checkColl.Count())
+	assert.True(t, checkColl.Contains("Chk_V1"))
+	got, ok := checkColl.Get("CHK_V1V2")
+	assert.True(t, ok)
+	assert.Equal(t, "v1 < v2", got.Expression)
+	assert.Equal(t, []Check{{"chk_v1", "v1 > 0"}, {"chk_v1v2", "v1 < v2"}}, checkColl.AllChecks())
+
+	other := NewCheckCollection()
+	other.Merge(checkColl.AllChecks()...)
+	assert.True(t, checkColl.Equals(other))
+	other.Merge(Check{Name: "chk_v1", Expression: "v1 > 5"})
+	assert.True(t, checkColl.Equals(other))
+
+	_, err = other.DropCheck("chk_v1")
+	require.NoError(t, err)
+	assert.False(t, checkColl.Equals(other))
+	_, err = other.DropCheck("chk_v1")
+	assert.Error(t, err)
+	_, err = other.AddCheck("chk_v1", "v1 > 5")
+	require.NoError(t, err)
+	assert.False(t, checkColl.Equals(other))
+}
@@ -31,8 +31,8 @@ var ErrColNotFound = errors.New("column not found")
 // different type or tag
 var ErrColNameCollision = errors.New("two different columns with the same name exist")
 
-// ErrNoPrimaryKeyColumns is an error that is returned when wo
-var ErrNoPrimaryKeyColumns = errors.New("no primary key columns")
+// ErrNoColumns is an error that is returned when attempting to write a schema without any columns
+var ErrNoColumns = errors.New("no columns")
 
 var EmptyColColl = &ColCollection{
 	[]Column{},
@@ -43,6 +43,9 @@ var (
 		false,
 		typeinfo.UnknownType,
 		nil,
+		"",
+		false,
+		"",
 	}
 )
 
@@ -72,6 +75,16 @@ type Column struct {
 
 	// Constraints are rules that can be checked on each column to say if the columns value is valid
 	Constraints []ColConstraint
+
+	// Default is the default value of this column written as a SQL literal, or the empty string if the column has no
+	// default value
+	Default string
+
+	// AutoIncrement says whether values of this column are generated when rows are inserted without them
+	AutoIncrement bool
+
+	// Comment is the user provided comment for this column
+	Comment string
 }
 
 // NewColumn creates a Column instance with the default type info for the NomsKind
@@ -103,6 +116,9 @@ func NewColumnWithTypeInfo(name string, tag uint64, typeInfo typeinfo.TypeInfo,
 		partOfPK,
 		typeInfo,
 		constraints,
+		"",
+		false,
+		"",
 	}, nil
 }
 
@@ -123,7 +139,15 @@ func (c Column) Equals(other Column) bool {
 		c.Kind == other.Kind &&
 		c.IsPartOfPK == other.IsPartOfPK &&
 		c.TypeInfo.Equals(other.TypeInfo) &&
-		ColConstraintsAreEqual(c.Constraints, other.Constraints)
+		ColConstraintsAreEqual(c.Constraints, other.Constraints) &&
+		c.Default == other.Default &&
+		c.AutoIncrement == other.AutoIncrement &&
+		c.Comment == other.Comment
+}
+
+// HasDefault returns whether the column has a default value.
+func (c Column) HasDefault() bool {
+	return len(c.Default) > 0
 }
 
 // KindString returns the string representation of the NomsKind stored in the column.
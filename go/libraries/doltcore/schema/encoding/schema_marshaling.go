@@ -47,6 +47,12 @@ type encodedColumn struct {
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
 
+	Default string `noms:"default,omitempty" json:"default,omitempty"`
+
+	AutoIncrement bool `noms:"auto_increment,omitempty" json:"auto_increment,omitempty"`
+
+	Comment string `noms:"comment,omitempty" json:"comment,omitempty"`
+
 	// NB: all new fields must have the 'omitempty' annotation. See comment above
 }
 
@@ -83,6 +89,9 @@ func encodeColumn(col schema.Column) encodedColumn {
 		col.IsPartOfPK,
 		encodeTypeInfo(col.TypeInfo),
 		encodeAllColConstraints(col.Constraints),
+		col.Default,
+		col.AutoIncrement,
+		col.Comment,
 	}
 }
 
@@ -100,7 +109,15 @@ func (nfd encodedColumn) decodeColumn() (schema.Column, error) {
 		return schema.Column{}, errors.New("cannot decode column due to unknown schema format")
 	}
 	colConstraints := decodeAllColConstraint(nfd.Constraints)
-	return schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, colConstraints...)
+	col, err := schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, colConstraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+
+	col.Default = nfd.Default
+	col.AutoIncrement = nfd.AutoIncrement
+	col.Comment = nfd.Comment
+	return col, nil
 }
 
 type encodedConstraint struct {
@@ -138,9 +155,15 @@ type encodedIndex struct {
 	Hidden  bool     `noms:"hidden,omitempty" json:"hidden,omitempty"`
 }
 
+type encodedCheck struct {
+	Name       string `noms:"name" json:"name"`
+	Expression string `noms:"expression" json:"expression"`
+}
+
 type schemaData struct {
 	Columns         []encodedColumn `noms:"columns" json:"columns"`
 	IndexCollection []encodedIndex  `noms:"idxColl,omitempty" json:"idxColl,omitempty"`
+	CheckCollection []encodedCheck  `noms:"checkColl,omitempty" json:"checkColl,omitempty"`
 }
 
 func toSchemaData(sch schema.Schema) (schemaData, error) {
@@ -170,7 +193,12 @@ func toSchemaData(sch schema.Schema) (schemaData, error) {
 		}
 	}
 
-	return schemaData{encCols, encodedIndexes}, nil
+	var encodedChecks []encodedCheck
+	for _, check := range sch.Checks().AllChecks() {
+		encodedChecks = append(encodedChecks, encodedCheck{Name: check.Name, Expression: check.Expression})
+	}
+
+	return schemaData{encCols, encodedIndexes, encodedChecks}, nil
 }
 
 func (sd schemaData) decodeSchema() (schema.Schema, error) {
@@ -199,6 +227,13 @@ func (sd schemaData) decodeSchema() (schema.Schema, error) {
 		}
 	}
 
+	for _, encodedCheck := range sd.CheckCollection {
+		_, err = sch.Checks().AddCheck(encodedCheck.Name, encodedCheck.Expression)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return sch, nil
 }
 
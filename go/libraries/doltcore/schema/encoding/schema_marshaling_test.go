@@ -40,10 +40,13 @@ func createTestSchema() schema.Schema {
 		schema.NewColumn("last", 2, types.StringKind, false, schema.NotNullConstraint{}),
 		schema.NewColumn("age", 3, types.UintKind, false),
 	}
+	columns[1].Comment = "given name"
+	columns[3].Default = "18"
 
 	colColl, _ := schema.NewColCollection(columns...)
 	sch := schema.SchemaFromCols(colColl)
 	_, _ = sch.Indexes().AddIndexByColTags("idx_age", []uint64{3}, schema.IndexProperties{IsUnique: false, IsHidden: false, Comment: ""})
+	_, _ = sch.Checks().AddCheck("chk_age", "age < 150")
 	return sch
 }
 
@@ -224,6 +227,12 @@ type testEncodedColumn struct {
 	TypeInfo encodedTypeInfo `noms:"typeinfo" json:"typeinfo"`
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
+
+	Default string `noms:"default,omitempty" json:"default,omitempty"`
+
+	AutoIncrement bool `noms:"auto_increment,omitempty" json:"auto_increment,omitempty"`
+
+	Comment string `noms:"comment,omitempty" json:"comment,omitempty"`
 }
 
 type testEncodedIndex struct {
@@ -234,9 +243,15 @@ type testEncodedIndex struct {
 	Hidden  bool     `noms:"hidden,omitempty" json:"hidden,omitempty"`
 }
 
+type testEncodedCheck struct {
+	Name       string `noms:"name" json:"name"`
+	Expression string `noms:"expression" json:"expression"`
+}
+
 type testSchemaData struct {
 	Columns         []testEncodedColumn `noms:"columns" json:"columns"`
 	IndexCollection []testEncodedIndex  `noms:"idxColl,omitempty" json:"idxColl,omitempty"`
+	CheckCollection []testEncodedCheck  `noms:"checkColl,omitempty" json:"checkColl,omitempty"`
 }
 
 func (tec testEncodedColumn) decodeColumn() (schema.Column, error) {
@@ -253,7 +268,15 @@ func (tec testEncodedColumn) decodeColumn() (schema.Column, error) {
 		return schema.Column{}, errors.New("cannot decode column due to unknown schema format")
 	}
 	colConstraints := decodeAllColConstraint(tec.Constraints)
-	return schema.NewColumnWithTypeInfo(tec.Name, tec.Tag, typeInfo, tec.IsPartOfPK, colConstraints...)
+	col, err := schema.NewColumnWithTypeInfo(tec.Name, tec.Tag, typeInfo, tec.IsPartOfPK, colConstraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+
+	col.Default = tec.Default
+	col.AutoIncrement = tec.AutoIncrement
+	col.Comment = tec.Comment
+	return col, nil
 }
 
 func (tsd testSchemaData) decodeSchema() (schema.Schema, error) {
@@ -283,5 +306,12 @@ func (tsd testSchemaData) decodeSchema() (schema.Schema, error) {
 		}
 	}
 
+	for _, encodedCheck := range tsd.CheckCollection {
+		_, err = sch.Checks().AddCheck(encodedCheck.Name, encodedCheck.Expression)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return sch, nil
 }
@@ -14,6 +14,8 @@
 
 package schema
 
+import "github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+
 type Index interface {
 	// AllTags returns the tags of the columns in the entire index, including the primary keys.
 	// If we imagined a dolt index as being a standard dolt table, then the tags would represent the schema columns.
@@ -120,12 +122,17 @@ func (ix *indexImpl) Schema() Schema {
 	cols := make([]Column, len(ix.allTags))
 	for i, tag := range ix.allTags {
 		col := ix.indexColl.colColl.TagToCol[tag]
+		// TEXT values are stored inline in the index so that index entries are ordered by their contents
+		ti := col.TypeInfo
+		if keyTi, err := typeinfo.ToKeyType(ti); err == nil {
+			ti = keyTi
+		}
 		cols[i] = Column{
 			Name:        col.Name,
 			Tag:         tag,
-			Kind:        col.Kind,
+			Kind:        ti.NomsKind(),
 			IsPartOfPK:  true,
-			TypeInfo:    col.TypeInfo,
+			TypeInfo:    ti,
 			Constraints: nil,
 		}
 	}
@@ -136,6 +143,7 @@ func (ix *indexImpl) Schema() Schema {
 		nonPKCols:       nonPkCols,
 		allCols:         allCols,
 		indexCollection: NewIndexCollection(nil),
+		checkCollection: NewCheckCollection(),
 	}
 }
 
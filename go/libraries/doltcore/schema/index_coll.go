@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 )
 
 type IndexCollection interface {
@@ -134,6 +136,11 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 	if !ixc.tagsExist(tags...) {
 		return nil, fmt.Errorf("tags %v do not exist on this table", tags)
 	}
+	for _, tag := range tags {
+		if col := ixc.colColl.TagToCol[tag]; !canIndex(col) {
+			return nil, fmt.Errorf("BLOB column `%s` cannot be used in an index", col.Name)
+		}
+	}
 	if !props.IsHidden {
 		if ixc.HasIndexOnTags(tags...) {
 			return nil, fmt.Errorf("cannot create a duplicate index on this table")
@@ -359,3 +366,10 @@ func combineAllTags(tags []uint64, pks []uint64) []uint64 {
 	}
 	return allTags
 }
+
+// canIndex returns whether the given column can be used in an index. BLOB values are ordered by their hash rather than
+// their contents, so they cannot be indexed.
+func canIndex(col Column) bool {
+	_, err := typeinfo.ToKeyType(col.TypeInfo)
+	return err == nil
+}
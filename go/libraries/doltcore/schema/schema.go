@@ -14,6 +14,8 @@
 
 package schema
 
+import "errors"
+
 // Schema is an interface for retrieving the columns that make up a schema
 type Schema interface {
 	// GetPKCols gets the collection of columns which make the primary key.
@@ -27,6 +29,38 @@ type Schema interface {
 
 	// Indexes returns a collection of all indexes on the table that this schema belongs to.
 	Indexes() IndexCollection
+
+	// Checks returns a collection of all CHECK constraints on the table that this schema belongs to.
+	Checks() CheckCollection
+}
+
+// ErrKeylessIndex is returned when attempting to index the rows of a keyless table
+var ErrKeylessIndex = errors.New("indexes are not supported on tables without a primary key")
+
+// ErrInvalidAutoIncrement is returned when a column other than the first column of an integer primary key is marked AUTO_INCREMENT
+var ErrInvalidAutoIncrement = errors.New("AUTO_INCREMENT is only supported on the first column of an integer primary key, without a default value")
+
+// ErrMultipleAutoIncrement is returned when more than one column of a table is marked AUTO_INCREMENT
+var ErrMultipleAutoIncrement = errors.New("there can be only one AUTO_INCREMENT column per table")
+
+// IsKeyless returns whether a schema has columns but no primary key. The rows of keyless tables are keyed by the hash
+// of their values, and store the number of copies of the row in the table.
+func IsKeyless(sch Schema) bool {
+	return sch.GetPKCols().Size() == 0 && sch.GetAllCols().Size() > 0
+}
+
+// AutoIncrementCol returns the AUTO_INCREMENT column of a schema, if it has one.
+func AutoIncrementCol(sch Schema) (Column, bool) {
+	var aiCol Column
+	var found bool
+	_ = sch.GetAllCols().Iter(func(tag uint64, col Column) (stop bool, err error) {
+		if col.AutoIncrement {
+			aiCol, found = col, true
+		}
+		return found, nil
+	})
+
+	return aiCol, found
 }
 
 // ColFromTag returns a schema.Column from a schema and a tag
@@ -66,7 +100,7 @@ func SchemasAreEqual(sch1, sch2 Schema) (bool, error) {
 	if !colCollIsEqual {
 		return false, nil
 	}
-	return sch1.Indexes().Equals(sch2.Indexes()), nil
+	return sch1.Indexes().Equals(sch2.Indexes()) && sch1.Checks().Equals(sch2.Checks()), nil
 }
 
 // TODO: this function never returns an error
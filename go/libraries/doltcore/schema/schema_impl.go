@@ -17,6 +17,8 @@ package schema
 import (
 	"strconv"
 	"strings"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
 // EmptySchema is an instance of a schema with no columns.
@@ -25,14 +27,17 @@ var EmptySchema = &schemaImpl{
 	nonPKCols:       EmptyColColl,
 	allCols:         EmptyColColl,
 	indexCollection: NewIndexCollection(nil),
+	checkCollection: NewCheckCollection(),
 }
 
 type schemaImpl struct {
 	pkCols, nonPKCols, allCols *ColCollection
 	indexCollection            IndexCollection
+	checkCollection            CheckCollection
 }
 
-// SchemaFromCols creates a Schema from a collection of columns
+// SchemaFromCols creates a Schema from a collection of columns. If none of the columns are part of the primary key, the
+// schema is keyless.
 func SchemaFromCols(allCols *ColCollection) Schema {
 	var pkCols []Column
 	var nonPKCols []Column
@@ -45,10 +50,6 @@ func SchemaFromCols(allCols *ColCollection) Schema {
 		}
 	}
 
-	if len(pkCols) == 0 {
-		panic("no primary key columns specified")
-	}
-
 	pkColColl, _ := NewColCollection(pkCols...)
 	nonPKColColl, _ := NewColCollection(nonPKCols...)
 
@@ -57,25 +58,20 @@ func SchemaFromCols(allCols *ColCollection) Schema {
 		nonPKCols:       nonPKColColl,
 		allCols:         allCols,
 		indexCollection: NewIndexCollection(allCols),
+		checkCollection: NewCheckCollection(),
 	}
 }
 
 // ValidateForInsert returns an error if the given schema cannot be written to the dolt database.
 func ValidateForInsert(allCols *ColCollection) error {
-	var seenPkCol bool
-	for _, c := range allCols.cols {
-		if c.IsPartOfPK {
-			seenPkCol = true
-			break
-		}
-	}
-
-	if !seenPkCol {
-		return ErrNoPrimaryKeyColumns
+	if allCols.Size() == 0 {
+		return ErrNoColumns
 	}
 
 	colNames := make(map[string]bool)
 	colTags := make(map[uint64]bool)
+	seenAutoIncrement := false
+	seenPK := false
 
 	err := allCols.Iter(func(tag uint64, col Column) (stop bool, err error) {
 		if _, ok := colTags[tag]; ok {
@@ -88,12 +84,32 @@ func ValidateForInsert(allCols *ColCollection) error {
 		}
 		colNames[col.Name] = true
 
+		if col.AutoIncrement {
+			if seenAutoIncrement {
+				return true, ErrMultipleAutoIncrement
+			}
+			seenAutoIncrement = true
+
+			// values are generated from the greatest existing key, so the column must be the first primary key column
+			if !col.IsPartOfPK || seenPK || !isIntegerKind(col.Kind) || col.HasDefault() {
+				return true, ErrInvalidAutoIncrement
+			}
+		}
+
+		if col.IsPartOfPK {
+			seenPK = true
+		}
+
 		return false, nil
 	})
 
 	return err
 }
 
+func isIntegerKind(kind types.NomsKind) bool {
+	return kind == types.IntKind || kind == types.UintKind
+}
+
 // UnkeyedSchemaFromCols creates a schema without any primary keys to be used for displaying to users, tests, etc. Such
 // unkeyed schemas are not suitable to be inserted into storage.
 func UnkeyedSchemaFromCols(allCols *ColCollection) Schema {
@@ -113,6 +129,7 @@ func UnkeyedSchemaFromCols(allCols *ColCollection) Schema {
 		nonPKCols:       nonPKColColl,
 		allCols:         nonPKColColl,
 		indexCollection: NewIndexCollection(nil),
+		checkCollection: NewCheckCollection(),
 	}
 }
 
@@ -150,6 +167,7 @@ func SchemaFromPKAndNonPKCols(pkCols, nonPKCols *ColCollection) (Schema, error)
 		nonPKCols:       nonPKCols,
 		allCols:         allColColl,
 		indexCollection: NewIndexCollection(allColColl),
+		checkCollection: NewCheckCollection(),
 	}, nil
 }
 
@@ -201,3 +219,7 @@ func (si *schemaImpl) String() string {
 func (si *schemaImpl) Indexes() IndexCollection {
 	return si.indexCollection
 }
+
+func (si *schemaImpl) Checks() CheckCollection {
+	return si.checkCollection
+}
@@ -47,14 +47,14 @@ var ageVal = types.Uint(53)
 var titleVal = types.NullValue
 
 var pkCols = []Column{
-	{lnColName, lnColTag, types.StringKind, true, typeinfo.StringDefaultType, nil},
-	{fnColName, fnColTag, types.StringKind, true, typeinfo.StringDefaultType, nil},
+	{lnColName, lnColTag, types.StringKind, true, typeinfo.StringDefaultType, nil, "", false, ""},
+	{fnColName, fnColTag, types.StringKind, true, typeinfo.StringDefaultType, nil, "", false, ""},
 }
 var nonPkCols = []Column{
-	{addrColName, addrColTag, types.StringKind, false, typeinfo.StringDefaultType, nil},
-	{ageColName, ageColTag, types.UintKind, false, typeinfo.FromKind(types.UintKind), nil},
-	{titleColName, titleColTag, types.StringKind, false, typeinfo.StringDefaultType, nil},
-	{reservedColName, reservedColTag, types.StringKind, false, typeinfo.StringDefaultType, nil},
+	{addrColName, addrColTag, types.StringKind, false, typeinfo.StringDefaultType, nil, "", false, ""},
+	{ageColName, ageColTag, types.UintKind, false, typeinfo.FromKind(types.UintKind), nil, "", false, ""},
+	{titleColName, titleColTag, types.StringKind, false, typeinfo.StringDefaultType, nil, "", false, ""},
+	{reservedColName, reservedColTag, types.StringKind, false, typeinfo.StringDefaultType, nil, "", false, ""},
 }
 
 var allCols = append(append([]Column(nil), pkCols...), nonPkCols...)
@@ -81,9 +81,10 @@ func TestSchemaWithNoPKs(t *testing.T) {
 	colColl, err := NewColCollection(nonPkCols...)
 	require.NoError(t, err)
 
-	assert.Panics(t, func() {
-		SchemaFromCols(colColl)
-	})
+	sch := SchemaFromCols(colColl)
+	assert.True(t, IsKeyless(sch))
+	assert.Equal(t, 0, sch.GetPKCols().Size())
+	assert.Equal(t, colColl.Size(), sch.GetNonPKCols().Size())
 
 	assert.NotPanics(t, func() {
 		UnkeyedSchemaFromCols(colColl)
@@ -98,7 +99,7 @@ func TestValidateForInsert(t *testing.T) {
 	})
 
 	t.Run("Name collision", func(t *testing.T) {
-		cols := append(allCols, Column{titleColName, 100, types.StringKind, false, typeinfo.StringDefaultType, nil})
+		cols := append(allCols, Column{titleColName, 100, types.StringKind, false, typeinfo.StringDefaultType, nil, "", false, ""})
 		colColl, err := NewColCollection(cols...)
 		require.NoError(t, err)
 
@@ -112,8 +113,13 @@ func TestValidateForInsert(t *testing.T) {
 		require.NoError(t, err)
 
 		err = ValidateForInsert(colColl)
-		assert.Error(t, err)
-		assert.Equal(t, err, ErrNoPrimaryKeyColumns)
+		assert.NoError(t, err)
+		assert.True(t, IsKeyless(SchemaFromCols(colColl)))
+	})
+
+	t.Run("No columns", func(t *testing.T) {
+		err := ValidateForInsert(EmptyColColl)
+		assert.Equal(t, err, ErrNoColumns)
 	})
 }
 
@@ -18,6 +18,7 @@ import (
 	"crypto/sha512"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/rand"
 	"regexp"
 	"strings"
@@ -29,6 +30,12 @@ import (
 const (
 	// ReservedTagMin is the start of a range of tags which the user should not be able to use in their schemas.
 	ReservedTagMin uint64 = 1 << 50
+
+	// KeylessRowIdTag is the tag of the hash of a row's values, which is the key of the rows of keyless tables.
+	KeylessRowIdTag uint64 = math.MaxUint64
+
+	// KeylessRowCardinalityTag is the tag of the number of copies of a row in a keyless table.
+	KeylessRowCardinalityTag uint64 = KeylessRowIdTag - 1
 )
 
 func ErrTagPrevUsed(tag uint64, newColName, tableName string) error {
@@ -0,0 +1,131 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"vitess.io/vitess/go/sqltypes"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestBlobStringConvertValueToNomsValue(t *testing.T) {
+	tests := []struct {
+		typ         *blobStringType
+		input       interface{}
+		output      string
+		expectedErr bool
+	}{
+		{
+			TextType,
+			"abc",
+			"abc",
+			false,
+		},
+		{
+			TextType,
+			int64(12),
+			"12",
+			false,
+		},
+		{
+			LongTextType,
+			strings.Repeat("dolt ", 1<<20),
+			strings.Repeat("dolt ", 1<<20),
+			false,
+		},
+		{
+			TinyTextType,
+			strings.Repeat("a", 256),
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), len(test.output)), func(t *testing.T) {
+			output, err := test.typ.ConvertValueToNomsValue(context.Background(), testVRW, test.input)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, types.BlobKind, output.Kind())
+			assert.Equal(t, uint64(len(test.output)), output.(types.Blob).Len())
+
+			val, err := test.typ.ConvertNomsValueToValue(output)
+			require.NoError(t, err)
+			assert.Equal(t, test.output, val)
+		})
+	}
+}
+
+func TestBlobStringSharesChunks(t *testing.T) {
+	ctx := context.Background()
+	rnd := rand.New(rand.NewSource(0))
+	words := make([]string, 500000)
+	for i := range words {
+		words[i] = strconv.Itoa(rnd.Int())
+	}
+	doc := strings.Join(words, " ")
+	edited := doc[:len(doc)/2] + "All play and no work makes Jack a mere toy. " + doc[len(doc)/2:]
+
+	val, err := LongTextType.ConvertValueToNomsValue(ctx, testVRW, doc)
+	require.NoError(t, err)
+	editedVal, err := LongTextType.ConvertValueToNomsValue(ctx, testVRW, edited)
+	require.NoError(t, err)
+
+	// large values are chunked, and an edit in the middle of the value leaves most of the chunks untouched
+	refs := make(map[hash.Hash]bool)
+	err = val.WalkRefs(types.Format_Default, func(r types.Ref) error {
+		refs[r.TargetHash()] = true
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, len(refs) > 1)
+
+	shared := 0
+	err = editedVal.WalkRefs(types.Format_Default, func(r types.Ref) error {
+		if refs[r.TargetHash()] {
+			shared++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, shared >= len(refs)-2, "only %d of %d chunks are shared", shared, len(refs))
+}
+
+func TestBlobStringParams(t *testing.T) {
+	collation := sql.Collation_utf8mb4_bin
+	ti, err := CreateBlobStringTypeFromParams(map[string]string{
+		blobStringTypeParam_Collate: collation.String(),
+		blobStringTypeParam_Length:  "65535",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &blobStringType{sql.MustCreateString(sqltypes.Text, 65535, collation)}, ti)
+
+	_, err = CreateBlobStringTypeFromParams(map[string]string{blobStringTypeParam_Length: "65535"})
+	assert.Error(t, err)
+}
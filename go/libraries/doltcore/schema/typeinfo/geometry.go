@@ -0,0 +1,154 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	geometryTypeParam_Shape = "shape"
+)
+
+// geometryType is the type of POINT, LINESTRING, POLYGON and GEOMETRY columns. Values are stored as an InlineBlob
+// holding the SRID followed by the WKB of the shape.
+type geometryType struct {
+	sqlGeometryType geometry.SqlType
+}
+
+var _ TypeInfo = (*geometryType)(nil)
+
+var (
+	GeometryType   = &geometryType{geometry.GeometrySqlType}
+	PointType      = &geometryType{geometry.PointSqlType}
+	LineStringType = &geometryType{geometry.LineStringSqlType}
+	PolygonType    = &geometryType{geometry.PolygonSqlType}
+)
+
+func CreateGeometryTypeFromParams(params map[string]string) (TypeInfo, error) {
+	if shapeStr, ok := params[geometryTypeParam_Shape]; ok {
+		shapeType, err := geometry.ShapeTypeFromString(shapeStr)
+		if err != nil {
+			return nil, err
+		}
+		return &geometryType{geometry.SqlType{ShapeType: shapeType}}, nil
+	} else {
+		return nil, fmt.Errorf(`create geometry type info is missing param "%v"`, geometryTypeParam_Shape)
+	}
+}
+
+// ConvertNomsValueToValue implements TypeInfo interface.
+func (ti *geometryType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+	if val, ok := v.(types.InlineBlob); ok {
+		g, err := ti.sqlGeometryType.Convert([]byte(val))
+		if err != nil {
+			return nil, fmt.Errorf(`"%v" cannot convert value: %v`, ti.String(), err)
+		}
+		return g, nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), v.Kind())
+}
+
+// ConvertValueToNomsValue implements TypeInfo interface.
+func (ti *geometryType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	if v == nil {
+		return types.NullValue, nil
+	}
+	g, err := ti.sqlGeometryType.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+	return types.InlineBlob(g.(geometry.Geometry).Serialize()), nil
+}
+
+// Equals implements TypeInfo interface.
+func (ti *geometryType) Equals(other TypeInfo) bool {
+	if other == nil {
+		return false
+	}
+	if ti2, ok := other.(*geometryType); ok {
+		return ti.sqlGeometryType.ShapeType == ti2.sqlGeometryType.ShapeType
+	}
+	return false
+}
+
+// FormatValue implements TypeInfo interface. Geometries are formatted as well-known text, preceded by their SRID when
+// it is not 0.
+func (ti *geometryType) FormatValue(v types.Value) (*string, error) {
+	if _, ok := v.(types.InlineBlob); ok {
+		g, err := ti.ConvertNomsValueToValue(v)
+		if err != nil {
+			return nil, err
+		}
+		res := g.(geometry.Geometry).String()
+		return &res, nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a string`, ti.String(), v.Kind())
+}
+
+// GetTypeIdentifier implements TypeInfo interface.
+func (ti *geometryType) GetTypeIdentifier() Identifier {
+	return GeometryTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo interface.
+func (ti *geometryType) GetTypeParams() map[string]string {
+	return map[string]string{geometryTypeParam_Shape: ti.sqlGeometryType.ShapeType.String()}
+}
+
+// IsValid implements TypeInfo interface.
+func (ti *geometryType) IsValid(v types.Value) bool {
+	_, err := ti.ConvertNomsValueToValue(v)
+	return err == nil
+}
+
+// NomsKind implements TypeInfo interface.
+func (ti *geometryType) NomsKind() types.NomsKind {
+	return types.InlineBlobKind
+}
+
+// ParseValue implements TypeInfo interface. The string is parsed in the format written by FormatValue.
+func (ti *geometryType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil || *str == "" {
+		return types.NullValue, nil
+	}
+	g, err := geometry.Parse(*str)
+	if err != nil {
+		return nil, err
+	}
+	return ti.ConvertValueToNomsValue(ctx, vrw, g)
+}
+
+// String implements TypeInfo interface.
+func (ti *geometryType) String() string {
+	return fmt.Sprintf(`Geometry(%v)`, ti.sqlGeometryType.ShapeType.String())
+}
+
+// ToSqlType implements TypeInfo interface.
+func (ti *geometryType) ToSqlType() sql.Type {
+	return ti.sqlGeometryType
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestGeometryConvertValueToNomsValue(t *testing.T) {
+	point := geometry.Geometry{SRID: 4326, Shape: geometry.Point{X: 1, Y: 2}}
+	line := geometry.Geometry{Shape: geometry.LineString{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+
+	tests := []struct {
+		typ         *geometryType
+		input       interface{}
+		output      types.Value
+		expectedErr bool
+	}{
+		{
+			PointType,
+			point,
+			types.InlineBlob(point.Serialize()),
+			false,
+		},
+		{
+			PointType,
+			point.Serialize(),
+			types.InlineBlob(point.Serialize()),
+			false,
+		},
+		{
+			GeometryType,
+			line,
+			types.InlineBlob(line.Serialize()),
+			false,
+		},
+		{
+			PointType,
+			line,
+			nil,
+			true,
+		},
+		{
+			PolygonType,
+			"not a geometry",
+			nil,
+			true,
+		},
+		{
+			GeometryType,
+			int64(5),
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), test.input), func(t *testing.T) {
+			output, err := test.typ.ConvertValueToNomsValue(context.Background(), nil, test.input)
+			if !test.expectedErr {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, output)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGeometryFormatParseValue(t *testing.T) {
+	str := "SRID=4326;LINESTRING(1.5 2,3 -4)"
+	val, err := LineStringType.ParseValue(context.Background(), nil, &str)
+	require.NoError(t, err)
+
+	output, err := LineStringType.FormatValue(val)
+	require.NoError(t, err)
+	assert.Equal(t, str, *output)
+
+	str = "POINT(1 2)"
+	_, err = PolygonType.ParseValue(context.Background(), nil, &str)
+	assert.Error(t, err)
+
+	str = "POINT(1)"
+	_, err = GeometryType.ParseValue(context.Background(), nil, &str)
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -93,7 +94,7 @@ func TestInlineBlobConvertValueToNomsValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, InlineBlobType.String(), test.input), func(t *testing.T) {
-			output, err := InlineBlobType.ConvertValueToNomsValue(test.input)
+			output, err := InlineBlobType.ConvertValueToNomsValue(context.Background(), nil, test.input)
 			if !test.expectedErr {
 				require.NoError(t, err)
 				assert.Equal(t, test.output, output)
@@ -153,7 +154,7 @@ func TestInlineBlobParseValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, InlineBlobType.String(), test.input), func(t *testing.T) {
-			output, err := InlineBlobType.ParseValue(&test.input)
+			output, err := InlineBlobType.ParseValue(context.Background(), nil, &test.input)
 			require.NoError(t, err)
 			assert.Equal(t, test.output, output)
 		})
@@ -0,0 +1,319 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/proto/query"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+type jsonType struct {
+	sqlJSONType sql.JsonType
+}
+
+var _ TypeInfo = (*jsonType)(nil)
+
+var JSONType = &jsonType{JSONSqlType}
+
+// ConvertNomsValueToValue implements TypeInfo interface.
+func (ti *jsonType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+	if val, ok := v.(types.String); ok {
+		return []byte(val), nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), v.Kind())
+}
+
+// ConvertValueToNomsValue implements TypeInfo interface.
+func (ti *jsonType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	var doc interface{}
+	var err error
+	switch val := v.(type) {
+	case nil:
+		return types.NullValue, nil
+	case string:
+		doc, err = UnmarshalJSON([]byte(val))
+	case []byte:
+		doc, err = UnmarshalJSON(val)
+	default:
+		// documents returned from functions such as JSON_EXTRACT are already decoded
+		doc = val
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`"%v" cannot convert value "%v" as it is not a valid JSON document: %v`, ti.String(), v, err)
+	}
+	canonical, err := MarshalJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf(`"%v" cannot convert value "%v" of type "%T" as it is invalid`, ti.String(), v, v)
+	}
+	return types.String(canonical), nil
+}
+
+// Equals implements TypeInfo interface.
+func (ti *jsonType) Equals(other TypeInfo) bool {
+	if other == nil {
+		return false
+	}
+	_, ok := other.(*jsonType)
+	return ok
+}
+
+// FormatValue implements TypeInfo interface.
+func (ti *jsonType) FormatValue(v types.Value) (*string, error) {
+	if val, ok := v.(types.String); ok {
+		res := string(val)
+		return &res, nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a string`, ti.String(), v.Kind())
+}
+
+// GetTypeIdentifier implements TypeInfo interface.
+func (ti *jsonType) GetTypeIdentifier() Identifier {
+	return JSONTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo interface.
+func (ti *jsonType) GetTypeParams() map[string]string {
+	return nil
+}
+
+// IsValid implements TypeInfo interface.
+func (ti *jsonType) IsValid(v types.Value) bool {
+	if val, ok := v.(types.String); ok {
+		_, err := UnmarshalJSON([]byte(val))
+		return err == nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return true
+	}
+	return false
+}
+
+// NomsKind implements TypeInfo interface.
+func (ti *jsonType) NomsKind() types.NomsKind {
+	return types.StringKind
+}
+
+// ParseValue implements TypeInfo interface.
+func (ti *jsonType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil || *str == "" {
+		return types.NullValue, nil
+	}
+	return ti.ConvertValueToNomsValue(ctx, vrw, *str)
+}
+
+// String implements TypeInfo interface.
+func (ti *jsonType) String() string {
+	return "JSON"
+}
+
+// ToSqlType implements TypeInfo interface.
+func (ti *jsonType) ToSqlType() sql.Type {
+	return ti.sqlJSONType
+}
+
+// jsonSqlType is the SQL type of JSON columns. Unlike the engine's JSON type, documents are decoded without losing the
+// precision of their numbers, and text which is not a valid JSON document is rejected rather than stored as a string.
+// Values are held by the engine as the canonical encoding of their documents.
+type jsonSqlType struct{}
+
+var _ sql.JsonType = jsonSqlType{}
+
+var JSONSqlType sql.JsonType = jsonSqlType{}
+
+// Compare implements sql.Type. Documents are ordered by their canonical encoding, which is only meaningful for equality.
+func (t jsonSqlType) Compare(a interface{}, b interface{}) (int, error) {
+	if a == nil && b == nil {
+		return 0, nil
+	} else if a == nil {
+		return -1, nil
+	} else if b == nil {
+		return 1, nil
+	}
+
+	ja, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	jb, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return bytes.Compare(ja.([]byte), jb.([]byte)), nil
+}
+
+// Convert implements sql.Type. Strings and byte slices must be JSON documents, and other values are treated as decoded
+// documents, as returned from functions such as JSON_EXTRACT.
+func (t jsonSqlType) Convert(v interface{}) (interface{}, error) {
+	var doc interface{}
+	var err error
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		doc, err = UnmarshalJSON([]byte(val))
+	case []byte:
+		doc, err = UnmarshalJSON(val)
+	default:
+		doc = val
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %v", err)
+	}
+
+	canonical, err := MarshalJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %v", err)
+	}
+
+	return []byte(canonical), nil
+}
+
+// MustConvert implements sql.Type.
+func (t jsonSqlType) MustConvert(v interface{}) interface{} {
+	value, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Promote implements sql.Type.
+func (t jsonSqlType) Promote() sql.Type {
+	return t
+}
+
+// SQL implements sql.Type.
+func (t jsonSqlType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+
+	doc, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.TypeJSON, doc.([]byte)), nil
+}
+
+// String implements sql.Type.
+func (t jsonSqlType) String() string {
+	return "JSON"
+}
+
+// Type implements sql.Type.
+func (t jsonSqlType) Type() query.Type {
+	return sqltypes.TypeJSON
+}
+
+// Zero implements sql.Type.
+func (t jsonSqlType) Zero() interface{} {
+	return []byte(`""`)
+}
+
+// UnmarshalJSON decodes a single JSON document. Numbers are decoded as json.Number so that no precision is lost.
+func UnmarshalJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the end of the JSON document")
+	}
+	return doc, nil
+}
+
+// MarshalJSON encodes the decoded JSON document in its canonical form. Object keys are sorted, there is no
+// insignificant whitespace and numbers are written in their shortest form, so equal documents are encoded identically.
+func MarshalJSON(doc interface{}) (string, error) {
+	doc, err := canonicalizeJSONNumbers(doc)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(buf.Bytes(), "\n")), nil
+}
+
+func canonicalizeJSONNumbers(doc interface{}) (interface{}, error) {
+	var err error
+	switch val := doc.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			if res[k], err = canonicalizeJSONNumbers(v); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, len(val))
+		for i, v := range val {
+			if res[i], err = canonicalizeJSONNumbers(v); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case json.Number:
+		return canonicalJSONNumber(string(val))
+	case float32:
+		return canonicalJSONNumber(strconv.FormatFloat(float64(val), 'g', -1, 32))
+	case float64:
+		return canonicalJSONNumber(strconv.FormatFloat(val, 'g', -1, 64))
+	default:
+		return doc, nil
+	}
+}
+
+func canonicalJSONNumber(num string) (json.Number, error) {
+	if _, err := strconv.ParseInt(num, 10, 64); err == nil {
+		return json.Number(num), nil
+	}
+	if _, err := strconv.ParseUint(num, 10, 64); err == nil {
+		return json.Number(num), nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return "", err
+	}
+	if f >= math.MinInt64 && f < math.MaxInt64 && f == math.Trunc(f) {
+		return json.Number(strconv.FormatInt(int64(f), 10)), nil
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestJSONConvertValueToNomsValue(t *testing.T) {
+	tests := []struct {
+		input       interface{}
+		output      types.String
+		expectedErr bool
+	}{
+		{
+			`{"b": 2, "a": [1, 2.50, 1e2]}`,
+			`{"a":[1,2.5,100],"b":2}`,
+			false,
+		},
+		{
+			[]byte(` { "z" : { "y": null, "x": "<tag>" } } `),
+			`{"z":{"x":"<tag>","y":null}}`,
+			false,
+		},
+		{
+			`12345678901234567890`,
+			`12345678901234567890`,
+			false,
+		},
+		{
+			map[string]interface{}{"b": float64(1.0), "a": "c"},
+			`{"a":"c","b":1}`,
+			false,
+		},
+		{
+			[]interface{}{"a", true},
+			`["a",true]`,
+			false,
+		},
+		{
+			`{"a": 1`,
+			"",
+			true,
+		},
+		{
+			`{"a": 1} {"b": 2}`,
+			"",
+			true,
+		},
+		{
+			`not json`,
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, JSONType.String(), test.input), func(t *testing.T) {
+			output, err := JSONType.ConvertValueToNomsValue(context.Background(), nil, test.input)
+			if !test.expectedErr {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, output)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestJSONParseValue(t *testing.T) {
+	output, err := JSONType.ParseValue(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.NullValue, output)
+
+	str := `[3, {"b": "x", "a": 1.0}]`
+	output, err = JSONType.ParseValue(context.Background(), nil, &str)
+	require.NoError(t, err)
+	assert.Equal(t, types.String(`[3,{"a":1,"b":"x"}]`), output)
+
+	str = `{"a": }`
+	_, err = JSONType.ParseValue(context.Background(), nil, &str)
+	assert.Error(t, err)
+}
+
+func TestJSONSqlTypeConvert(t *testing.T) {
+	tests := []struct {
+		input       interface{}
+		output      interface{}
+		expectedErr bool
+	}{
+		{nil, nil, false},
+		{`9007199254740993`, []byte(`9007199254740993`), false},
+		{[]byte(`{"b": 12345678901234567890, "a": [1.50]}`), []byte(`{"a":[1.5],"b":12345678901234567890}`), false},
+		{map[string]interface{}{"a": float64(2)}, []byte(`{"a":2}`), false},
+		{`not json`, nil, true},
+		{`{"a":1e400}`, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v`, test.input), func(t *testing.T) {
+			output, err := JSONSqlType.Convert(test.input)
+			if !test.expectedErr {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, output)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	cmp, err := JSONSqlType.Compare(`{"a": 1, "b": 2}`, []byte(`{"b":2,"a":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+}
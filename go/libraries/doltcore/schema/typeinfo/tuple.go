@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/liquidata-inc/go-mysql-server/sql"
@@ -38,7 +39,7 @@ func (ti *tupleType) ConvertNomsValueToValue(v types.Value) (interface{}, error)
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *tupleType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *tupleType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	if tVal, ok := v.(types.Value); ok {
 		return tVal, nil
 	}
@@ -87,7 +88,7 @@ func (ti *tupleType) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *tupleType) ParseValue(str *string) (types.Value, error) {
+func (ti *tupleType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	return nil, fmt.Errorf(`"%v" cannot parse strings`, ti.String())
 }
 
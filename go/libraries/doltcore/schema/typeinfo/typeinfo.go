@@ -15,11 +15,13 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/liquidata-inc/go-mysql-server/sql"
 	"vitess.io/vitess/go/sqltypes"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -28,13 +30,16 @@ type Identifier string
 const (
 	UnknownTypeIdentifier    Identifier = "unknown"
 	BitTypeIdentifier        Identifier = "bit"
+	BlobStringTypeIdentifier Identifier = "blobstring"
 	BoolTypeIdentifier       Identifier = "bool"
 	DatetimeTypeIdentifier   Identifier = "datetime"
 	DecimalTypeIdentifier    Identifier = "decimal"
 	EnumTypeIdentifier       Identifier = "enum"
 	FloatTypeIdentifier      Identifier = "float"
+	GeometryTypeIdentifier   Identifier = "geometry"
 	InlineBlobTypeIdentifier Identifier = "inlineblob"
 	IntTypeIdentifier        Identifier = "int"
+	JSONTypeIdentifier       Identifier = "json"
 	SetTypeIdentifier        Identifier = "set"
 	TimeTypeIdentifier       Identifier = "time"
 	TupleTypeIdentifier      Identifier = "tuple"
@@ -48,13 +53,16 @@ const (
 var Identifiers = map[Identifier]struct{}{
 	UnknownTypeIdentifier:    {},
 	BitTypeIdentifier:        {},
+	BlobStringTypeIdentifier: {},
 	BoolTypeIdentifier:       {},
 	DatetimeTypeIdentifier:   {},
 	DecimalTypeIdentifier:    {},
 	EnumTypeIdentifier:       {},
 	FloatTypeIdentifier:      {},
+	GeometryTypeIdentifier:   {},
 	InlineBlobTypeIdentifier: {},
 	IntTypeIdentifier:        {},
+	JSONTypeIdentifier:       {},
 	SetTypeIdentifier:        {},
 	TimeTypeIdentifier:       {},
 	TupleTypeIdentifier:      {},
@@ -72,8 +80,9 @@ type TypeInfo interface {
 	ConvertNomsValueToValue(v types.Value) (interface{}, error)
 
 	// ConvertValueToNomsValue converts a go value or Noms value to a Noms value. The type of the Noms
-	// value will be equivalent to the NomsKind returned from NomsKind.
-	ConvertValueToNomsValue(v interface{}) (types.Value, error)
+	// value will be equivalent to the NomsKind returned from NomsKind. Values that are stored outside
+	// of the row, such as blobs, are written to the given ValueReadWriter.
+	ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error)
 
 	// Equals returns whether the given TypeInfo is equivalent to this TypeInfo.
 	Equals(other TypeInfo) bool
@@ -95,7 +104,8 @@ type TypeInfo interface {
 	NomsKind() types.NomsKind
 
 	// ParseValue parses a string and returns a go value that represents it according to this type.
-	ParseValue(str *string) (types.Value, error)
+	// Values that are stored outside of the row, such as blobs, are written to the given ValueReadWriter.
+	ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error)
 
 	// ToSqlType returns the TypeInfo as a sql.Type. If an exact match is able to be made then that is
 	// the one returned, otherwise the sql.Type is the closest match possible.
@@ -155,12 +165,8 @@ func FromSqlType(sqlType sql.Type) (TypeInfo, error) {
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Text"`)
 		}
-		return &varStringType{stringType}, nil
+		return &blobStringType{stringType}, nil
 	case sqltypes.Blob:
-		//TODO: determine the storage format
-		if fmt.Sprintf("a") != "" { // always evaluates to true, compiler won't complain about unreachable code
-			return nil, fmt.Errorf(`"%v" has not yet been implemented`, sqlType.String())
-		}
 		stringType, ok := sqlType.(sql.StringType)
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Blob"`)
@@ -198,6 +204,14 @@ func FromSqlType(sqlType sql.Type) (TypeInfo, error) {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Binary"`)
 		}
 		return &varBinaryType{stringType}, nil
+	case sqltypes.TypeJSON:
+		return JSONType, nil
+	case sqltypes.Geometry:
+		geometrySQLType, ok := sqlType.(geometry.SqlType)
+		if !ok {
+			return nil, fmt.Errorf(`expected "GeometryTypeIdentifier" from SQL basetype "Geometry"`)
+		}
+		return &geometryType{geometrySQLType}, nil
 	case sqltypes.Bit:
 		bitSQLType, ok := sqlType.(sql.BitType)
 		if !ok {
@@ -226,6 +240,8 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 	switch id {
 	case BitTypeIdentifier:
 		return CreateBitTypeFromParams(params)
+	case BlobStringTypeIdentifier:
+		return CreateBlobStringTypeFromParams(params)
 	case BoolTypeIdentifier:
 		return BoolType, nil
 	case DatetimeTypeIdentifier:
@@ -236,10 +252,14 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 		return CreateEnumTypeFromParams(params)
 	case FloatTypeIdentifier:
 		return CreateFloatTypeFromParams(params)
+	case GeometryTypeIdentifier:
+		return CreateGeometryTypeFromParams(params)
 	case InlineBlobTypeIdentifier:
 		return InlineBlobType, nil
 	case IntTypeIdentifier:
 		return CreateIntTypeFromParams(params)
+	case JSONTypeIdentifier:
+		return JSONType, nil
 	case SetTypeIdentifier:
 		return CreateSetTypeFromParams(params)
 	case TimeTypeIdentifier:
@@ -264,6 +284,8 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 // FromKind returns the default TypeInfo for a given types.Value.
 func FromKind(kind types.NomsKind) TypeInfo {
 	switch kind {
+	case types.BlobKind:
+		return LongBlobType
 	case types.BoolKind:
 		return BoolType
 	case types.FloatKind:
@@ -291,19 +313,43 @@ func FromKind(kind types.NomsKind) TypeInfo {
 
 // Convert takes in a types.Value, as well as the source and destination TypeInfos, and
 // converts the TypeInfo into the applicable types.Value.
-func Convert(v types.Value, srcTi TypeInfo, destTi TypeInfo) (types.Value, error) {
+func Convert(ctx context.Context, vrw types.ValueReadWriter, v types.Value, srcTi TypeInfo, destTi TypeInfo) (types.Value, error) {
 	str, err := srcTi.FormatValue(v)
 	if err != nil {
 		return nil, err
 	}
-	val, err := destTi.ParseValue(str)
+	val, err := destTi.ParseValue(ctx, vrw, str)
 	if err != nil {
 		return nil, err
 	}
 	return val, nil
 }
 
-// IsStringType returns whether the given TypeInfo represents a CHAR, VARCHAR, or TEXT-derivative.
+// IsBlobType returns whether the given TypeInfo represents a TEXT or BLOB type, whose values are stored as Noms blobs.
+func IsBlobType(ti TypeInfo) bool {
+	switch ti.(type) {
+	case *blobStringType, *varBinaryType:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToKeyType returns the TypeInfo used for a primary key or index column of the given type. Key values must be stored in
+// the row itself so that rows are ordered by them, so TEXT types are stored as strings, and BLOB types are not supported.
+func ToKeyType(ti TypeInfo) (TypeInfo, error) {
+	switch ti := ti.(type) {
+	case *blobStringType:
+		return &varStringType{ti.sqlStringType}, nil
+	case *varBinaryType:
+		return nil, fmt.Errorf(`"%v" cannot be used in a key`, ti.ToSqlType().String())
+	default:
+		return ti, nil
+	}
+}
+
+// IsStringType returns whether the given TypeInfo represents a CHAR, VARCHAR, or TEXT-derivative that is stored inline as a
+// string. TEXT types stored as blobs are not included.
 func IsStringType(ti TypeInfo) bool {
 	_, ok := ti.(*varStringType)
 	return ok
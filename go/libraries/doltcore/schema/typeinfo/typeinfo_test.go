@@ -15,7 +15,10 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -69,8 +73,6 @@ func verifyTypeInfoArrays(t *testing.T, tiArrays [][]TypeInfo, vaArrays [][]type
 	// delete any types that should not be tested
 	delete(seenTypeInfos, UnknownTypeIdentifier)
 	delete(seenTypeInfos, TupleTypeIdentifier)
-	//TODO: determine the storage format for VarBinaryType
-	delete(seenTypeInfos, VarBinaryTypeIdentifier)
 	for _, tiArray := range tiArrays {
 		// no row should be empty
 		require.True(t, len(tiArray) > 0, `length of array "%v" should be greater than zero`, len(tiArray))
@@ -110,12 +112,12 @@ func testTypeInfoConvertRoundTrip(t *testing.T, tiArrays [][]TypeInfo, vaArrays
 				atLeastOneValid := false
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, val := range vaArrays[rowIndex] {
-						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
+						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), humanReadableString(val)), func(t *testing.T) {
 							vInterface, err := ti.ConvertNomsValueToValue(val)
 							if ti.IsValid(val) {
 								atLeastOneValid = true
 								require.NoError(t, err)
-								outVal, err := ti.ConvertValueToNomsValue(vInterface)
+								outVal, err := ti.ConvertValueToNomsValue(context.Background(), testVRW, vInterface)
 								require.NoError(t, err)
 								if ti == DateType { // Special case as DateType removes the hh:mm:ss
 									val = types.Timestamp(time.Time(val.(types.Timestamp)).Truncate(24 * time.Hour))
@@ -186,7 +188,7 @@ func testTypeInfoForeignKindHandling(t *testing.T, tiArrays [][]TypeInfo, vaArra
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, vaArray := range vaArrays {
 						for _, val := range vaArray {
-							t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
+							t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), humanReadableString(val)), func(t *testing.T) {
 								if ti.NomsKind() != val.Kind() {
 									_, err := ti.ConvertNomsValueToValue(val)
 									assert.Error(t, err)
@@ -210,12 +212,12 @@ func testTypeInfoFormatParseRoundTrip(t *testing.T, tiArrays [][]TypeInfo, vaArr
 				atLeastOneValid := false
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, val := range vaArrays[rowIndex] {
-						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
+						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), humanReadableString(val)), func(t *testing.T) {
 							str, err := ti.FormatValue(val)
 							if ti.IsValid(val) {
 								atLeastOneValid = true
 								require.NoError(t, err)
-								outVal, err := ti.ParseValue(str)
+								outVal, err := ti.ParseValue(context.Background(), testVRW, str)
 								require.NoError(t, err)
 								if ti == DateType { // special case as DateType removes the hh:mm:ss
 									val = types.Timestamp(time.Time(val.(types.Timestamp)).Truncate(24 * time.Hour))
@@ -265,7 +267,7 @@ func testTypeInfoNullHandling(t *testing.T, tiArrays [][]TypeInfo) {
 						require.Nil(t, val)
 					})
 					t.Run("ConvertValueToNomsValue", func(t *testing.T) {
-						tVal, err := ti.ConvertValueToNomsValue(nil)
+						tVal, err := ti.ConvertValueToNomsValue(context.Background(), testVRW, nil)
 						require.NoError(t, err)
 						require.Equal(t, types.NullValue, tVal)
 					})
@@ -282,7 +284,7 @@ func testTypeInfoNullHandling(t *testing.T, tiArrays [][]TypeInfo) {
 						require.True(t, ti.IsValid(nil))
 					})
 					t.Run("ParseValue", func(t *testing.T) {
-						tVal, err := ti.ParseValue(nil)
+						tVal, err := ti.ParseValue(context.Background(), testVRW, nil)
 						require.NoError(t, err)
 						require.Equal(t, types.NullValue, tVal)
 					})
@@ -328,20 +330,21 @@ func testTypeInfoToSqlType(t *testing.T, tiArrays [][]TypeInfo) {
 func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 	return [][]TypeInfo{
 			generateBitTypes(t, 16),
+			{TinyTextType, TextType, MediumTextType, LongTextType},
 			{BoolType},
 			{DateType, DatetimeType, TimestampType},
 			generateDecimalTypes(t, 16),
 			generateEnumTypes(t, 16),
 			{Float32Type, Float64Type},
+			{GeometryType, PointType, LineStringType, PolygonType},
 			{InlineBlobType},
 			{Int8Type, Int16Type, Int24Type, Int32Type, Int64Type},
+			{JSONType},
 			generateSetTypes(t, 16),
 			{TimeType},
 			{Uint8Type, Uint16Type, Uint24Type, Uint32Type, Uint64Type},
 			{UuidType},
-			//append(generateVarBinaryTypes(t, 12),
-			//	&varBinaryType{sql.TinyBlob}, &varBinaryType{sql.Blob},
-			//	&varBinaryType{sql.MediumBlob}, &varBinaryType{sql.LongBlob}),
+			{TinyBlobType, BlobType, MediumBlobType, LongBlobType},
 			append(generateVarStringTypes(t, 12),
 				&varStringType{sql.CreateTinyText(sql.Collation_Default)}, &varStringType{sql.CreateText(sql.Collation_Default)},
 				&varStringType{sql.CreateMediumText(sql.Collation_Default)}, &varStringType{sql.CreateLongText(sql.Collation_Default)}),
@@ -349,6 +352,8 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 		},
 		[][]types.Value{
 			{types.Uint(1), types.Uint(207), types.Uint(79147), types.Uint(34845728), types.Uint(9274618927)}, //Bit
+			{mustBlob(t, ""), mustBlob(t, "a"), mustBlob(t, "abc"), //BlobString
+				mustBlob(t, "abcdefghijklmnopqrstuvwxyz"), mustBlob(t, "هذا هو بعض نماذج النص التي أستخدمها لاختبار عناصر")},
 			{types.Bool(false), types.Bool(true)}, //Bool
 			{types.Timestamp(time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)), //Datetime
 				types.Timestamp(time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC)),
@@ -362,16 +367,46 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 				types.Decimal(decimal.RequireFromString("198728394234798423466321.27349757"))},
 			{types.Uint(1), types.Uint(3), types.Uint(5), types.Uint(7), types.Uint(8)},                                                                                                    //Enum
 			{types.Float(1.0), types.Float(65513.75), types.Float(4293902592), types.Float(4.58E71), types.Float(7.172E285)},                                                               //Float
+			{mustGeometry(t, "POINT(1 2)"), mustGeometry(t, "SRID=4326;POINT(-122.4 37.8)"), mustGeometry(t, "LINESTRING(0 0,1 1,2 0)"), //Geometry
+				mustGeometry(t, "POLYGON((0 0,4 0,4 4,0 0))"), mustGeometry(t, "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))")},
 			{types.InlineBlob{0}, types.InlineBlob{21}, types.InlineBlob{1, 17}, types.InlineBlob{72, 42}, types.InlineBlob{21, 122, 236}},                                                 //InlineBlob
 			{types.Int(20), types.Int(215), types.Int(237493), types.Int(2035753568), types.Int(2384384576063)},                                                                            //Int
+			{types.String(`{}`), types.String(`{"a":1,"b":[true,null]}`), types.String(`[1.5,"abc",{"c":{"d":-3}}]`), //JSON
+				types.String(`"abc"`), types.String(`12345678901234567890`)},
 			{types.Uint(1), types.Uint(5), types.Uint(64), types.Uint(42), types.Uint(192)},                                                                                                //Set
 			{types.Int(0), types.Int(1000000 /*"00:00:01"*/), types.Int(113000000 /*"00:01:53"*/), types.Int(247019000000 /*"68:36:59"*/), types.Int(458830485214 /*"127:27:10.485214"*/)}, //Time
 			{types.Uint(20), types.Uint(275), types.Uint(328395), types.Uint(630257298), types.Uint(93897259874)},                                                                          //Uint
 			{types.UUID{3}, types.UUID{3, 13}, types.UUID{128, 238, 82, 12}, types.UUID{31, 54, 23, 13, 63, 43}, types.UUID{83, 64, 21, 14, 42, 6, 35, 7, 54, 234, 6, 32, 1, 4, 2, 4}},     //Uuid
-			//{types.String([]byte{1}), types.String([]byte{42, 52}), types.String([]byte{84, 32, 13, 63, 12, 86}), //VarBinary
-			//	types.String([]byte{1, 32, 235, 64, 32, 23, 45, 76}), types.String([]byte{123, 234, 34, 223, 76, 35, 32, 12, 84, 26, 15, 34, 65, 86, 45, 23, 43, 12, 76, 154, 234, 76, 34})},
+			{mustBlob(t, string([]byte{1})), mustBlob(t, string([]byte{42, 52})), mustBlob(t, string([]byte{84, 32, 13, 63, 12, 86})), //VarBinary
+				mustBlob(t, string([]byte{1, 32, 235, 64, 32, 23, 45, 76})), mustBlob(t, string([]byte{123, 234, 34, 223, 76, 35, 32, 12, 84, 26, 15, 34, 65, 86, 45, 23, 43, 12, 76, 154, 234, 76, 34}))},
 			{types.String(""), types.String("a"), types.String("abc"), //VarString
 				types.String("abcdefghijklmnopqrstuvwxyz"), types.String("هذا هو بعض نماذج النص التي أستخدمها لاختبار عناصر")},
 			{types.Int(1901), types.Int(1950), types.Int(2000), types.Int(2080), types.Int(2155)}, //Year
 		}
 }
+
+var testVRW = types.NewMemoryValueStore()
+
+func mustBlob(t *testing.T, str string) types.Blob {
+	b, err := types.NewBlob(context.Background(), testVRW, strings.NewReader(str))
+	require.NoError(t, err)
+	return b
+}
+
+func mustGeometry(t *testing.T, str string) types.InlineBlob {
+	g, err := geometry.Parse(str)
+	require.NoError(t, err)
+	return types.InlineBlob(g.Serialize())
+}
+
+// humanReadableString returns a description of the value for a test name. Blobs do not implement HumanReadableString.
+func humanReadableString(val types.Value) string {
+	if b, ok := val.(types.Blob); ok {
+		str, err := fromBlob(b)
+		if err != nil {
+			panic(err)
+		}
+		return strconv.Quote(str)
+	}
+	return val.HumanReadableString()
+}
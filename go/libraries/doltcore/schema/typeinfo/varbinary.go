@@ -15,8 +15,10 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/liquidata-inc/go-mysql-server/sql"
 	"vitess.io/vitess/go/sqltypes"
@@ -36,12 +38,22 @@ const (
 // as a string that is interpreted as raw bytes, rather than as a bespoke data structure,
 // and thus this is mirrored here in its implementation. This will minimize any differences
 // that could arise.
+//
+// Values are stored as Noms blobs rather than inline in the row, so that large values are
+// chunked by their content, shared between versions of the row, and only read when needed.
 type varBinaryType struct {
 	sqlBinaryType sql.StringType
 }
 
 var _ TypeInfo = (*varBinaryType)(nil)
 
+var (
+	TinyBlobType   = &varBinaryType{sql.TinyBlob}
+	BlobType       = &varBinaryType{sql.Blob}
+	MediumBlobType = &varBinaryType{sql.MediumBlob}
+	LongBlobType   = &varBinaryType{sql.LongBlob}
+)
+
 func CreateVarBinaryTypeFromParams(params map[string]string) (TypeInfo, error) {
 	var length int64
 	var err error
@@ -76,8 +88,12 @@ func CreateVarBinaryTypeFromParams(params map[string]string) (TypeInfo, error) {
 
 // ConvertNomsValueToValue implements TypeInfo interface.
 func (ti *varBinaryType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
-	if val, ok := v.(types.String); ok {
-		return ti.sqlBinaryType.Convert(string(val))
+	if val, ok := v.(types.Blob); ok {
+		str, err := fromBlob(val)
+		if err != nil {
+			return nil, err
+		}
+		return ti.sqlBinaryType.Convert(str)
 	}
 	if _, ok := v.(types.Null); ok || v == nil {
 		return nil, nil
@@ -86,7 +102,7 @@ func (ti *varBinaryType) ConvertNomsValueToValue(v types.Value) (interface{}, er
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *varBinaryType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *varBinaryType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	if v == nil {
 		return types.NullValue, nil
 	}
@@ -96,7 +112,7 @@ func (ti *varBinaryType) ConvertValueToNomsValue(v interface{}) (types.Value, er
 	}
 	val, ok := strVal.(string)
 	if ok {
-		return types.String(val), nil
+		return types.NewBlob(ctx, vrw, strings.NewReader(val))
 	}
 	return nil, fmt.Errorf(`"%v" cannot convert value "%v" of type "%T" as it is invalid`, ti.String(), v, v)
 }
@@ -115,8 +131,8 @@ func (ti *varBinaryType) Equals(other TypeInfo) bool {
 
 // FormatValue implements TypeInfo interface.
 func (ti *varBinaryType) FormatValue(v types.Value) (*string, error) {
-	if val, ok := v.(types.String); ok {
-		res, err := ti.sqlBinaryType.Convert(string(val))
+	if val, ok := v.(types.Blob); ok {
+		res, err := ti.ConvertNomsValueToValue(val)
 		if err != nil {
 			return nil, err
 		}
@@ -156,28 +172,26 @@ func (ti *varBinaryType) GetTypeParams() map[string]string {
 
 // IsValid implements TypeInfo interface.
 func (ti *varBinaryType) IsValid(v types.Value) bool {
-	_, err := ti.ConvertNomsValueToValue(v)
-	return err == nil
+	if val, ok := v.(types.Blob); ok {
+		return int64(val.Len()) <= ti.sqlBinaryType.MaxByteLength()
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return true
+	}
+	return false
 }
 
 // NomsKind implements TypeInfo interface.
 func (ti *varBinaryType) NomsKind() types.NomsKind {
-	return types.StringKind
+	return types.BlobKind
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *varBinaryType) ParseValue(str *string) (types.Value, error) {
+func (ti *varBinaryType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	if str == nil {
 		return types.NullValue, nil
 	}
-	strVal, err := ti.sqlBinaryType.Convert(*str)
-	if err != nil {
-		return nil, err
-	}
-	if val, ok := strVal.(string); ok {
-		return types.String(val), nil
-	}
-	return nil, fmt.Errorf(`"%v" cannot convert the string "%v" to a value`, ti.String(), str)
+	return ti.ConvertValueToNomsValue(ctx, vrw, *str)
 }
 
 // String implements TypeInfo interface.
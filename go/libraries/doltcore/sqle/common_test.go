@@ -120,6 +120,13 @@ func schemaNewColumn(t *testing.T, name string, tag uint64, sqlType sql.Type, pa
 	return col
 }
 
+func schemaNewColumnWithAttrs(t *testing.T, name string, tag uint64, sqlType sql.Type, partOfPK bool, defaultVal, comment string, constraints ...schema.ColConstraint) schema.Column {
+	col := schemaNewColumn(t, name, tag, sqlType, partOfPK, constraints...)
+	col.Default = defaultVal
+	col.Comment = comment
+	return col
+}
+
 // TODO: this shouldn't be here
 func CreateWorkingRootUpdate() map[string]envtestutils.TableUpdate {
 	return map[string]envtestutils.TableUpdate{
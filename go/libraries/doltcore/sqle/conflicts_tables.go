@@ -135,7 +135,7 @@ type conflictDeleter struct {
 // Close is called.
 func (cd *conflictDeleter) Delete(ctx *sql.Context, r sql.Row) error {
 	cnfSch := cd.ct.rd.GetSchema()
-	cnfRow, err := SqlRowToDoltRow(cd.ct.tbl.Format(), r, cnfSch)
+	cnfRow, err := SqlRowToDoltRow(ctx, cd.ct.tbl.ValueReadWriter(), r, cnfSch)
 
 	if err != nil {
 		return err
@@ -42,6 +42,11 @@ type commitBehavior int8
 var ErrInvalidTableName = errors.NewKind("Invalid table name %s. Table names must match the regular expression " + doltdb.TableNameRegexStr)
 var ErrReservedTableName = errors.NewKind("Invalid table name %s. Table names beginning with `dolt_` are reserved for internal use")
 var ErrSystemTableAlter = errors.NewKind("Cannot alter table %s: system tables cannot be dropped or altered")
+var ErrBranchNotFound = errors.NewKind("branch not found: %s")
+var ErrUncommittedChanges = errors.NewKind("cannot switch branches: there are uncommitted changes on branch %s")
+var ErrBranchMoved = errors.NewKind("branch %s was updated after this session's head. Check it out again to continue from its new head")
+var ErrTransactionConflict = errors.NewKind("serialization failure: changes to table %s conflict with changes committed after this transaction started. Try restarting the transaction")
+var ErrCommitAuthorFixed = errors.NewKind("variable '%s' can't be set: the author of this user's commits is set by the server")
 
 const (
 	batched commitBehavior = iota
@@ -61,6 +66,22 @@ func IsHeadKey(key string) (bool, string) {
 	return false, ""
 }
 
+// BranchDatabaseSeparator separates the name of a database from the name of one of its branches in database names such
+// as `mydb/feature-x`, which select the database and check out the branch for the rest of the session.
+const BranchDatabaseSeparator = "/"
+
+// SplitBranchDatabase splits a database name such as `mydb/feature-x` into the name of the database and the name of the
+// branch. It returns false if the name given does not name a branch.
+func SplitBranchDatabase(name string) (dbName, branch string, ok bool) {
+	i := strings.Index(name, BranchDatabaseSeparator)
+
+	if i == -1 {
+		return name, "", false
+	}
+
+	return name[:i], name[i+len(BranchDatabaseSeparator):], true
+}
+
 func IsWorkingKey(key string) (bool, string) {
 	if strings.HasSuffix(key, WorkingKeySuffix) {
 		return true, key[:len(key)-len(WorkingKeySuffix)]
@@ -129,6 +150,9 @@ type Database struct {
 	rsw       env.RepoStateWriter
 	batchMode commitBehavior
 	tc        *tableCache
+
+	// txLock is held by sessions while they merge their transactions into the working set
+	txLock *sync.Mutex
 }
 
 var _ sql.Database = Database{}
@@ -146,6 +170,7 @@ func NewDatabase(name string, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw e
 		rsw:       rsw,
 		batchMode: single,
 		tc:        &tableCache{&sync.Mutex{}, make(map[*doltdb.RootValue]map[string]sql.Table)},
+		txLock:    &sync.Mutex{},
 	}
 }
 
@@ -159,6 +184,7 @@ func NewBatchedDatabase(name string, ddb *doltdb.DoltDB, rsr env.RepoStateReader
 		rsw:       rsw,
 		batchMode: batched,
 		tc:        &tableCache{&sync.Mutex{}, make(map[*doltdb.RootValue]map[string]sql.Table)},
+		txLock:    &sync.Mutex{},
 	}
 }
 
@@ -246,6 +272,16 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		return bt, true, nil
 	}
 
+	if lwrName == doltdb.TagsTableName {
+		tt, err := NewTagsTable(ctx, db.Name())
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return tt, true, nil
+	}
+
 	return db.getTable(ctx, root, tblName)
 }
 
@@ -601,7 +637,7 @@ func (db Database) createTable(ctx *sql.Context, tableName string, sch sql.Schem
 		return sql.ErrTableAlreadyExists.New(tableName)
 	}
 
-	doltSch, err := SqlSchemaToDoltSchema(ctx, root, tableName, sch)
+	doltSch, err := sqlSchemaToDoltSchema(ctx, root, tableName, sch, autoIncrementColsFromQuery(ctx.Query(), tableName))
 	if err != nil {
 		return err
 	}
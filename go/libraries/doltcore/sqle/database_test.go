@@ -34,3 +34,24 @@ func TestIsKeyFuncs(t *testing.T) {
 	testKeyFunc(t, IsHeadKey, "dolt_working", false, "")
 	testKeyFunc(t, IsWorkingKey, "dolt_working", true, "dolt")
 }
+
+func TestSplitBranchDatabase(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbName string
+		branch string
+		ok     bool
+	}{
+		{"dolt", "dolt", "", false},
+		{"dolt/master", "dolt", "master", true},
+		{"dolt/feature/x", "dolt", "feature/x", true},
+		{"dolt/", "dolt", "", true},
+	}
+
+	for _, test := range tests {
+		dbName, branch, ok := SplitBranchDatabase(test.name)
+		assert.Equal(t, test.dbName, dbName, test.name)
+		assert.Equal(t, test.branch, branch, test.name)
+		assert.Equal(t, test.ok, ok, test.name)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/liquidata-inc/go-mysql-server/sql/expression"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+)
+
+const CheckoutFuncName = "dolt_checkout"
+
+// CheckoutFunc checks out a branch of the current database for the rest of the session, and returns the hash of the
+// branch's head commit. See DoltSession.CheckoutBranch.
+type CheckoutFunc struct {
+	expression.UnaryExpression
+}
+
+// NewCheckoutFunc creates a new CheckoutFunc expression.
+func NewCheckoutFunc(e sql.Expression) sql.Expression {
+	return &CheckoutFunc{expression.UnaryExpression{Child: e}}
+}
+
+// Eval implements the Expression interface.
+func (cf *CheckoutFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := cf.Child.Eval(ctx, row)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	branchName, ok := val.(string)
+
+	if !ok {
+		return nil, errors.New("branch name is not a string")
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+
+	if dbName == "" {
+		return nil, sql.ErrNoDatabaseSelected.New()
+	}
+
+	dSess := sqle.DSessFromSess(ctx.Session)
+	cm, err := dSess.CheckoutBranch(ctx, dbName, branchName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// String implements the Stringer interface.
+func (cf *CheckoutFunc) String() string {
+	return fmt.Sprintf("DOLT_CHECKOUT(%s)", cf.Child.String())
+}
+
+// IsNullable implements the Expression interface.
+func (cf *CheckoutFunc) IsNullable() bool {
+	return cf.Child.IsNullable()
+}
+
+// WithChildren implements the Expression interface.
+func (cf *CheckoutFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(cf, len(children), 1)
+	}
+
+	return NewCheckoutFunc(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (cf *CheckoutFunc) Type() sql.Type {
+	return sql.Text
+}
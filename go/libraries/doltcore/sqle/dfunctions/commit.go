@@ -80,11 +80,12 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	if dSess.Username == "" || dSess.Email == "" {
-		return nil, errors.New("commit function failure: Username and/or email not configured")
+	name, email := dSess.CommitAuthor()
+	if name == "" || email == "" {
+		return nil, errors.New("commit function failure: Username and/or email not configured. Set @@dolt_user_name and @@dolt_user_email")
 	}
 
-	meta, err := doltdb.NewCommitMeta(dSess.Username, dSess.Email, commitMessage)
+	meta, err := doltdb.NewCommitMeta(name, email, commitMessage)
 
 	if err != nil {
 		return nil, err
@@ -96,6 +97,14 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
+	err = dSess.UpdateBranch(ctx, dbName, cm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dSess.CommitWritten(dbName)
+
 	h, err = cm.HashOf()
 
 	if err != nil {
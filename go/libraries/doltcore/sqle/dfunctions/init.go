@@ -20,4 +20,11 @@ var DoltFunctions = []sql.Function{
 	sql.Function1{Name: HashOfFuncName, Fn: NewHashOf},
 	sql.Function1{Name: CommitFuncName, Fn: NewCommitFunc},
 	sql.Function1{Name: MergeFuncName, Fn: NewMergeFunc},
+	sql.Function1{Name: CheckoutFuncName, Fn: NewCheckoutFunc},
+	sql.FunctionN{Name: STGeomFromTextFuncName, Fn: NewSTGeomFromText},
+	sql.Function1{Name: STAsTextFuncName, Fn: NewSTAsText},
+	sql.Function1{Name: STSRIDFuncName, Fn: NewSTSRID},
+	sql.Function1{Name: STXFuncName, Fn: NewSTX},
+	sql.Function1{Name: STYFuncName, Fn: NewSTY},
+	sql.Function2{Name: STDistanceFuncName, Fn: NewSTDistance},
 }
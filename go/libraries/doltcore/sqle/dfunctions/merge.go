@@ -48,8 +48,9 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	sess := sqle.DSessFromSess(ctx.Session)
-	if sess.Username == "" || sess.Email == "" {
-		return nil, errors.New("commit function failure: Username and/or email not configured")
+	name, email := sess.CommitAuthor()
+	if name == "" || email == "" {
+		return nil, errors.New("commit function failure: Username and/or email not configured. Set @@dolt_user_name and @@dolt_user_email")
 	}
 
 	dbName := sess.GetCurrentDatabase()
@@ -80,6 +81,11 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	mergeRoot, _, err := merge.MergeCommits(ctx, ddb, parent, cm)
 	if err == merge.ErrFastForward {
+		err = sess.UpdateBranch(ctx, dbName, cm)
+		if err != nil {
+			return nil, err
+		}
+
 		return cmh.String(), nil
 	}
 
@@ -89,7 +95,7 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	commitMessage := fmt.Sprintf("SQL Generated commit merging %s into %s", ph.String(), cmh.String())
-	meta, err := doltdb.NewCommitMeta(sess.Username, sess.Email, commitMessage)
+	meta, err := doltdb.NewCommitMeta(name, email, commitMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +105,13 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
+	err = sess.UpdateBranch(ctx, dbName, mergeCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.CommitWritten(dbName)
+
 	h, err = mergeCommit.HashOf()
 	if err != nil {
 		return nil, err
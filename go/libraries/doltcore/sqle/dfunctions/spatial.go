@@ -0,0 +1,350 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/liquidata-inc/go-mysql-server/sql/expression"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
+)
+
+const (
+	STGeomFromTextFuncName = "st_geomfromtext"
+	STAsTextFuncName       = "st_astext"
+	STSRIDFuncName         = "st_srid"
+	STXFuncName            = "st_x"
+	STYFuncName            = "st_y"
+	STDistanceFuncName     = "st_distance"
+)
+
+// evalGeometry evaluates the expression given, which must return a geometry. Returns false if the value is null.
+func evalGeometry(ctx *sql.Context, row sql.Row, e sql.Expression, funcName string) (geometry.Geometry, bool, error) {
+	val, err := e.Eval(ctx, row)
+	if err != nil {
+		return geometry.Geometry{}, false, err
+	}
+
+	if val == nil {
+		return geometry.Geometry{}, false, nil
+	}
+
+	g, err := geometry.GeometrySqlType.Convert(val)
+	if err != nil {
+		return geometry.Geometry{}, false, fmt.Errorf("invalid argument to %s: %v", funcName, err)
+	}
+
+	return g.(geometry.Geometry), true, nil
+}
+
+// STGeomFromText constructs a geometry from its well-known text and an optional SRID.
+type STGeomFromText struct {
+	wkt  sql.Expression
+	srid sql.Expression
+}
+
+var _ sql.Expression = (*STGeomFromText)(nil)
+
+// NewSTGeomFromText creates a new STGeomFromText expression.
+func NewSTGeomFromText(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &STGeomFromText{wkt: args[0]}, nil
+	case 2:
+		return &STGeomFromText{wkt: args[0], srid: args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New(STGeomFromTextFuncName, "1 or 2", len(args))
+	}
+}
+
+// Eval implements the Expression interface.
+func (f *STGeomFromText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := f.wkt.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	wkt, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	shape, err := geometry.ParseWKT(wkt.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	g := geometry.Geometry{Shape: shape}
+	if f.srid != nil {
+		val, err := f.srid.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			return nil, nil
+		}
+
+		srid, err := sql.Uint32.Convert(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SRID %v: %v", val, err)
+		}
+
+		g.SRID = srid.(uint32)
+	}
+
+	return g, nil
+}
+
+// Resolved implements the Expression interface.
+func (f *STGeomFromText) Resolved() bool {
+	return f.wkt.Resolved() && (f.srid == nil || f.srid.Resolved())
+}
+
+// String implements the Stringer interface.
+func (f *STGeomFromText) String() string {
+	if f.srid == nil {
+		return fmt.Sprintf("ST_GEOMFROMTEXT(%s)", f.wkt.String())
+	}
+	return fmt.Sprintf("ST_GEOMFROMTEXT(%s, %s)", f.wkt.String(), f.srid.String())
+}
+
+// IsNullable implements the Expression interface.
+func (f *STGeomFromText) IsNullable() bool {
+	return f.wkt.IsNullable() || (f.srid != nil && f.srid.IsNullable())
+}
+
+// Children implements the Expression interface.
+func (f *STGeomFromText) Children() []sql.Expression {
+	if f.srid == nil {
+		return []sql.Expression{f.wkt}
+	}
+	return []sql.Expression{f.wkt, f.srid}
+}
+
+// WithChildren implements the Expression interface.
+func (f *STGeomFromText) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(f.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), len(f.Children()))
+	}
+	return NewSTGeomFromText(children...)
+}
+
+// Type implements the Expression interface.
+func (f *STGeomFromText) Type() sql.Type {
+	return geometry.GeometrySqlType
+}
+
+// STAsText returns the well-known text of a geometry.
+type STAsText struct {
+	expression.UnaryExpression
+}
+
+// NewSTAsText creates a new STAsText expression.
+func NewSTAsText(e sql.Expression) sql.Expression {
+	return &STAsText{expression.UnaryExpression{Child: e}}
+}
+
+// Eval implements the Expression interface.
+func (f *STAsText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, ok, err := evalGeometry(ctx, row, f.Child, STAsTextFuncName)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return geometry.MarshalWKT(g.Shape), nil
+}
+
+// String implements the Stringer interface.
+func (f *STAsText) String() string {
+	return fmt.Sprintf("ST_ASTEXT(%s)", f.Child.String())
+}
+
+// IsNullable implements the Expression interface.
+func (f *STAsText) IsNullable() bool {
+	return f.Child.IsNullable()
+}
+
+// WithChildren implements the Expression interface.
+func (f *STAsText) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 1)
+	}
+	return NewSTAsText(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (f *STAsText) Type() sql.Type {
+	return sql.LongText
+}
+
+// STSRID returns the SRID of a geometry.
+type STSRID struct {
+	expression.UnaryExpression
+}
+
+// NewSTSRID creates a new STSRID expression.
+func NewSTSRID(e sql.Expression) sql.Expression {
+	return &STSRID{expression.UnaryExpression{Child: e}}
+}
+
+// Eval implements the Expression interface.
+func (f *STSRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, ok, err := evalGeometry(ctx, row, f.Child, STSRIDFuncName)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return g.SRID, nil
+}
+
+// String implements the Stringer interface.
+func (f *STSRID) String() string {
+	return fmt.Sprintf("ST_SRID(%s)", f.Child.String())
+}
+
+// IsNullable implements the Expression interface.
+func (f *STSRID) IsNullable() bool {
+	return f.Child.IsNullable()
+}
+
+// WithChildren implements the Expression interface.
+func (f *STSRID) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 1)
+	}
+	return NewSTSRID(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (f *STSRID) Type() sql.Type {
+	return sql.Uint32
+}
+
+// STCoordinate returns the X or Y coordinate of a point.
+type STCoordinate struct {
+	expression.UnaryExpression
+	funcName string
+}
+
+// NewSTX creates a new STCoordinate expression returning the X coordinate of a point.
+func NewSTX(e sql.Expression) sql.Expression {
+	return &STCoordinate{expression.UnaryExpression{Child: e}, STXFuncName}
+}
+
+// NewSTY creates a new STCoordinate expression returning the Y coordinate of a point.
+func NewSTY(e sql.Expression) sql.Expression {
+	return &STCoordinate{expression.UnaryExpression{Child: e}, STYFuncName}
+}
+
+// Eval implements the Expression interface.
+func (f *STCoordinate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, ok, err := evalGeometry(ctx, row, f.Child, f.funcName)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	p, ok := g.Shape.(geometry.Point)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument to %s: expected a POINT but got a %s", f.funcName, g.Shape.Type())
+	}
+
+	if f.funcName == STXFuncName {
+		return p.X, nil
+	}
+	return p.Y, nil
+}
+
+// String implements the Stringer interface.
+func (f *STCoordinate) String() string {
+	if f.funcName == STXFuncName {
+		return fmt.Sprintf("ST_X(%s)", f.Child.String())
+	}
+	return fmt.Sprintf("ST_Y(%s)", f.Child.String())
+}
+
+// IsNullable implements the Expression interface.
+func (f *STCoordinate) IsNullable() bool {
+	return f.Child.IsNullable()
+}
+
+// WithChildren implements the Expression interface.
+func (f *STCoordinate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 1)
+	}
+	return &STCoordinate{expression.UnaryExpression{Child: children[0]}, f.funcName}, nil
+}
+
+// Type implements the Expression interface.
+func (f *STCoordinate) Type() sql.Type {
+	return sql.Float64
+}
+
+// STDistance returns the minimum distance between two geometries, on a flat plane in the units of their coordinates.
+type STDistance struct {
+	expression.BinaryExpression
+}
+
+// NewSTDistance creates a new STDistance expression.
+func NewSTDistance(e1, e2 sql.Expression) sql.Expression {
+	return &STDistance{expression.BinaryExpression{Left: e1, Right: e2}}
+}
+
+// Eval implements the Expression interface.
+func (f *STDistance) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g1, ok, err := evalGeometry(ctx, row, f.Left, STDistanceFuncName)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	g2, ok, err := evalGeometry(ctx, row, f.Right, STDistanceFuncName)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	if g1.SRID != g2.SRID {
+		return nil, fmt.Errorf("%s was given geometries with different SRIDs: %d and %d", STDistanceFuncName, g1.SRID, g2.SRID)
+	}
+
+	return geometry.Distance(g1.Shape, g2.Shape), nil
+}
+
+// String implements the Stringer interface.
+func (f *STDistance) String() string {
+	return fmt.Sprintf("ST_DISTANCE(%s, %s)", f.Left.String(), f.Right.String())
+}
+
+// IsNullable implements the Expression interface.
+func (f *STDistance) IsNullable() bool {
+	return f.Left.IsNullable() || f.Right.IsNullable()
+}
+
+// WithChildren implements the Expression interface.
+func (f *STDistance) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 2)
+	}
+	return NewSTDistance(children[0], children[1]), nil
+}
+
+// Type implements the Expression interface.
+func (f *STDistance) Type() sql.Type {
+	return sql.Float64
+}
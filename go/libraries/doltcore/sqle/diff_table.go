@@ -207,13 +207,13 @@ func (dt *DiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.Ro
 		return nil, err
 	}
 
-	fromConv, err := rowConvForSchema(dt.ss, fromSch)
+	fromConv, err := rowConvForSchema(ctx, dt.ddb.ValueReadWriter(), dt.ss, fromSch)
 
 	if err != nil {
 		return nil, err
 	}
 
-	toConv, err := rowConvForSchema(dt.ss, toSch)
+	toConv, err := rowConvForSchema(ctx, dt.ddb.ValueReadWriter(), dt.ss, toSch)
 
 	if err != nil {
 		return nil, err
@@ -569,7 +569,7 @@ func (dp *diffPartitions) Close() error {
 }
 
 // creates a RowConverter for transforming rows with the the given schema to this super schema.
-func rowConvForSchema(ss *schema.SuperSchema, sch schema.Schema) (*rowconv.RowConverter, error) {
+func rowConvForSchema(ctx context.Context, vrw types.ValueReadWriter, ss *schema.SuperSchema, sch schema.Schema) (*rowconv.RowConverter, error) {
 	eq, err := schema.SchemasAreEqual(sch, schema.EmptySchema)
 	if err != nil {
 		return nil, err
@@ -596,5 +596,5 @@ func rowConvForSchema(ss *schema.SuperSchema, sch schema.Schema) (*rowconv.RowCo
 		return nil, err
 	}
 
-	return rowconv.NewRowConverter(fm)
+	return rowconv.NewRowConverter(ctx, vrw, fm)
 }
@@ -15,6 +15,7 @@
 package sqle
 
 import (
+	"context"
 	"errors"
 
 	"github.com/liquidata-inc/go-mysql-server/sql"
@@ -197,7 +198,7 @@ func (di *doltIndex) keysToTuple(keys []interface{}, appendMaxValue bool) (types
 	}
 	var vals []types.Value
 	for i, col := range di.cols {
-		val, err := col.TypeInfo.ConvertValueToNomsValue(keys[i])
+		val, err := col.TypeInfo.ConvertValueToNomsValue(context.Background(), di.table.ValueReadWriter(), keys[i])
 		if err != nil {
 			return types.EmptyTuple(nbf), err
 		}
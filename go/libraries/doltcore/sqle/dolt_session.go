@@ -17,11 +17,17 @@ package sqle
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/liquidata-inc/go-mysql-server/sql"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/datas"
 	"github.com/liquidata-inc/dolt/go/store/hash"
 )
 
@@ -32,11 +38,31 @@ type dbRoot struct {
 
 type dbData struct {
 	ddb *doltdb.DoltDB
+	rsr env.RepoStateReader
 	rsw env.RepoStateWriter
+
+	// branch is the branch checked out in this session, or nil if the session uses the repository's working set
+	branch ref.DoltRef
+
+	// txLock is shared by the sessions of the database, and is held while reading or merging into its working set
+	txLock *sync.Mutex
+}
+
+// hasOwnWorkingSet returns whether the session has checked out a branch other than the one checked out in the
+// repository, in which case its changes are kept in the session rather than in the repository's working set.
+func (dbd dbData) hasOwnWorkingSet() bool {
+	return dbd.branch != nil && !ref.Equals(dbd.branch, dbd.rsr.CWBHeadRef())
 }
 
 var _ sql.Session = &DoltSession{}
 
+const (
+	// UserNameSessionVar is the session variable holding the name of the author of the commits made in a session
+	UserNameSessionVar = "dolt_user_name"
+	// UserEmailSessionVar is the session variable holding the email of the author of the commits made in a session
+	UserEmailSessionVar = "dolt_user_email"
+)
+
 // DoltSession is the sql.Session implementation used by dolt.  It is accessible through a *sql.Context instance
 type DoltSession struct {
 	sql.Session
@@ -44,8 +70,21 @@ type DoltSession struct {
 	dbDatas   map[string]dbData
 	dbEditors map[string]*doltdb.TableEditSession
 
+	// txRoots are the working roots from which the open transaction of each database started
+	txRoots map[string]*doltdb.RootValue
+	// inExplicitTx is set from START TRANSACTION until COMMIT or ROLLBACK, and txAutocommit holds the value of
+	// @@autocommit to restore when it ends
+	inExplicitTx bool
+	txAutocommit interface{}
+
 	Username string
 	Email    string
+	// AuthorFixed is set when the author of the commits made in the session may not be changed with
+	// @@dolt_user_name and @@dolt_user_email
+	AuthorFixed bool
+
+	// CommitListener, if set, is called with the database of each commit written in the session
+	CommitListener func(dbName string)
 }
 
 // DefaultDoltSession creates a DoltSession object with default values
@@ -55,6 +94,7 @@ func DefaultDoltSession() *DoltSession {
 		dbRoots:   make(map[string]dbRoot),
 		dbDatas:   make(map[string]dbData),
 		dbEditors: make(map[string]*doltdb.TableEditSession),
+		txRoots:   make(map[string]*doltdb.RootValue),
 		Username:  "",
 		Email:     "",
 	}
@@ -67,11 +107,31 @@ func NewDoltSession(ctx context.Context, sqlSess sql.Session, username, email st
 	dbDatas := make(map[string]dbData)
 	dbEditors := make(map[string]*doltdb.TableEditSession)
 	for _, db := range dbs {
-		dbDatas[db.Name()] = dbData{rsw: db.rsw, ddb: db.ddb}
+		dbDatas[db.Name()] = dbData{rsr: db.rsr, rsw: db.rsw, ddb: db.ddb, txLock: db.txLock}
 		dbEditors[db.Name()] = doltdb.CreateTableEditSession(nil, doltdb.TableEditSessionProps{})
 	}
 
-	sess := &DoltSession{sqlSess, dbRoots, dbDatas, dbEditors, username, email}
+	sess := &DoltSession{
+		Session:   sqlSess,
+		dbRoots:   dbRoots,
+		dbDatas:   dbDatas,
+		dbEditors: dbEditors,
+		txRoots:   make(map[string]*doltdb.RootValue),
+		Username:  username,
+		Email:     email,
+	}
+	err := sess.Session.Set(ctx, UserNameSessionVar, sql.Text, username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Session.Set(ctx, UserEmailSessionVar, sql.Text, email)
+
+	if err != nil {
+		return nil, err
+	}
+
 	for _, db := range dbs {
 		err := sess.AddDB(ctx, db)
 
@@ -88,26 +148,361 @@ func DSessFromSess(sess sql.Session) *DoltSession {
 	return sess.(*DoltSession)
 }
 
+// CommitTransaction writes the working root of the current database to the repository's working set. If the session
+// started a transaction in the database, and the working set has changed since, the changes made in the transaction
+// are merged into the working set. If they conflict, the transaction is rolled back and ErrTransactionConflict is
+// returned. If the session has checked out a branch with a working set of its own, its changes are kept in the session.
+// Committing ends an explicit transaction started with START TRANSACTION.
 func (sess *DoltSession) CommitTransaction(ctx *sql.Context) error {
 	currentDb := sess.GetCurrentDatabase()
 	if currentDb == "" {
 		return sql.ErrNoDatabaseSelected.New()
 	}
 
-	dbRoot, ok := sess.dbRoots[currentDb]
-	if !ok {
+	if _, ok := sess.dbRoots[currentDb]; !ok {
 		return sql.ErrDatabaseNotFound.New(currentDb)
 	}
 
-	dbData := sess.dbDatas[currentDb]
+	if sess.dbDatas[currentDb].hasOwnWorkingSet() {
+		delete(sess.txRoots, currentDb)
+	} else {
+		err := sess.commitWorkingRoot(ctx, currentDb)
+
+		if ErrTransactionConflict.Is(err) {
+			if endErr := sess.endExplicitTransaction(ctx); endErr != nil {
+				return endErr
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return sess.endExplicitTransaction(ctx)
+}
+
+// commitWorkingRoot writes the working root of the database given to the repository's working set, merging it with the
+// changes made to the working set since the session's transaction started.
+func (sess *DoltSession) commitWorkingRoot(ctx context.Context, dbName string) error {
+	dbd := sess.dbDatas[dbName]
+	root := sess.dbRoots[dbName].root
+	txRoot, inTx := sess.txRoots[dbName]
+
+	dbd.txLock.Lock()
+	defer dbd.txLock.Unlock()
+
+	if inTx {
+		changed, err := sess.changedSince(dbName, txRoot)
+
+		if err != nil {
+			return err
+		} else if !changed {
+			delete(sess.txRoots, dbName)
+			return nil
+		}
+
+		txHash, err := txRoot.HashOf()
+
+		if err != nil {
+			return err
+		}
+
+		if workingHash := dbd.rsr.WorkingHash(); workingHash != txHash {
+			working, err := dbd.ddb.ReadRootValue(ctx, workingHash)
+
+			if err != nil {
+				return err
+			}
+
+			root, err = mergeTransaction(ctx, dbd.ddb, working, root, txRoot)
+
+			if ErrTransactionConflict.Is(err) {
+				delete(sess.txRoots, dbName)
+
+				if rollbackErr := sess.setWorkingRoot(ctx, dbName, working); rollbackErr != nil {
+					return rollbackErr
+				}
+			}
+
+			if err != nil {
+				return err
+			}
+
+			err = sess.setWorkingRoot(ctx, dbName, root)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	h, err := dbd.ddb.WriteRootValue(ctx, root)
+
+	if err != nil {
+		return err
+	}
+
+	err = dbd.rsw.SetWorkingHash(ctx, h)
 
-	root := dbRoot.root
-	h, err := dbData.ddb.WriteRootValue(ctx, root)
 	if err != nil {
 		return err
 	}
 
-	return dbData.rsw.SetWorkingHash(ctx, h)
+	delete(sess.txRoots, dbName)
+	return nil
+}
+
+// mergeTransaction merges the changes made to txRoot, the working root a transaction started from, which resulted in
+// root, into working, the current working root of the repository. It returns ErrTransactionConflict if any row was
+// changed differently in both, or if a table was dropped or created in one and changed in the other.
+func mergeTransaction(ctx context.Context, ddb *doltdb.DoltDB, working, root, txRoot *doltdb.RootValue) (*doltdb.RootValue, error) {
+	merged, tblToStats, err := merge.MergeRoots(ctx, working, root, txRoot, ddb.ValueReadWriter())
+
+	if err == merge.ErrTableDeletedAndModified || err == merge.ErrSameTblAddedTwice {
+		tblName, findErr := conflictingTable(ctx, working, root, txRoot)
+
+		if findErr != nil {
+			return nil, findErr
+		}
+
+		return nil, ErrTransactionConflict.New(tblName)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicted []string
+	for tblName, stats := range tblToStats {
+		if stats.Conflicts > 0 {
+			conflicted = append(conflicted, tblName)
+		}
+	}
+
+	if len(conflicted) > 0 {
+		sort.Strings(conflicted)
+		return nil, ErrTransactionConflict.New(conflicted[0])
+	}
+
+	return merged, nil
+}
+
+// conflictingTable returns the name of the first table which exists in only one of working and root and was changed
+// in the other since txRoot, or which did not exist in txRoot and was created differently in both.
+func conflictingTable(ctx context.Context, working, root, txRoot *doltdb.RootValue) (string, error) {
+	tblNames := make(map[string]bool)
+	for _, r := range []*doltdb.RootValue{working, root, txRoot} {
+		names, err := r.GetTableNames(ctx)
+
+		if err != nil {
+			return "", err
+		}
+
+		for _, name := range names {
+			tblNames[name] = true
+		}
+	}
+
+	sortedNames := make([]string, 0, len(tblNames))
+	for name := range tblNames {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		var hashes [3]hash.Hash
+		var exists [3]bool
+		for i, r := range []*doltdb.RootValue{working, root, txRoot} {
+			var err error
+			hashes[i], exists[i], err = r.GetTableHash(ctx, name)
+
+			if err != nil {
+				return "", err
+			}
+		}
+
+		if exists[0] != exists[1] && exists[2] && hashes[0] != hashes[2] && hashes[1] != hashes[2] {
+			return name, nil
+		} else if exists[0] && exists[1] && !exists[2] && hashes[0] != hashes[1] {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to find the conflicting table of a transaction")
+}
+
+// StartStatement starts a transaction, from the repository's current working set, in each database of the session
+// which has no open transaction. If autocommit is on, the open transactions which have made no changes are started
+// again, so that every statement reads the latest working set. In databases in which the session has checked out a
+// branch with a working set of its own, transactions start from the session's working root.
+func (sess *DoltSession) StartStatement(ctx context.Context) error {
+	return sess.startTransactions(ctx, sess.autocommit())
+}
+
+// StartTransaction commits the open transaction of the current database, and starts an explicit transaction in every
+// database of the session. Autocommit is off until the transaction ends with COMMIT or ROLLBACK.
+func (sess *DoltSession) StartTransaction(ctx *sql.Context) error {
+	if sess.GetCurrentDatabase() != "" {
+		if err := sess.CommitTransaction(ctx); err != nil {
+			return err
+		}
+	}
+
+	if !sess.inExplicitTx {
+		_, sess.txAutocommit = sess.Session.Get(sql.AutoCommitSessionVar)
+		sess.inExplicitTx = true
+	}
+
+	err := sess.Session.Set(ctx, sql.AutoCommitSessionVar, sql.Boolean, false)
+
+	if err != nil {
+		return err
+	}
+
+	return sess.startTransactions(ctx, true)
+}
+
+// RollbackTransaction discards the changes made in the open transaction of the current database, and ends an explicit
+// transaction started with START TRANSACTION.
+func (sess *DoltSession) RollbackTransaction(ctx *sql.Context) error {
+	currentDb := sess.GetCurrentDatabase()
+
+	if txRoot, ok := sess.txRoots[currentDb]; ok {
+		delete(sess.txRoots, currentDb)
+
+		// the tables read from a root are cached, and are updated in place by the writes made to them, so the root is
+		// read again rather than reused
+		ddb := sess.dbDatas[currentDb].ddb
+		h, err := ddb.WriteRootValue(ctx, txRoot)
+
+		if err != nil {
+			return err
+		}
+
+		root, err := ddb.ReadRootValue(ctx, h)
+
+		if err != nil {
+			return err
+		}
+
+		err = sess.setWorkingRoot(ctx, currentDb, root)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return sess.endExplicitTransaction(ctx)
+}
+
+// startTransactions starts a transaction in each database without an open transaction. If restartUnchanged is set, the
+// transactions which have made no changes are started again.
+func (sess *DoltSession) startTransactions(ctx context.Context, restartUnchanged bool) error {
+	for dbName, dbd := range sess.dbDatas {
+		if txRoot, ok := sess.txRoots[dbName]; ok {
+			if !restartUnchanged {
+				continue
+			}
+
+			changed, err := sess.changedSince(dbName, txRoot)
+
+			if err != nil {
+				return err
+			} else if changed {
+				continue
+			}
+		}
+
+		if dbd.hasOwnWorkingSet() {
+			// the working set of a branch checked out in the session is only changed by the session
+			sess.txRoots[dbName] = sess.dbRoots[dbName].root
+			continue
+		}
+
+		dbd.txLock.Lock()
+		h := dbd.rsr.WorkingHash()
+		dbd.txLock.Unlock()
+
+		root, err := dbd.ddb.ReadRootValue(ctx, h)
+
+		if err != nil {
+			return err
+		}
+
+		err = sess.setWorkingRoot(ctx, dbName, root)
+
+		if err != nil {
+			return err
+		}
+
+		sess.txRoots[dbName] = root
+	}
+
+	return nil
+}
+
+// changedSince returns whether the working root of the database given differs from the root given.
+func (sess *DoltSession) changedSince(dbName string, root *doltdb.RootValue) (bool, error) {
+	h, err := root.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	return h.String() != sess.dbRoots[dbName].hashStr, nil
+}
+
+// endExplicitTransaction restores the value @@autocommit had before START TRANSACTION, if an explicit transaction is
+// open.
+func (sess *DoltSession) endExplicitTransaction(ctx context.Context) error {
+	if !sess.inExplicitTx {
+		return nil
+	}
+
+	sess.inExplicitTx = false
+	return sess.Session.Set(ctx, sql.AutoCommitSessionVar, sql.Boolean, sess.txAutocommit)
+}
+
+// autocommit returns whether @@autocommit is on.
+func (sess *DoltSession) autocommit() bool {
+	_, val := sess.Session.Get(sql.AutoCommitSessionVar)
+
+	if val == nil {
+		return false
+	}
+
+	autocommit, _ := sql.ConvertToBool(val)
+	return autocommit
+}
+
+// CommitAuthor returns the name and email of the author of the commits made in this session. They are the values of
+// @@dolt_user_name and @@dolt_user_email, which are initially the Username and Email of the session. If the author of
+// the session is fixed, they are always the Username and Email of the session.
+func (sess *DoltSession) CommitAuthor() (name, email string) {
+	name, email = sess.Username, sess.Email
+
+	if sess.AuthorFixed {
+		return name, email
+	}
+
+	if _, val := sess.Session.Get(UserNameSessionVar); val != nil {
+		name, _ = val.(string)
+	}
+
+	if _, val := sess.Session.Get(UserEmailSessionVar); val != nil {
+		email, _ = val.(string)
+	}
+
+	return name, email
+}
+
+// CommitWritten is called by COMMIT() and MERGE() with the database of each commit they write, and notifies the
+// session's CommitListener.
+func (sess *DoltSession) CommitWritten(dbName string) {
+	if sess.CommitListener != nil {
+		sess.CommitListener(dbName)
+	}
 }
 
 // GetDoltDB returns the *DoltDB for a given database by name
@@ -195,33 +590,17 @@ func (sess *DoltSession) Set(ctx context.Context, key string, typ sql.Type, valu
 			return err
 		}
 
-		h, err := root.HashOf()
-
-		if err != nil {
-			return err
-		}
-
 		err = sess.Session.Set(ctx, key, typ, value)
 
 		if err != nil {
 			return err
 		}
 
-		hashStr := h.String()
-		err = sess.Session.Set(ctx, dbName+WorkingKeySuffix, sql.Text, hashStr)
-
-		if err != nil {
-			return err
-		}
-
-		sess.dbRoots[dbName] = dbRoot{hashStr, root}
-
-		err = sess.dbEditors[dbName].SetRoot(ctx, root)
-		if err != nil {
-			return err
-		}
+		return sess.setWorkingRoot(ctx, dbName, root)
+	}
 
-		return nil
+	if sess.AuthorFixed && (strings.EqualFold(key, UserNameSessionVar) || strings.EqualFold(key, UserEmailSessionVar)) {
+		return ErrCommitAuthorFixed.New(key)
 	}
 
 	if key == "foreign_key_checks" {
@@ -252,7 +631,7 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 	rsw := db.GetStateWriter()
 	ddb := db.GetDoltDB()
 
-	sess.dbDatas[db.Name()] = dbData{rsw: rsw, ddb: ddb}
+	sess.dbDatas[db.Name()] = dbData{rsr: rsr, rsw: rsw, ddb: ddb, txLock: db.txLock}
 
 	sess.dbEditors[db.Name()] = doltdb.CreateTableEditSession(nil, doltdb.TableEditSessionProps{})
 
@@ -272,3 +651,176 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 
 	return sess.Set(ctx, name+HeadKeySuffix, sql.Text, h.String())
 }
+
+// HasOwnWorkingSet returns whether the session has checked out a branch of the database given other than the one
+// checked out in the repository. Its working root for the database must not be written to the repository's working
+// set.
+func (sess *DoltSession) HasOwnWorkingSet(dbName string) bool {
+	dbd, ok := sess.dbDatas[dbName]
+	return ok && dbd.hasOwnWorkingSet()
+}
+
+// setWorkingRoot sets the working root of the database given in this session.
+func (sess *DoltSession) setWorkingRoot(ctx context.Context, dbName string, root *doltdb.RootValue) error {
+	h, err := root.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	hashStr := h.String()
+	err = sess.Session.Set(ctx, dbName+WorkingKeySuffix, sql.Text, hashStr)
+
+	if err != nil {
+		return err
+	}
+
+	sess.dbRoots[dbName] = dbRoot{hashStr, root}
+	return sess.dbEditors[dbName].SetRoot(ctx, root)
+}
+
+// CheckoutBranch checks out the branch given in the database given for the rest of the session, and returns the head
+// commit of the branch. The session's head becomes the head of the branch, and the commits made by COMMIT() and MERGE()
+// are added to the branch. If the branch is the one checked out in the repository, the session uses the repository's
+// working set. Otherwise the session has a working set of its own, which starts at the head of the branch and is lost
+// if it is not committed.
+func (sess *DoltSession) CheckoutBranch(ctx context.Context, dbName, branchName string) (*doltdb.Commit, error) {
+	dbd, ok := sess.dbDatas[dbName]
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	dref := ref.NewBranchRef(branchName)
+	hasBranch, err := dbd.ddb.HasRef(ctx, dref)
+
+	if err != nil {
+		return nil, err
+	} else if !hasBranch {
+		return nil, ErrBranchNotFound.New(branchName)
+	}
+
+	if dbd.hasOwnWorkingSet() {
+		dirty, err := sess.hasUncommittedChanges(ctx, dbName)
+
+		if err != nil {
+			return nil, err
+		} else if dirty {
+			return nil, ErrUncommittedChanges.New(dbd.branch.GetPath())
+		}
+	}
+
+	cs, err := doltdb.NewCommitSpec(dref.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := dbd.ddb.Resolve(ctx, cs, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	dbd.branch = dref
+	sess.dbDatas[dbName] = dbd
+	delete(sess.txRoots, dbName)
+
+	var root *doltdb.RootValue
+	if dbd.hasOwnWorkingSet() {
+		root, err = cm.GetRootValue()
+	} else {
+		root, err = dbd.ddb.ReadRootValue(ctx, dbd.rsr.WorkingHash())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Session.Set(ctx, dbName+HeadKeySuffix, sql.Text, h.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cm, sess.setWorkingRoot(ctx, dbName, root)
+}
+
+// hasUncommittedChanges returns whether the working root of the database given differs from the root of the session's
+// head commit.
+func (sess *DoltSession) hasUncommittedChanges(ctx context.Context, dbName string) (bool, error) {
+	parent, _, err := sess.GetParentCommit(ctx, dbName)
+
+	if err != nil {
+		return false, err
+	}
+
+	headRoot, err := parent.GetRootValue()
+
+	if err != nil {
+		return false, err
+	}
+
+	headHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	return headHash.String() != sess.dbRoots[dbName].hashStr, nil
+}
+
+// UpdateBranch adds the commit given, which must descend from the session's head, to the branch checked out in the
+// session, and makes it the session's head and working root. It does nothing if the session has not checked out a
+// branch. If the branch is checked out in the repository, the commit is also staged there, as it is by `dolt commit`.
+func (sess *DoltSession) UpdateBranch(ctx context.Context, dbName string, cm *doltdb.Commit) error {
+	dbd, ok := sess.dbDatas[dbName]
+
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	} else if dbd.branch == nil {
+		return nil
+	}
+
+	err := dbd.ddb.FastForward(ctx, dbd.branch, cm)
+
+	if err == datas.ErrMergeNeeded {
+		return ErrBranchMoved.New(dbd.branch.GetPath())
+	} else if err != nil {
+		return err
+	}
+
+	if !dbd.hasOwnWorkingSet() {
+		root, err := cm.GetRootValue()
+
+		if err != nil {
+			return err
+		}
+
+		h, err := root.HashOf()
+
+		if err != nil {
+			return err
+		}
+
+		err = dbd.rsw.SetStagedHash(ctx, h)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	return sess.Set(ctx, dbName+HeadKeySuffix, sql.Text, h.String())
+}
@@ -211,6 +211,11 @@ type CreateReaderFunc func(ctx context.Context, m types.Map) (table.TableReadClo
 // CreateReaderFuncLimitedByExpressions takes a table schema and a slice of sql filters and returns a CreateReaderFunc
 // which limits the rows read based on the filters supplied.
 func CreateReaderFuncLimitedByExpressions(nbf *types.NomsBinFormat, tblSch schema.Schema, filters []sql.Expression) (CreateReaderFunc, error) {
+	if schema.IsKeyless(tblSch) {
+		// the rows of keyless tables are keyed by the hash of their values, so filters can't limit the keys read
+		return getCreateFuncForKeySet(nbf, setalgebra.UniversalSet{}, tblSch)
+	}
+
 	pkCols := tblSch.GetPKCols()
 	var keySet setalgebra.Set = setalgebra.UniversalSet{}
 	var err error
@@ -350,7 +350,7 @@ func newRowItrForTableAtCommit(
 		return nil, err
 	}
 
-	toSuperSchConv, err := rowConvForSchema(ss, tblSch)
+	toSuperSchConv, err := rowConvForSchema(ctx, root.VRW(), ss, tblSch)
 
 	if err != nil {
 		return nil, err
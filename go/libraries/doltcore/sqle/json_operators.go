@@ -0,0 +1,101 @@
+// Copyright 2019 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"reflect"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+var binaryExprType = reflect.TypeOf(&sqlparser.BinaryExpr{})
+
+// RewriteJSONOperators rewrites the JSON column path operators of the given query, which the SQL engine does not
+// support, to the functions they are shorthand for: `col->path` becomes `JSON_EXTRACT(col, path)` and `col->>path`
+// becomes `JSON_UNQUOTE(JSON_EXTRACT(col, path))`. Queries without these operators are returned unchanged.
+func RewriteJSONOperators(query string) string {
+	if !strings.Contains(query, sqlparser.JSONExtractOp) {
+		return query
+	}
+
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		// let the engine report the error
+		return query
+	}
+
+	if !rewriteJSONOperators(reflect.ValueOf(stmt)) {
+		return query
+	}
+
+	return sqlparser.String(stmt)
+}
+
+// rewriteJSONOperators walks the exported fields of the given AST node, replacing any JSON operator expressions. Returns
+// whether any expressions were replaced.
+func rewriteJSONOperators(v reflect.Value) bool {
+	rewritten := false
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			rewritten = rewriteJSONOperators(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		elem := v.Elem()
+		rewritten = rewriteJSONOperators(elem)
+		if elem.Type() == binaryExprType && v.CanSet() {
+			if funcExpr := jsonOperatorToFunc(elem.Interface().(*sqlparser.BinaryExpr)); funcExpr != nil {
+				v.Set(reflect.ValueOf(funcExpr))
+				rewritten = true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" && rewriteJSONOperators(v.Field(i)) {
+				rewritten = true
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if rewriteJSONOperators(v.Index(i)) {
+				rewritten = true
+			}
+		}
+	}
+	return rewritten
+}
+
+func jsonOperatorToFunc(be *sqlparser.BinaryExpr) sqlparser.Expr {
+	switch be.Operator {
+	case sqlparser.JSONExtractOp:
+		return newFuncExpr("json_extract", be.Left, be.Right)
+	case sqlparser.JSONUnquoteExtractOp:
+		return newFuncExpr("json_unquote", newFuncExpr("json_extract", be.Left, be.Right))
+	default:
+		return nil
+	}
+}
+
+func newFuncExpr(name string, args ...sqlparser.Expr) *sqlparser.FuncExpr {
+	exprs := make(sqlparser.SelectExprs, len(args))
+	for i, arg := range args {
+		exprs[i] = &sqlparser.AliasedExpr{Expr: arg}
+	}
+	return &sqlparser.FuncExpr{Name: sqlparser.NewColIdent(name), Exprs: exprs}
+}
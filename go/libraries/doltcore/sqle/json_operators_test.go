@@ -0,0 +1,59 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteJSONOperators(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			"select v->'$.a' from t",
+			"select json_extract(v, '$.a') from t",
+		},
+		{
+			"SELECT pk FROM t WHERE t.v->>'$.a.b' = 'x' ORDER BY v->'$[0]'",
+			"select pk from t where json_unquote(json_extract(t.v, '$.a.b')) = 'x' order by json_extract(v, '$[0]') asc",
+		},
+		{
+			"update t set v2 = v->'$.a' where pk in (select pk from t2 where v->'$.b' > 1)",
+			"update t set v2 = json_extract(v, '$.a') where pk in (select pk from t2 where json_extract(v, '$.b') > 1)",
+		},
+		{
+			"select '->' from t",
+			"select '->' from t",
+		},
+		{
+			"SELECT * FROM t",
+			"SELECT * FROM t",
+		},
+		{
+			"not -> a query",
+			"not -> a query",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			assert.Equal(t, test.expected, RewriteJSONOperators(test.query))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/liquidata-inc/go-mysql-server/auth"
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"vitess.io/vitess/go/mysql"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// Auth authenticates the users of a UserStore using the mysql_native_password method, and authorizes each query
+// using the privileges granted to the user executing it.
+type Auth struct {
+	users    *UserStore
+	readOnly bool
+}
+
+var _ auth.Auth = (*Auth)(nil)
+var _ mysql.AuthServer = (*Auth)(nil)
+
+// NewAuth returns an Auth for the given users. If |readOnly| is true, only queries which read tables are allowed.
+func NewAuth(users *UserStore, readOnly bool) *Auth {
+	return &Auth{users: users, readOnly: readOnly}
+}
+
+// Mysql implements auth.Auth
+func (a *Auth) Mysql() mysql.AuthServer {
+	return a
+}
+
+// Allowed implements auth.Auth
+func (a *Auth) Allowed(ctx *sql.Context, permission auth.Permission) error {
+	if a.readOnly && permission&auth.WritePerm != 0 {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(auth.WritePerm))
+	}
+
+	reqs, err := RequiredPrivileges(ctx, ctx.Query())
+
+	if err != nil {
+		return err
+	}
+
+	if a.readOnly {
+		for _, req := range reqs {
+			if req.Privilege != PrivilegeSelect {
+				return auth.ErrNotAuthorized.Wrap(fmt.Errorf("the server is read only: %s is not allowed", req))
+			}
+		}
+	}
+
+	user := ctx.Client().User
+	if req, ok := a.users.Check(user, reqs); !ok {
+		return auth.ErrNotAuthorized.Wrap(fmt.Errorf("user '%s' does not have the %s privilege", user, req))
+	}
+
+	return nil
+}
+
+// AuthMethod implements mysql.AuthServer
+func (a *Auth) AuthMethod(user string) (string, error) {
+	return mysql.MysqlNativePassword, nil
+}
+
+// Salt implements mysql.AuthServer
+func (a *Auth) Salt() ([]byte, error) {
+	return mysql.NewSalt()
+}
+
+// ValidateHash implements mysql.AuthServer
+func (a *Auth) ValidateHash(salt []byte, user string, authResponse []byte, remoteAddr net.Addr) (mysql.Getter, error) {
+	if !a.users.CheckPassword(user, authResponse, salt) {
+		return nil, mysql.NewSQLError(mysql.ERAccessDeniedError, mysql.SSAccessDeniedError, "Access denied for user '%v'", user)
+	}
+
+	return userData{user}, nil
+}
+
+// Negotiate implements mysql.AuthServer. It is never called, as the mysql_native_password method needs no further
+// negotiation.
+func (a *Auth) Negotiate(c *mysql.Conn, user string, remoteAddr net.Addr) (mysql.Getter, error) {
+	return nil, mysql.NewSQLError(mysql.ERAccessDeniedError, mysql.SSAccessDeniedError, "Access denied for user '%v'", user)
+}
+
+type userData struct {
+	name string
+}
+
+// Get implements mysql.Getter
+func (ud userData) Get() *querypb.VTGateCallerID {
+	return &querypb.VTGateCallerID{Username: ud.name}
+}
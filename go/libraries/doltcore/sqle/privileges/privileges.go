@@ -0,0 +1,194 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Privilege is a set of the operations a user may perform on a database or table
+type Privilege uint8
+
+const (
+	// PrivilegeSelect allows reading rows
+	PrivilegeSelect Privilege = 1 << iota
+	// PrivilegeInsert allows inserting rows
+	PrivilegeInsert
+	// PrivilegeUpdate allows updating rows
+	PrivilegeUpdate
+	// PrivilegeDelete allows deleting rows
+	PrivilegeDelete
+	// PrivilegeDDL allows creating, altering and dropping tables, views and indexes
+	PrivilegeDDL
+	// PrivilegeCommit allows creating dolt commits and merging
+	PrivilegeCommit
+
+	// PrivilegeNone is the empty set of privileges
+	PrivilegeNone Privilege = 0
+	// PrivilegeAll is the set of all privileges
+	PrivilegeAll = PrivilegeSelect | PrivilegeInsert | PrivilegeUpdate | PrivilegeDelete | PrivilegeDDL | PrivilegeCommit
+)
+
+var privilegeNames = []struct {
+	p    Privilege
+	name string
+}{
+	{PrivilegeSelect, "SELECT"},
+	{PrivilegeInsert, "INSERT"},
+	{PrivilegeUpdate, "UPDATE"},
+	{PrivilegeDelete, "DELETE"},
+	{PrivilegeDDL, "DDL"},
+	{PrivilegeCommit, "COMMIT"},
+}
+
+// ErrUnknownPrivilege is returned when parsing the name of a privilege which does not exist
+type ErrUnknownPrivilege struct {
+	Name string
+}
+
+func (e ErrUnknownPrivilege) Error() string {
+	return fmt.Sprintf("unknown privilege '%s'", e.Name)
+}
+
+// ParsePrivilege returns the privilege with the given name, ignoring case. "ALL" and "ALL PRIVILEGES" are parsed as
+// PrivilegeAll.
+func ParsePrivilege(name string) (Privilege, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(name), " "))
+
+	if normalized == "ALL" || normalized == "ALL PRIVILEGES" {
+		return PrivilegeAll, nil
+	}
+
+	for _, pn := range privilegeNames {
+		if pn.name == normalized {
+			return pn.p, nil
+		}
+	}
+
+	return PrivilegeNone, ErrUnknownPrivilege{name}
+}
+
+// Has returns whether every privilege in |other| is in |p|
+func (p Privilege) Has(other Privilege) bool {
+	return p&other == other
+}
+
+// Names returns the names of the privileges in the set
+func (p Privilege) Names() []string {
+	var names []string
+	for _, pn := range privilegeNames {
+		if p.Has(pn.p) {
+			names = append(names, pn.name)
+		}
+	}
+
+	return names
+}
+
+// String returns the names of the privileges in the set separated by commas, or "ALL PRIVILEGES" if every privilege
+// is in the set.
+func (p Privilege) String() string {
+	if p == PrivilegeAll {
+		return "ALL PRIVILEGES"
+	} else if p == PrivilegeNone {
+		return "USAGE"
+	}
+
+	return strings.Join(p.Names(), ", ")
+}
+
+// MarshalJSON implements json.Marshaler, writing the privileges as a list of names
+func (p Privilege) MarshalJSON() ([]byte, error) {
+	names := p.Names()
+
+	if names == nil {
+		names = []string{}
+	}
+
+	return json.Marshal(names)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading the privileges from a list of names
+func (p *Privilege) UnmarshalJSON(data []byte) error {
+	var names []string
+	err := json.Unmarshal(data, &names)
+
+	if err != nil {
+		return err
+	}
+
+	*p = PrivilegeNone
+	for _, name := range names {
+		priv, err := ParsePrivilege(name)
+
+		if err != nil {
+			return err
+		}
+
+		*p |= priv
+	}
+
+	return nil
+}
+
+// Grant is a set of privileges granted on a table, on every table of a database if Table is empty, or on every
+// database if Database is also empty.
+type Grant struct {
+	Database   string    `json:"database,omitempty"`
+	Table      string    `json:"table,omitempty"`
+	Privileges Privilege `json:"privileges"`
+}
+
+// Target returns the database and table the grant applies to, in the form used by GRANT statements
+func (g Grant) Target() string {
+	if g.Database == "" {
+		return "*.*"
+	} else if g.Table == "" {
+		return fmt.Sprintf("`%s`.*", g.Database)
+	}
+
+	return fmt.Sprintf("`%s`.`%s`", g.Database, g.Table)
+}
+
+func (g Grant) appliesTo(dbName, tblName string) bool {
+	if g.Database == "" {
+		return true
+	} else if !strings.EqualFold(g.Database, dbName) {
+		return false
+	}
+
+	return g.Table == "" || strings.EqualFold(g.Table, tblName)
+}
+
+func (g Grant) sameTarget(dbName, tblName string) bool {
+	return strings.EqualFold(g.Database, dbName) && strings.EqualFold(g.Table, tblName)
+}
+
+// Requirement is a privilege needed on a table, or on a database if Table is empty, to execute a query
+type Requirement struct {
+	Database  string
+	Table     string
+	Privilege Privilege
+}
+
+func (r Requirement) String() string {
+	if r.Table == "" {
+		return fmt.Sprintf("%s on database '%s'", r.Privilege, r.Database)
+	}
+
+	return fmt.Sprintf("%s on table '%s.%s'", r.Privilege, r.Database, r.Table)
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"strings"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/liquidata-inc/go-mysql-server/sql/parse"
+	"github.com/liquidata-inc/go-mysql-server/sql/plan"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/dfunctions"
+)
+
+// commitFuncs are the names of the functions which require PrivilegeCommit on the current database
+var commitFuncs = map[string]bool{
+	dfunctions.CommitFuncName: true,
+	dfunctions.MergeFuncName:  true,
+}
+
+type requirementCollector struct {
+	currentDB string
+	reqs      []Requirement
+}
+
+func (rc *requirementCollector) addTable(tn sqlparser.TableName, priv Privilege) {
+	// vitess reads SELECTs without a FROM clause from the dual table
+	if tn.IsEmpty() || (tn.Qualifier.IsEmpty() && strings.EqualFold(tn.Name.String(), "dual")) {
+		return
+	}
+
+	dbName := rc.currentDB
+	if !tn.Qualifier.IsEmpty() {
+		dbName = tn.Qualifier.String()
+	}
+
+	rc.add(dbName, tn.Name.String(), priv)
+}
+
+func (rc *requirementCollector) add(dbName, tblName string, priv Privilege) {
+	if strings.EqualFold(dbName, sql.InformationSchemaDatabaseName) {
+		return
+	}
+
+	for i, req := range rc.reqs {
+		if strings.EqualFold(req.Database, dbName) && strings.EqualFold(req.Table, tblName) {
+			rc.reqs[i].Privilege |= priv
+			return
+		}
+	}
+
+	rc.reqs = append(rc.reqs, Requirement{Database: dbName, Table: tblName, Privilege: priv})
+}
+
+// addReadTables adds a requirement of |priv| on every table read by the given nodes, of PrivilegeCommit on the current
+// database if the nodes call a function which creates a commit, and of PrivilegeSelect on the current database if
+// they check out a branch.
+func (rc *requirementCollector) addReadTables(priv Privilege, nodes ...sqlparser.SQLNode) {
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.AliasedTableExpr:
+			if tn, ok := node.Expr.(sqlparser.TableName); ok {
+				rc.addTable(tn, priv)
+			}
+		case *sqlparser.FuncExpr:
+			if node.Qualifier.IsEmpty() && commitFuncs[node.Name.Lowered()] {
+				rc.add(rc.currentDB, "", PrivilegeCommit)
+			} else if node.Qualifier.IsEmpty() && node.Name.Lowered() == dfunctions.CheckoutFuncName {
+				rc.add(rc.currentDB, "", PrivilegeSelect)
+			}
+		}
+
+		return true, nil
+	}, nodes...)
+}
+
+// RequiredPrivileges returns the privileges needed to execute |query| in the current database of |ctx|
+func RequiredPrivileges(ctx *sql.Context, query string) ([]Requirement, error) {
+	rc := &requirementCollector{currentDB: ctx.GetCurrentDatabase()}
+	stmt, err := sqlparser.Parse(query)
+
+	if err != nil {
+		// statements such as LOCK TABLES are parsed by the engine rather than by vitess
+		return rc.fromPlan(ctx, query)
+	}
+
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		priv := PrivilegeInsert
+		if stmt.Action == sqlparser.ReplaceStr {
+			priv |= PrivilegeDelete
+		}
+
+		if len(stmt.OnDup) > 0 {
+			priv |= PrivilegeUpdate
+		}
+
+		rc.addTable(stmt.Table, priv)
+		rc.addReadTables(PrivilegeSelect, stmt.Rows, stmt.OnDup)
+	case *sqlparser.Update:
+		rc.addReadTables(PrivilegeUpdate, stmt.TableExprs)
+		rc.addReadTables(PrivilegeSelect, stmt.Exprs, stmt.Where)
+	case *sqlparser.Delete:
+		if len(stmt.Targets) > 0 {
+			for _, tn := range stmt.Targets {
+				rc.addTable(tn, PrivilegeDelete)
+			}
+
+			rc.addReadTables(PrivilegeSelect, stmt.TableExprs)
+		} else {
+			rc.addReadTables(PrivilegeDelete, stmt.TableExprs)
+		}
+
+		rc.addReadTables(PrivilegeSelect, stmt.Where)
+	case *sqlparser.DDL:
+		rc.addTable(stmt.Table, PrivilegeDDL)
+		rc.addTable(stmt.View, PrivilegeDDL)
+
+		for _, tns := range []sqlparser.TableNames{stmt.FromTables, stmt.ToTables, stmt.FromViews} {
+			for _, tn := range tns {
+				rc.addTable(tn, PrivilegeDDL)
+			}
+		}
+
+		rc.addReadTables(PrivilegeSelect, stmt.ViewExpr)
+	case *sqlparser.DBDDL:
+		rc.add(stmt.DBName, "", PrivilegeDDL)
+	case *sqlparser.Show:
+		rc.addTable(stmt.Table, PrivilegeSelect)
+	case *sqlparser.Use:
+		rc.add(stmt.DBName.String(), "", PrivilegeSelect)
+	default:
+		rc.addReadTables(PrivilegeSelect, stmt)
+	}
+
+	return rc.reqs, nil
+}
+
+func (rc *requirementCollector) fromPlan(ctx *sql.Context, query string) ([]Requirement, error) {
+	node, err := parse.Parse(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Inspect(node, func(node sql.Node) bool {
+		if t, ok := node.(*plan.UnresolvedTable); ok {
+			dbName := t.Database
+			if dbName == "" {
+				dbName = rc.currentDB
+			}
+
+			rc.add(dbName, t.Name(), PrivilegeSelect)
+		}
+
+		return true
+	})
+
+	return rc.reqs, nil
+}
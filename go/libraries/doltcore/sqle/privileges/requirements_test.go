@@ -0,0 +1,65 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"context"
+	"testing"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredPrivileges(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []Requirement
+	}{
+		{"SELECT * FROM t1", []Requirement{{"db", "t1", PrivilegeSelect}}},
+		{"SELECT * FROM t1 JOIN other.t2 ON t1.a = t2.a WHERE t1.b IN (SELECT b FROM t3)", []Requirement{
+			{"db", "t1", PrivilegeSelect},
+			{"other", "t2", PrivilegeSelect},
+			{"db", "t3", PrivilegeSelect},
+		}},
+		{"SELECT * FROM information_schema.tables", nil},
+		{"SELECT 1", nil},
+		{"INSERT INTO t1 VALUES (1)", []Requirement{{"db", "t1", PrivilegeInsert}}},
+		{"INSERT INTO t1 SELECT * FROM t2", []Requirement{{"db", "t1", PrivilegeInsert}, {"db", "t2", PrivilegeSelect}}},
+		{"REPLACE INTO t1 VALUES (1)", []Requirement{{"db", "t1", PrivilegeInsert | PrivilegeDelete}}},
+		{"INSERT INTO t1 VALUES (1) ON DUPLICATE KEY UPDATE a = 2", []Requirement{{"db", "t1", PrivilegeInsert | PrivilegeUpdate}}},
+		{"UPDATE t1 SET a = 1 WHERE b IN (SELECT b FROM t2)", []Requirement{{"db", "t1", PrivilegeUpdate}, {"db", "t2", PrivilegeSelect}}},
+		{"DELETE FROM t1 WHERE a = 1", []Requirement{{"db", "t1", PrivilegeDelete}}},
+		{"CREATE TABLE t1 (a int primary key)", []Requirement{{"db", "t1", PrivilegeDDL}}},
+		{"DROP TABLE t1, t2", []Requirement{{"db", "t1", PrivilegeDDL}, {"db", "t2", PrivilegeDDL}}},
+		{"CREATE VIEW v AS SELECT * FROM t1", []Requirement{{"db", "v", PrivilegeDDL}, {"db", "t1", PrivilegeSelect}}},
+		{"CREATE DATABASE db2", []Requirement{{"db2", "", PrivilegeDDL}}},
+		{"SELECT COMMIT('-m', 'msg')", []Requirement{{"db", "", PrivilegeCommit}}},
+		{"SELECT MERGE('branch') FROM dual", []Requirement{{"db", "", PrivilegeCommit}}},
+		{"SELECT DOLT_CHECKOUT('branch')", []Requirement{{"db", "", PrivilegeSelect}}},
+		{"USE db2", []Requirement{{"db2", "", PrivilegeSelect}}},
+	}
+
+	ctx := sql.NewContext(context.Background())
+	ctx.SetCurrentDatabase("db")
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			reqs, err := RequiredPrivileges(ctx, test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, reqs)
+		})
+	}
+}
@@ -0,0 +1,506 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// The statements below manage users and their privileges. They are not supported by the sql parser, so they are
+// parsed here. Account names may include a host, as in 'name'@'host', but hosts are ignored.
+
+// CreateUser is a CREATE USER [IF NOT EXISTS] user [IDENTIFIED BY 'password'] statement
+type CreateUser struct {
+	Name        string
+	Password    string
+	IfNotExists bool
+}
+
+// AlterUser is an ALTER USER user IDENTIFIED BY 'password' statement
+type AlterUser struct {
+	Name     string
+	Password string
+}
+
+// DropUser is a DROP USER [IF EXISTS] user [, user]... statement
+type DropUser struct {
+	Names    []string
+	IfExists bool
+}
+
+// GrantPrivileges is a GRANT privileges ON target TO user statement, where the target is *.*, db.* or db.table
+type GrantPrivileges struct {
+	Privileges Privilege
+	Database   string
+	Table      string
+	User       string
+}
+
+// RevokePrivileges is a REVOKE privileges ON target FROM user statement, where the target is *.*, db.* or db.table
+type RevokePrivileges struct {
+	Privileges Privilege
+	Database   string
+	Table      string
+	User       string
+}
+
+// ShowGrants is a SHOW GRANTS [FOR user] statement. User is empty if the statement applies to the current user.
+type ShowGrants struct {
+	User string
+}
+
+// ParseStatement parses a statement which manages users and their privileges. If |query| is not such a statement,
+// nil is returned with no error.
+func ParseStatement(query string) (interface{}, error) {
+	toks, err := tokenize(query)
+
+	if err != nil || len(toks) == 0 {
+		// statements which can't be tokenized are left for the sql parser to report
+		return nil, nil
+	}
+
+	p := &stmtParser{toks: toks}
+
+	var stmt interface{}
+	switch {
+	case p.keywords("CREATE", "USER"):
+		stmt, err = p.parseCreateUser()
+	case p.keywords("ALTER", "USER"):
+		stmt, err = p.parseAlterUser()
+	case p.keywords("DROP", "USER"):
+		stmt, err = p.parseDropUser()
+	case p.keywords("GRANT"):
+		stmt, err = p.parseGrantOrRevoke("TO")
+	case p.keywords("REVOKE"):
+		stmt, err = p.parseGrantOrRevoke("FROM")
+	case p.keywords("SHOW", "GRANTS"):
+		stmt, err = p.parseShowGrants()
+	default:
+		return nil, nil
+	}
+
+	if err == nil && !p.done() {
+		err = p.unexpected()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	quotedToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(query string) ([]token, error) {
+	var toks []token
+	runes := []rune(strings.TrimRight(strings.TrimSpace(query), ";"))
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == '\\' && r != '`' && j+1 < len(runes) {
+					j++
+				} else if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						j++
+					} else {
+						break
+					}
+				}
+
+				sb.WriteRune(runes[j])
+			}
+
+			if j == len(runes) {
+				return nil, errors.New("unterminated quoted string")
+			}
+
+			toks = append(toks, token{quotedToken, sb.String()})
+			i = j + 1
+		case r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (runes[j] == '_' || runes[j] == '$' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+
+			toks = append(toks, token{wordToken, string(runes[i:j])})
+			i = j
+		default:
+			toks = append(toks, token{symbolToken, string(r)})
+			i++
+		}
+	}
+
+	return toks, nil
+}
+
+type stmtParser struct {
+	toks []token
+	pos  int
+}
+
+func (p *stmtParser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+// keywords consumes the given keywords and returns true if they are next, otherwise it consumes nothing
+func (p *stmtParser) keywords(kws ...string) bool {
+	if p.pos+len(kws) > len(p.toks) {
+		return false
+	}
+
+	for i, kw := range kws {
+		tok := p.toks[p.pos+i]
+		if tok.kind != wordToken || !strings.EqualFold(tok.text, kw) {
+			return false
+		}
+	}
+
+	p.pos += len(kws)
+	return true
+}
+
+func (p *stmtParser) symbol(sym string) bool {
+	if !p.done() && p.toks[p.pos].kind == symbolToken && p.toks[p.pos].text == sym {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *stmtParser) unexpected() error {
+	if p.done() {
+		return errors.New("syntax error: unexpected end of statement")
+	}
+
+	return fmt.Errorf("syntax error at '%s'", p.toks[p.pos].text)
+}
+
+func (p *stmtParser) expectKeyword(kw string) error {
+	if !p.keywords(kw) {
+		return p.unexpected()
+	}
+
+	return nil
+}
+
+// name parses an identifier or quoted string
+func (p *stmtParser) name() (string, error) {
+	if p.done() || p.toks[p.pos].kind == symbolToken {
+		return "", p.unexpected()
+	}
+
+	p.pos++
+	return p.toks[p.pos-1].text, nil
+}
+
+func (p *stmtParser) quotedString() (string, error) {
+	if p.done() || p.toks[p.pos].kind != quotedToken {
+		return "", p.unexpected()
+	}
+
+	p.pos++
+	return p.toks[p.pos-1].text, nil
+}
+
+// account parses an account name, ignoring its host
+func (p *stmtParser) account() (string, error) {
+	name, err := p.name()
+
+	if err != nil {
+		return "", err
+	}
+
+	if p.symbol("@") {
+		if _, err := p.name(); err != nil {
+			return "", err
+		}
+	}
+
+	return name, nil
+}
+
+func (p *stmtParser) identifiedBy() (string, error) {
+	if !p.keywords("IDENTIFIED", "BY") {
+		return "", p.unexpected()
+	}
+
+	return p.quotedString()
+}
+
+func (p *stmtParser) parseCreateUser() (interface{}, error) {
+	stmt := CreateUser{IfNotExists: p.keywords("IF", "NOT", "EXISTS")}
+
+	var err error
+	stmt.Name, err = p.account()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		stmt.Password, err = p.identifiedBy()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *stmtParser) parseAlterUser() (interface{}, error) {
+	name, err := p.account()
+
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := p.identifiedBy()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return AlterUser{Name: name, Password: password}, nil
+}
+
+func (p *stmtParser) parseDropUser() (interface{}, error) {
+	stmt := DropUser{IfExists: p.keywords("IF", "EXISTS")}
+
+	for {
+		name, err := p.account()
+
+		if err != nil {
+			return nil, err
+		}
+
+		stmt.Names = append(stmt.Names, name)
+
+		if !p.symbol(",") {
+			return stmt, nil
+		}
+	}
+}
+
+func (p *stmtParser) parseGrantOrRevoke(userKeyword string) (interface{}, error) {
+	privs := PrivilegeNone
+
+	for {
+		if p.done() || p.toks[p.pos].kind != wordToken {
+			return nil, p.unexpected()
+		}
+
+		name := p.toks[p.pos].text
+		p.pos++
+
+		if strings.EqualFold(name, "ALL") {
+			p.keywords("PRIVILEGES")
+		}
+
+		priv, err := ParsePrivilege(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		privs |= priv
+
+		if !p.symbol(",") {
+			break
+		}
+	}
+
+	if err := p.expectKeyword("ON"); err != nil {
+		return nil, err
+	}
+
+	dbName, tblName, err := p.target()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectKeyword(userKeyword); err != nil {
+		return nil, err
+	}
+
+	user, err := p.account()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if userKeyword == "TO" {
+		return GrantPrivileges{Privileges: privs, Database: dbName, Table: tblName, User: user}, nil
+	}
+
+	return RevokePrivileges{Privileges: privs, Database: dbName, Table: tblName, User: user}, nil
+}
+
+// target parses *.*, db.* or db.table
+func (p *stmtParser) target() (dbName, tblName string, err error) {
+	if p.symbol("*") {
+		if !p.symbol(".") || !p.symbol("*") {
+			return "", "", errors.New("privileges must be granted on *.*, a database using db.* or a table using db.table")
+		}
+
+		return "", "", nil
+	}
+
+	dbName, err = p.name()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if !p.symbol(".") {
+		return "", "", errors.New("privileges must be granted on *.*, a database using db.* or a table using db.table")
+	}
+
+	if p.symbol("*") {
+		return dbName, "", nil
+	}
+
+	tblName, err = p.name()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return dbName, tblName, nil
+}
+
+func (p *stmtParser) parseShowGrants() (interface{}, error) {
+	if !p.keywords("FOR") {
+		return ShowGrants{}, nil
+	}
+
+	if p.keywords("CURRENT_USER") {
+		if p.symbol("(") && !p.symbol(")") {
+			return nil, p.unexpected()
+		}
+
+		return ShowGrants{}, nil
+	}
+
+	user, err := p.account()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ShowGrants{User: user}, nil
+}
+
+// ErrNotSuperuser is returned when a user other than a superuser executes a statement which manages users
+var ErrNotSuperuser = errors.New("access denied; only the users of the server config may manage users and privileges")
+
+// ExecStatement executes a statement returned by ParseStatement on behalf of the user named |user|. For SHOW GRANTS,
+// the GRANT statements which recreate the privileges of the user are returned.
+func ExecStatement(users *UserStore, user string, stmt interface{}) ([]string, error) {
+	if showGrants, ok := stmt.(ShowGrants); ok {
+		if showGrants.User == "" {
+			showGrants.User = user
+		} else if showGrants.User != user && !users.IsSuperuser(user) {
+			return nil, ErrNotSuperuser
+		}
+
+		grants, err := users.Grants(showGrants.User)
+
+		if err != nil {
+			return nil, fmt.Errorf("user '%s' does not exist", showGrants.User)
+		}
+
+		rows := []string{fmt.Sprintf("GRANT USAGE ON *.* TO `%s`", showGrants.User)}
+		for _, grant := range grants {
+			rows = append(rows, fmt.Sprintf("GRANT %s ON %s TO `%s`", grant.Privileges, grant.Target(), showGrants.User))
+		}
+
+		return rows, nil
+	}
+
+	if !users.IsSuperuser(user) {
+		return nil, ErrNotSuperuser
+	}
+
+	var err error
+	switch stmt := stmt.(type) {
+	case CreateUser:
+		err = users.CreateUser(stmt.Name, stmt.Password)
+
+		if err == ErrUserExists {
+			if stmt.IfNotExists {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("user '%s' already exists", stmt.Name)
+		}
+	case AlterUser:
+		err = userErr(users.SetPassword(stmt.Name, stmt.Password), stmt.Name)
+	case DropUser:
+		for _, name := range stmt.Names {
+			err = users.DropUser(name)
+
+			if err == ErrUserNotFound && stmt.IfExists {
+				err = nil
+			} else if err != nil {
+				return nil, userErr(err, name)
+			}
+		}
+	case GrantPrivileges:
+		err = userErr(users.GrantPrivileges(stmt.User, stmt.Database, stmt.Table, stmt.Privileges), stmt.User)
+	case RevokePrivileges:
+		err = userErr(users.RevokePrivileges(stmt.User, stmt.Database, stmt.Table, stmt.Privileges), stmt.User)
+	default:
+		err = fmt.Errorf("unknown statement type %T", stmt)
+	}
+
+	return nil, err
+}
+
+func userErr(err error, name string) error {
+	switch err {
+	case ErrUserNotFound:
+		return fmt.Errorf("user '%s' does not exist", name)
+	case ErrSuperuser:
+		return fmt.Errorf("user '%s' is defined by the server config and cannot be changed using sql", name)
+	}
+
+	return err
+}
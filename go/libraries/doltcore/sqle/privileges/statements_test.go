@@ -0,0 +1,113 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatement(t *testing.T) {
+	tests := []struct {
+		query       string
+		expected    interface{}
+		expectedErr bool
+	}{
+		{"SELECT * FROM users", nil, false},
+		{"CREATE TABLE user (pk int primary key)", nil, false},
+		{"CREATE USER bob", CreateUser{Name: "bob"}, false},
+		{"create user if not exists 'bob'@'%' identified by 'pass';", CreateUser{Name: "bob", Password: "pass", IfNotExists: true}, false},
+		{"CREATE USER bob IDENTIFIED BY pass", nil, true},
+		{"ALTER USER `bob`@localhost IDENTIFIED BY 'it''s'", AlterUser{Name: "bob", Password: "it's"}, false},
+		{"ALTER USER bob", nil, true},
+		{"DROP USER IF EXISTS bob, 'alice'", DropUser{Names: []string{"bob", "alice"}, IfExists: true}, false},
+		{"DROP USER", nil, true},
+		{"GRANT SELECT, INSERT ON *.* TO bob", GrantPrivileges{Privileges: PrivilegeSelect | PrivilegeInsert, User: "bob"}, false},
+		{"GRANT ALL PRIVILEGES ON db.* TO bob", GrantPrivileges{Privileges: PrivilegeAll, Database: "db", User: "bob"}, false},
+		{"grant commit on `db`.`tbl` to 'bob'@'%'", GrantPrivileges{Privileges: PrivilegeCommit, Database: "db", Table: "tbl", User: "bob"}, false},
+		{"GRANT SELECT ON tbl TO bob", nil, true},
+		{"GRANT EXECUTE ON *.* TO bob", nil, true},
+		{"GRANT SELECT ON *.* bob", nil, true},
+		{"REVOKE UPDATE, DELETE ON db.tbl FROM bob", RevokePrivileges{Privileges: PrivilegeUpdate | PrivilegeDelete, Database: "db", Table: "tbl", User: "bob"}, false},
+		{"SHOW GRANTS", ShowGrants{}, false},
+		{"SHOW GRANTS FOR CURRENT_USER()", ShowGrants{}, false},
+		{"SHOW GRANTS FOR bob", ShowGrants{User: "bob"}, false},
+		{"SHOW GRANTS FOR bob extra", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := ParseStatement(test.query)
+
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, stmt)
+			}
+		})
+	}
+}
+
+func TestExecStatement(t *testing.T) {
+	users, err := NewUserStore(nil, "")
+	require.NoError(t, err)
+	users.AddSuperuser("root", "")
+
+	exec := func(user, query string) ([]string, error) {
+		stmt, err := ParseStatement(query)
+		require.NoError(t, err)
+		return ExecStatement(users, user, stmt)
+	}
+
+	_, err = exec("root", "CREATE USER bob IDENTIFIED BY 'pass'")
+	require.NoError(t, err)
+	_, err = exec("root", "CREATE USER bob")
+	assert.Error(t, err)
+	_, err = exec("root", "CREATE USER IF NOT EXISTS bob")
+	assert.NoError(t, err)
+	_, err = exec("root", "GRANT SELECT ON db.* TO bob")
+	require.NoError(t, err)
+	_, err = exec("root", "GRANT ALL ON db.tbl TO bob")
+	require.NoError(t, err)
+
+	rows, err := exec("bob", "SHOW GRANTS")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GRANT USAGE ON *.* TO `bob`",
+		"GRANT SELECT ON `db`.* TO `bob`",
+		"GRANT ALL PRIVILEGES ON `db`.`tbl` TO `bob`",
+	}, rows)
+
+	rows, err = exec("root", "SHOW GRANTS")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GRANT USAGE ON *.* TO `root`", "GRANT ALL PRIVILEGES ON *.* TO `root`"}, rows)
+
+	_, err = exec("bob", "SHOW GRANTS FOR root")
+	assert.Equal(t, ErrNotSuperuser, err)
+	_, err = exec("bob", "CREATE USER alice")
+	assert.Equal(t, ErrNotSuperuser, err)
+	_, err = exec("root", "DROP USER root")
+	assert.Error(t, err)
+	_, err = exec("root", "GRANT SELECT ON *.* TO alice")
+	assert.Error(t, err)
+
+	_, err = exec("root", "DROP USER IF EXISTS bob, alice")
+	require.NoError(t, err)
+	_, err = exec("root", "DROP USER bob")
+	assert.Error(t, err)
+}
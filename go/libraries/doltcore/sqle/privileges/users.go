@@ -0,0 +1,363 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var ErrUserExists = errors.New("user already exists")
+var ErrUserNotFound = errors.New("user not found")
+var ErrSuperuser = errors.New("the users of the server config cannot be changed using sql")
+
+// User is a user which may connect to the server, along with the privileges granted to it
+type User struct {
+	Name string `json:"name"`
+	// PasswordHash is the mysql_native_password hash of the password, or empty if the user has no password
+	PasswordHash string  `json:"password_hash"`
+	Grants       []Grant `json:"grants,omitempty"`
+
+	superuser bool
+}
+
+// HashPassword returns the mysql_native_password hash of a password, SHA1(SHA1(password)), as stored in the
+// authentication_string column of the mysql.user table.
+func HashPassword(password string) string {
+	if password == "" {
+		return ""
+	}
+
+	h1 := sha1.Sum([]byte(password))
+	h2 := sha1.Sum(h1[:])
+
+	return "*" + strings.ToUpper(hex.EncodeToString(h2[:]))
+}
+
+// checkScrambledPassword returns whether |scrambled| is the password hashed by |passwordHash| scrambled with |salt|
+// according to the mysql_native_password authentication method, in which the client sends
+// SHA1(password) XOR SHA1(salt + SHA1(SHA1(password))).
+func checkScrambledPassword(scrambled, salt []byte, passwordHash string) bool {
+	if passwordHash == "" {
+		return len(scrambled) == 0
+	}
+
+	stage2, err := hex.DecodeString(strings.TrimPrefix(passwordHash, "*"))
+
+	if err != nil || len(scrambled) != sha1.Size {
+		return false
+	}
+
+	crypt := sha1.New()
+	crypt.Write(salt)
+	crypt.Write(stage2)
+	mask := crypt.Sum(nil)
+
+	stage1 := make([]byte, sha1.Size)
+	for i := range stage1 {
+		stage1[i] = scrambled[i] ^ mask[i]
+	}
+
+	candidate := sha1.Sum(stage1)
+	return bytes.Equal(candidate[:], stage2)
+}
+
+// UserStore holds the users of a server. Users created using sql are persisted to a json file if the store was
+// created with a path. Superusers come from the server config, have every privilege, and are never persisted.
+type UserStore struct {
+	fs   filesys.Filesys
+	path string
+
+	mu    *sync.RWMutex
+	users map[string]*User
+}
+
+// NewUserStore returns a UserStore holding the users persisted in the file at |path|. If the file does not exist the
+// store is empty, and the file is created when a user is first created. If |path| is empty, users are not persisted.
+func NewUserStore(fs filesys.Filesys, path string) (*UserStore, error) {
+	us := &UserStore{fs: fs, path: path, mu: &sync.RWMutex{}, users: make(map[string]*User)}
+
+	if path == "" {
+		return us, nil
+	}
+
+	if exists, isDir := fs.Exists(path); !exists {
+		return us, nil
+	} else if isDir {
+		return nil, fmt.Errorf("privilege file '%s' is a directory", path)
+	}
+
+	data, err := fs.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*User
+	err = json.Unmarshal(data, &users)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse privilege file '%s': %w", path, err)
+	}
+
+	for _, user := range users {
+		if _, ok := us.users[user.Name]; ok {
+			return nil, fmt.Errorf("failed to parse privilege file '%s': duplicate user '%s'", path, user.Name)
+		}
+
+		us.users[user.Name] = user
+	}
+
+	return us, nil
+}
+
+// AddSuperuser adds a user with every privilege which cannot be changed using sql
+func (us *UserStore) AddSuperuser(name, password string) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	us.users[name] = &User{Name: name, PasswordHash: HashPassword(password), superuser: true}
+}
+
+// IsSuperuser returns whether the user with the given name is a superuser
+func (us *UserStore) IsSuperuser(name string) bool {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	user, ok := us.users[name]
+	return ok && user.superuser
+}
+
+// CheckPassword returns whether a user with the given name exists and |scrambled| is its password scrambled with
+// |salt| by the mysql_native_password authentication method.
+func (us *UserStore) CheckPassword(name string, scrambled, salt []byte) bool {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	user, ok := us.users[name]
+	return ok && checkScrambledPassword(scrambled, salt, user.PasswordHash)
+}
+
+// CreateUser creates a user with the given password and no privileges
+func (us *UserStore) CreateUser(name, password string) error {
+	return us.update(func(users map[string]*User) error {
+		if _, ok := users[name]; ok {
+			return ErrUserExists
+		}
+
+		users[name] = &User{Name: name, PasswordHash: HashPassword(password)}
+		return nil
+	})
+}
+
+// SetPassword changes the password of a user
+func (us *UserStore) SetPassword(name, password string) error {
+	return us.update(func(users map[string]*User) error {
+		user, err := getMutableUser(users, name)
+
+		if err != nil {
+			return err
+		}
+
+		user.PasswordHash = HashPassword(password)
+		return nil
+	})
+}
+
+// DropUser removes a user along with its privileges
+func (us *UserStore) DropUser(name string) error {
+	return us.update(func(users map[string]*User) error {
+		if _, err := getMutableUser(users, name); err != nil {
+			return err
+		}
+
+		delete(users, name)
+		return nil
+	})
+}
+
+// GrantPrivileges adds privileges on a table, or on every table of a database if |tblName| is empty, or on every
+// database if |dbName| is also empty.
+func (us *UserStore) GrantPrivileges(name, dbName, tblName string, privs Privilege) error {
+	return us.update(func(users map[string]*User) error {
+		user, err := getMutableUser(users, name)
+
+		if err != nil {
+			return err
+		}
+
+		for i := range user.Grants {
+			if user.Grants[i].sameTarget(dbName, tblName) {
+				user.Grants[i].Privileges |= privs
+				return nil
+			}
+		}
+
+		user.Grants = append(user.Grants, Grant{Database: dbName, Table: tblName, Privileges: privs})
+		return nil
+	})
+}
+
+// RevokePrivileges removes privileges granted on a table, on every table of a database if |tblName| is empty, or on
+// every database if |dbName| is also empty. Only the privileges granted on exactly that target are removed, so
+// revoking a privilege on a table does not affect the same privilege granted on its database.
+func (us *UserStore) RevokePrivileges(name, dbName, tblName string, privs Privilege) error {
+	return us.update(func(users map[string]*User) error {
+		user, err := getMutableUser(users, name)
+
+		if err != nil {
+			return err
+		}
+
+		grants := user.Grants[:0]
+		for _, grant := range user.Grants {
+			if grant.sameTarget(dbName, tblName) {
+				grant.Privileges &^= privs
+			}
+
+			if grant.Privileges != PrivilegeNone {
+				grants = append(grants, grant)
+			}
+		}
+
+		user.Grants = grants
+		return nil
+	})
+}
+
+// Grants returns the privileges granted to a user
+func (us *UserStore) Grants(name string) ([]Grant, error) {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	user, ok := us.users[name]
+
+	if !ok {
+		return nil, ErrUserNotFound
+	} else if user.superuser {
+		return []Grant{{Privileges: PrivilegeAll}}, nil
+	}
+
+	return append([]Grant(nil), user.Grants...), nil
+}
+
+// Check returns the first of the requirements which the user with the given name does not meet, or true if it meets
+// all of them.
+func (us *UserStore) Check(name string, reqs []Requirement) (Requirement, bool) {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	user, ok := us.users[name]
+
+	for _, req := range reqs {
+		if !ok {
+			return req, false
+		} else if user.superuser {
+			continue
+		}
+
+		granted := PrivilegeNone
+		for _, grant := range user.Grants {
+			if grant.appliesTo(req.Database, req.Table) {
+				granted |= grant.Privileges
+			}
+		}
+
+		if !granted.Has(req.Privilege) {
+			return req, false
+		}
+	}
+
+	return Requirement{}, true
+}
+
+// update applies |change| to a copy of the users, persists the copy, and only then replaces the users with it, so that
+// the users are left unchanged if the change fails or cannot be persisted.
+func (us *UserStore) update(change func(users map[string]*User) error) error {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	users := make(map[string]*User, len(us.users))
+	for name, user := range us.users {
+		users[name] = user
+	}
+
+	err := change(users)
+
+	if err != nil {
+		return err
+	}
+
+	err = us.persist(users)
+
+	if err != nil {
+		return err
+	}
+
+	us.users = users
+	return nil
+}
+
+// getMutableUser replaces the user with the given name in |users| with a copy which may be changed, and returns it
+func getMutableUser(users map[string]*User, name string) (*User, error) {
+	user, ok := users[name]
+
+	if !ok {
+		return nil, ErrUserNotFound
+	} else if user.superuser {
+		return nil, ErrSuperuser
+	}
+
+	mutable := *user
+	mutable.Grants = append([]Grant(nil), user.Grants...)
+	users[name] = &mutable
+
+	return &mutable, nil
+}
+
+// persist writes the given users which are not superusers to the privilege file
+func (us *UserStore) persist(users map[string]*User) error {
+	if us.path == "" {
+		return nil
+	}
+
+	toWrite := make([]*User, 0, len(users))
+	for _, user := range users {
+		if !user.superuser {
+			toWrite = append(toWrite, user)
+		}
+	}
+
+	sort.Slice(toWrite, func(i, j int) bool {
+		return toWrite[i].Name < toWrite[j].Name
+	})
+
+	data, err := json.MarshalIndent(toWrite, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return us.fs.WriteFile(us.path, data)
+}
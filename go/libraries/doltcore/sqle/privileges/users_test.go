@@ -0,0 +1,168 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"crypto/sha1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+// scramble scrambles a password with a salt in the same way as a mysql_native_password client
+func scramble(password string, salt []byte) []byte {
+	if password == "" {
+		return nil
+	}
+
+	stage1 := sha1.Sum([]byte(password))
+	stage2 := sha1.Sum(stage1[:])
+
+	crypt := sha1.New()
+	crypt.Write(salt)
+	crypt.Write(stage2[:])
+	scrambled := crypt.Sum(nil)
+
+	for i := range scrambled {
+		scrambled[i] ^= stage1[i]
+	}
+
+	return scrambled
+}
+
+func TestHashPassword(t *testing.T) {
+	assert.Equal(t, "", HashPassword(""))
+	assert.Equal(t, "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19", HashPassword("password"))
+}
+
+func TestCheckPassword(t *testing.T) {
+	users, err := NewUserStore(nil, "")
+	require.NoError(t, err)
+	users.AddSuperuser("root", "")
+	require.NoError(t, users.CreateUser("bob", "pass"))
+
+	salt := []byte("01234567890123456789")
+	assert.True(t, users.CheckPassword("root", scramble("", salt), salt))
+	assert.True(t, users.CheckPassword("bob", scramble("pass", salt), salt))
+	assert.False(t, users.CheckPassword("bob", scramble("wrong", salt), salt))
+	assert.False(t, users.CheckPassword("bob", scramble("", salt), salt))
+	assert.False(t, users.CheckPassword("alice", scramble("pass", salt), salt))
+
+	require.NoError(t, users.SetPassword("bob", "new"))
+	assert.True(t, users.CheckPassword("bob", scramble("new", salt), salt))
+	assert.Equal(t, ErrSuperuser, users.SetPassword("root", "new"))
+}
+
+func TestUserStoreCheck(t *testing.T) {
+	users, err := NewUserStore(nil, "")
+	require.NoError(t, err)
+	users.AddSuperuser("root", "")
+	require.NoError(t, users.CreateUser("bob", ""))
+	require.NoError(t, users.GrantPrivileges("bob", "db", "", PrivilegeSelect|PrivilegeInsert))
+	require.NoError(t, users.GrantPrivileges("bob", "db", "tbl", PrivilegeDelete))
+	require.NoError(t, users.GrantPrivileges("bob", "", "", PrivilegeCommit))
+
+	tests := []struct {
+		user     string
+		reqs     []Requirement
+		expected bool
+	}{
+		{"root", []Requirement{{"any", "tbl", PrivilegeAll}}, true},
+		{"alice", []Requirement{{"db", "tbl", PrivilegeSelect}}, false},
+		{"alice", nil, true},
+		{"bob", []Requirement{{"db", "tbl", PrivilegeSelect | PrivilegeInsert | PrivilegeDelete}}, true},
+		{"bob", []Requirement{{"DB", "TBL", PrivilegeDelete}}, true},
+		{"bob", []Requirement{{"db", "other", PrivilegeDelete}}, false},
+		{"bob", []Requirement{{"db", "other", PrivilegeSelect}, {"db2", "tbl", PrivilegeSelect}}, false},
+		{"bob", []Requirement{{"db2", "", PrivilegeCommit}}, true},
+		{"bob", []Requirement{{"db", "tbl", PrivilegeUpdate}}, false},
+	}
+
+	for _, test := range tests {
+		_, ok := users.Check(test.user, test.reqs)
+		assert.Equal(t, test.expected, ok, "%s %v", test.user, test.reqs)
+	}
+
+	require.NoError(t, users.RevokePrivileges("bob", "db", "tbl", PrivilegeSelect|PrivilegeDelete))
+	_, ok := users.Check("bob", []Requirement{{"db", "tbl", PrivilegeSelect}})
+	assert.True(t, ok)
+	_, ok = users.Check("bob", []Requirement{{"db", "tbl", PrivilegeDelete}})
+	assert.False(t, ok)
+
+	grants, err := users.Grants("bob")
+	require.NoError(t, err)
+	assert.Equal(t, []Grant{{"db", "", PrivilegeSelect | PrivilegeInsert}, {"", "", PrivilegeCommit}}, grants)
+}
+
+func TestUserStorePersistence(t *testing.T) {
+	const path = "/privileges.json"
+	fs := filesys.EmptyInMemFS("/")
+
+	users, err := NewUserStore(fs, path)
+	require.NoError(t, err)
+	users.AddSuperuser("root", "secret")
+	require.NoError(t, users.CreateUser("bob", "pass"))
+	require.NoError(t, users.GrantPrivileges("bob", "db", "tbl", PrivilegeSelect|PrivilegeDDL))
+	require.NoError(t, users.CreateUser("alice", ""))
+
+	loaded, err := NewUserStore(fs, path)
+	require.NoError(t, err)
+	assert.False(t, loaded.IsSuperuser("root"))
+	_, err = loaded.Grants("root")
+	assert.Equal(t, ErrUserNotFound, err)
+
+	grants, err := loaded.Grants("bob")
+	require.NoError(t, err)
+	assert.Equal(t, []Grant{{"db", "tbl", PrivilegeSelect | PrivilegeDDL}}, grants)
+
+	salt := []byte("01234567890123456789")
+	assert.True(t, loaded.CheckPassword("bob", scramble("pass", salt), salt))
+	assert.True(t, loaded.CheckPassword("alice", nil, salt))
+
+	require.NoError(t, loaded.DropUser("bob"))
+	loaded, err = NewUserStore(fs, path)
+	require.NoError(t, err)
+	_, err = loaded.Grants("bob")
+	assert.Equal(t, ErrUserNotFound, err)
+
+	require.NoError(t, fs.WriteFile(path, []byte("not json")))
+	_, err = NewUserStore(fs, path)
+	assert.Error(t, err)
+
+	// users are not changed if they cannot be persisted
+	const dirPath = "/dir"
+	users, err = NewUserStore(fs, dirPath)
+	require.NoError(t, err)
+	require.NoError(t, fs.MkDirs(dirPath))
+	assert.Error(t, users.CreateUser("carol", "pass"))
+	_, err = users.Grants("carol")
+	assert.Equal(t, ErrUserNotFound, err)
+
+	users, err = NewUserStore(fs, "/other.json")
+	require.NoError(t, err)
+	require.NoError(t, users.CreateUser("carol", "pass"))
+	require.NoError(t, users.GrantPrivileges("carol", "db", "", PrivilegeSelect))
+	users.path = dirPath
+	assert.Error(t, users.GrantPrivileges("carol", "db", "", PrivilegeInsert))
+	assert.Error(t, users.SetPassword("carol", "new"))
+	assert.Error(t, users.DropUser("carol"))
+	grants, err = users.Grants("carol")
+	require.NoError(t, err)
+	assert.Equal(t, []Grant{{"db", "", PrivilegeSelect}}, grants)
+	assert.True(t, users.CheckPassword("carol", scramble("pass", salt), salt))
+}
@@ -15,6 +15,7 @@
 package sqle
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -25,13 +26,16 @@ import (
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
-// An iterator over the rows of a table.
+// An iterator over the rows of a table. Rows of keyless tables are returned once for each copy of the row in the table.
 type doltTableRowIter struct {
 	sql.RowIter
 	table    *DoltTable
 	rowData  types.Map
 	ctx      *sql.Context
 	nomsIter types.MapIterator
+
+	dupRow   sql.Row
+	dupsLeft uint64
 }
 
 // Returns a new row iterator for the table given
@@ -53,6 +57,11 @@ func newRowIterator(tbl *DoltTable, ctx *sql.Context) (*doltTableRowIter, error)
 
 // Next returns the next row in this row iterator, or an io.EOF error if there aren't any more.
 func (itr *doltTableRowIter) Next() (sql.Row, error) {
+	if itr.dupsLeft > 0 {
+		itr.dupsLeft--
+		return itr.dupRow.Copy(), nil
+	}
+
 	key, val, err := itr.nomsIter.Next(itr.ctx)
 
 	if err != nil {
@@ -69,7 +78,19 @@ func (itr *doltTableRowIter) Next() (sql.Row, error) {
 		return nil, err
 	}
 
-	return doltRowToSqlRow(doltRow, itr.table.sch)
+	sqlRow, err := doltRowToSqlRow(doltRow, itr.table.sch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if card := row.Cardinality(doltRow); card > 1 {
+		itr.dupRow = sqlRow
+		itr.dupsLeft = card - 1
+		return sqlRow.Copy(), nil
+	}
+
+	return sqlRow, nil
 }
 
 // Close required by sql.RowIter interface
@@ -100,8 +121,9 @@ func doltRowToSqlRow(doltRow row.Row, sch schema.Schema) (sql.Row, error) {
 	return sql.NewRow(colVals...), nil
 }
 
-// Returns a Dolt row representation for SQL row given
-func SqlRowToDoltRow(nbf *types.NomsBinFormat, r sql.Row, doltSchema schema.Schema) (row.Row, error) {
+// Returns a Dolt row representation for SQL row given. Values which are stored out of row are written to the
+// ValueReadWriter given.
+func SqlRowToDoltRow(ctx context.Context, vrw types.ValueReadWriter, r sql.Row, doltSchema schema.Schema) (row.Row, error) {
 	taggedVals := make(row.TaggedValues)
 	allCols := doltSchema.GetAllCols()
 	for i, val := range r {
@@ -109,13 +131,14 @@ func SqlRowToDoltRow(nbf *types.NomsBinFormat, r sql.Row, doltSchema schema.Sche
 		schCol := allCols.TagToCol[tag]
 		if val != nil {
 			var err error
-			taggedVals[tag], err = schCol.TypeInfo.ConvertValueToNomsValue(val)
+			taggedVals[tag], err = schCol.TypeInfo.ConvertValueToNomsValue(ctx, vrw, val)
 			if err != nil {
 				return nil, err
 			}
-		} else if !schCol.IsNullable() {
+		} else if !schCol.IsNullable() && !schCol.AutoIncrement {
+			// the values of AUTO_INCREMENT columns are generated by the table editor
 			return nil, fmt.Errorf("column <%v> received nil but is non-nullable", schCol.Name)
 		}
 	}
-	return row.New(nbf, doltSchema, taggedVals)
+	return row.New(vrw.Format(), doltSchema, taggedVals)
 }
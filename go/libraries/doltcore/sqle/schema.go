@@ -76,7 +76,7 @@ func SqlSchemaToDoltResultSchema(sqlSchema sql.Schema) (schema.Schema, error) {
 // is used to generate unique tags for the Schema
 func ParseCreateTableStatement(ctx context.Context, root *doltdb.RootValue, query string) (string, schema.Schema, error) {
 	// todo: verify create table statement
-	ddl, err := sqlparser.ParseStrictDDL(query)
+	ddl, err := sqlparser.ParseStrictDDL(RewriteSpatialTypes(query))
 
 	if err != nil {
 		return "", nil, err
@@ -93,7 +93,7 @@ func ParseCreateTableStatement(ctx context.Context, root *doltdb.RootValue, quer
 	buf := sqlparser.NewTrackedBuffer(nil)
 	tn.Format(buf)
 	tableName := buf.String()
-	sch, err := SqlSchemaToDoltSchema(ctx, root, tableName, s)
+	sch, err := sqlSchemaToDoltSchema(ctx, root, tableName, s, autoIncrementCols(ts))
 
 	if err != nil {
 		return "", nil, err
@@ -105,6 +105,12 @@ func ParseCreateTableStatement(ctx context.Context, root *doltdb.RootValue, quer
 // SqlSchemaToDoltResultSchema returns a dolt Schema from the sql schema given, suitable for use in creating a table.
 // For result set schemas, see SqlSchemaToDoltResultSchema.
 func SqlSchemaToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableName string, sqlSchema sql.Schema) (schema.Schema, error) {
+	return sqlSchemaToDoltSchema(ctx, root, tableName, sqlSchema, nil)
+}
+
+// sqlSchemaToDoltSchema returns a dolt Schema from the sql schema given, where the columns with the lower case names in
+// autoIncCols are AUTO_INCREMENT columns. The sql schema doesn't describe which columns are AUTO_INCREMENT.
+func sqlSchemaToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableName string, sqlSchema sql.Schema, autoIncCols map[string]bool) (schema.Schema, error) {
 	var cols []schema.Column
 	var err error
 
@@ -126,7 +132,7 @@ func SqlSchemaToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableNam
 		var kinds []types.NomsKind
 		for _, col := range sqlSchema {
 			names = append(names, col.Name)
-			ti, err := typeinfo.FromSqlType(col.Type)
+			ti, err := sqlColTypeInfo(col)
 			if err != nil {
 				return nil, err
 			}
@@ -152,6 +158,7 @@ func SqlSchemaToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableNam
 		if err != nil {
 			return nil, err
 		}
+		convertedCol.AutoIncrement = autoIncCols[strings.ToLower(col.Name)]
 		cols = append(cols, convertedCol)
 	}
 
@@ -168,41 +175,98 @@ func SqlSchemaToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableNam
 	return schema.SchemaFromCols(colColl), nil
 }
 
-// doltColToSqlCol returns the SQL column corresponding to the dolt column given.
+// doltColToSqlCol returns the SQL column corresponding to the dolt column given. AUTO_INCREMENT columns are nullable in
+// the SQL schema so that rows may be inserted without their values, which are generated by the table editor.
 func doltColToSqlCol(tableName string, col schema.Column) (*sql.Column, error) {
+	var defaultVal interface{}
+	if col.HasDefault() {
+		// BLOB and TEXT columns cannot have defaults, so default values are never stored out of row
+		val, err := sqlfmt.SqlStringAsValue(context.Background(), nil, col.TypeInfo, col.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		defaultVal, err = col.TypeInfo.ConvertNomsValueToValue(val)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	sqlType := col.TypeInfo.ToSqlType()
 	return &sql.Column{
 		Name:       col.Name,
 		Type:       sqlType,
-		Default:    nil,
-		Nullable:   col.IsNullable(),
+		Default:    defaultVal,
+		Nullable:   col.IsNullable() || col.AutoIncrement,
 		Source:     tableName,
 		PrimaryKey: col.IsPartOfPK,
-		Comment:    fmt.Sprintf("tag:%d", col.Tag),
+		Comment:    sqlfmt.FmtColCommentWithTag(col.Comment, col.Tag),
 	}, nil
 }
 
+// sqlColTypeInfo returns the type info of the dolt column corresponding to the SQL column given.
+func sqlColTypeInfo(col *sql.Column) (typeinfo.TypeInfo, error) {
+	if ti, ok := spatialPlaceholderTypeInfo(col.Type); ok {
+		if col.PrimaryKey {
+			return nil, fmt.Errorf("spatial column %s cannot be used in a primary key", col.Name)
+		}
+		return ti, nil
+	}
+
+	ti, err := typeinfo.FromSqlType(col.Type)
+	if err != nil {
+		return nil, err
+	}
+	if col.PrimaryKey {
+		return typeinfo.ToKeyType(ti)
+	}
+	return ti, nil
+}
+
 // doltColToSqlCol returns the dolt column corresponding to the SQL column given
 func SqlColToDoltCol(tag uint64, col *sql.Column) (schema.Column, error) {
 	var constraints []schema.ColConstraint
 	if !col.Nullable {
 		constraints = append(constraints, schema.NotNullConstraint{})
 	}
-	typeInfo, err := typeinfo.FromSqlType(col.Type)
+	typeInfo, err := sqlColTypeInfo(col)
 	if err != nil {
 		return schema.Column{}, err
 	}
 
-	return schema.NewColumnWithTypeInfo(col.Name, tag, typeInfo, col.PrimaryKey, constraints...)
+	doltCol, err := schema.NewColumnWithTypeInfo(col.Name, tag, typeInfo, col.PrimaryKey, constraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+
+	if col.Default != nil {
+		if typeinfo.IsBlobType(typeInfo) {
+			return schema.Column{}, fmt.Errorf("BLOB and TEXT column %s can't have a default value", col.Name)
+		}
+
+		val, err := typeInfo.ConvertValueToNomsValue(context.Background(), nil, col.Default)
+		if err != nil {
+			return schema.Column{}, fmt.Errorf("invalid default value for column %s: %v", col.Name, err)
+		}
+
+		doltCol.Default, err = sqlfmt.DefaultValueAsSqlString(typeInfo, val)
+		if err != nil {
+			return schema.Column{}, err
+		}
+	}
+
+	doltCol.Comment = extractComment(col)
+	return doltCol, nil
 }
 
-// Extracts the optional comment tag from a column type defn, or InvalidTag if it can't be extracted
+// Extracts the optional comment tag from a column type defn, or InvalidTag if it can't be extracted. The tag is always
+// at the end of the comment.
 func extractTag(col *sql.Column) uint64 {
 	if len(col.Comment) == 0 {
 		return schema.InvalidTag
 	}
 
-	i := strings.Index(col.Comment, sqlfmt.TagCommentPrefix)
+	i := strings.LastIndex(col.Comment, sqlfmt.TagCommentPrefix)
 	if i >= 0 {
 		startIdx := i + len(sqlfmt.TagCommentPrefix)
 		tag, err := strconv.ParseUint(col.Comment[startIdx:], 10, 64)
@@ -214,3 +278,46 @@ func extractTag(col *sql.Column) uint64 {
 
 	return schema.InvalidTag
 }
+
+// extractComment returns the comment of a column type defn without its optional tag.
+func extractComment(col *sql.Column) string {
+	if extractTag(col) == schema.InvalidTag {
+		return col.Comment
+	}
+
+	i := strings.LastIndex(col.Comment, sqlfmt.TagCommentPrefix)
+	return strings.TrimSpace(col.Comment[:i])
+}
+
+// autoIncrementCols returns the lower case names of the AUTO_INCREMENT columns in a table spec. AUTO_INCREMENT isn't
+// part of sql.Column, so it must be read from the parsed statement.
+func autoIncrementCols(ts *sqlparser.TableSpec) map[string]bool {
+	autoIncCols := make(map[string]bool)
+	if ts == nil {
+		return autoIncCols
+	}
+
+	for _, cd := range ts.Columns {
+		if cd.Type.Autoincrement {
+			autoIncCols[cd.Name.Lowered()] = true
+		}
+	}
+
+	return autoIncCols
+}
+
+// autoIncrementColsFromQuery returns the lower case names of the AUTO_INCREMENT columns declared by a CREATE TABLE or
+// ALTER TABLE query of the table given.
+func autoIncrementColsFromQuery(query, tableName string) map[string]bool {
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil
+	}
+
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || !strings.EqualFold(ddl.Table.Name.String(), tableName) {
+		return nil
+	}
+
+	return autoIncrementCols(ddl.TableSpec)
+}
@@ -63,7 +63,7 @@ func GetOrCreateDoltSchemasTable(ctx *sql.Context, db Database) (*WritableDoltTa
 // Database. Returns `false` otherwise.
 func viewExistsInSchemasTable(ctx *sql.Context, tbl *WritableDoltTable, name string) (bool, error) {
 	row := sql.Row{"view", name}
-	doltLookup, err := SqlRowToDoltRow(tbl.table.Format(), row, tbl.sch)
+	doltLookup, err := SqlRowToDoltRow(ctx, tbl.table.ValueReadWriter(), row, tbl.sch)
 	if err != nil {
 		return false, err
 	}
@@ -15,8 +15,10 @@
 package sqle
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
 
@@ -71,6 +73,16 @@ func NewResultSetRow(colVals ...types.Value) row.Row {
 	return r
 }
 
+// NewBlob creates a blob with the given contents, as stored for TEXT and BLOB columns. Values this small fit in a single
+// chunk, so the blob can be written to any table.
+func NewBlob(str string) types.Blob {
+	b, err := types.NewBlob(context.Background(), types.NewMemoryValueStore(), strings.NewReader(str))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
 // NewRow creates a new row with the values given, using ascending tag numbers starting at 0.
 // Uses the first value as the primary key.
 func NewRow(colVals ...types.Value) row.Row {
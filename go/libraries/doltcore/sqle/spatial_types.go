@@ -0,0 +1,147 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// spatialTypePlaceholderPrefix begins the single value of the ENUM types which stand in for spatial column types in
+// DDL statements, as the SQL engine cannot create columns of these types itself.
+const spatialTypePlaceholderPrefix = "dolt_spatial_type:"
+
+var spatialTypeTokens = map[int]bool{
+	sqlparser.GEOMETRY:   true,
+	sqlparser.POINT:      true,
+	sqlparser.LINESTRING: true,
+	sqlparser.POLYGON:    true,
+}
+
+// RewriteQuery rewrites the parts of the given query which the SQL engine does not support to equivalents which it
+// does. See RewriteJSONOperators and RewriteSpatialTypes.
+func RewriteQuery(query string) string {
+	return RewriteSpatialTypes(RewriteJSONOperators(query))
+}
+
+// RewriteSpatialTypes rewrites the POINT, LINESTRING, POLYGON and GEOMETRY column types of the given CREATE TABLE or
+// ALTER TABLE statement to placeholder ENUM types, such as ENUM('dolt_spatial_type:point'). Columns of these types are
+// created with the matching spatial type info. Other queries are returned unchanged.
+func RewriteSpatialTypes(query string) string {
+	lowerQuery := strings.ToLower(query)
+	if !containsSpatialTypeName(lowerQuery) {
+		return query
+	}
+
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		// let the engine report the error
+		return query
+	}
+
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil {
+		return query
+	}
+
+	spatialCols := make(map[string]string)
+	for _, col := range ddl.TableSpec.Columns {
+		if _, err := geometry.ShapeTypeFromString(col.Type.Type); err == nil {
+			spatialCols[col.Name.Lowered()] = strings.ToLower(col.Type.Type)
+		}
+	}
+
+	if len(spatialCols) == 0 {
+		return query
+	}
+
+	// The statement can't be regenerated from its AST without losing parts of it, so the type names are replaced in
+	// the original text. A column's type immediately follows its name.
+	sb := strings.Builder{}
+	copied := 0
+	prevVal := ""
+	tokenizer := sqlparser.NewStringTokenizer(query)
+	for {
+		typ, val := tokenizer.Scan()
+		if typ == 0 || typ == sqlparser.LEX_ERROR {
+			break
+		}
+
+		if spatialTypeTokens[typ] {
+			if shape, ok := spatialCols[strings.ToLower(prevVal)]; ok && shape == strings.ToLower(string(val)) {
+				// the tokenizer's position is past the end of the token, so search backwards for its start
+				end := tokenizer.Position
+				if end > len(lowerQuery) {
+					end = len(lowerQuery)
+				}
+				start := strings.LastIndex(lowerQuery[:end], shape)
+				if start >= copied {
+					sb.WriteString(query[copied:start])
+					sb.WriteString(fmt.Sprintf("ENUM('%s%s')", spatialTypePlaceholderPrefix, shape))
+					copied = start + len(shape)
+				}
+			}
+		}
+
+		prevVal = string(val)
+	}
+
+	if copied == 0 {
+		return query
+	}
+
+	sb.WriteString(query[copied:])
+	return sb.String()
+}
+
+func containsSpatialTypeName(lowerQuery string) bool {
+	for _, name := range []string{"geometry", "point", "linestring", "polygon"} {
+		if strings.Contains(lowerQuery, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// spatialPlaceholderTypeInfo returns the spatial type info for the placeholder type written by RewriteSpatialTypes,
+// or false if the type given is not a placeholder.
+func spatialPlaceholderTypeInfo(sqlType sql.Type) (typeinfo.TypeInfo, bool) {
+	enumType, ok := sqlType.(sql.EnumType)
+	if !ok || enumType.NumberOfElements() != 1 {
+		return nil, false
+	}
+
+	val, ok := enumType.At(1)
+	if !ok || !strings.HasPrefix(val, spatialTypePlaceholderPrefix) {
+		return nil, false
+	}
+
+	shapeType, err := geometry.ShapeTypeFromString(strings.TrimPrefix(val, spatialTypePlaceholderPrefix))
+	if err != nil {
+		return nil, false
+	}
+
+	ti, err := typeinfo.FromSqlType(geometry.SqlType{ShapeType: shapeType})
+	if err != nil {
+		return nil, false
+	}
+	return ti, true
+}
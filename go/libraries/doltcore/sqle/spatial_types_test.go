@@ -0,0 +1,63 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSpatialTypes(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			"CREATE TABLE t (pk int primary key, g POINT not null, `line` linestring comment 'a point')",
+			"CREATE TABLE t (pk int primary key, g ENUM('dolt_spatial_type:point') not null, `line` ENUM('dolt_spatial_type:linestring') comment 'a point')",
+		},
+		{
+			"create table t (pk int primary key, `point` polygon, `geometry` geometry)",
+			"create table t (pk int primary key, `point` ENUM('dolt_spatial_type:polygon'), `geometry` ENUM('dolt_spatial_type:geometry'))",
+		},
+		{
+			"alter table t add column g geometry",
+			"alter table t add column g ENUM('dolt_spatial_type:geometry')",
+		},
+		{
+			"ALTER TABLE t CHANGE COLUMN a b Point AFTER pk",
+			"ALTER TABLE t CHANGE COLUMN a b ENUM('dolt_spatial_type:point') AFTER pk",
+		},
+		{
+			"select st_astext(point) from t",
+			"select st_astext(point) from t",
+		},
+		{
+			"create table t (pk int primary key, `point` int)",
+			"create table t (pk int primary key, `point` int)",
+		},
+		{
+			"create table t (pk int primary key, g point",
+			"create table t (pk int primary key, g point",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			assert.Equal(t, test.expected, RewriteSpatialTypes(test.query))
+		})
+	}
+}
@@ -64,9 +64,12 @@ func TestCreateTable(t *testing.T) {
 			expectedErr:   "syntax error",
 		},
 		{
-			name:        "Test no primary keys",
-			query:       "create table testTable (id int, age int)",
-			expectedErr: "no primary key columns",
+			name:          "Test no primary keys",
+			query:         "create table testTable (id int comment 'tag:100', age int comment 'tag:101')",
+			expectedTable: "testTable",
+			expectedSchema: dtestutils.CreateSchema(
+				schemaNewColumn(t, "id", 100, sql.Int32, false),
+				schemaNewColumn(t, "age", 101, sql.Int32, false)),
 		},
 		{
 			name:        "Test bad table name",
@@ -225,8 +228,8 @@ func TestCreateTable(t *testing.T) {
 								id int primary key comment 'tag:a', age int comment 'this is my personal area')`,
 			expectedTable: "testTable",
 			expectedSchema: dtestutils.CreateSchema(
-				schemaNewColumn(t, "id", 4817, sql.Int32, true, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "age", 7208, sql.Int32, false)),
+				schemaNewColumnWithAttrs(t, "id", 4817, sql.Int32, true, "", "tag:a", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "age", 7208, sql.Int32, false, "", "this is my personal area")),
 		},
 		// Real world examples for regression testing
 		{
@@ -237,8 +240,8 @@ func TestCreateTable(t *testing.T) {
 							PRIMARY KEY (ip));`,
 			expectedTable: "ip2nation",
 			expectedSchema: dtestutils.CreateSchema(
-				schemaNewColumn(t, "ip", 100, sql.Uint32, true, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "country", 101, sql.MustCreateStringWithDefaults(sqltypes.Char, 2), false, schema.NotNullConstraint{})),
+				schemaNewColumnWithAttrs(t, "ip", 100, sql.Uint32, true, "0", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "country", 101, sql.MustCreateStringWithDefaults(sqltypes.Char, 2), false, "''", "", schema.NotNullConstraint{})),
 		},
 		{
 			name:          "Test ip2nationCountries",
@@ -253,13 +256,13 @@ func TestCreateTable(t *testing.T) {
 							lon float NOT NULL default 0.0 COMMENT 'tag:106',
 							PRIMARY KEY (code));`,
 			expectedSchema: dtestutils.CreateSchema(
-				schemaNewColumn(t, "code", 100, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 4), true, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "iso_code_2", 101, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 2), false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "iso_code_3", 102, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3), false),
-				schemaNewColumn(t, "iso_country", 103, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "country", 104, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "lat", 105, sql.Float32, false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "lon", 106, sql.Float32, false, schema.NotNullConstraint{})),
+				schemaNewColumnWithAttrs(t, "code", 100, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 4), true, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "iso_code_2", 101, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 2), false, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "iso_code_3", 102, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3), false, "''", ""),
+				schemaNewColumnWithAttrs(t, "iso_country", 103, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "country", 104, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "lat", 105, sql.Float32, false, "0", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "lon", 106, sql.Float32, false, "0", "", schema.NotNullConstraint{})),
 		},
 	}
 
@@ -415,21 +418,21 @@ func TestAddColumn(t *testing.T) {
 			name:  "alter add column not null",
 			query: "alter table people add (newColumn varchar(80) not null default 'default' comment 'tag:100')",
 			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
-				schemaNewColumn(t, "newColumn", 100, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 80), false, schema.NotNullConstraint{})),
+				schemaNewColumnWithAttrs(t, "newColumn", 100, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 80), false, "'default'", "", schema.NotNullConstraint{})),
 			expectedRows: dtestutils.AddColToRows(t, AllPeopleRows, 100, types.String("default")),
 		},
 		{
 			name:  "alter add column not null with expression default",
 			query: "alter table people add (newColumn int not null default 2+2/2 comment 'tag:100')",
 			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
-				schemaNewColumn(t, "newColumn", 100, sql.Int32, false, schema.NotNullConstraint{})),
+				schemaNewColumnWithAttrs(t, "newColumn", 100, sql.Int32, false, "3", "", schema.NotNullConstraint{})),
 			expectedRows: dtestutils.AddColToRows(t, AllPeopleRows, 100, types.Int(3)),
 		},
 		{
 			name:  "alter add column not null with negative expression",
 			query: "alter table people add (newColumn float not null default -1.1 comment 'tag:100')",
 			expectedSchema: dtestutils.AddColumnToSchema(PeopleTestSchema,
-				schemaNewColumn(t, "newColumn", 100, sql.Float32, false, schema.NotNullConstraint{})),
+				schemaNewColumnWithAttrs(t, "newColumn", 100, sql.Float32, false, "-1.100000023841858", "", schema.NotNullConstraint{})),
 			expectedRows: dtestutils.AddColToRows(t, AllPeopleRows, 100, types.Float(float32(-1.1))),
 		},
 		{
@@ -1008,7 +1011,7 @@ func TestAlterSystemTables(t *testing.T) {
 		NewRow(types.String("abc123"), types.Uint(1), types.String("example"), types.String("select 2+2 from dual"), types.String("description")))
 	dtestutils.CreateTestTable(t, dEnv, doltdb.SchemasTableName,
 		schemasTableDoltSchema(),
-		NewRowWithPks([]types.Value{types.String("view"), types.String("name")}, types.String("select 2+2 from dual")))
+		NewRowWithPks([]types.Value{types.String("view"), types.String("name")}, NewBlob("select 2+2 from dual")))
 
 	// The _history and _diff tables give not found errors right now because of https://github.com/liquidata-inc/dolt/issues/373.
 	// We can remove the divergent failure logic when the issue is fixed.
@@ -1079,9 +1082,12 @@ func TestParseCreateTableStatement(t *testing.T) {
 			expectedErr:   "syntax error",
 		},
 		{
-			name:        "Test no primary keys",
-			query:       "create table testTable (id int, age int)",
-			expectedErr: "no primary key columns",
+			name:          "Test no primary keys",
+			query:         "create table testTable (id int comment 'tag:100', age int comment 'tag:101')",
+			expectedTable: "testTable",
+			expectedSchema: dtestutils.CreateSchema(
+				schemaNewColumn(t, "id", 100, sql.Int32, false),
+				schemaNewColumn(t, "age", 101, sql.Int32, false)),
 		},
 		{
 			name:        "Test bad table name begins with number",
@@ -1219,8 +1225,8 @@ func TestParseCreateTableStatement(t *testing.T) {
 								id int primary key comment 'tag:a', age int comment 'this is my personal area')`,
 			expectedTable: "testTable",
 			expectedSchema: dtestutils.CreateSchema(
-				schemaNewColumn(t, "id", 4817, sql.Int32, true, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "age", 7208, sql.Int32, false)),
+				schemaNewColumnWithAttrs(t, "id", 4817, sql.Int32, true, "", "tag:a", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "age", 7208, sql.Int32, false, "", "this is my personal area")),
 		},
 		// Real world examples for regression testing
 		{
@@ -1231,8 +1237,8 @@ func TestParseCreateTableStatement(t *testing.T) {
 							PRIMARY KEY (ip));`,
 			expectedTable: "ip2nation",
 			expectedSchema: dtestutils.CreateSchema(
-				schemaNewColumn(t, "ip", 100, sql.Uint32, true, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "country", 101, sql.MustCreateStringWithDefaults(sqltypes.Char, 2), false, schema.NotNullConstraint{})),
+				schemaNewColumnWithAttrs(t, "ip", 100, sql.Uint32, true, "0", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "country", 101, sql.MustCreateStringWithDefaults(sqltypes.Char, 2), false, "''", "", schema.NotNullConstraint{})),
 		},
 		{
 			name:          "Test ip2nationCountries",
@@ -1247,13 +1253,13 @@ func TestParseCreateTableStatement(t *testing.T) {
 							lon float NOT NULL default 0.0 COMMENT 'tag:106',
 							PRIMARY KEY (code));`,
 			expectedSchema: dtestutils.CreateSchema(
-				schemaNewColumn(t, "code", 100, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 4), true, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "iso_code_2", 101, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 2), false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "iso_code_3", 102, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3), false),
-				schemaNewColumn(t, "iso_country", 103, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "country", 104, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "lat", 105, sql.Float32, false, schema.NotNullConstraint{}),
-				schemaNewColumn(t, "lon", 106, sql.Float32, false, schema.NotNullConstraint{})),
+				schemaNewColumnWithAttrs(t, "code", 100, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 4), true, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "iso_code_2", 101, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 2), false, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "iso_code_3", 102, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3), false, "''", ""),
+				schemaNewColumnWithAttrs(t, "iso_country", 103, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "country", 104, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 255), false, "''", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "lat", 105, sql.Float32, false, "0", "", schema.NotNullConstraint{}),
+				schemaNewColumnWithAttrs(t, "lon", 106, sql.Float32, false, "0", "", schema.NotNullConstraint{})),
 		},
 	}
 
@@ -16,11 +16,14 @@ package sqlfmt
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
@@ -69,7 +72,7 @@ func RowAsInsertStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 			b.WriteRune(',')
 		}
 		col, _ := tableSch.GetAllCols().GetByTag(tag)
-		sqlString, err := valueAsSqlString(col.TypeInfo, val)
+		sqlString, err := ValueAsSqlString(col.TypeInfo, val)
 		if err != nil {
 			return true, err
 		}
@@ -87,27 +90,36 @@ func RowAsInsertStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 	return b.String(), nil
 }
 
+// RowAsDeleteStmt returns a statement deleting the row. Rows of a keyless table are matched on all of their columns,
+// and only a single copy of the row is deleted.
 func RowAsDeleteStmt(r row.Row, tableName string, tableSch schema.Schema) (string, error) {
 	var b strings.Builder
 	b.WriteString("DELETE FROM ")
 	b.WriteString(QuoteIdentifier(tableName))
 
+	keyless := schema.IsKeyless(tableSch)
 	b.WriteString(" WHERE (")
 	seenOne := false
 	_, err := r.IterSchema(tableSch, func(tag uint64, val types.Value) (stop bool, err error) {
 		col, _ := tableSch.GetAllCols().GetByTag(tag)
-		if col.IsPartOfPK {
+		if col.IsPartOfPK || keyless {
 			if seenOne {
 				b.WriteString(" AND ")
 			}
-			sqlString, err := valueAsSqlString(col.TypeInfo, val)
+			b.WriteString(QuoteIdentifier(col.Name))
+			seenOne = true
+
+			if types.IsNull(val) {
+				b.WriteString(" IS NULL")
+				return false, nil
+			}
+
+			sqlString, err := ValueAsSqlString(col.TypeInfo, val)
 			if err != nil {
 				return true, err
 			}
-			b.WriteString(QuoteIdentifier(col.Name))
 			b.WriteRune('=')
 			b.WriteString(sqlString)
-			seenOne = true
 		}
 		return false, nil
 	})
@@ -116,7 +128,11 @@ func RowAsDeleteStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 		return "", err
 	}
 
-	b.WriteString(");")
+	b.WriteString(")")
+	if keyless {
+		b.WriteString(" LIMIT 1")
+	}
+	b.WriteString(";")
 	return b.String(), nil
 }
 
@@ -134,7 +150,7 @@ func RowAsUpdateStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 			if seenOne {
 				b.WriteRune(',')
 			}
-			sqlString, err := valueAsSqlString(col.TypeInfo, val)
+			sqlString, err := ValueAsSqlString(col.TypeInfo, val)
 			if err != nil {
 				return true, err
 			}
@@ -158,7 +174,7 @@ func RowAsUpdateStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 			if seenOne {
 				b.WriteString(" AND ")
 			}
-			sqlString, err := valueAsSqlString(col.TypeInfo, val)
+			sqlString, err := ValueAsSqlString(col.TypeInfo, val)
 			if err != nil {
 				return true, err
 			}
@@ -178,7 +194,8 @@ func RowAsUpdateStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 	return b.String(), nil
 }
 
-func valueAsSqlString(ti typeinfo.TypeInfo, value types.Value) (string, error) {
+// ValueAsSqlString returns the value given as a SQL literal of the given type.
+func ValueAsSqlString(ti typeinfo.TypeInfo, value types.Value) (string, error) {
 	if types.IsNull(value) {
 		return "NULL", nil
 	}
@@ -210,11 +227,83 @@ func valueAsSqlString(ti typeinfo.TypeInfo, value types.Value) (string, error) {
 			return "", fmt.Errorf("typeinfo.VarStringTypeIdentifier is not types.String")
 		}
 		return quoteAndEscapeString(string(s)), nil
+	case typeinfo.BlobStringTypeIdentifier, typeinfo.VarBinaryTypeIdentifier, typeinfo.JSONTypeIdentifier:
+		return quoteAndEscapeString(*str), nil
+	case typeinfo.GeometryTypeIdentifier:
+		g, err := ti.ConvertNomsValueToValue(value)
+		if err != nil {
+			return "", err
+		}
+		geom := g.(geometry.Geometry)
+		return fmt.Sprintf("ST_GeomFromText(%s, %d)", quoteAndEscapeString(geometry.MarshalWKT(geom.Shape)), geom.SRID), nil
 	default:
 		return *str, nil
 	}
 }
 
+// DefaultValueAsSqlString returns the value given as a SQL literal suitable for the DEFAULT clause of a column of the
+// given type. Numeric values are unquoted, and all other values are written as quoted strings.
+func DefaultValueAsSqlString(ti typeinfo.TypeInfo, value types.Value) (string, error) {
+	if types.IsNull(value) {
+		return "NULL", nil
+	}
+
+	switch ti.GetTypeIdentifier() {
+	case typeinfo.BoolTypeIdentifier, typeinfo.IntTypeIdentifier, typeinfo.UintTypeIdentifier,
+		typeinfo.FloatTypeIdentifier, typeinfo.DecimalTypeIdentifier, typeinfo.BitTypeIdentifier:
+		return ValueAsSqlString(ti, value)
+	}
+
+	str, err := ti.FormatValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	return quoteAndEscapeString(*str), nil
+}
+
+// SqlStringAsValue parses a SQL literal, such as the default value of a column, as a value of the given type.
+func SqlStringAsValue(ctx context.Context, vrw types.ValueReadWriter, ti typeinfo.TypeInfo, literal string) (types.Value, error) {
+	stmt, err := sqlparser.Parse("SELECT " + literal)
+	if err != nil {
+		return nil, err
+	}
+
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return nil, fmt.Errorf("%s is not a literal value", literal)
+	}
+
+	aliased, ok := sel.SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a literal value", literal)
+	}
+
+	expr := aliased.Expr
+	negate := false
+	if unary, ok := expr.(*sqlparser.UnaryExpr); ok && unary.Operator == sqlparser.UMinusStr {
+		expr, negate = unary.Expr, true
+	}
+
+	switch v := expr.(type) {
+	case *sqlparser.NullVal:
+		return types.NullValue, nil
+	case sqlparser.BoolVal:
+		return ti.ConvertValueToNomsValue(ctx, vrw, bool(v))
+	case *sqlparser.SQLVal:
+		switch v.Type {
+		case sqlparser.StrVal, sqlparser.IntVal, sqlparser.FloatVal:
+			str := string(v.Val)
+			if negate {
+				str = "-" + str
+			}
+			return ti.ParseValue(ctx, vrw, &str)
+		}
+	}
+
+	return nil, fmt.Errorf("%s is not a literal value", literal)
+}
+
 // todo: this is a hack, varstring should handle this
 func quoteAndEscapeString(s string) string {
 	buf := &bytes.Buffer{}
@@ -15,6 +15,7 @@
 package sqlfmt
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -45,6 +46,7 @@ const expectedDropIfExistsSql = "DROP TABLE IF EXISTS `table_name`;"
 const expectedAddColSql = "ALTER TABLE `table_name` ADD `c0` BIGINT NOT NULL COMMENT 'tag:9';"
 const expectedDropColSql = "ALTER TABLE `table_name` DROP `first_name`;"
 const expectedRenameColSql = "ALTER TABLE `table_name` RENAME COLUMN `id` TO `pk`;"
+const expectedModifyColSql = "ALTER TABLE `table_name` MODIFY COLUMN `c0` BIGINT NOT NULL DEFAULT 5;"
 const expectedRenameTableSql = "RENAME TABLE `table_name` TO `new_table_name`;"
 
 type test struct {
@@ -92,6 +94,13 @@ func TestAlterTableRenameColStmt(t *testing.T) {
 	assert.Equal(t, expectedRenameColSql, stmt)
 }
 
+func TestAlterTableModifyColStmt(t *testing.T) {
+	newColDef := "`c0` BIGINT NOT NULL DEFAULT 5"
+	stmt := AlterTableModifyColStmt("table_name", newColDef)
+
+	assert.Equal(t, expectedModifyColSql, stmt)
+}
+
 func TestRenameTableStmt(t *testing.T) {
 	stmt := RenameTableStmt("table_name", "new_table_name")
 
@@ -259,13 +268,74 @@ func TestValueAsSqlString(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			act, err := valueAsSqlString(test.ti, test.val)
+			act, err := ValueAsSqlString(test.ti, test.val)
 			require.NoError(t, err)
 			assert.Equal(t, test.exp, act)
 		})
 	}
 }
 
+func TestDefaultValueRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		val  types.Value
+		ti   typeinfo.TypeInfo
+		exp  string
+	}{
+		{
+			name: "null",
+			val:  types.NullValue,
+			ti:   typeinfo.Int32Type,
+			exp:  "NULL",
+		},
+		{
+			name: "int",
+			val:  types.Int(-12),
+			ti:   typeinfo.Int32Type,
+			exp:  "-12",
+		},
+		{
+			name: "uint",
+			val:  types.Uint(7),
+			ti:   typeinfo.Uint64Type,
+			exp:  "7",
+		},
+		{
+			name: "float",
+			val:  types.Float(1.5),
+			ti:   typeinfo.Float64Type,
+			exp:  "1.5",
+		},
+		{
+			name: "bool",
+			val:  types.Bool(true),
+			ti:   typeinfo.BoolType,
+			exp:  "TRUE",
+		},
+		{
+			name: "escape string",
+			val:  types.String("it's \\ here"),
+			ti:   typeinfo.StringDefaultType,
+			exp:  "'it\\'s \\\\ here'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lit, err := DefaultValueAsSqlString(test.ti, test.val)
+			require.NoError(t, err)
+			assert.Equal(t, test.exp, lit)
+
+			val, err := SqlStringAsValue(context.Background(), nil, test.ti, lit)
+			require.NoError(t, err)
+			assert.Equal(t, test.val, val)
+		})
+	}
+
+	_, err := SqlStringAsValue(context.Background(), nil, typeinfo.Int32Type, "1 + 1")
+	assert.Error(t, err)
+}
+
 func strPointer(s string) *string {
 	return &s
 }
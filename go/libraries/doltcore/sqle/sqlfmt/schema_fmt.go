@@ -29,13 +29,20 @@ const TagCommentPrefix = "tag:"
 // typeWidth are 0 or less than the length of the name or type, then the length of the name or type will be used
 func FmtCol(indent, nameWidth, typeWidth int, col schema.Column) string {
 	sqlType := col.TypeInfo.ToSqlType()
-	return FmtColWithNameAndType(indent, nameWidth, typeWidth, col.Name, sqlType.String(), col)
+	fc := FmtColWithNameAndType(indent, nameWidth, typeWidth, col.Name, sqlType.String(), col)
+
+	if len(col.Comment) > 0 {
+		return fmt.Sprintf("%s COMMENT %s", fc, QuoteComment(col.Comment))
+	}
+
+	return fc
 }
 
 // FmtColWithTag follows the same logic as FmtCol, but includes the column's tag as a comment
 func FmtColWithTag(indent, nameWidth, typeWidth int, col schema.Column) string {
-	fc := FmtCol(indent, nameWidth, typeWidth, col)
-	return fmt.Sprintf("%s COMMENT '%s'", fc, FmtColTagComment(col.Tag))
+	sqlType := col.TypeInfo.ToSqlType()
+	fc := FmtColWithNameAndType(indent, nameWidth, typeWidth, col.Name, sqlType.String(), col)
+	return fmt.Sprintf("%s COMMENT %s", fc, QuoteComment(FmtColCommentWithTag(col.Comment, col.Tag)))
 }
 
 // FmtColWithNameAndType creates a string representing a column within a sql create table statement with a given indent
@@ -55,6 +62,14 @@ func FmtColWithNameAndType(indent, nameWidth, typeWidth int, colName, typeStr st
 		}
 	}
 
+	if col.AutoIncrement {
+		colStr += " AUTO_INCREMENT"
+	}
+
+	if col.HasDefault() {
+		colStr += " DEFAULT " + col.Default
+	}
+
 	return colStr
 }
 
@@ -69,6 +84,15 @@ func FmtColTagComment(tag uint64) string {
 	return fmt.Sprintf("%s%d", TagCommentPrefix, tag)
 }
 
+// FmtColCommentWithTag returns the comment of a column followed by its tag. The tag always comes last so that it can be
+// split from the column's comment when parsed.
+func FmtColCommentWithTag(comment string, tag uint64) string {
+	if len(comment) == 0 {
+		return FmtColTagComment(tag)
+	}
+	return fmt.Sprintf("%s %s", comment, FmtColTagComment(tag))
+}
+
 // CreateTableStmtWithTags generates a SQL CREATE TABLE command
 func CreateTableStmt(tableName string, sch schema.Schema, foreignKeys []*doltdb.DisplayForeignKey) string {
 	return createTableStmt(tableName, sch, func(col schema.Column) string {
@@ -116,7 +140,9 @@ func createTableStmt(tableName string, sch schema.Schema, fmtCol fmtColFunc, for
 		return false, nil
 	})
 
-	sb.WriteRune(')')
+	if !firstPK {
+		sb.WriteRune(')')
+	}
 
 	for _, index := range sch.Indexes().AllIndexes() {
 		if index.IsHidden() {
@@ -225,6 +251,16 @@ func AlterTableRenameColStmt(tableName string, oldColName string, newColName str
 	return b.String()
 }
 
+func AlterTableModifyColStmt(tableName string, newColDef string) string {
+	var b strings.Builder
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(QuoteIdentifier(tableName))
+	b.WriteString(" MODIFY COLUMN ")
+	b.WriteString(newColDef)
+	b.WriteRune(';')
+	return b.String()
+}
+
 func RenameTableStmt(fromName string, toName string) string {
 	var b strings.Builder
 	b.WriteString("RENAME TABLE ")
@@ -23,6 +23,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func newColWithAttrs(name string, tag uint64, kind types.NomsKind, partOfPK bool, def string, autoInc bool, comment string) schema.Column {
+	col := schema.NewColumn(name, tag, kind, partOfPK)
+	col.Default = def
+	col.AutoIncrement = autoInc
+	col.Comment = comment
+	return col
+}
+
 func TestFmtCol(t *testing.T) {
 	tests := []struct {
 		Col       schema.Column
@@ -59,6 +67,20 @@ func TestFmtCol(t *testing.T) {
 			15,
 			"   `aoeui` BIGINT UNSIGNED COMMENT 'tag:52'",
 		},
+		{
+			newColWithAttrs("id", 7, types.IntKind, true, "", true, ""),
+			0,
+			0,
+			0,
+			"`id` BIGINT AUTO_INCREMENT COMMENT 'tag:7'",
+		},
+		{
+			newColWithAttrs("name", 8, types.StringKind, false, "'it''s'", false, "user's name"),
+			0,
+			0,
+			0,
+			"`name` LONGTEXT DEFAULT 'it''s' COMMENT 'user\\'s name tag:8'",
+		},
 	}
 
 	for _, test := range tests {
@@ -68,3 +90,11 @@ func TestFmtCol(t *testing.T) {
 		})
 	}
 }
+
+func TestFmtColWithoutTag(t *testing.T) {
+	col := newColWithAttrs("age", 3, types.UintKind, false, "18", false, "years")
+	assert.Equal(t, "`age` BIGINT UNSIGNED DEFAULT 18 COMMENT 'years'", FmtCol(0, 0, 0, col))
+
+	col = schema.NewColumn("age", 3, types.UintKind, false)
+	assert.Equal(t, "`age` BIGINT UNSIGNED", FmtCol(0, 0, 0, col))
+}
@@ -751,6 +751,19 @@ var BasicSelectTests = []SelectTest{
 			&sql.Column{Name: "latest_commit_message", Type: sql.Text},
 		},
 	},
+	{
+		Name:         "select * from tags system table",
+		Query:        "select * from dolt_tags",
+		ExpectedRows: []sql.Row{},
+		ExpectedSqlSchema: sql.Schema{
+			&sql.Column{Name: "name", Type: sql.Text},
+			&sql.Column{Name: "hash", Type: sql.Text},
+			&sql.Column{Name: "tagger", Type: sql.Text},
+			&sql.Column{Name: "email", Type: sql.Text},
+			&sql.Column{Name: "date", Type: sql.Datetime},
+			&sql.Column{Name: "message", Type: sql.Text},
+		},
+	},
 }
 
 var sqlDiffSchema = sql.Schema{
@@ -1491,11 +1504,11 @@ var systemTableSelectTests = []SelectTest{
 			NewRowWithSchema(schemasTableDoltSchema(),
 				types.String("view"),
 				types.String("name"),
-				types.String("select 2+2 from dual"),
+				NewBlob("select 2+2 from dual"),
 			)),
 		Query: "select * from dolt_schemas",
 		ExpectedRows: ToSqlRows(CompressSchema(schemasTableDoltSchema()),
-			NewRow(types.String("view"), types.String("name"), types.String("select 2+2 from dual")),
+			NewRow(types.String("view"), types.String("name"), NewBlob("select 2+2 from dual")),
 		),
 		ExpectedSchema: CompressSchema(schemasTableDoltSchema()),
 	},
@@ -401,12 +401,12 @@ var systemTableUpdateTests = []UpdateTest{
 			NewRowWithSchema(schemasTableDoltSchema(),
 				types.String("view"),
 				types.String("name"),
-				types.String("select 2+2 from dual"),
+				NewBlob("select 2+2 from dual"),
 			)),
 		UpdateQuery: "update dolt_schemas set type = 'not a view'",
 		SelectQuery: "select * from dolt_schemas",
 		ExpectedRows: ToSqlRows(CompressSchema(schemasTableDoltSchema()),
-			NewRow(types.String("not a view"), types.String("name"), types.String("select 2+2 from dual")),
+			NewRow(types.String("not a view"), types.String("name"), NewBlob("select 2+2 from dual")),
 		),
 		ExpectedSchema: CompressSchema(schemasTableDoltSchema()),
 	},
@@ -27,7 +27,6 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/alterschema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
-	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -64,6 +63,10 @@ func (t *DoltTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 		return nil, err
 	}
 
+	if schema.IsKeyless(sch) {
+		return nil, nil
+	}
+
 	rowData, err := tbl.GetRowData(ctx)
 	if err != nil {
 		return nil, err
@@ -90,16 +93,17 @@ func (t *DoltTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 		if err != nil {
 			return nil, err
 		}
+		indexSch := index.Schema()
 		cols := make([]schema.Column, index.Count())
 		for i, tag := range index.IndexedColumnTags() {
-			cols[i], _ = index.GetColumn(tag)
+			cols[i], _ = indexSch.GetAllCols().GetByTag(tag)
 		}
 		sqlIndexes = append(sqlIndexes, &doltIndex{
 			cols:         cols,
 			db:           t.db,
 			id:           index.Name(),
 			indexRowData: indexRowData,
-			indexSch:     index.Schema(),
+			indexSch:     indexSch,
 			table:        tbl,
 			tableData:    rowData,
 			tableName:    t.Name(),
@@ -281,7 +285,7 @@ func (t *AlterableDoltTable) AddColumn(ctx *sql.Context, column *sql.Column, ord
 	tag := extractTag(column)
 	if tag == schema.InvalidTag {
 		// generate a tag if we don't have a user-defined tag
-		ti, err := typeinfo.FromSqlType(column.Type)
+		ti, err := sqlColTypeInfo(column)
 		if err != nil {
 			return err
 		}
@@ -302,20 +306,19 @@ func (t *AlterableDoltTable) AddColumn(ctx *sql.Context, column *sql.Column, ord
 		return errors.New("adding primary keys is not supported")
 	}
 
-	nullable := alterschema.NotNull
-	if col.IsNullable() {
-		nullable = alterschema.Null
+	if autoIncrementColsFromQuery(ctx.Query(), t.name)[strings.ToLower(col.Name)] {
+		return schema.ErrInvalidAutoIncrement
 	}
 
 	var defaultVal types.Value
 	if column.Default != nil {
-		defaultVal, err = col.TypeInfo.ConvertValueToNomsValue(column.Default)
+		defaultVal, err = col.TypeInfo.ConvertValueToNomsValue(ctx, t.table.ValueReadWriter(), column.Default)
 		if err != nil {
 			return err
 		}
 	}
 
-	updatedTable, err := alterschema.AddColumnToTable(ctx, root, table, t.name, col.Tag, col.Name, col.TypeInfo, nullable, defaultVal, orderToOrder(order))
+	updatedTable, err := alterschema.AddColumnDefToTable(ctx, root, table, t.name, col, defaultVal, orderToOrder(order))
 	if err != nil {
 		return err
 	}
@@ -422,10 +425,16 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 	if err != nil {
 		return err
 	}
+	col.AutoIncrement = autoIncrementColsFromQuery(ctx.Query(), t.name)[strings.ToLower(col.Name)]
+	// a column restated with the same SQL type keeps its storage, such as TEXT columns stored inline before blobs
+	if existingCol.TypeInfo.ToSqlType().String() == col.TypeInfo.ToSqlType().String() {
+		col.Kind = existingCol.Kind
+		col.TypeInfo = existingCol.TypeInfo
+	}
 
 	var defVal types.Value
 	if column.Default != nil {
-		defVal, err = col.TypeInfo.ConvertValueToNomsValue(column.Default)
+		defVal, err = col.TypeInfo.ConvertValueToNomsValue(ctx, t.table.ValueReadWriter(), column.Default)
 		if err != nil {
 			return err
 		}
@@ -448,6 +457,15 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 		return err
 	}
 
+	updatedSch, err := updatedTable.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = schema.ValidateForInsert(updatedSch.GetAllCols()); err != nil {
+		return err
+	}
+
 	newRoot, err := root.PutTable(ctx, t.name, updatedTable)
 	if err != nil {
 		return err
@@ -800,6 +818,10 @@ func (t *AlterableDoltTable) createIndex(
 		return nil, nil, nil, fmt.Errorf("not yet supported")
 	}
 
+	if schema.IsKeyless(tblSch) {
+		return nil, nil, nil, schema.ErrKeylessIndex
+	}
+
 	if !hidden && !doltdb.IsValidTableName(indexName) {
 		return nil, nil, nil, fmt.Errorf("invalid index name `%s` as they must match the regular expression %s", indexName, doltdb.TableNameRegexStr)
 	}
@@ -0,0 +1,131 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"io"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+var _ sql.Table = (*TagsTable)(nil)
+
+// TagsTable is a sql.Table implementation that implements a system table which shows the dolt tags
+type TagsTable struct {
+	ddb *doltdb.DoltDB
+}
+
+// NewTagsTable creates a TagsTable
+func NewTagsTable(sqlCtx *sql.Context, dbName string) (*TagsTable, error) {
+	ddb, ok := DSessFromSess(sqlCtx.Session).GetDoltDB(dbName)
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	return &TagsTable{ddb}, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// TagsTableName
+func (tt *TagsTable) Name() string {
+	return doltdb.TagsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// TagsTableName
+func (tt *TagsTable) String() string {
+	return doltdb.TagsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the tags system table
+func (tt *TagsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "hash", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "tagger", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "email", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "date", Type: sql.Datetime, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "message", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (tt *TagsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (tt *TagsTable) PartitionRows(sqlCtx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	return NewTagItr(sqlCtx, tt.ddb)
+}
+
+// TagItr is a sql.RowItr implementation which iterates over each tag as if it's a row in the table.
+type TagItr struct {
+	tags []*doltdb.Tag
+	idx  int
+}
+
+// NewTagItr creates a TagItr from the current environment.
+func NewTagItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB) (*TagItr, error) {
+	tagRefs, err := ddb.GetTags(sqlCtx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*doltdb.Tag, len(tagRefs))
+	for i, tr := range tagRefs {
+		tag, err := ddb.ResolveTag(sqlCtx, tr.(ref.TagRef))
+
+		if err != nil {
+			return nil, err
+		}
+
+		tags[i] = tag
+	}
+
+	return &TagItr{tags, 0}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *TagItr) Next() (sql.Row, error) {
+	if itr.idx >= len(itr.tags) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	tag := itr.tags[itr.idx]
+
+	h, err := tag.Commit.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.NewRow(tag.Name, h.String(), tag.Meta.Name, tag.Meta.Email, tag.Meta.Time(), tag.Meta.Description), nil
+}
+
+// Close closes the iterator.
+func (itr *TagItr) Close() error {
+	return nil
+}
@@ -49,6 +49,8 @@ func ExecuteSql(dEnv *env.DoltEnv, root *doltdb.RootValue, statements string) (*
 			return nil, err
 		}
 
+		ctx.ApplyOpts(sql.WithQuery(query))
+
 		var execErr error
 		switch sqlStatement.(type) {
 		case *sqlparser.Show:
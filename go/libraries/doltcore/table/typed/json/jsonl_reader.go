@@ -0,0 +1,372 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// JSONLReader reads JSON Lines files, in which each line holds a JSON object that is a single row. Blank lines are
+// skipped. The keys of nested objects are flattened into dotted column names, so {"a": {"b": 1}} has the column a.b,
+// unless the schema has a column named after the nested object itself, such as a JSON column.
+//
+// A JSONLReader created with a schema returns rows of that schema. Without one, the reader is untyped: the columns are
+// the keys found in the file, in the order in which they first appear, and every value is returned as a string.
+type JSONLReader struct {
+	vrw     types.ValueReadWriter
+	closer  io.Closer
+	bRd     *bufio.Reader
+	sch     schema.Schema
+	untyped bool
+	numLine int
+	isDone  bool
+}
+
+// OpenJSONLReader opens a reader for the JSON Lines file at the path given. If sch is nil the file is read once to
+// find its columns, and the reader returned is untyped.
+func OpenJSONLReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONLReader, error) {
+	untypedRd := sch == nil
+	if untypedRd {
+		var err error
+		sch, err = jsonlUntypedSchema(path, fs)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := fs.OpenForRead(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rd, err := NewJSONLReader(vrw, r, sch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rd.untyped = untypedRd
+	return rd, nil
+}
+
+// NewJSONLReader creates a JSONLReader that reads rows of the schema given from a ReadCloser.
+func NewJSONLReader(vrw types.ValueReadWriter, r io.ReadCloser, sch schema.Schema) (*JSONLReader, error) {
+	if sch == nil {
+		r.Close()
+		return nil, errors.New("schema must be provided to JSONLReader")
+	}
+
+	return &JSONLReader{vrw: vrw, closer: r, bRd: bufio.NewReaderSize(r, ReadBufSize), sch: sch}, nil
+}
+
+// jsonlUntypedSchema returns an untyped schema with a column for each of the flattened keys in the file at the path
+// given.
+func jsonlUntypedSchema(path string, fs filesys.ReadableFS) (schema.Schema, error) {
+	r, err := fs.OpenForRead(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	var colNames []string
+	seen := make(map[string]bool)
+	addCol := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			colNames = append(colNames, name)
+		}
+	}
+
+	bRd := bufio.NewReaderSize(r, ReadBufSize)
+	for numLine := 1; ; numLine++ {
+		line, err := readJSONLine(bRd)
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(line))
+		if err := flattenedKeys(dec, "", addCol); err != nil {
+			return nil, fmt.Errorf("line %d: %v", numLine, err)
+		}
+	}
+
+	if len(colNames) == 0 {
+		return nil, errors.New("no columns found in JSON Lines file " + path)
+	}
+
+	_, sch := untyped.NewUntypedSchema(colNames...)
+	return sch, nil
+}
+
+// flattenedKeys calls add with the flattened name of each of the keys of the JSON object read from dec, in order.
+func flattenedKeys(dec *json.Decoder, prefix string, add func(string)) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("expected a JSON object but got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name := prefix + tok.(string)
+
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+
+		if trimmed := bytes.TrimSpace(val); len(trimmed) > 0 && trimmed[0] == '{' {
+			if err := flattenedKeys(json.NewDecoder(bytes.NewReader(trimmed)), name+".", add); err != nil {
+				return err
+			}
+		} else {
+			add(name)
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// readJSONLine returns the next line, without surrounding whitespace.
+func readJSONLine(bRd *bufio.Reader) ([]byte, error) {
+	line, err := bRd.ReadBytes('\n')
+
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+
+	return bytes.TrimSpace(line), err
+}
+
+// ReadRow reads a row from the file. If the line cannot be converted to a row the error returned is a bad row error, so
+// callers may skip the line and continue.
+func (r *JSONLReader) ReadRow(ctx context.Context) (row.Row, error) {
+	if r.isDone {
+		return nil, io.EOF
+	}
+
+	var line []byte
+	for len(line) == 0 {
+		var err error
+		line, err = readJSONLine(r.bRd)
+		r.numLine++
+
+		if err == io.EOF {
+			r.isDone = true
+			return nil, io.EOF
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	doc, err := typeinfo.UnmarshalJSON(line)
+	if err != nil {
+		return nil, r.badLine(line, err)
+	}
+
+	obj, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, r.badLine(line, errors.New("expected a JSON object"))
+	}
+
+	vals := make(map[string]interface{})
+	r.flatten("", obj, vals)
+
+	var taggedVals row.TaggedValues
+	if r.untyped {
+		taggedVals, err = r.untypedVals(vals)
+	} else {
+		taggedVals, err = r.typedVals(ctx, vals)
+	}
+
+	if err != nil {
+		return nil, r.badLine(line, err)
+	}
+
+	return row.New(r.vrw.Format(), r.sch, taggedVals)
+}
+
+func (r *JSONLReader) badLine(line []byte, err error) error {
+	return table.NewBadRow(nil, fmt.Sprintf("line %d: %s", r.numLine, err.Error()), fmt.Sprintf("line: '%s'", line))
+}
+
+// flatten adds the values of obj to vals, named by their dotted paths. Nested objects are flattened unless the
+// reader's schema is typed and has a column with the object's name.
+func (r *JSONLReader) flatten(prefix string, obj map[string]interface{}, vals map[string]interface{}) {
+	for k, v := range obj {
+		name := prefix + k
+		if nested, ok := v.(map[string]interface{}); ok {
+			if _, isCol := r.sch.GetAllCols().GetByName(name); r.untyped || !isCol {
+				r.flatten(name+".", nested, vals)
+				continue
+			}
+		}
+		vals[name] = v
+	}
+}
+
+func (r *JSONLReader) untypedVals(vals map[string]interface{}) (row.TaggedValues, error) {
+	allCols := r.sch.GetAllCols()
+	taggedVals := make(row.TaggedValues, len(vals))
+
+	for name, v := range vals {
+		col, ok := allCols.GetByName(name)
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in schema", name)
+		}
+
+		var str string
+		switch val := v.(type) {
+		case nil:
+			continue
+		case string:
+			str = val
+		case json.Number:
+			str = val.String()
+		case bool:
+			str = strconv.FormatBool(val)
+		default:
+			doc, err := typeinfo.MarshalJSON(val)
+			if err != nil {
+				return nil, err
+			}
+			str = doc
+		}
+
+		taggedVals[col.Tag] = types.String(str)
+	}
+
+	return taggedVals, nil
+}
+
+func (r *JSONLReader) typedVals(ctx context.Context, vals map[string]interface{}) (row.TaggedValues, error) {
+	allCols := r.sch.GetAllCols()
+	taggedVals := make(row.TaggedValues, len(vals))
+
+	for name, v := range vals {
+		col, ok := allCols.GetByName(name)
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in schema", name)
+		}
+
+		if v == nil {
+			continue
+		}
+
+		var val types.Value
+		var err error
+		switch col.TypeInfo.GetTypeIdentifier() {
+		case typeinfo.JSONTypeIdentifier:
+			// JSON columns hold the document itself, rather than a string containing it
+			var doc string
+			if doc, err = typeinfo.MarshalJSON(v); err == nil {
+				val = types.String(doc)
+			}
+		case typeinfo.GeometryTypeIdentifier:
+			val, err = geometryFromJSON(ctx, r.vrw, col, v)
+		default:
+			val, err = jsonToNomsValue(ctx, r.vrw, col, v)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", name, err)
+		}
+
+		taggedVals[col.Tag] = val
+	}
+
+	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if val, ok := taggedVals.Get(tag); !col.IsNullable() && (!ok || types.IsNull(val)) {
+			return true, fmt.Errorf("column `%s` does not allow null values", col.Name)
+		}
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return taggedVals, nil
+}
+
+// jsonToNomsValue converts a decoded JSON value to a value of the column's type. Numbers are parsed from their text so
+// that no precision is lost, and arrays and objects are converted from their JSON text.
+func jsonToNomsValue(ctx context.Context, vrw types.ValueReadWriter, col schema.Column, v interface{}) (types.Value, error) {
+	switch val := v.(type) {
+	case json.Number:
+		str := val.String()
+		return col.TypeInfo.ParseValue(ctx, vrw, &str)
+	case string, bool:
+		return col.TypeInfo.ConvertValueToNomsValue(ctx, vrw, val)
+	default:
+		doc, err := typeinfo.MarshalJSON(val)
+		if err != nil {
+			return nil, err
+		}
+		return col.TypeInfo.ConvertValueToNomsValue(ctx, vrw, doc)
+	}
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (r *JSONLReader) GetSchema() schema.Schema {
+	return r.sch
+}
+
+// VerifySchema checks that the in schema matches the original schema
+func (r *JSONLReader) VerifySchema(outSch schema.Schema) (bool, error) {
+	return schema.VerifyInSchema(r.sch, outSch)
+}
+
+// Close should release resources being held
+func (r *JSONLReader) Close(ctx context.Context) error {
+	if r.closer != nil {
+		err := r.closer.Close()
+		r.closer = nil
+
+		return err
+	}
+	return errors.New("already closed")
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const testJSONL = `{"id": 0, "name": {"first": "tim", "last": "sehn"}, "tags": ["a", "b"]}
+
+{"id": 1, "name": {"first": "brian"}, "active": true, "score": 12345678901234567}
+`
+
+func readAllJSONL(t *testing.T, rd *JSONLReader) ([]row.Row, int) {
+	var rows []row.Row
+	badRows := 0
+	for {
+		r, err := rd.ReadRow(context.Background())
+		if err == io.EOF {
+			break
+		} else if table.IsBadRow(err) {
+			badRows++
+			continue
+		}
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+	return rows, badRows
+}
+
+func TestJSONLReaderUntyped(t *testing.T) {
+	fs := filesys.EmptyInMemFS("/")
+	require.NoError(t, fs.WriteFile("file.jsonl", []byte(testJSONL)))
+
+	rd, err := OpenJSONLReader(types.NewMemoryValueStore(), "file.jsonl", fs, nil)
+	require.NoError(t, err)
+	defer rd.Close(context.Background())
+
+	assert.Equal(t, []string{"id", "name.first", "name.last", "tags", "active", "score"}, rd.GetSchema().GetAllCols().GetColumnNames())
+
+	rows, badRows := readAllJSONL(t, rd)
+	require.Equal(t, 0, badRows)
+	require.Len(t, rows, 2)
+
+	expected := []row.TaggedValues{
+		{0: types.String("0"), 1: types.String("tim"), 2: types.String("sehn"), 3: types.String(`["a","b"]`)},
+		{0: types.String("1"), 1: types.String("brian"), 4: types.String("true"), 5: types.String("12345678901234567")},
+	}
+	for i := range rows {
+		r, err := row.New(types.Format_Default, rd.GetSchema(), expected[i])
+		require.NoError(t, err)
+		assert.True(t, row.AreEqual(r, rows[i], rd.GetSchema()), "row %d", i)
+	}
+}
+
+func TestJSONLReaderTyped(t *testing.T) {
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name.first", 1, types.StringKind, false),
+		schema.Column{Name: "doc", Tag: 2, Kind: types.StringKind, TypeInfo: typeinfo.JSONType},
+	)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	data := `{"id": 0, "name": {"first": "tim"}, "doc": {"b": 1, "a": [1.50, 2]}}
+{"id": "not a number"}
+{"name": {"first": "no id"}}
+{"id": 1, "unknown": 1}
+not json
+{"id": 2}
+`
+
+	rd, err := NewJSONLReader(types.NewMemoryValueStore(), ioutil.NopCloser(bytes.NewBufferString(data)), sch)
+	require.NoError(t, err)
+	defer rd.Close(context.Background())
+
+	rows, badRows := readAllJSONL(t, rd)
+	assert.Equal(t, 4, badRows)
+	require.Len(t, rows, 2)
+
+	expected, err := row.New(types.Format_Default, sch, row.TaggedValues{0: types.Int(0), 1: types.String("tim"), 2: types.String(`{"a":[1.5,2],"b":1}`)})
+	require.NoError(t, err)
+	assert.True(t, row.AreEqual(expected, rows[0], sch))
+
+	expected, err = row.New(types.Format_Default, sch, row.TaggedValues{0: types.Int(2)})
+	require.NoError(t, err)
+	assert.True(t, row.AreEqual(expected, rows[1], sch))
+}
+
+func TestJSONLRoundTrip(t *testing.T) {
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		schema.Column{Name: "doc", Tag: 2, Kind: types.StringKind, TypeInfo: typeinfo.JSONType},
+	)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	var rows []row.Row
+	for i, vals := range []row.TaggedValues{
+		{0: types.Int(1), 1: types.String("one"), 2: types.String(`{"a":1}`)},
+		{0: types.Int(2), 1: types.String("line\nbreak")},
+		{0: types.Int(3), 2: types.String(`[1,2,3]`)},
+	} {
+		r, err := row.New(types.Format_Default, sch, vals)
+		require.NoError(t, err, "row %d", i)
+		rows = append(rows, r)
+	}
+
+	fs := filesys.EmptyInMemFS("/")
+	wr, err := OpenJSONLWriter("/out/file.jsonl", fs, sch)
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NoError(t, wr.WriteRow(context.Background(), r))
+	}
+	require.NoError(t, wr.Close(context.Background()))
+
+	data, err := fs.ReadFile("/out/file.jsonl")
+	require.NoError(t, err)
+	assert.Equal(t, `{"doc":{"a":1},"id":1,"name":"one"}
+{"id":2,"name":"line\nbreak"}
+{"doc":[1,2,3],"id":3}
+`, string(data))
+
+	rd, err := OpenJSONLReader(types.NewMemoryValueStore(), "/out/file.jsonl", fs, sch)
+	require.NoError(t, err)
+	defer rd.Close(context.Background())
+
+	read, badRows := readAllJSONL(t, rd)
+	require.Equal(t, 0, badRows)
+	require.Len(t, read, len(rows))
+	for i := range rows {
+		assert.True(t, row.AreEqual(rows[i], read[i], sch), "row %d", i)
+	}
+}
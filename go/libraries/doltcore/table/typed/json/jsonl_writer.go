@@ -0,0 +1,94 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
+)
+
+// JSONLWriter writes rows as JSON Lines, one JSON object per line. Values are written as they are by JSONWriter.
+type JSONLWriter struct {
+	closer io.Closer
+	bWr    *bufio.Writer
+	sch    schema.Schema
+}
+
+// OpenJSONLWriter creates a JSONLWriter that writes to the file at the path given, creating its directory if needed.
+func OpenJSONLWriter(path string, fs filesys.WritableFS, outSch schema.Schema) (*JSONLWriter, error) {
+	err := fs.MkDirs(filepath.Dir(path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := fs.OpenForWrite(path, os.ModePerm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLWriter(wr, outSch)
+}
+
+// NewJSONLWriter creates a JSONLWriter that writes to a WriteCloser.
+func NewJSONLWriter(wr io.WriteCloser, outSch schema.Schema) (*JSONLWriter, error) {
+	return &JSONLWriter{closer: wr, bWr: bufio.NewWriterSize(wr, WriteBufSize), sch: outSch}, nil
+}
+
+// GetSchema gets the schema of the rows written
+func (w *JSONLWriter) GetSchema() schema.Schema {
+	return w.sch
+}
+
+// WriteRow writes a row as a line of JSON
+func (w *JSONLWriter) WriteRow(ctx context.Context, r row.Row) error {
+	colValMap, err := RowToJSONMap(w.sch, r)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalToJson(colValMap)
+	if err != nil {
+		return err
+	}
+
+	return iohelp.WriteAll(w.bWr, append(data, '\n'))
+}
+
+// Close should flush all writes, release resources being held
+func (w *JSONLWriter) Close(ctx context.Context) error {
+	if w.closer != nil {
+		errFl := w.bWr.Flush()
+		errCl := w.closer.Close()
+		w.closer = nil
+
+		if errCl != nil {
+			return errCl
+		}
+
+		return errFl
+	}
+	return errors.New("already closed")
+}
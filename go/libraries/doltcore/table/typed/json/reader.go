@@ -16,14 +16,17 @@ package json
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 
 	"github.com/bcicen/jstream"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -31,7 +34,7 @@ import (
 var ReadBufSize = 256 * 1024
 
 type JSONReader struct {
-	nbf        *types.NomsBinFormat
+	vrw        types.ValueReadWriter
 	closer     io.Closer
 	sch        schema.Schema
 	jsonStream *jstream.Decoder
@@ -39,17 +42,17 @@ type JSONReader struct {
 	sampleRow  row.Row
 }
 
-func OpenJSONReader(nbf *types.NomsBinFormat, path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONReader, error) {
+func OpenJSONReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONReader, error) {
 	r, err := fs.OpenForRead(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return newJsonReader(nbf, r, fs, sch, path)
+	return newJsonReader(vrw, r, fs, sch, path)
 }
 
-func newJsonReader(nbf *types.NomsBinFormat, r io.ReadCloser, fs filesys.ReadableFS, sch schema.Schema, tblPath string) (*JSONReader, error) {
+func newJsonReader(vrw types.ValueReadWriter, r io.ReadCloser, fs filesys.ReadableFS, sch schema.Schema, tblPath string) (*JSONReader, error) {
 	if sch == nil {
 		return nil, errors.New("schema must be provided to JsonReader")
 	}
@@ -61,7 +64,7 @@ func newJsonReader(nbf *types.NomsBinFormat, r io.ReadCloser, fs filesys.Readabl
 
 	decoder := jstream.NewDecoder(tblData, 2) // extract JSON values at a depth level of 1
 
-	return &JSONReader{nbf: nbf, closer: r, sch: sch, jsonStream: decoder}, nil
+	return &JSONReader{vrw: vrw, closer: r, sch: sch, jsonStream: decoder}, nil
 }
 
 // Close should release resources being held
@@ -117,10 +120,10 @@ func (r *JSONReader) ReadRow(ctx context.Context) (row.Row, error) {
 	if !ok {
 		return nil, fmt.Errorf("Unexpected json value: %v", row.Value)
 	}
-	return r.convToRow(m)
+	return r.convToRow(ctx, m)
 }
 
-func (r *JSONReader) convToRow(rowMap map[string]interface{}) (row.Row, error) {
+func (r *JSONReader) convToRow(ctx context.Context, rowMap map[string]interface{}) (row.Row, error) {
 	allCols := r.sch.GetAllCols()
 
 	taggedVals := make(row.TaggedValues, allCols.Size())
@@ -131,9 +134,28 @@ func (r *JSONReader) convToRow(rowMap map[string]interface{}) (row.Row, error) {
 			return nil, fmt.Errorf("column %s not found in schema", k)
 		}
 
+		if col.TypeInfo.GetTypeIdentifier() == typeinfo.JSONTypeIdentifier && v != nil {
+			// JSON columns hold the document itself, rather than a string containing it
+			doc, err := typeinfo.MarshalJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			taggedVals[col.Tag] = types.String(doc)
+			continue
+		}
+
+		if col.TypeInfo.GetTypeIdentifier() == typeinfo.GeometryTypeIdentifier && v != nil {
+			val, err := geometryFromJSON(ctx, r.vrw, col, v)
+			if err != nil {
+				return nil, err
+			}
+			taggedVals[col.Tag] = val
+			continue
+		}
+
 		switch v.(type) {
 		case int, string, bool, float64:
-			taggedVals[col.Tag], _ = col.TypeInfo.ConvertValueToNomsValue(v)
+			taggedVals[col.Tag], _ = col.TypeInfo.ConvertValueToNomsValue(ctx, r.vrw, v)
 		}
 
 	}
@@ -149,5 +171,26 @@ func (r *JSONReader) convToRow(rowMap map[string]interface{}) (row.Row, error) {
 		return nil, err
 	}
 
-	return row.New(r.nbf, r.sch, taggedVals)
+	return row.New(r.vrw.Format(), r.sch, taggedVals)
+}
+
+// geometryFromJSON returns the value of a spatial column, which is either a GeoJSON geometry object or a string in
+// the format written by geometry.Geometry.String. GeoJSON geometries have an SRID of geometry.GeoJSONSRID.
+func geometryFromJSON(ctx context.Context, vrw types.ValueReadWriter, col schema.Column, v interface{}) (types.Value, error) {
+	switch val := v.(type) {
+	case string:
+		return col.TypeInfo.ParseValue(ctx, vrw, &val)
+	case map[string]interface{}:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		shape, err := geometry.UnmarshalGeoJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", col.Name, err)
+		}
+		return col.TypeInfo.ConvertValueToNomsValue(ctx, vrw, geometry.Geometry{SRID: geometry.GeoJSONSRID, Shape: shape})
+	default:
+		return nil, fmt.Errorf("column %s: expected a GeoJSON geometry or text but got %v", col.Name, v)
+	}
 }
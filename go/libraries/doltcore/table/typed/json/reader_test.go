@@ -75,7 +75,7 @@ func TestReader(t *testing.T) {
 
 	sch := schema.SchemaFromCols(colColl)
 
-	reader, err := OpenJSONReader(types.Format_LD_1, "file.json", fs, sch)
+	reader, err := OpenJSONReader(types.NewMemoryValueStore(), "file.json", fs, sch)
 	require.NoError(t, err)
 
 	verifySchema, err := reader.VerifySchema(sch)
@@ -154,7 +154,7 @@ func TestReaderBadJson(t *testing.T) {
 
 	sch := schema.SchemaFromCols(colColl)
 
-	reader, err := OpenJSONReader(types.Format_LD_1, "file.json", fs, sch)
+	reader, err := OpenJSONReader(types.NewMemoryValueStore(), "file.json", fs, sch)
 	require.NoError(t, err)
 
 	err = nil
@@ -175,7 +175,7 @@ func newRow(sch schema.Schema, id int, first, last string) row.Row {
 		2: types.String(last),
 	}
 
-	r, err := row.New(types.Format_LD_1, sch, vals)
+	r, err := row.New(types.Format_Default, sch, vals)
 
 	if err != nil {
 		panic(err)
@@ -25,6 +25,7 @@ import (
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/geometry"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
@@ -75,44 +76,10 @@ func (jsonw *JSONWriter) GetSchema() schema.Schema {
 
 // WriteRow will write a row to a table
 func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
-	allCols := jsonw.sch.GetAllCols()
-	colValMap := make(map[string]interface{}, allCols.Size())
-	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		val, ok := r.GetColVal(tag)
-		if !ok || types.IsNull(val) {
-			return false, nil
-		}
-
-		switch col.TypeInfo.GetTypeIdentifier() {
-		case typeinfo.DatetimeTypeIdentifier,
-			typeinfo.DecimalTypeIdentifier,
-			typeinfo.EnumTypeIdentifier,
-			typeinfo.InlineBlobTypeIdentifier,
-			typeinfo.SetTypeIdentifier,
-			typeinfo.TimeTypeIdentifier,
-			typeinfo.TupleTypeIdentifier,
-			typeinfo.UuidTypeIdentifier,
-			typeinfo.VarBinaryTypeIdentifier,
-			typeinfo.YearTypeIdentifier:
-			v, err := col.TypeInfo.FormatValue(val)
-			if err != nil {
-				return true, err
-			}
-			val = types.String(*v)
-
-		case typeinfo.BitTypeIdentifier,
-			typeinfo.BoolTypeIdentifier,
-			typeinfo.VarStringTypeIdentifier,
-			typeinfo.UintTypeIdentifier,
-			typeinfo.IntTypeIdentifier,
-			typeinfo.FloatTypeIdentifier:
-			// use primitive type
-		}
-
-		colValMap[col.Name] = val
-
-		return false, nil
-	})
+	colValMap, err := RowToJSONMap(jsonw.sch, r)
+	if err != nil {
+		return err
+	}
 
 	data, err := marshalToJson(colValMap)
 	if err != nil {
@@ -159,6 +126,82 @@ func (jsonw *JSONWriter) Close(ctx context.Context) error {
 
 }
 
+// RowToJSONMap returns a map from column names to the values of the row's columns, as they are written to JSON. Null
+// values are omitted.
+func RowToJSONMap(sch schema.Schema, r row.Row) (map[string]interface{}, error) {
+	allCols := sch.GetAllCols()
+	colValMap := make(map[string]interface{}, allCols.Size())
+	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(val) {
+			return false, nil
+		}
+
+		switch col.TypeInfo.GetTypeIdentifier() {
+		case typeinfo.BlobStringTypeIdentifier,
+			typeinfo.DatetimeTypeIdentifier,
+			typeinfo.DecimalTypeIdentifier,
+			typeinfo.EnumTypeIdentifier,
+			typeinfo.InlineBlobTypeIdentifier,
+			typeinfo.SetTypeIdentifier,
+			typeinfo.TimeTypeIdentifier,
+			typeinfo.TupleTypeIdentifier,
+			typeinfo.UuidTypeIdentifier,
+			typeinfo.VarBinaryTypeIdentifier,
+			typeinfo.YearTypeIdentifier:
+			v, err := col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return true, err
+			}
+			val = types.String(*v)
+
+		case typeinfo.BitTypeIdentifier,
+			typeinfo.BoolTypeIdentifier,
+			typeinfo.VarStringTypeIdentifier,
+			typeinfo.UintTypeIdentifier,
+			typeinfo.IntTypeIdentifier,
+			typeinfo.FloatTypeIdentifier:
+			// use primitive type
+
+		case typeinfo.JSONTypeIdentifier:
+			// embed the document itself rather than a string containing it
+			v, err := col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return true, err
+			}
+			colValMap[col.Name] = json.RawMessage(*v)
+			return false, nil
+
+		case typeinfo.GeometryTypeIdentifier:
+			// GeoJSON coordinates are always longitudes and latitudes, so other geometries are written as text
+			g, err := col.TypeInfo.ConvertNomsValueToValue(val)
+			if err != nil {
+				return true, err
+			}
+			if g.(geometry.Geometry).SRID == geometry.GeoJSONSRID {
+				data, err := geometry.MarshalGeoJSON(g.(geometry.Geometry).Shape)
+				if err != nil {
+					return true, err
+				}
+				colValMap[col.Name] = json.RawMessage(data)
+			} else {
+				colValMap[col.Name] = g.(geometry.Geometry).String()
+			}
+			return false, nil
+		}
+
+		colValMap[col.Name] = val
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return colValMap, nil
+}
+
 func marshalToJson(valMap interface{}) ([]byte, error) {
 	var jsonBytes []byte
 	var err error
@@ -24,10 +24,14 @@ import (
 )
 
 // NomsMapReader is a TableReader that reads rows from a noms table which is stored in a types.Map where the key is
-// a types.Value and the value is a types.Tuple of field values.
+// a types.Value and the value is a types.Tuple of field values. Rows of keyless tables are read once for each copy of
+// the row in the table.
 type NomsMapReader struct {
 	sch schema.Schema
 	itr types.MapIterator
+
+	dupRow   row.Row
+	dupsLeft uint64
 }
 
 // NewNomsMapReader creates a NomsMapReader for a given noms types.Map
@@ -38,7 +42,7 @@ func NewNomsMapReader(ctx context.Context, m types.Map, sch schema.Schema) (*Nom
 		return nil, err
 	}
 
-	return &NomsMapReader{sch, itr}, nil
+	return &NomsMapReader{sch: sch, itr: itr}, nil
 }
 
 // GetSchema gets the schema of the rows that this reader will return
@@ -49,6 +53,11 @@ func (nmr *NomsMapReader) GetSchema() schema.Schema {
 // ReadRow reads a row from a table.  If there is a bad row the returned error will be non nil, and callin IsBadRow(err)
 // will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row, or fail.
 func (nmr *NomsMapReader) ReadRow(ctx context.Context) (row.Row, error) {
+	if nmr.dupsLeft > 0 {
+		nmr.dupsLeft--
+		return nmr.dupRow, nil
+	}
+
 	key, val, err := nmr.itr.Next(ctx)
 
 	if err != nil {
@@ -57,7 +66,19 @@ func (nmr *NomsMapReader) ReadRow(ctx context.Context) (row.Row, error) {
 		return nil, io.EOF
 	}
 
-	return row.FromNoms(nmr.sch, key.(types.Tuple), val.(types.Tuple))
+	r, err := row.FromNoms(nmr.sch, key.(types.Tuple), val.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if card := row.Cardinality(r); card > 1 {
+		nmr.dupRow = row.WithCardinality(r, 1)
+		nmr.dupsLeft = card - 1
+		return nmr.dupRow, nil
+	}
+
+	return r, nil
 }
 
 // Close should release resources being held
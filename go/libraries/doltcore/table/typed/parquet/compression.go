@@ -0,0 +1,104 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+)
+
+// ErrUnsupportedCompression is returned when a parquet file is compressed with a codec that can't be read.
+var ErrUnsupportedCompression = errors.New("unsupported compression")
+
+var codecNames = map[int32]string{
+	0: "UNCOMPRESSED",
+	1: "SNAPPY",
+	2: "GZIP",
+	3: "LZO",
+	4: "BROTLI",
+	5: "LZ4",
+	6: "ZSTD",
+	7: "LZ4_RAW",
+}
+
+// decompress decompresses a page compressed with the codec given into a buffer of uncompressedSize bytes.
+func decompress(codec int32, data []byte, uncompressedSize int) ([]byte, error) {
+	switch codec {
+	case codecUncompressed:
+		return data, nil
+
+	case codecSnappy:
+		n, err := snappy.DecodedLen(data)
+		if err != nil {
+			return nil, err
+		}
+		if n != uncompressedSize {
+			return nil, fmt.Errorf("invalid parquet page: expected %d bytes but snappy data holds %d", uncompressedSize, n)
+		}
+		return snappy.Decode(nil, data)
+
+	case codecGzip:
+		rd, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer rd.Close()
+
+		decompressed, err := ioutil.ReadAll(io.LimitReader(rd, int64(uncompressedSize)+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(decompressed) != uncompressedSize {
+			return nil, fmt.Errorf("invalid parquet page: expected %d bytes but gzip data holds %d", uncompressedSize, len(decompressed))
+		}
+		return decompressed, nil
+
+	default:
+		return nil, checkCodec(codec)
+	}
+}
+
+// checkCodec returns an error wrapping ErrUnsupportedCompression if pages compressed with the codec given can't be
+// decompressed.
+func checkCodec(codec int32) error {
+	switch codec {
+	case codecUncompressed, codecSnappy, codecGzip:
+		return nil
+	}
+
+	name, ok := codecNames[codec]
+	if !ok {
+		name = fmt.Sprint(codec)
+	}
+	return fmt.Errorf("%w: parquet compression codec %s is not supported, only UNCOMPRESSED, SNAPPY and GZIP can be read", ErrUnsupportedCompression, name)
+}
+
+// compress compresses a page with the codec given.
+func compress(codec int32, data []byte) []byte {
+	switch codec {
+	case codecSnappy:
+		return snappy.Encode(nil, data)
+	case codecUncompressed:
+		return data
+	default:
+		panic(fmt.Sprintf("unsupported parquet compression codec %d", codec))
+	}
+}
@@ -0,0 +1,543 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Values are decoded to bool, int32, int64, float32, float64 or []byte, according to their physical type. INT96 and
+// FIXED_LEN_BYTE_ARRAY values are decoded to []byte.
+
+var errPageTruncated = errors.New("invalid parquet page: unexpected end of data")
+
+// readBits reads the width bit value which begins bitOffset bits into data. Values are packed from the least
+// significant bit of each byte.
+func readBits(data []byte, bitOffset uint64, width uint) uint64 {
+	var v uint64
+	for read := uint(0); read < width; {
+		b := data[bitOffset/8]
+		shift := uint(bitOffset % 8)
+		n := 8 - shift
+		if n > width-read {
+			n = width - read
+		}
+		v |= uint64((b>>shift)&byte(1<<n-1)) << read
+		read += n
+		bitOffset += uint64(n)
+	}
+	return v
+}
+
+// packBits packs the values given using width bits for each.
+func packBits(vals []uint64, width uint) []byte {
+	data := make([]byte, (uint(len(vals))*width+7)/8)
+	bitOffset := uint(0)
+	for _, v := range vals {
+		for written := uint(0); written < width; {
+			shift := bitOffset % 8
+			n := 8 - shift
+			if n > width-written {
+				n = width - written
+			}
+			data[bitOffset/8] |= byte((v>>written)&(1<<n-1)) << shift
+			written += n
+			bitOffset += n
+		}
+	}
+	return data
+}
+
+func bitWidth(max uint64) uint {
+	width := uint(0)
+	for max != 0 {
+		width++
+		max >>= 1
+	}
+	return width
+}
+
+// decodeRLEHybrid decodes count values from the RLE / bit-packing hybrid encoding, which is used for definition levels,
+// dictionary indices and booleans.
+func decodeRLEHybrid(data []byte, width uint, count int) ([]uint64, error) {
+	if width > 32 {
+		return nil, fmt.Errorf("invalid parquet page: bit width %d is too large", width)
+	}
+
+	vals := make([]uint64, 0, count)
+	pos := 0
+	for len(vals) < count {
+		header, n := binary.Uvarint(data[pos:])
+		if n <= 0 {
+			return nil, errPageTruncated
+		}
+		pos += n
+
+		if header&1 == 0 {
+			runLen := int(header >> 1)
+			byteWidth := int(width+7) / 8
+			if pos+byteWidth > len(data) {
+				return nil, errPageTruncated
+			}
+
+			var v uint64
+			for i := 0; i < byteWidth; i++ {
+				v |= uint64(data[pos+i]) << (8 * uint(i))
+			}
+			pos += byteWidth
+
+			for i := 0; i < runLen && len(vals) < count; i++ {
+				vals = append(vals, v)
+			}
+		} else {
+			numVals := int(header>>1) * 8
+			numBytes := int(header>>1) * int(width)
+			if numBytes > len(data)-pos {
+				// the last run may be truncated if it holds fewer values than it was padded to
+				numBytes = len(data) - pos
+				numVals = numBytes * 8 / int(maxUint(width, 1))
+			}
+
+			packed := data[pos : pos+numBytes]
+			pos += numBytes
+
+			for i := 0; i < numVals && len(vals) < count; i++ {
+				vals = append(vals, readBits(packed, uint64(i)*uint64(width), width))
+			}
+		}
+
+		if header>>1 == 0 && len(vals) < count {
+			return nil, errors.New("invalid parquet page: empty run")
+		}
+	}
+
+	return vals, nil
+}
+
+func maxUint(a, b uint) uint {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// encodeRLEHybrid encodes values with the RLE / bit-packing hybrid encoding. Runs of 8 or more equal values are run
+// length encoded and the rest are bit packed.
+func encodeRLEHybrid(vals []uint64, width uint) []byte {
+	buf := &bytes.Buffer{}
+	var hdr [binary.MaxVarintLen64]byte
+
+	runLen := func(i int) int {
+		j := i + 1
+		for j < len(vals) && vals[j] == vals[i] {
+			j++
+		}
+		return j - i
+	}
+
+	for i := 0; i < len(vals); {
+		if n := runLen(i); n >= 8 {
+			buf.Write(hdr[:binary.PutUvarint(hdr[:], uint64(n)<<1)])
+			for b := 0; b < int(width+7)/8; b++ {
+				buf.WriteByte(byte(vals[i] >> (8 * uint(b))))
+			}
+			i += n
+			continue
+		}
+
+		start := i
+		for i < len(vals) && runLen(i) < 8 {
+			i += 8
+		}
+
+		group := make([]uint64, i-start)
+		if i > len(vals) {
+			i = len(vals)
+		}
+		copy(group, vals[start:i])
+
+		buf.Write(hdr[:binary.PutUvarint(hdr[:], uint64(len(group)/8)<<1|1)])
+		buf.Write(packBits(group, width))
+	}
+
+	return buf.Bytes()
+}
+
+// decodeLengthPrefixedRLE decodes RLE / bit-packing hybrid data which is preceded by its length, as definition levels
+// are in version 1 data pages. It returns the values and the number of bytes read.
+func decodeLengthPrefixedRLE(data []byte, width uint, count int) ([]uint64, int, error) {
+	if len(data) < 4 {
+		return nil, 0, errPageTruncated
+	}
+
+	n := binary.LittleEndian.Uint32(data)
+	if uint64(n) > uint64(len(data)-4) {
+		return nil, 0, errPageTruncated
+	}
+
+	vals, err := decodeRLEHybrid(data[4:4+n], width, count)
+	return vals, 4 + int(n), err
+}
+
+func encodeLengthPrefixedRLE(vals []uint64, width uint) []byte {
+	encoded := encodeRLEHybrid(vals, width)
+	data := make([]byte, 4, 4+len(encoded))
+	binary.LittleEndian.PutUint32(data, uint32(len(encoded)))
+	return append(data, encoded...)
+}
+
+// decodePlain decodes count values of the physical type given from the PLAIN encoding.
+func decodePlain(typ, typeLength int32, data []byte, count int) ([]interface{}, error) {
+	vals := make([]interface{}, count)
+	pos := 0
+
+	need := func(n int) error {
+		if n < 0 || pos+n > len(data) {
+			return errPageTruncated
+		}
+		return nil
+	}
+
+	for i := range vals {
+		switch typ {
+		case typeBoolean:
+			if i/8 >= len(data) {
+				return nil, errPageTruncated
+			}
+			vals[i] = data[i/8]&(1<<uint(i%8)) != 0
+			continue
+		case typeInt32:
+			if err := need(4); err != nil {
+				return nil, err
+			}
+			vals[i] = int32(binary.LittleEndian.Uint32(data[pos:]))
+			pos += 4
+		case typeInt64:
+			if err := need(8); err != nil {
+				return nil, err
+			}
+			vals[i] = int64(binary.LittleEndian.Uint64(data[pos:]))
+			pos += 8
+		case typeFloat:
+			if err := need(4); err != nil {
+				return nil, err
+			}
+			vals[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[pos:]))
+			pos += 4
+		case typeDouble:
+			if err := need(8); err != nil {
+				return nil, err
+			}
+			vals[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[pos:]))
+			pos += 8
+		case typeInt96:
+			if err := need(12); err != nil {
+				return nil, err
+			}
+			vals[i] = data[pos : pos+12]
+			pos += 12
+		case typeFixedLenByteArray:
+			if err := need(int(typeLength)); err != nil {
+				return nil, err
+			}
+			vals[i] = data[pos : pos+int(typeLength)]
+			pos += int(typeLength)
+		case typeByteArray:
+			if err := need(4); err != nil {
+				return nil, err
+			}
+			n := int(binary.LittleEndian.Uint32(data[pos:]))
+			pos += 4
+			if err := need(n); err != nil {
+				return nil, err
+			}
+			vals[i] = data[pos : pos+n]
+			pos += n
+		default:
+			return nil, fmt.Errorf("unsupported parquet physical type %d", typ)
+		}
+	}
+
+	return vals, nil
+}
+
+// appendPlain appends the PLAIN encoding of a value of the physical type given to buf. Booleans are bit packed, so
+// they are encoded with encodePlainBooleans instead.
+func appendPlain(buf *bytes.Buffer, typ int32, v interface{}) {
+	var b [8]byte
+	switch typ {
+	case typeInt32:
+		binary.LittleEndian.PutUint32(b[:], uint32(v.(int32)))
+		buf.Write(b[:4])
+	case typeInt64:
+		binary.LittleEndian.PutUint64(b[:], uint64(v.(int64)))
+		buf.Write(b[:8])
+	case typeFloat:
+		binary.LittleEndian.PutUint32(b[:], math.Float32bits(v.(float32)))
+		buf.Write(b[:4])
+	case typeDouble:
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v.(float64)))
+		buf.Write(b[:8])
+	case typeByteArray:
+		binary.LittleEndian.PutUint32(b[:], uint32(len(v.([]byte))))
+		buf.Write(b[:4])
+		buf.Write(v.([]byte))
+	case typeFixedLenByteArray:
+		buf.Write(v.([]byte))
+	default:
+		panic(fmt.Sprintf("cannot PLAIN encode parquet physical type %d", typ))
+	}
+}
+
+func encodePlainBooleans(vals []bool) []byte {
+	data := make([]byte, (len(vals)+7)/8)
+	for i, v := range vals {
+		if v {
+			data[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return data
+}
+
+// decodeDeltaBinaryPacked decodes count integers from the DELTA_BINARY_PACKED encoding, returning them and the number
+// of bytes read.
+func decodeDeltaBinaryPacked(data []byte, count int) ([]int64, int, error) {
+	pos := 0
+	readUvarint := func() (uint64, error) {
+		v, n := binary.Uvarint(data[pos:])
+		if n <= 0 {
+			return 0, errPageTruncated
+		}
+		pos += n
+		return v, nil
+	}
+	readVarint := func() (int64, error) {
+		v, n := binary.Varint(data[pos:])
+		if n <= 0 {
+			return 0, errPageTruncated
+		}
+		pos += n
+		return v, nil
+	}
+
+	blockSize, err := readUvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	numMiniBlocks, err := readUvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	totalCount, err := readUvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	first, err := readVarint()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if numMiniBlocks == 0 || blockSize%numMiniBlocks != 0 || (blockSize/numMiniBlocks)%8 != 0 {
+		return nil, 0, errors.New("invalid parquet page: bad DELTA_BINARY_PACKED block size")
+	}
+	if totalCount < uint64(count) || totalCount > math.MaxInt32 {
+		return nil, 0, errors.New("invalid parquet page: bad DELTA_BINARY_PACKED value count")
+	}
+
+	valsPerMiniBlock := blockSize / numMiniBlocks
+	vals := make([]int64, 0, count)
+	if totalCount > 0 {
+		vals = append(vals, first)
+	}
+
+	last := first
+	for uint64(len(vals)) < totalCount {
+		minDelta, err := readVarint()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if pos+int(numMiniBlocks) > len(data) {
+			return nil, 0, errPageTruncated
+		}
+		widths := data[pos : pos+int(numMiniBlocks)]
+		pos += int(numMiniBlocks)
+
+		for _, width := range widths {
+			if uint64(len(vals)) >= totalCount {
+				break
+			}
+			if width > 64 {
+				return nil, 0, errors.New("invalid parquet page: bad DELTA_BINARY_PACKED bit width")
+			}
+
+			numBytes := int(valsPerMiniBlock) * int(width) / 8
+			if pos+numBytes > len(data) {
+				return nil, 0, errPageTruncated
+			}
+			packed := data[pos : pos+numBytes]
+			pos += numBytes
+
+			for i := uint64(0); i < valsPerMiniBlock && uint64(len(vals)) < totalCount; i++ {
+				delta := int64(readBits(packed, i*uint64(width), uint(width)))
+				last = last + minDelta + delta
+				vals = append(vals, last)
+			}
+		}
+	}
+
+	return vals[:count], pos, nil
+}
+
+// decodeDeltaLengthByteArray decodes count byte arrays from the DELTA_LENGTH_BYTE_ARRAY encoding, returning them and
+// the number of bytes read.
+func decodeDeltaLengthByteArray(data []byte, count int) ([][]byte, int, error) {
+	lengths, pos, err := decodeDeltaBinaryPacked(data, count)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	vals := make([][]byte, count)
+	for i, n := range lengths {
+		if n < 0 || int64(pos)+n > int64(len(data)) {
+			return nil, 0, errPageTruncated
+		}
+		vals[i] = data[pos : pos+int(n)]
+		pos += int(n)
+	}
+
+	return vals, pos, nil
+}
+
+// decodeDeltaByteArray decodes count byte arrays from the DELTA_BYTE_ARRAY encoding, in which each value is stored as
+// the length of the prefix it shares with the previous value followed by the rest of it.
+func decodeDeltaByteArray(data []byte, count int) ([][]byte, error) {
+	prefixLens, pos, err := decodeDeltaBinaryPacked(data, count)
+	if err != nil {
+		return nil, err
+	}
+
+	suffixes, _, err := decodeDeltaLengthByteArray(data[pos:], count)
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make([][]byte, count)
+	var prev []byte
+	for i, suffix := range suffixes {
+		prefixLen := prefixLens[i]
+		if prefixLen < 0 || prefixLen > int64(len(prev)) {
+			return nil, errors.New("invalid parquet page: bad DELTA_BYTE_ARRAY prefix length")
+		}
+
+		val := make([]byte, 0, int(prefixLen)+len(suffix))
+		val = append(val, prev[:prefixLen]...)
+		val = append(val, suffix...)
+		vals[i] = val
+		prev = val
+	}
+
+	return vals, nil
+}
+
+// decodeValues decodes count values of a column from a data page's values, which use the encoding given. dict holds
+// the values of the column chunk's dictionary page, if it has one.
+func decodeValues(el *schemaElement, encoding int32, data []byte, count int, dict []interface{}) ([]interface{}, error) {
+	switch encoding {
+	case encodingPlain:
+		return decodePlain(el.typ, el.typeLength, data, count)
+
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if dict == nil {
+			return nil, errors.New("invalid parquet page: dictionary encoded page without a dictionary")
+		}
+		if count == 0 {
+			return nil, nil
+		}
+		if len(data) == 0 {
+			return nil, errPageTruncated
+		}
+
+		indices, err := decodeRLEHybrid(data[1:], uint(data[0]), count)
+		if err != nil {
+			return nil, err
+		}
+
+		vals := make([]interface{}, count)
+		for i, idx := range indices {
+			if idx >= uint64(len(dict)) {
+				return nil, fmt.Errorf("invalid parquet page: dictionary index %d out of range", idx)
+			}
+			vals[i] = dict[idx]
+		}
+		return vals, nil
+
+	case encodingRLE:
+		if el.typ != typeBoolean {
+			return nil, fmt.Errorf("RLE encoding is not supported for parquet physical type %d", el.typ)
+		}
+		bits, _, err := decodeLengthPrefixedRLE(data, 1, count)
+		if err != nil {
+			return nil, err
+		}
+		vals := make([]interface{}, count)
+		for i, b := range bits {
+			vals[i] = b == 1
+		}
+		return vals, nil
+
+	case encodingDeltaBinaryPacked:
+		ints, _, err := decodeDeltaBinaryPacked(data, count)
+		if err != nil {
+			return nil, err
+		}
+		vals := make([]interface{}, count)
+		for i, v := range ints {
+			switch el.typ {
+			case typeInt32:
+				vals[i] = int32(v)
+			case typeInt64:
+				vals[i] = v
+			default:
+				return nil, fmt.Errorf("DELTA_BINARY_PACKED encoding is not supported for parquet physical type %d", el.typ)
+			}
+		}
+		return vals, nil
+
+	case encodingDeltaLengthByteArray, encodingDeltaByteArray:
+		var arrays [][]byte
+		var err error
+		if encoding == encodingDeltaLengthByteArray {
+			arrays, _, err = decodeDeltaLengthByteArray(data, count)
+		} else {
+			arrays, err = decodeDeltaByteArray(data, count)
+		}
+		if err != nil {
+			return nil, err
+		}
+		vals := make([]interface{}, count)
+		for i, v := range arrays {
+			vals[i] = v
+		}
+		return vals, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported parquet encoding %d", encoding)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRLEHybridRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		vals  []uint64
+		width uint
+	}{
+		{"empty", nil, 1},
+		{"short", []uint64{1, 0, 1}, 1},
+		{"long run", []uint64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 1},
+		{"mixed", []uint64{0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1}, 1},
+		{"wide", []uint64{5, 300, 7, 7, 7, 7, 7, 7, 7, 7, 7, 1000}, 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded := encodeRLEHybrid(test.vals, test.width)
+			decoded, err := decodeRLEHybrid(encoded, test.width, len(test.vals))
+			require.NoError(t, err)
+			assert.Equal(t, len(test.vals), len(decoded))
+			for i := range test.vals {
+				assert.Equal(t, test.vals[i], decoded[i])
+			}
+		})
+	}
+
+	// a run of 12 ones should be run length encoded as a single header and value
+	assert.Equal(t, []byte{24, 1}, encodeRLEHybrid([]uint64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 1))
+
+	_, err := decodeRLEHybrid([]byte{24}, 1, 12)
+	assert.Error(t, err)
+}
+
+func TestDecodeDeltaBinaryPacked(t *testing.T) {
+	// examples from the parquet format specification
+	vals, n, err := decodeDeltaBinaryPacked([]byte{0x80, 0x01, 0x04, 0x05, 0x02, 0x02, 0x00, 0x00, 0x00, 0x00}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, vals)
+	assert.Equal(t, 10, n)
+
+	data := []byte{0x80, 0x01, 0x04, 0x08, 0x0e, 0x03, 0x02, 0x00, 0x00, 0x00, 0xc0, 0x3f, 0, 0, 0, 0, 0, 0}
+	vals, n, err = decodeDeltaBinaryPacked(data, 8)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7, 5, 3, 1, 2, 3, 4, 5}, vals)
+	assert.Equal(t, len(data), n)
+
+	_, _, err = decodeDeltaBinaryPacked(data[:len(data)-1], 8)
+	assert.Error(t, err)
+}
+
+func TestDecodeDeltaByteArray(t *testing.T) {
+	// "abc", "abd", "abe": prefix lengths 0, 2, 2 and suffixes "abc", "d", "e"
+	prefixLens := []byte{0x80, 0x01, 0x04, 0x03, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x02, 0, 0, 0, 0, 0, 0, 0}
+	suffixLens := []byte{0x80, 0x01, 0x04, 0x03, 0x06, 0x03, 0x02, 0x00, 0x00, 0x00, 0x08, 0, 0, 0, 0, 0, 0, 0}
+
+	data := append(append(append([]byte{}, prefixLens...), suffixLens...), []byte("abcde")...)
+	vals, err := decodeDeltaByteArray(data, 3)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("abc"), []byte("abd"), []byte("abe")}, vals)
+}
+
+func TestPlainRoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	appendPlain(buf, typeInt32, int32(-5))
+	appendPlain(buf, typeInt32, int32(1<<30))
+	vals, err := decodePlain(typeInt32, 0, buf.Bytes(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int32(-5), int32(1 << 30)}, vals)
+
+	buf.Reset()
+	appendPlain(buf, typeByteArray, []byte("hello"))
+	appendPlain(buf, typeByteArray, []byte{})
+	vals, err = decodePlain(typeByteArray, 0, buf.Bytes(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]byte("hello"), []byte{}}, vals)
+
+	_, err = decodePlain(typeByteArray, 0, buf.Bytes(), 3)
+	assert.Error(t, err)
+
+	bools := []bool{true, false, false, true, true, false, true, true, false, true}
+	vals, err = decodePlain(typeBoolean, 0, encodePlainBooleans(bools), len(bools))
+	require.NoError(t, err)
+	for i, b := range bools {
+		assert.Equal(t, b, vals[i])
+	}
+}
+
+func TestTwosComplement(t *testing.T) {
+	for _, i := range []int64{0, 1, -1, 127, 128, -128, -129, 255, -256, 1 << 40, -(1 << 40)} {
+		b := twosComplementFromBigInt(big.NewInt(i))
+		assert.Equal(t, i, bigIntFromTwosComplement(b).Int64(), "%d", i)
+	}
+
+	assert.Equal(t, []byte{0x00, 0x80}, twosComplementFromBigInt(big.NewInt(128)))
+	assert.Equal(t, []byte{0xff, 0x7f}, twosComplementFromBigInt(big.NewInt(-129)))
+}
+
+func TestThriftRoundTrip(t *testing.T) {
+	md := &fileMetaData{
+		version: 1,
+		schema: []schemaElement{
+			{name: "schema", numChildren: 2, hasNumChildren: true},
+			{name: "a", typ: typeInt64, hasType: true, repetition: repetitionRequired, hasRepetition: true},
+			{
+				name: "b", typ: typeInt32, hasType: true, repetition: repetitionOptional, hasRepetition: true,
+				convertedType: convertedInt8, hasConverted: true,
+				logicalType: &logicalType{kind: logicalInteger, bitWidth: 8, isSigned: true},
+			},
+		},
+		numRows: 100,
+		rowGroups: []rowGroup{{
+			numRows:       100,
+			totalByteSize: 1234,
+			columns: []columnChunk{{
+				fileOffset: 4,
+				metaData: &columnMetaData{
+					typ:                  typeInt64,
+					encodings:            []int32{encodingPlain, encodingRLE},
+					pathInSchema:         []string{"a"},
+					codec:                codecSnappy,
+					numValues:            100,
+					dataPageOffset:       4,
+					dictionaryPageOffset: 1 << 40,
+					hasDictionaryPage:    true,
+				},
+			}},
+		}},
+		keyValueMetadata: []keyValue{{"k", "v"}},
+		createdBy:        createdBy,
+	}
+
+	read, err := readFileMetaData(writeFileMetaData(md))
+	require.NoError(t, err)
+	assert.Equal(t, md, read)
+
+	_, err = readFileMetaData(writeFileMetaData(md)[:20])
+	assert.Error(t, err)
+}
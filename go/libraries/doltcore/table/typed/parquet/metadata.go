@@ -0,0 +1,497 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+// The types in this file mirror the structs of parquet.thrift, from the Apache Parquet format specification, which
+// are needed to read and write flat tables. Field ids are those of the specification.
+
+const magic = "PAR1"
+
+// Physical types
+const (
+	typeBoolean           int32 = 0
+	typeInt32             int32 = 1
+	typeInt64             int32 = 2
+	typeInt96             int32 = 3
+	typeFloat             int32 = 4
+	typeDouble            int32 = 5
+	typeByteArray         int32 = 6
+	typeFixedLenByteArray int32 = 7
+)
+
+// Field repetition types
+const (
+	repetitionRequired int32 = 0
+	repetitionOptional int32 = 1
+	repetitionRepeated int32 = 2
+)
+
+// Converted types, which annotate physical types in files written before logical types were added to the format.
+const (
+	convertedUTF8            int32 = 0
+	convertedEnum            int32 = 4
+	convertedDecimal         int32 = 5
+	convertedDate            int32 = 6
+	convertedTimeMillis      int32 = 7
+	convertedTimeMicros      int32 = 8
+	convertedTimestampMillis int32 = 9
+	convertedTimestampMicros int32 = 10
+	convertedUint8           int32 = 11
+	convertedUint16          int32 = 12
+	convertedUint32          int32 = 13
+	convertedUint64          int32 = 14
+	convertedInt8            int32 = 15
+	convertedInt16           int32 = 16
+	convertedInt32           int32 = 17
+	convertedInt64           int32 = 18
+	convertedJSON            int32 = 19
+	convertedBSON            int32 = 20
+)
+
+// Encodings
+const (
+	encodingPlain                int32 = 0
+	encodingPlainDictionary      int32 = 2
+	encodingRLE                  int32 = 3
+	encodingBitPacked            int32 = 4
+	encodingDeltaBinaryPacked    int32 = 5
+	encodingDeltaLengthByteArray int32 = 6
+	encodingDeltaByteArray       int32 = 7
+	encodingRLEDictionary        int32 = 8
+)
+
+// Compression codecs
+const (
+	codecUncompressed int32 = 0
+	codecSnappy       int32 = 1
+	codecGzip         int32 = 2
+)
+
+// Page types
+const (
+	pageData       int32 = 0
+	pageIndex      int32 = 1
+	pageDictionary int32 = 2
+	pageDataV2     int32 = 3
+)
+
+// Logical type union members
+const (
+	logicalString    int16 = 1
+	logicalEnum      int16 = 4
+	logicalDecimal   int16 = 5
+	logicalDate      int16 = 6
+	logicalTime      int16 = 7
+	logicalTimestamp int16 = 8
+	logicalInteger   int16 = 10
+	logicalJSON      int16 = 12
+	logicalBSON      int16 = 13
+	logicalUUID      int16 = 14
+)
+
+// Time units of TIME and TIMESTAMP logical types
+const (
+	unitMillis int16 = 1
+	unitMicros int16 = 2
+	unitNanos  int16 = 3
+)
+
+// logicalType is the LogicalType union. Only the member set in kind is meaningful.
+type logicalType struct {
+	kind int16
+
+	// DECIMAL
+	scale, precision int32
+	// TIME and TIMESTAMP
+	isAdjustedToUTC bool
+	unit            int16
+	// INTEGER
+	bitWidth int8
+	isSigned bool
+}
+
+type schemaElement struct {
+	typ            int32
+	hasType        bool
+	typeLength     int32
+	repetition     int32
+	name           string
+	numChildren    int32
+	convertedType  int32
+	hasConverted   bool
+	scale          int32
+	precision      int32
+	logicalType    *logicalType
+	hasRepetition  bool
+	hasNumChildren bool
+}
+
+type columnMetaData struct {
+	typ                   int32
+	encodings             []int32
+	pathInSchema          []string
+	codec                 int32
+	numValues             int64
+	totalUncompressedSize int64
+	totalCompressedSize   int64
+	dataPageOffset        int64
+	dictionaryPageOffset  int64
+	hasDictionaryPage     bool
+}
+
+type columnChunk struct {
+	filePath   string
+	fileOffset int64
+	metaData   *columnMetaData
+}
+
+type rowGroup struct {
+	columns       []columnChunk
+	totalByteSize int64
+	numRows       int64
+}
+
+type keyValue struct {
+	key, value string
+}
+
+type fileMetaData struct {
+	version          int32
+	schema           []schemaElement
+	numRows          int64
+	rowGroups        []rowGroup
+	keyValueMetadata []keyValue
+	createdBy        string
+}
+
+type dataPageHeader struct {
+	numValues               int32
+	encoding                int32
+	definitionLevelEncoding int32
+	repetitionLevelEncoding int32
+}
+
+type dictionaryPageHeader struct {
+	numValues int32
+	encoding  int32
+}
+
+type dataPageHeaderV2 struct {
+	numValues                  int32
+	numNulls                   int32
+	numRows                    int32
+	encoding                   int32
+	definitionLevelsByteLength int32
+	repetitionLevelsByteLength int32
+	isCompressed               bool
+}
+
+type pageHeader struct {
+	typ                  int32
+	uncompressedPageSize int32
+	compressedPageSize   int32
+	dataPageHeader       *dataPageHeader
+	dictionaryPageHeader *dictionaryPageHeader
+	dataPageHeaderV2     *dataPageHeaderV2
+}
+
+func readLogicalType(st thriftStruct) *logicalType {
+	for kind, v := range st {
+		member, ok := v.(thriftStruct)
+		if !ok {
+			continue
+		}
+
+		lt := &logicalType{kind: kind}
+		switch kind {
+		case logicalDecimal:
+			lt.scale = member.i32(1)
+			lt.precision = member.i32(2)
+		case logicalTime, logicalTimestamp:
+			lt.isAdjustedToUTC = member.bool(1)
+			for unit := range member.strct(2) {
+				lt.unit = unit
+			}
+		case logicalInteger:
+			lt.bitWidth = int8(member.i64(1))
+			lt.isSigned = member.bool(2)
+		}
+
+		return lt
+	}
+
+	return nil
+}
+
+func writeLogicalType(w *compactWriter, lt *logicalType) {
+	w.fieldStruct(lt.kind, func() {
+		switch lt.kind {
+		case logicalDecimal:
+			w.fieldI32(1, lt.scale)
+			w.fieldI32(2, lt.precision)
+		case logicalTime, logicalTimestamp:
+			w.fieldBool(1, lt.isAdjustedToUTC)
+			w.fieldStruct(2, func() {
+				w.fieldStruct(lt.unit, func() {})
+			})
+		case logicalInteger:
+			w.fieldByte(1, lt.bitWidth)
+			w.fieldBool(2, lt.isSigned)
+		}
+	})
+}
+
+func readSchemaElement(st thriftStruct) schemaElement {
+	el := schemaElement{
+		typ:            st.i32(1),
+		hasType:        st.has(1),
+		typeLength:     st.i32(2),
+		repetition:     st.i32(3),
+		hasRepetition:  st.has(3),
+		name:           st.str(4),
+		numChildren:    st.i32(5),
+		hasNumChildren: st.has(5),
+		convertedType:  st.i32(6),
+		hasConverted:   st.has(6),
+		scale:          st.i32(7),
+		precision:      st.i32(8),
+	}
+
+	if st.has(10) {
+		el.logicalType = readLogicalType(st.strct(10))
+	}
+
+	return el
+}
+
+func writeSchemaElement(w *compactWriter, el schemaElement) {
+	if el.hasType {
+		w.fieldI32(1, el.typ)
+	}
+	if el.typ == typeFixedLenByteArray {
+		w.fieldI32(2, el.typeLength)
+	}
+	if el.hasRepetition {
+		w.fieldI32(3, el.repetition)
+	}
+	w.fieldString(4, el.name)
+	if el.hasNumChildren {
+		w.fieldI32(5, el.numChildren)
+	}
+	if el.hasConverted {
+		w.fieldI32(6, el.convertedType)
+	}
+	if el.logicalType != nil && el.logicalType.kind == logicalDecimal {
+		w.fieldI32(7, el.scale)
+		w.fieldI32(8, el.precision)
+	}
+	if el.logicalType != nil {
+		w.fieldStruct(10, func() {
+			writeLogicalType(w, el.logicalType)
+		})
+	}
+}
+
+func readColumnMetaData(st thriftStruct) *columnMetaData {
+	md := &columnMetaData{
+		typ:                   st.i32(1),
+		codec:                 st.i32(4),
+		numValues:             st.i64(5),
+		totalUncompressedSize: st.i64(6),
+		totalCompressedSize:   st.i64(7),
+		dataPageOffset:        st.i64(9),
+		dictionaryPageOffset:  st.i64(11),
+		hasDictionaryPage:     st.has(11),
+	}
+
+	for _, v := range st.list(2) {
+		if enc, ok := v.(int64); ok {
+			md.encodings = append(md.encodings, int32(enc))
+		}
+	}
+
+	for _, v := range st.list(3) {
+		if name, ok := v.([]byte); ok {
+			md.pathInSchema = append(md.pathInSchema, string(name))
+		}
+	}
+
+	return md
+}
+
+func writeColumnMetaData(w *compactWriter, md *columnMetaData) {
+	w.fieldI32(1, md.typ)
+	w.fieldI32List(2, md.encodings)
+	w.fieldStringList(3, md.pathInSchema)
+	w.fieldI32(4, md.codec)
+	w.fieldI64(5, md.numValues)
+	w.fieldI64(6, md.totalUncompressedSize)
+	w.fieldI64(7, md.totalCompressedSize)
+	w.fieldI64(9, md.dataPageOffset)
+	if md.hasDictionaryPage {
+		w.fieldI64(11, md.dictionaryPageOffset)
+	}
+}
+
+func readFileMetaData(data []byte) (*fileMetaData, error) {
+	r := &compactReader{data: data}
+	st, err := r.readStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	md := &fileMetaData{
+		version:   st.i32(1),
+		numRows:   st.i64(3),
+		createdBy: st.str(6),
+	}
+
+	for _, el := range st.structList(2) {
+		md.schema = append(md.schema, readSchemaElement(el))
+	}
+
+	for _, rgSt := range st.structList(4) {
+		rg := rowGroup{totalByteSize: rgSt.i64(2), numRows: rgSt.i64(3)}
+		for _, ccSt := range rgSt.structList(1) {
+			cc := columnChunk{filePath: ccSt.str(1), fileOffset: ccSt.i64(2)}
+			if ccSt.has(3) {
+				cc.metaData = readColumnMetaData(ccSt.strct(3))
+			}
+			rg.columns = append(rg.columns, cc)
+		}
+		md.rowGroups = append(md.rowGroups, rg)
+	}
+
+	for _, kvSt := range st.structList(5) {
+		md.keyValueMetadata = append(md.keyValueMetadata, keyValue{kvSt.str(1), kvSt.str(2)})
+	}
+
+	return md, nil
+}
+
+func writeFileMetaData(md *fileMetaData) []byte {
+	w := &compactWriter{}
+	w.structBegin()
+	w.fieldI32(1, md.version)
+	w.fieldStructList(2, len(md.schema), func(i int) {
+		writeSchemaElement(w, md.schema[i])
+	})
+	w.fieldI64(3, md.numRows)
+	w.fieldStructList(4, len(md.rowGroups), func(i int) {
+		rg := md.rowGroups[i]
+		w.fieldStructList(1, len(rg.columns), func(j int) {
+			cc := rg.columns[j]
+			w.fieldI64(2, cc.fileOffset)
+			w.fieldStruct(3, func() {
+				writeColumnMetaData(w, cc.metaData)
+			})
+		})
+		w.fieldI64(2, rg.totalByteSize)
+		w.fieldI64(3, rg.numRows)
+	})
+	if len(md.keyValueMetadata) > 0 {
+		w.fieldStructList(5, len(md.keyValueMetadata), func(i int) {
+			w.fieldString(1, md.keyValueMetadata[i].key)
+			w.fieldString(2, md.keyValueMetadata[i].value)
+		})
+	}
+	if md.createdBy != "" {
+		w.fieldString(6, md.createdBy)
+	}
+	w.structEnd()
+
+	return w.buf.Bytes()
+}
+
+// readPageHeader reads the header of the page at the start of data, returning it and its length.
+func readPageHeader(data []byte) (*pageHeader, int, error) {
+	r := &compactReader{data: data}
+	st, err := r.readStruct()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ph := &pageHeader{
+		typ:                  st.i32(1),
+		uncompressedPageSize: st.i32(2),
+		compressedPageSize:   st.i32(3),
+	}
+
+	if dp := st.strct(5); dp != nil {
+		ph.dataPageHeader = &dataPageHeader{
+			numValues:               dp.i32(1),
+			encoding:                dp.i32(2),
+			definitionLevelEncoding: dp.i32(3),
+			repetitionLevelEncoding: dp.i32(4),
+		}
+	}
+
+	if dict := st.strct(7); dict != nil {
+		ph.dictionaryPageHeader = &dictionaryPageHeader{
+			numValues: dict.i32(1),
+			encoding:  dict.i32(2),
+		}
+	}
+
+	if dp := st.strct(8); dp != nil {
+		ph.dataPageHeaderV2 = &dataPageHeaderV2{
+			numValues:                  dp.i32(1),
+			numNulls:                   dp.i32(2),
+			numRows:                    dp.i32(3),
+			encoding:                   dp.i32(4),
+			definitionLevelsByteLength: dp.i32(5),
+			repetitionLevelsByteLength: dp.i32(6),
+			isCompressed:               !dp.has(7) || dp.bool(7),
+		}
+	}
+
+	return ph, r.pos, nil
+}
+
+func writePageHeader(ph *pageHeader) []byte {
+	w := &compactWriter{}
+	w.structBegin()
+	w.fieldI32(1, ph.typ)
+	w.fieldI32(2, ph.uncompressedPageSize)
+	w.fieldI32(3, ph.compressedPageSize)
+	if dp := ph.dataPageHeader; dp != nil {
+		w.fieldStruct(5, func() {
+			w.fieldI32(1, dp.numValues)
+			w.fieldI32(2, dp.encoding)
+			w.fieldI32(3, dp.definitionLevelEncoding)
+			w.fieldI32(4, dp.repetitionLevelEncoding)
+		})
+	}
+	if dict := ph.dictionaryPageHeader; dict != nil {
+		w.fieldStruct(7, func() {
+			w.fieldI32(1, dict.numValues)
+			w.fieldI32(2, dict.encoding)
+		})
+	}
+	if dp := ph.dataPageHeaderV2; dp != nil {
+		w.fieldStruct(8, func() {
+			w.fieldI32(1, dp.numValues)
+			w.fieldI32(2, dp.numNulls)
+			w.fieldI32(3, dp.numRows)
+			w.fieldI32(4, dp.encoding)
+			w.fieldI32(5, dp.definitionLevelsByteLength)
+			w.fieldI32(6, dp.repetitionLevelsByteLength)
+			w.fieldBool(7, dp.isCompressed)
+		})
+	}
+	w.structEnd()
+
+	return w.buf.Bytes()
+}
@@ -0,0 +1,481 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// maxFooterSize bounds the size of the file metadata read from the end of a file.
+const maxFooterSize = 256 * 1024 * 1024
+
+// readerAtCloser is implemented by files opened from the local filesystem.
+type readerAtCloser interface {
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+// readerColumn is a column of the file being read, and the Dolt column its values are read into.
+type readerColumn struct {
+	el       *schemaElement
+	col      schema.Column
+	toSql    toSqlValue
+	optional bool
+}
+
+// ParquetReader reads the rows of a parquet file. Row groups are read one at a time, so memory use is bounded by the
+// size of a row group rather than the size of the file. Only flat schemas, without nested or repeated columns, are
+// supported.
+type ParquetReader struct {
+	vrw    types.ValueReadWriter
+	closer io.Closer
+	file   io.ReaderAt
+	size   int64
+	sch    schema.Schema
+	meta   *fileMetaData
+	cols   []readerColumn
+
+	nextRowGroup int
+	rowGroupVals [][]interface{}
+	rowGroupRows int
+	rowIdx       int
+}
+
+// OpenParquetReader opens the parquet file at the path given. The schema of the rows read is derived from the schema
+// of the file, with the first column as the primary key.
+func OpenParquetReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS) (*ParquetReader, error) {
+	rd, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if f, ok := rd.(readerAtCloser); ok {
+		size, err := f.Seek(0, io.SeekEnd)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		return newParquetReader(vrw, f, size, f)
+	}
+
+	// files which can't be read at arbitrary offsets are read into memory
+	data, err := fs.ReadFile(path)
+	rd.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return newParquetReader(vrw, bytes.NewReader(data), int64(len(data)), nil)
+}
+
+func newParquetReader(vrw types.ValueReadWriter, file io.ReaderAt, size int64, closer io.Closer) (*ParquetReader, error) {
+	r := &ParquetReader{vrw: vrw, closer: closer, file: file, size: size}
+
+	err := r.readMetadata()
+	if err == nil {
+		err = r.readSchema()
+	}
+
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *ParquetReader) readMetadata() error {
+	notParquet := errors.New("not a parquet file")
+	if r.size < int64(2*len(magic)+4) {
+		return notParquet
+	}
+
+	tail := make([]byte, 4+len(magic))
+	if _, err := r.file.ReadAt(tail, r.size-int64(len(tail))); err != nil {
+		return err
+	}
+
+	head := make([]byte, len(magic))
+	if _, err := r.file.ReadAt(head, 0); err != nil {
+		return err
+	}
+
+	if string(head) != magic || string(tail[4:]) != magic {
+		return notParquet
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(tail))
+	if footerSize > maxFooterSize || footerSize > r.size-int64(2*len(magic)+4) {
+		return errors.New("invalid parquet file: bad metadata length")
+	}
+
+	footer := make([]byte, footerSize)
+	if _, err := r.file.ReadAt(footer, r.size-int64(len(tail))-footerSize); err != nil {
+		return err
+	}
+
+	meta, err := readFileMetaData(footer)
+	if err != nil {
+		return err
+	}
+
+	// codecs are checked up front so that a file which can't be read fails before any of its rows are imported
+	for _, rg := range meta.rowGroups {
+		for _, cc := range rg.columns {
+			if cc.metaData == nil {
+				continue
+			}
+			if err := checkCodec(cc.metaData.codec); err != nil {
+				return fmt.Errorf("parquet column %s: %w", strings.Join(cc.metaData.pathInSchema, "."), err)
+			}
+		}
+	}
+
+	r.meta = meta
+	return nil
+}
+
+func (r *ParquetReader) readSchema() error {
+	if len(r.meta.schema) < 2 {
+		return errors.New("parquet file has no columns")
+	}
+
+	root := r.meta.schema[0]
+	if int(root.numChildren) != len(r.meta.schema)-1 {
+		return errors.New("parquet files with nested columns are not supported")
+	}
+
+	var cols []schema.Column
+	for i := range r.meta.schema[1:] {
+		el := &r.meta.schema[i+1]
+		if el.numChildren > 0 || !el.hasType {
+			return fmt.Errorf("parquet column %s is nested, which is not supported", el.name)
+		}
+		if el.repetition == repetitionRepeated {
+			return fmt.Errorf("parquet column %s is repeated, which is not supported", el.name)
+		}
+
+		ti, toSql, err := typeInfoForElement(el)
+		if err != nil {
+			return fmt.Errorf("parquet column %s: %v", el.name, err)
+		}
+
+		// there must be a primary key, so the first column is used
+		isPk := i == 0
+		var constraints []schema.ColConstraint
+		if el.repetition == repetitionRequired {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+
+		col, err := schema.NewColumnWithTypeInfo(el.name, uint64(i), ti, isPk, constraints...)
+		if err != nil {
+			return err
+		}
+
+		cols = append(cols, col)
+		r.cols = append(r.cols, readerColumn{el: el, col: col, toSql: toSql, optional: el.repetition == repetitionOptional})
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+	if err != nil {
+		return err
+	}
+
+	r.sch = schema.SchemaFromCols(colColl)
+	return nil
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (r *ParquetReader) GetSchema() schema.Schema {
+	return r.sch
+}
+
+// VerifySchema checks that the incoming schema matches the schema from the existing table
+func (r *ParquetReader) VerifySchema(outSch schema.Schema) (bool, error) {
+	return schema.VerifyInSchema(r.sch, outSch)
+}
+
+// ReadRow reads a row from a table.  If there is a bad row the returned error will be non nil, and calling IsBadRow(err)
+// will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row, or fail.
+func (r *ParquetReader) ReadRow(ctx context.Context) (row.Row, error) {
+	for r.rowIdx >= r.rowGroupRows {
+		if r.nextRowGroup >= len(r.meta.rowGroups) {
+			r.rowGroupVals = nil
+			return nil, io.EOF
+		}
+
+		err := r.readRowGroup(r.nextRowGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		r.nextRowGroup++
+	}
+
+	idx := r.rowIdx
+	r.rowIdx++
+
+	taggedVals := make(row.TaggedValues, len(r.cols))
+	for i, rc := range r.cols {
+		v := r.rowGroupVals[i][idx]
+		if v == nil {
+			continue
+		}
+
+		nomsVal, err := rc.col.TypeInfo.ConvertValueToNomsValue(ctx, r.vrw, v)
+		if err != nil {
+			return nil, table.NewBadRow(nil, fmt.Sprintf("column %s: %v", rc.col.Name, err))
+		}
+
+		taggedVals[rc.col.Tag] = nomsVal
+	}
+
+	return row.New(r.vrw.Format(), r.sch, taggedVals)
+}
+
+// readRowGroup reads the values of every column of a row group.
+func (r *ParquetReader) readRowGroup(idx int) error {
+	rg := r.meta.rowGroups[idx]
+	if rg.numRows < 0 || rg.numRows > r.meta.numRows {
+		return fmt.Errorf("invalid parquet file: row group %d has an invalid row count", idx)
+	}
+	if len(rg.columns) != len(r.cols) {
+		return fmt.Errorf("invalid parquet file: row group %d has %d columns but the schema has %d", idx, len(rg.columns), len(r.cols))
+	}
+
+	vals := make([][]interface{}, len(r.cols))
+	for i := range r.cols {
+		colVals, err := r.readColumnChunk(&r.cols[i], rg.columns[i], rg.numRows)
+		if err != nil {
+			return fmt.Errorf("error reading parquet column %s: %v", r.cols[i].el.name, err)
+		}
+		vals[i] = colVals
+	}
+
+	r.rowGroupVals = vals
+	r.rowGroupRows = int(rg.numRows)
+	r.rowIdx = 0
+	return nil
+}
+
+// readColumnChunk reads the pages of a column within a row group, returning a value for each row. Values are those
+// returned by the column's toSqlValue function, or nil for nulls.
+func (r *ParquetReader) readColumnChunk(rc *readerColumn, cc columnChunk, numRows int64) ([]interface{}, error) {
+	md := cc.metaData
+	if md == nil {
+		return nil, errors.New("missing column metadata")
+	}
+	if cc.filePath != "" {
+		return nil, errors.New("column data in external files is not supported")
+	}
+	if md.typ != rc.el.typ {
+		return nil, errors.New("column chunk type does not match the schema")
+	}
+
+	start := md.dataPageOffset
+	if md.hasDictionaryPage && md.dictionaryPageOffset > 0 && md.dictionaryPageOffset < start {
+		start = md.dictionaryPageOffset
+	}
+
+	if start < int64(len(magic)) || md.totalCompressedSize < 0 || start+md.totalCompressedSize > r.size {
+		return nil, errors.New("invalid column chunk offsets")
+	}
+
+	data := make([]byte, md.totalCompressedSize)
+	if _, err := r.file.ReadAt(data, start); err != nil {
+		return nil, err
+	}
+
+	vals := make([]interface{}, 0, numRows)
+	var dict []interface{}
+	for pos := 0; int64(len(vals)) < numRows; {
+		if pos >= len(data) {
+			return nil, fmt.Errorf("expected %d values but found %d", numRows, len(vals))
+		}
+
+		ph, n, err := readPageHeader(data[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+
+		if ph.compressedPageSize < 0 || int(ph.compressedPageSize) > len(data)-pos || ph.uncompressedPageSize < 0 {
+			return nil, errPageTruncated
+		}
+		body := data[pos : pos+int(ph.compressedPageSize)]
+		pos += int(ph.compressedPageSize)
+
+		switch ph.typ {
+		case pageDictionary:
+			if ph.dictionaryPageHeader == nil {
+				return nil, errors.New("dictionary page is missing its header")
+			}
+			page, err := decompress(md.codec, body, int(ph.uncompressedPageSize))
+			if err != nil {
+				return nil, err
+			}
+			dict, err = decodePlain(rc.el.typ, rc.el.typeLength, page, int(ph.dictionaryPageHeader.numValues))
+			if err != nil {
+				return nil, err
+			}
+
+		case pageData:
+			dp := ph.dataPageHeader
+			if dp == nil {
+				return nil, errors.New("data page is missing its header")
+			}
+			page, err := decompress(md.codec, body, int(ph.uncompressedPageSize))
+			if err != nil {
+				return nil, err
+			}
+
+			var defLevels []uint64
+			if rc.optional {
+				if dp.definitionLevelEncoding != encodingRLE {
+					return nil, fmt.Errorf("definition level encoding %d is not supported", dp.definitionLevelEncoding)
+				}
+				var n int
+				defLevels, n, err = decodeLengthPrefixedRLE(page, 1, int(dp.numValues))
+				if err != nil {
+					return nil, err
+				}
+				page = page[n:]
+			}
+
+			vals, err = r.appendPageValues(vals, rc, dp.encoding, page, int(dp.numValues), defLevels, dict)
+			if err != nil {
+				return nil, err
+			}
+
+		case pageDataV2:
+			dp := ph.dataPageHeaderV2
+			if dp == nil {
+				return nil, errors.New("data page is missing its header")
+			}
+
+			levelsLen := int(dp.repetitionLevelsByteLength) + int(dp.definitionLevelsByteLength)
+			if dp.repetitionLevelsByteLength < 0 || dp.definitionLevelsByteLength < 0 || levelsLen > len(body) {
+				return nil, errPageTruncated
+			}
+
+			var defLevels []uint64
+			if rc.optional {
+				defLevels, err = decodeRLEHybrid(body[dp.repetitionLevelsByteLength:levelsLen], 1, int(dp.numValues))
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			page := body[levelsLen:]
+			if dp.isCompressed {
+				page, err = decompress(md.codec, page, int(ph.uncompressedPageSize)-levelsLen)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			vals, err = r.appendPageValues(vals, rc, dp.encoding, page, int(dp.numValues), defLevels, dict)
+			if err != nil {
+				return nil, err
+			}
+
+		case pageIndex:
+			// index pages aren't needed to read every value
+
+		default:
+			return nil, fmt.Errorf("unknown page type %d", ph.typ)
+		}
+	}
+
+	if int64(len(vals)) != numRows {
+		return nil, fmt.Errorf("expected %d values but found %d", numRows, len(vals))
+	}
+
+	return vals, nil
+}
+
+// appendPageValues decodes the values of a data page and appends them to vals. defLevels is nil for required columns,
+// and otherwise holds 0 for each null and 1 for each value.
+func (r *ParquetReader) appendPageValues(vals []interface{}, rc *readerColumn, encoding int32, data []byte, numValues int, defLevels []uint64, dict []interface{}) ([]interface{}, error) {
+	if numValues < 0 || cap(vals)-len(vals) < numValues {
+		return nil, errors.New("page holds more values than its row group has rows")
+	}
+
+	numNonNull := numValues
+	if defLevels != nil {
+		numNonNull = 0
+		for _, level := range defLevels {
+			if level > 1 {
+				return nil, fmt.Errorf("invalid definition level %d", level)
+			}
+			numNonNull += int(level)
+		}
+	}
+
+	decoded, err := decodeValues(rc.el, encoding, data, numNonNull, dict)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 0
+	for i := 0; i < numValues; i++ {
+		if defLevels != nil && defLevels[i] == 0 {
+			vals = append(vals, nil)
+			continue
+		}
+
+		v, err := rc.toSql(decoded[next])
+		if err != nil {
+			return nil, err
+		}
+		next++
+
+		vals = append(vals, v)
+	}
+
+	return vals, nil
+}
+
+// Close should release resources being held
+func (r *ParquetReader) Close(ctx context.Context) error {
+	if r.meta == nil {
+		return errors.New("already closed")
+	}
+
+	r.meta = nil
+	r.rowGroupVals = nil
+
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+
+	return nil
+}
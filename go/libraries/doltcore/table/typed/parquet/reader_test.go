@@ -0,0 +1,458 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"vitess.io/vitess/go/sqltypes"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func mustTypeInfo(sqlType sql.Type) typeinfo.TypeInfo {
+	ti, err := typeinfo.FromSqlType(sqlType)
+	if err != nil {
+		panic(err)
+	}
+	return ti
+}
+
+var roundTripCols = []struct {
+	name     string
+	ti       typeinfo.TypeInfo
+	readAsTi typeinfo.TypeInfo
+}{
+	{"id", typeinfo.Int64Type, typeinfo.Int64Type},
+	{"i8", typeinfo.Int8Type, typeinfo.Int8Type},
+	{"i24", typeinfo.Int24Type, typeinfo.Int32Type},
+	{"u32", typeinfo.Uint32Type, typeinfo.Uint32Type},
+	{"u64", typeinfo.Uint64Type, typeinfo.Uint64Type},
+	{"f32", typeinfo.Float32Type, typeinfo.Float32Type},
+	{"f64", typeinfo.Float64Type, typeinfo.Float64Type},
+	{"b", typeinfo.BoolType, typeinfo.BoolType},
+	{"dec", mustTypeInfo(sql.MustCreateDecimalType(10, 2)), mustTypeInfo(sql.MustCreateDecimalType(10, 2))},
+	{"dt", typeinfo.DatetimeType, typeinfo.DatetimeType},
+	{"d", typeinfo.DateType, typeinfo.DateType},
+	{"vc", mustTypeInfo(sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20)), typeinfo.StringDefaultType},
+	{"txt", typeinfo.TextType, typeinfo.StringDefaultType},
+	{"bin", typeinfo.BlobType, typeinfo.LongBlobType},
+	{"uuid", typeinfo.UuidType, typeinfo.UuidType},
+	{"js", typeinfo.JSONType, typeinfo.JSONType},
+	{"e", mustTypeInfo(sql.MustCreateEnumType([]string{"a", "b"}, sql.Collation_Default)), typeinfo.StringDefaultType},
+	{"t", typeinfo.TimeType, typeinfo.StringDefaultType},
+	{"y", typeinfo.YearType, typeinfo.Int16Type},
+	{"pt", typeinfo.PointType, typeinfo.StringDefaultType},
+}
+
+func roundTripRow(i int) []interface{} {
+	if i%3 == 1 {
+		// every column but the key is null
+		vals := make([]interface{}, len(roundTripCols))
+		vals[0] = fmt.Sprint(i)
+		return vals
+	}
+
+	return []interface{}{
+		fmt.Sprint(i),
+		fmt.Sprint(i%256 - 128),
+		fmt.Sprint(-i * 1000),
+		fmt.Sprint(i * 7),
+		"18446744073709551615",
+		"1.5",
+		fmt.Sprint(float64(i) / 3),
+		fmt.Sprint(i % 2),
+		fmt.Sprintf("%d.%02d", -i, i%100),
+		"1969-07-20 20:17:40.123456",
+		"2020-02-29",
+		fmt.Sprintf("name %d", i),
+		"a longer piece of text",
+		"\x00\x01binary\xff",
+		"2f7f2b8a-3f35-4fb4-b8c1-d8f3e1e7a0c2",
+		`{"a": [1, 2, {"b": null}]}`,
+		"b",
+		"-12:34:56.789",
+		"2020",
+		"SRID=4326;POINT(1 2)",
+	}
+}
+
+func newRoundTripSchema(t *testing.T, tis func(i int) typeinfo.TypeInfo) schema.Schema {
+	var cols []schema.Column
+	for i, c := range roundTripCols {
+		col, err := schema.NewColumnWithTypeInfo(c.name, uint64(i), tis(i), i == 0)
+		require.NoError(t, err)
+		cols = append(cols, col)
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+	require.NoError(t, err)
+	return schema.SchemaFromCols(colColl)
+}
+
+func TestRoundTrip(t *testing.T) {
+	oldPageSize, oldRowGroupSize := PageSize, RowGroupSize
+	defer func() {
+		PageSize, RowGroupSize = oldPageSize, oldRowGroupSize
+	}()
+
+	// small enough to write several pages and row groups
+	PageSize = 100
+	RowGroupSize = 4000
+
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+	fs := filesys.EmptyInMemFS("/")
+
+	sch := newRoundTripSchema(t, func(i int) typeinfo.TypeInfo { return roundTripCols[i].ti })
+	wr, err := OpenParquetWriter("/data/file.parquet", fs, sch)
+	require.NoError(t, err)
+
+	const numRows = 200
+	var expected [][]interface{}
+	for i := 0; i < numRows; i++ {
+		vals := roundTripRow(i)
+		taggedVals := make(row.TaggedValues)
+		for j, v := range vals {
+			if v == nil {
+				continue
+			}
+			str := v.(string)
+			taggedVals[uint64(j)], err = roundTripCols[j].ti.ParseValue(ctx, vrw, &str)
+			require.NoError(t, err, "column %s", roundTripCols[j].name)
+		}
+
+		r, err := row.New(vrw.Format(), sch, taggedVals)
+		require.NoError(t, err)
+		require.NoError(t, wr.WriteRow(ctx, r))
+
+		formatted := make([]interface{}, len(vals))
+		for j := range vals {
+			formatted[j], err = roundTripCols[j].ti.FormatValue(taggedVals[uint64(j)])
+			require.NoError(t, err)
+		}
+		expected = append(expected, formatted)
+	}
+	require.NoError(t, wr.Close(ctx))
+
+	rd, err := OpenParquetReader(vrw, "/data/file.parquet", fs)
+	require.NoError(t, err)
+	assert.True(t, len(rd.meta.rowGroups) > 1)
+
+	expectedSch := newRoundTripSchema(t, func(i int) typeinfo.TypeInfo { return roundTripCols[i].readAsTi })
+	for i, col := range rd.GetSchema().GetAllCols().GetColumns() {
+		assert.Equal(t, roundTripCols[i].name, col.Name)
+		assert.True(t, expectedSch.GetAllCols().GetByIndex(i).TypeInfo.Equals(col.TypeInfo), "column %s is %s", col.Name, col.TypeInfo)
+	}
+
+	rows, badRows, err := table.ReadAllRows(ctx, rd, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, badRows)
+	require.Equal(t, numRows, len(rows))
+
+	for i, r := range rows {
+		for j, col := range rd.GetSchema().GetAllCols().GetColumns() {
+			val, _ := r.GetColVal(col.Tag)
+			str, err := col.TypeInfo.FormatValue(val)
+			require.NoError(t, err)
+			assert.Equal(t, expected[i][j], str, "row %d column %s", i, col.Name)
+		}
+	}
+
+	_, err = rd.ReadRow(ctx)
+	assert.Equal(t, io.EOF, err)
+	assert.NoError(t, rd.Close(ctx))
+}
+
+func TestWriteNullToRequiredColumn(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+
+	col, err := schema.NewColumnWithTypeInfo("pk", 0, typeinfo.Int64Type, true)
+	require.NoError(t, err)
+	colColl, err := schema.NewColCollection(col)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	wr, err := NewParquetWriter(nopCloser{&bytes.Buffer{}}, sch)
+	require.NoError(t, err)
+
+	r, err := row.New(vrw.Format(), sch, row.TaggedValues{})
+	require.NoError(t, err)
+	assert.Error(t, wr.WriteRow(ctx, r))
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// TestReadEncodings reads a file written with dictionary pages, version 2 data pages, delta encodings, gzip
+// compression and converted rather than logical types, as other writers produce.
+func TestReadEncodings(t *testing.T) {
+	file := &bytes.Buffer{}
+	file.WriteString(magic)
+
+	gzipped := func(data []byte) []byte {
+		buf := &bytes.Buffer{}
+		gz := gzip.NewWriter(buf)
+		_, err := gz.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	writePage := func(ph *pageHeader, body, compressed []byte) {
+		ph.uncompressedPageSize = int32(len(body))
+		ph.compressedPageSize = int32(len(compressed))
+		file.Write(writePageHeader(ph))
+		file.Write(compressed)
+	}
+
+	// an optional UTF8 column holding "x", null, "y", "x", with a dictionary page and a version 1 data page
+	nameOffset := int64(file.Len())
+	dict := &bytes.Buffer{}
+	appendPlain(dict, typeByteArray, []byte("x"))
+	appendPlain(dict, typeByteArray, []byte("y"))
+	writePage(&pageHeader{
+		typ:                  pageDictionary,
+		dictionaryPageHeader: &dictionaryPageHeader{numValues: 2, encoding: encodingPlainDictionary},
+	}, dict.Bytes(), gzipped(dict.Bytes()))
+
+	nameDataOffset := int64(file.Len())
+	body := encodeLengthPrefixedRLE([]uint64{1, 0, 1, 1}, 1)
+	body = append(body, 1)
+	body = append(body, encodeRLEHybrid([]uint64{0, 1, 0}, 1)...)
+	writePage(&pageHeader{
+		typ: pageData,
+		dataPageHeader: &dataPageHeader{
+			numValues:               4,
+			encoding:                encodingRLEDictionary,
+			definitionLevelEncoding: encodingRLE,
+			repetitionLevelEncoding: encodingRLE,
+		},
+	}, body, gzipped(body))
+	nameSize := int64(file.Len()) - nameOffset
+
+	// a required TIMESTAMP_MILLIS column holding 4 timestamps a second apart, delta encoded in a version 2 data page
+	tsOffset := int64(file.Len())
+	values := []byte{0x80, 0x01, 0x04, 0x04, 0x80, 0xc0, 0xe2, 0xfa, 0xe0, 0x5c, 0xd0, 0x0f, 0x00, 0x00, 0x00, 0x00}
+	writePage(&pageHeader{
+		typ: pageDataV2,
+		dataPageHeaderV2: &dataPageHeaderV2{
+			numValues:    4,
+			numRows:      4,
+			encoding:     encodingDeltaBinaryPacked,
+			isCompressed: true,
+		},
+	}, values, gzipped(values))
+	tsSize := int64(file.Len()) - tsOffset
+
+	footer := writeFileMetaData(&fileMetaData{
+		version: 1,
+		schema: []schemaElement{
+			{name: "spark_schema", numChildren: 2, hasNumChildren: true},
+			{name: "name", typ: typeByteArray, hasType: true, repetition: repetitionOptional, hasRepetition: true, convertedType: convertedUTF8, hasConverted: true},
+			{name: "ts", typ: typeInt64, hasType: true, repetition: repetitionRequired, hasRepetition: true, convertedType: convertedTimestampMillis, hasConverted: true},
+		},
+		numRows: 4,
+		rowGroups: []rowGroup{{
+			numRows: 4,
+			columns: []columnChunk{
+				{fileOffset: nameOffset, metaData: &columnMetaData{
+					typ: typeByteArray, codec: codecGzip, numValues: 4, totalCompressedSize: nameSize,
+					dataPageOffset: nameDataOffset, dictionaryPageOffset: nameOffset, hasDictionaryPage: true,
+					encodings: []int32{encodingPlainDictionary, encodingRLE}, pathInSchema: []string{"name"},
+				}},
+				{fileOffset: tsOffset, metaData: &columnMetaData{
+					typ: typeInt64, codec: codecGzip, numValues: 4, totalCompressedSize: tsSize, dataPageOffset: tsOffset,
+					encodings: []int32{encodingDeltaBinaryPacked}, pathInSchema: []string{"ts"},
+				}},
+			},
+		}},
+	})
+	file.Write(footer)
+	require.NoError(t, binary.Write(file, binary.LittleEndian, uint32(len(footer))))
+	file.WriteString(magic)
+
+	fs := filesys.EmptyInMemFS("/")
+	require.NoError(t, fs.WriteFile("/file.parquet", file.Bytes()))
+
+	ctx := context.Background()
+	rd, err := OpenParquetReader(types.NewMemoryValueStore(), "/file.parquet", fs)
+	require.NoError(t, err)
+
+	cols := rd.GetSchema().GetAllCols().GetColumns()
+	require.Equal(t, 2, len(cols))
+	assert.Equal(t, typeinfo.StringDefaultType, cols[0].TypeInfo)
+	assert.True(t, cols[0].IsPartOfPK)
+	assert.Equal(t, typeinfo.DatetimeType, cols[1].TypeInfo)
+	assert.False(t, cols[1].IsNullable())
+
+	assert.Equal(t, []string{
+		"[x 2020-07-01 00:00:00]",
+		"[NULL 2020-07-01 00:00:01]",
+		"[y 2020-07-01 00:00:02]",
+		"[x 2020-07-01 00:00:03]",
+	}, readRowStrings(t, ctx, rd))
+}
+
+// readRowStrings reads every row of rd, formatting each as a list of its column values.
+func readRowStrings(t *testing.T, ctx context.Context, rd *ParquetReader) []string {
+	rows, _, err := table.ReadAllRows(ctx, rd, false)
+	require.NoError(t, err)
+
+	cols := rd.GetSchema().GetAllCols().GetColumns()
+	var actual []string
+	for _, r := range rows {
+		var strs []string
+		for _, col := range cols {
+			val, _ := r.GetColVal(col.Tag)
+			str, err := col.TypeInfo.FormatValue(val)
+			require.NoError(t, err)
+			if str == nil {
+				strs = append(strs, "NULL")
+			} else {
+				strs = append(strs, *str)
+			}
+		}
+		actual = append(actual, fmt.Sprint(strs))
+	}
+
+	return actual
+}
+
+// The files in testdata are written by testdata/gen_testdata.py, which shares no code with this package.
+func TestReadTestdataFiles(t *testing.T) {
+	tests := []struct {
+		file     string
+		colTypes []typeinfo.TypeInfo
+		rows     []string
+	}{
+		{
+			file:     "snappy_dictionary_v1.parquet",
+			colTypes: []typeinfo.TypeInfo{typeinfo.Int64Type, typeinfo.StringDefaultType, typeinfo.Float64Type},
+			rows: []string{
+				"[1 Seattle 1.5]",
+				"[2 Portland NULL]",
+				"[3 Seattle 3]",
+				"[4 NULL 4.5]",
+				"[5 Seattle 6]",
+				"[6 Boston NULL]",
+				"[7 NULL 10.5]",
+				"[8 Portland 12]",
+				"[9 Boston 13.5]",
+			},
+		},
+		{
+			file:     "data_page_v2.parquet",
+			colTypes: []typeinfo.TypeInfo{typeinfo.Int64Type, typeinfo.StringDefaultType, typeinfo.DatetimeType},
+			rows: []string{
+				"[10 alpha 2020-07-01 00:00:00]",
+				"[20 NULL 2020-07-01 00:00:01.5]",
+				"[30 beta NULL]",
+				"[40 alpha 2020-07-02 00:00:00]",
+				"[50 alpha NULL]",
+				"[60 NULL 2020-07-02 00:00:00.00025]",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			ctx := context.Background()
+			rd, err := OpenParquetReader(types.NewMemoryValueStore(), filepath.Join("testdata", test.file), filesys.LocalFS)
+			require.NoError(t, err)
+			defer rd.Close(ctx)
+
+			var colTypes []typeinfo.TypeInfo
+			for _, col := range rd.GetSchema().GetAllCols().GetColumns() {
+				colTypes = append(colTypes, col.TypeInfo)
+			}
+			assert.Equal(t, test.colTypes, colTypes)
+			assert.Equal(t, test.rows, readRowStrings(t, ctx, rd))
+		})
+	}
+}
+
+func TestReadUnsupportedCompression(t *testing.T) {
+	for _, codec := range []int32{4, 5, 6, 7} {
+		t.Run(codecNames[codec], func(t *testing.T) {
+			file := &bytes.Buffer{}
+			file.WriteString(magic)
+
+			offset := int64(file.Len())
+			file.WriteString("compressed data")
+
+			footer := writeFileMetaData(&fileMetaData{
+				version: 1,
+				schema: []schemaElement{
+					{name: "schema", numChildren: 1, hasNumChildren: true},
+					{name: "id", typ: typeInt64, hasType: true, repetition: repetitionRequired, hasRepetition: true},
+				},
+				numRows: 1,
+				rowGroups: []rowGroup{{
+					numRows: 1,
+					columns: []columnChunk{{fileOffset: offset, metaData: &columnMetaData{
+						typ: typeInt64, codec: codec, numValues: 1, totalCompressedSize: int64(file.Len()) - offset,
+						dataPageOffset: offset, encodings: []int32{encodingPlain}, pathInSchema: []string{"id"},
+					}}},
+				}},
+			})
+			file.Write(footer)
+			require.NoError(t, binary.Write(file, binary.LittleEndian, uint32(len(footer))))
+			file.WriteString(magic)
+
+			fs := filesys.EmptyInMemFS("/")
+			require.NoError(t, fs.WriteFile("/file.parquet", file.Bytes()))
+
+			_, err := OpenParquetReader(types.NewMemoryValueStore(), "/file.parquet", fs)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrUnsupportedCompression))
+			assert.Contains(t, err.Error(), "unsupported compression")
+			assert.Contains(t, err.Error(), codecNames[codec])
+		})
+	}
+}
+
+func TestReadInvalidFile(t *testing.T) {
+	fs := filesys.EmptyInMemFS("/")
+	require.NoError(t, fs.WriteFile("/file.parquet", []byte("PAR1 this is not a parquet file PAR1")))
+	require.NoError(t, fs.WriteFile("/short.parquet", []byte("PAR1")))
+
+	_, err := OpenParquetReader(types.NewMemoryValueStore(), "/file.parquet", fs)
+	assert.Error(t, err)
+	_, err = OpenParquetReader(types.NewMemoryValueStore(), "/short.parquet", fs)
+	assert.Error(t, err)
+}
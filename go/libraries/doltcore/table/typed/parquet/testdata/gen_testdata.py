@@ -0,0 +1,390 @@
+#!/usr/bin/env python3
+# Copyright 2020 Liquidata, Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Generates the parquet files in this directory.
+
+The files are written independently of the Go reader and writer, with nothing but the Python standard library, so
+that the reader is tested against an encoder it shares no code with. They follow the layout used by parquet-cpp
+(pyarrow): snappy compressed pages, dictionary pages, version 1 and version 2 data pages, and the optional metadata
+fields (statistics, encoding stats, column orders) that the reader has to skip.
+
+Run it from this directory to regenerate the files:
+
+    python3 gen_testdata.py
+"""
+
+import struct
+
+# thrift compact protocol types
+BOOL_TRUE, BOOL_FALSE, BYTE, I16, I32, I64, DOUBLE, BINARY, LIST, SET, MAP, STRUCT = range(1, 13)
+
+# parquet enums
+INT32, INT64, DOUBLE_T, BYTE_ARRAY = 1, 2, 5, 6
+REQUIRED, OPTIONAL = 0, 1
+UTF8, TIMESTAMP_MICROS = 0, 10
+PLAIN, PLAIN_DICTIONARY, RLE, RLE_DICTIONARY = 0, 2, 3, 8
+SNAPPY = 1
+DATA_PAGE, DICTIONARY_PAGE, DATA_PAGE_V2 = 0, 2, 3
+
+CREATED_BY = "dolt parquet testdata generator (gen_testdata.py)"
+
+
+def uvarint(v):
+    out = bytearray()
+    while True:
+        b = v & 0x7F
+        v >>= 7
+        if v:
+            out.append(b | 0x80)
+        else:
+            out.append(b)
+            return bytes(out)
+
+
+def zigzag(v):
+    return uvarint((v << 1) ^ (v >> 63))
+
+
+# Thrift values are written from tuples of (type, value). Structs are lists of (field id, value) pairs.
+def i16(v): return (I16, v)
+def i32(v): return (I32, v)
+def i64(v): return (I64, v)
+def binary(v): return (BINARY, v if isinstance(v, bytes) else v.encode())
+def boolean(v): return (BOOL_TRUE if v else BOOL_FALSE, v)
+def strct(*fields): return (STRUCT, list(fields))
+def lst(elem_type, items): return (LIST, (elem_type, items))
+
+
+def write_value(out, typ, v):
+    if typ in (I16, I32, I64):
+        out += zigzag(v)
+    elif typ == BYTE:
+        out.append(v & 0xFF)
+    elif typ == BINARY:
+        out += uvarint(len(v)) + v
+    elif typ == STRUCT:
+        write_struct(out, v)
+    elif typ == LIST:
+        elem_type, items = v
+        if len(items) < 15:
+            out.append(len(items) << 4 | elem_type)
+        else:
+            out.append(0xF0 | elem_type)
+            out += uvarint(len(items))
+        for item in items:
+            write_value(out, elem_type, item[1] if isinstance(item, tuple) else item)
+    else:
+        raise ValueError(typ)
+
+
+def write_struct(out, fields):
+    last = 0
+    for fid, (typ, v) in fields:
+        delta = fid - last
+        if 0 < delta <= 15:
+            out.append(delta << 4 | typ)
+        else:
+            out.append(typ)
+            out += zigzag(fid)
+        last = fid
+        if typ not in (BOOL_TRUE, BOOL_FALSE):
+            write_value(out, typ, v)
+    out.append(0)
+
+
+def thrift(fields):
+    out = bytearray()
+    write_struct(out, fields)
+    return bytes(out)
+
+
+def snappy(data):
+    """Compresses data in the snappy format, using literals and 2 byte offset copies."""
+    out = bytearray(uvarint(len(data)))
+    table = {}
+    lit_start = 0
+    i = 0
+
+    def emit_literal(lit):
+        n = len(lit) - 1
+        if n < 60:
+            out.append(n << 2)
+        elif n < 256:
+            out.extend(bytes([60 << 2, n]))
+        else:
+            out.extend(bytes([61 << 2]) + struct.pack("<H", n))
+        out.extend(lit)
+
+    while i + 4 <= len(data):
+        key = data[i:i + 4]
+        cand = table.get(key)
+        table[key] = i
+        if cand is None or i - cand > 0xFFFF:
+            i += 1
+            continue
+
+        if lit_start < i:
+            emit_literal(data[lit_start:i])
+        length = 4
+        while i + length < len(data) and data[cand + length] == data[i + length]:
+            length += 1
+        offset = i - cand
+        remaining = length
+        while remaining > 0:
+            n = min(remaining, 64)
+            out.append((n - 1) << 2 | 2)
+            out += struct.pack("<H", offset)
+            remaining -= n
+        i += length
+        lit_start = i
+
+    if lit_start < len(data):
+        emit_literal(data[lit_start:])
+    return bytes(out)
+
+
+def rle_runs(vals, bit_width):
+    """Encodes vals in the RLE / bit packing hybrid encoding using only RLE runs."""
+    out = bytearray()
+    width = (bit_width + 7) // 8
+    i = 0
+    while i < len(vals):
+        j = i
+        while j < len(vals) and vals[j] == vals[i]:
+            j += 1
+        out += uvarint((j - i) << 1)
+        out += vals[i].to_bytes(width, "little")
+        i = j
+    return bytes(out)
+
+
+def bit_packed(vals, bit_width):
+    """Encodes vals in the RLE / bit packing hybrid encoding using a single bit packed run, padded to 8 values."""
+    groups = (len(vals) + 7) // 8
+    padded = list(vals) + [0] * (groups * 8 - len(vals))
+    acc, nbits, packed = 0, 0, bytearray()
+    for v in padded:
+        acc |= v << nbits
+        nbits += bit_width
+        while nbits >= 8:
+            packed.append(acc & 0xFF)
+            acc >>= 8
+            nbits -= 8
+    return uvarint(groups << 1 | 1) + bytes(packed)
+
+
+def plain(typ, vals):
+    out = bytearray()
+    for v in vals:
+        if typ == INT32:
+            out += struct.pack("<i", v)
+        elif typ == INT64:
+            out += struct.pack("<q", v)
+        elif typ == DOUBLE_T:
+            out += struct.pack("<d", v)
+        elif typ == BYTE_ARRAY:
+            b = v.encode()
+            out += struct.pack("<I", len(b)) + b
+    return bytes(out)
+
+
+def statistics(typ, vals):
+    present = [v for v in vals if v is not None]
+    fields = [(3, i64(len(vals) - len(present)))]
+    if present:
+        fields += [(5, binary(plain(typ, [max(present)])[4 if typ == BYTE_ARRAY else 0:])),
+                   (6, binary(plain(typ, [min(present)])[4 if typ == BYTE_ARRAY else 0:]))]
+    return strct(*fields)
+
+
+class Column:
+    def __init__(self, name, typ, optional, converted=None, logical=None, dictionary=False):
+        self.name = name
+        self.typ = typ
+        self.optional = optional
+        self.converted = converted
+        self.logical = logical
+        self.dictionary = dictionary
+
+    def schema_element(self):
+        fields = [(1, i32(self.typ)), (3, i32(OPTIONAL if self.optional else REQUIRED)), (4, binary(self.name))]
+        if self.converted is not None:
+            fields.append((6, i32(self.converted)))
+        if self.logical is not None:
+            fields.append((10, self.logical))
+        return strct(*fields)
+
+
+class FileWriter:
+    def __init__(self, columns, page_version):
+        self.columns = columns
+        self.page_version = page_version
+        self.buf = bytearray(b"PAR1")
+        self.row_groups = []
+        self.num_rows = 0
+
+    def page(self, header, compressed):
+        self.buf += thrift(header)
+        self.buf += compressed
+
+    def data_page(self, col, vals, encoding, encoded, compress_values):
+        defs = [0 if v is None else 1 for v in vals]
+        stats = statistics(col.typ, vals)
+        if self.page_version == 1:
+            body = b""
+            if col.optional:
+                levels = rle_runs(defs, 1)
+                body += struct.pack("<I", len(levels)) + levels
+            body += encoded
+            compressed = snappy(body)
+            self.page([(1, i32(DATA_PAGE)), (2, i32(len(body))), (3, i32(len(compressed))),
+                       (5, strct((1, i32(len(vals))), (2, i32(encoding)), (3, i32(RLE)), (4, i32(RLE)),
+                                 (5, stats)))], compressed)
+        else:
+            levels = rle_runs(defs, 1) if col.optional else b""
+            values = snappy(encoded) if compress_values else encoded
+            self.page([(1, i32(DATA_PAGE_V2)), (2, i32(len(levels) + len(encoded))),
+                       (3, i32(len(levels) + len(values))),
+                       (8, strct((1, i32(len(vals))), (2, i32(defs.count(0))), (3, i32(len(vals))),
+                                 (4, i32(encoding)), (5, i32(len(levels))), (6, i32(0)),
+                                 (7, boolean(compress_values)), (8, stats)))],
+                      levels + values)
+
+    def column_chunk(self, col, pages):
+        start = len(self.buf)
+        vals = [v for page in pages for v in page]
+        present = [v for v in vals if v is not None]
+        dict_offset = None
+        encodings = [RLE]
+        page_encodings = []
+
+        if col.dictionary:
+            dictionary = sorted(set(present), key=present.index)
+            dict_offset = len(self.buf)
+            body = plain(col.typ, dictionary)
+            compressed = snappy(body)
+            dict_encoding = PLAIN_DICTIONARY if self.page_version == 1 else PLAIN
+            self.page([(1, i32(DICTIONARY_PAGE)), (2, i32(len(body))), (3, i32(len(compressed))),
+                       (7, strct((1, i32(len(dictionary))), (2, i32(dict_encoding)), (3, boolean(False))))],
+                      compressed)
+            encodings.insert(0, dict_encoding)
+            page_encodings.append(strct((1, i32(DICTIONARY_PAGE)), (2, i32(dict_encoding)), (3, i32(1))))
+
+        data_offset = len(self.buf)
+        for n, page in enumerate(pages):
+            # writers may leave the values of a version 2 page uncompressed when compression doesn't make it smaller,
+            # which is done here for the last of several pages
+            compress_values = self.page_version == 1 or n == 0 or n < len(pages) - 1
+            page_present = [v for v in page if v is not None]
+            if col.dictionary:
+                bit_width = max(1, (len(dictionary) - 1).bit_length())
+                idxs = [dictionary.index(v) for v in page_present]
+                encoding = RLE_DICTIONARY if self.page_version == 2 else PLAIN_DICTIONARY
+                encoded = bytes([bit_width]) + bit_packed(idxs, bit_width)
+            else:
+                encoding = PLAIN
+                encoded = plain(col.typ, page_present)
+            self.data_page(col, page, encoding, encoded, compress_values)
+
+        data_encoding = (RLE_DICTIONARY if self.page_version == 2 else PLAIN_DICTIONARY) if col.dictionary else PLAIN
+        if data_encoding not in encodings:
+            encodings.append(data_encoding)
+        page_encodings.append(strct((1, i32(DATA_PAGE if self.page_version == 1 else DATA_PAGE_V2)),
+                                    (2, i32(data_encoding)), (3, i32(len(pages)))))
+
+        size = len(self.buf) - start
+        md = [(1, i32(col.typ)), (2, lst(I32, [i32(e) for e in encodings])), (3, lst(BINARY, [col.name.encode()])),
+              (4, i32(SNAPPY)), (5, i64(len(vals))), (6, i64(size)), (7, i64(size)), (9, i64(data_offset))]
+        if dict_offset is not None:
+            md.append((11, i64(dict_offset)))
+        md += [(12, statistics(col.typ, vals)), (13, lst(STRUCT, page_encodings))]
+
+        # like parquet-cpp, file_offset is the offset of the end of the column chunk
+        return strct((2, i64(len(self.buf))), (3, strct(*md))), size
+
+    def row_group(self, columns_pages):
+        start = len(self.buf)
+        chunks = []
+        total = 0
+        for col, pages in zip(self.columns, columns_pages):
+            chunk, size = self.column_chunk(col, pages)
+            chunks.append(chunk)
+            total += size
+        num_rows = sum(len(p) for p in columns_pages[0])
+        self.row_groups.append(strct((1, lst(STRUCT, chunks)), (2, i64(total)), (3, i64(num_rows)),
+                                     (5, i64(start)), (6, i64(total)), (7, i16(len(self.row_groups)))))
+        self.num_rows += num_rows
+
+    def finish(self, path):
+        schema = [strct((4, binary("schema")), (5, i32(len(self.columns))))]
+        schema += [c.schema_element() for c in self.columns]
+        footer = thrift([(1, i32(1 if self.page_version == 1 else 2)), (2, lst(STRUCT, schema)),
+                         (3, i64(self.num_rows)), (4, lst(STRUCT, self.row_groups)),
+                         (5, lst(STRUCT, [strct((1, binary("writer.note")), (2, binary("generated for tests")))])),
+                         (6, binary(CREATED_BY)),
+                         (7, lst(STRUCT, [strct((1, strct())) for _ in self.columns]))])
+        self.buf += footer + struct.pack("<I", len(footer)) + b"PAR1"
+        with open(path, "wb") as f:
+            f.write(self.buf)
+
+
+STRING = strct((1, strct()))
+INT64_SIGNED = strct((10, strct((1, (BYTE, 64)), (2, boolean(True)))))
+TIMESTAMP_US_UTC = strct((8, strct((1, boolean(True)), (2, strct((2, strct()))))))
+
+
+def write_dictionary_v1():
+    """Two row groups of snappy compressed version 1 data pages. city is dictionary encoded, and in the second row
+    group its values are split across two data pages."""
+    cols = [
+        Column("id", INT64, False, logical=INT64_SIGNED),
+        Column("city", BYTE_ARRAY, True, converted=UTF8, logical=STRING, dictionary=True),
+        Column("score", DOUBLE_T, True),
+    ]
+    w = FileWriter(cols, 1)
+    w.row_group([
+        [[1, 2, 3, 4, 5]],
+        [["Seattle", "Portland", "Seattle", None, "Seattle"]],
+        [[1.5, None, 3.0, 4.5, 6.0]],
+    ])
+    w.row_group([
+        [[6, 7, 8, 9]],
+        [["Boston", None], ["Portland", "Boston"]],
+        [[None, 10.5, 12.0, 13.5]],
+    ])
+    w.finish("snappy_dictionary_v1.parquet")
+
+
+def write_data_page_v2():
+    """One row group of version 2 data pages. Levels are stored uncompressed ahead of the snappy compressed values,
+    and the last page of each chunk with more than one page isn't compressed."""
+    cols = [
+        Column("id", INT64, False, dictionary=True),
+        Column("name", BYTE_ARRAY, True, converted=UTF8, logical=STRING, dictionary=True),
+        Column("ts", INT64, True, converted=TIMESTAMP_MICROS, logical=TIMESTAMP_US_UTC),
+    ]
+    base = 1593561600000000  # 2020-07-01 00:00:00 UTC
+    w = FileWriter(cols, 2)
+    w.row_group([
+        [[10, 20, 30], [40, 50, 60]],
+        [["alpha", None, "beta"], ["alpha", "alpha", None]],
+        [[base, base + 1500000, None], [base + 86400000000, None, base + 86400000000 + 250]],
+    ])
+    w.finish("data_page_v2.parquet")
+
+
+if __name__ == "__main__":
+    write_dictionary_v1()
+    write_data_page_v2()
@@ -0,0 +1,417 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Parquet metadata is serialized with the Thrift compact protocol. Only the parts of the protocol used by the Parquet
+// format are implemented here. Structs are decoded into a generic thriftStruct, from which the metadata types read the
+// fields they know about, so that fields added by newer writers are skipped.
+
+const (
+	tStop         = 0
+	tBooleanTrue  = 1
+	tBooleanFalse = 2
+	tByte         = 3
+	tI16          = 4
+	tI32          = 5
+	tI64          = 6
+	tDouble       = 7
+	tBinary       = 8
+	tList         = 9
+	tSet          = 10
+	tMap          = 11
+	tStruct       = 12
+)
+
+// maxThriftDepth bounds the nesting of structs and containers, to protect against malformed metadata.
+const maxThriftDepth = 64
+
+var errThriftTruncated = errors.New("invalid parquet metadata: unexpected end of data")
+
+// thriftStruct is a decoded Thrift struct, mapping field ids to their values. Values are bool, int64, float64, []byte,
+// []interface{} or thriftStruct.
+type thriftStruct map[int16]interface{}
+
+func (s thriftStruct) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s thriftStruct) i64(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s thriftStruct) i32(id int16) int32 {
+	return int32(s.i64(id))
+}
+
+func (s thriftStruct) bool(id int16) bool {
+	v, _ := s[id].(bool)
+	return v
+}
+
+func (s thriftStruct) binary(id int16) []byte {
+	v, _ := s[id].([]byte)
+	return v
+}
+
+func (s thriftStruct) str(id int16) string {
+	return string(s.binary(id))
+}
+
+func (s thriftStruct) strct(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+func (s thriftStruct) list(id int16) []interface{} {
+	v, _ := s[id].([]interface{})
+	return v
+}
+
+func (s thriftStruct) structList(id int16) []thriftStruct {
+	var res []thriftStruct
+	for _, v := range s.list(id) {
+		if st, ok := v.(thriftStruct); ok {
+			res = append(res, st)
+		}
+	}
+	return res
+}
+
+// compactReader decodes Thrift compact protocol data from a byte slice.
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+// readStruct reads a struct from the reader's current position.
+func (r *compactReader) readStruct() (thriftStruct, error) {
+	return r.readStructAtDepth(0)
+}
+
+func (r *compactReader) readStructAtDepth(depth int) (thriftStruct, error) {
+	if depth > maxThriftDepth {
+		return nil, errors.New("invalid parquet metadata: nested too deeply")
+	}
+
+	st := make(thriftStruct)
+	var lastID int16
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		typ := b & 0x0f
+		if typ == tStop {
+			return st, nil
+		}
+
+		var id int16
+		if delta := int16(b >> 4); delta != 0 {
+			id = lastID + delta
+		} else {
+			v, err := r.readZigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		lastID = id
+
+		var val interface{}
+		switch typ {
+		case tBooleanTrue:
+			val = true
+		case tBooleanFalse:
+			val = false
+		default:
+			val, err = r.readValue(typ, depth)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		st[id] = val
+	}
+}
+
+func (r *compactReader) readValue(typ byte, depth int) (interface{}, error) {
+	switch typ {
+	case tBooleanTrue, tBooleanFalse:
+		// booleans inside containers are a single byte
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return b == tBooleanTrue, nil
+	case tByte:
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return int64(int8(b)), nil
+	case tI16, tI32, tI64:
+		return r.readZigzag()
+	case tDouble:
+		if r.pos+8 > len(r.data) {
+			return nil, errThriftTruncated
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos:]))
+		r.pos += 8
+		return v, nil
+	case tBinary:
+		return r.readBinary()
+	case tList, tSet:
+		return r.readList(depth + 1)
+	case tMap:
+		return nil, r.skipMap(depth + 1)
+	case tStruct:
+		return r.readStructAtDepth(depth + 1)
+	default:
+		return nil, fmt.Errorf("invalid parquet metadata: unknown thrift type %d", typ)
+	}
+}
+
+func (r *compactReader) readList(depth int) ([]interface{}, error) {
+	if depth > maxThriftDepth {
+		return nil, errors.New("invalid parquet metadata: nested too deeply")
+	}
+
+	b, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	size := uint64(b >> 4)
+	elemType := b & 0x0f
+	if size == 15 {
+		size, err = r.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// every element takes at least one byte
+	if size > uint64(len(r.data)-r.pos) {
+		return nil, errThriftTruncated
+	}
+
+	list := make([]interface{}, size)
+	for i := range list {
+		list[i], err = r.readValue(elemType, depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+func (r *compactReader) skipMap(depth int) error {
+	size, err := r.readUvarint()
+	if err != nil || size == 0 {
+		return err
+	}
+
+	if size > uint64(len(r.data)-r.pos) {
+		return errThriftTruncated
+	}
+
+	types, err := r.readByte()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < size; i++ {
+		if _, err := r.readValue(types>>4, depth); err != nil {
+			return err
+		}
+		if _, err := r.readValue(types&0x0f, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *compactReader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errThriftTruncated
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *compactReader) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errThriftTruncated
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *compactReader) readZigzag() (int64, error) {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errThriftTruncated
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *compactReader) readBinary() ([]byte, error) {
+	size, err := r.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if size > uint64(len(r.data)-r.pos) {
+		return nil, errThriftTruncated
+	}
+	b := r.data[r.pos : r.pos+int(size)]
+	r.pos += int(size)
+	return b, nil
+}
+
+// compactWriter encodes Thrift compact protocol data. Structs are written by calling structBegin, the field methods
+// and then structEnd.
+type compactWriter struct {
+	buf     bytes.Buffer
+	lastIDs []int16
+}
+
+func (w *compactWriter) structBegin() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+func (w *compactWriter) structEnd() {
+	w.buf.WriteByte(tStop)
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *compactWriter) fieldBegin(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta<<4) | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.writeZigzag(int64(id))
+	}
+	*last = id
+}
+
+func (w *compactWriter) fieldBool(id int16, v bool) {
+	if v {
+		w.fieldBegin(id, tBooleanTrue)
+	} else {
+		w.fieldBegin(id, tBooleanFalse)
+	}
+}
+
+func (w *compactWriter) fieldByte(id int16, v int8) {
+	w.fieldBegin(id, tByte)
+	w.buf.WriteByte(byte(v))
+}
+
+func (w *compactWriter) fieldI32(id int16, v int32) {
+	w.fieldBegin(id, tI32)
+	w.writeZigzag(int64(v))
+}
+
+func (w *compactWriter) fieldI64(id int16, v int64) {
+	w.fieldBegin(id, tI64)
+	w.writeZigzag(v)
+}
+
+func (w *compactWriter) fieldBinary(id int16, v []byte) {
+	w.fieldBegin(id, tBinary)
+	w.writeBinary(v)
+}
+
+func (w *compactWriter) fieldString(id int16, v string) {
+	w.fieldBinary(id, []byte(v))
+}
+
+// fieldStruct writes a struct field whose fields are written by the function given.
+func (w *compactWriter) fieldStruct(id int16, writeFields func()) {
+	w.fieldBegin(id, tStruct)
+	w.structBegin()
+	writeFields()
+	w.structEnd()
+}
+
+// fieldStructList writes a list of n structs, the fields of each of which are written by the function given.
+func (w *compactWriter) fieldStructList(id int16, n int, writeFields func(i int)) {
+	w.fieldBegin(id, tList)
+	w.listBegin(tStruct, n)
+	for i := 0; i < n; i++ {
+		w.structBegin()
+		writeFields(i)
+		w.structEnd()
+	}
+}
+
+func (w *compactWriter) fieldI32List(id int16, vals []int32) {
+	w.fieldBegin(id, tList)
+	w.listBegin(tI32, len(vals))
+	for _, v := range vals {
+		w.writeZigzag(int64(v))
+	}
+}
+
+func (w *compactWriter) fieldStringList(id int16, vals []string) {
+	w.fieldBegin(id, tList)
+	w.listBegin(tBinary, len(vals))
+	for _, v := range vals {
+		w.writeBinary([]byte(v))
+	}
+}
+
+func (w *compactWriter) listBegin(elemType byte, n int) {
+	if n < 15 {
+		w.buf.WriteByte(byte(n<<4) | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.writeUvarint(uint64(n))
+	}
+}
+
+func (w *compactWriter) writeBinary(v []byte) {
+	w.writeUvarint(uint64(len(v)))
+	w.buf.Write(v)
+}
+
+func (w *compactWriter) writeUvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf.Write(b[:n])
+}
+
+func (w *compactWriter) writeZigzag(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v)
+	w.buf.Write(b[:n])
+}
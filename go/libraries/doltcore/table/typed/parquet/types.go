@@ -0,0 +1,457 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/shopspring/decimal"
+	"vitess.io/vitess/go/sqltypes"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// julianDayOfUnixEpoch is the Julian day number of 1970-01-01, used to decode legacy INT96 timestamps.
+const julianDayOfUnixEpoch = 2440588
+
+// toSqlValue converts a decoded parquet value to a value which a column's type info can convert to a noms value.
+type toSqlValue func(v interface{}) (interface{}, error)
+
+// toParquetValue converts a noms value of a column to the value written to a parquet file.
+type toParquetValue func(v types.Value) (interface{}, error)
+
+func identity(v interface{}) (interface{}, error) {
+	return v, nil
+}
+
+// annotation returns the logical type of a parquet column, converting its converted type if it was written without a
+// logical type.
+func annotation(el *schemaElement) *logicalType {
+	if el.logicalType != nil || !el.hasConverted {
+		return el.logicalType
+	}
+
+	switch el.convertedType {
+	case convertedUTF8:
+		return &logicalType{kind: logicalString}
+	case convertedEnum:
+		return &logicalType{kind: logicalEnum}
+	case convertedDecimal:
+		return &logicalType{kind: logicalDecimal, scale: el.scale, precision: el.precision}
+	case convertedDate:
+		return &logicalType{kind: logicalDate}
+	case convertedTimeMillis:
+		return &logicalType{kind: logicalTime, unit: unitMillis, isAdjustedToUTC: true}
+	case convertedTimeMicros:
+		return &logicalType{kind: logicalTime, unit: unitMicros, isAdjustedToUTC: true}
+	case convertedTimestampMillis:
+		return &logicalType{kind: logicalTimestamp, unit: unitMillis, isAdjustedToUTC: true}
+	case convertedTimestampMicros:
+		return &logicalType{kind: logicalTimestamp, unit: unitMicros, isAdjustedToUTC: true}
+	case convertedUint8, convertedUint16, convertedUint32, convertedUint64:
+		return &logicalType{kind: logicalInteger, bitWidth: 8 << uint(el.convertedType-convertedUint8), isSigned: false}
+	case convertedInt8, convertedInt16, convertedInt32, convertedInt64:
+		return &logicalType{kind: logicalInteger, bitWidth: 8 << uint(el.convertedType-convertedInt8), isSigned: true}
+	case convertedJSON:
+		return &logicalType{kind: logicalJSON}
+	case convertedBSON:
+		return &logicalType{kind: logicalBSON}
+	default:
+		return nil
+	}
+}
+
+// typeInfoForElement returns the type info of the Dolt column which holds the values of a parquet column, and a
+// function converting the column's decoded values to values of that type.
+func typeInfoForElement(el *schemaElement) (typeinfo.TypeInfo, toSqlValue, error) {
+	lt := annotation(el)
+	kind := int16(0)
+	if lt != nil {
+		kind = lt.kind
+	}
+
+	if kind == logicalDecimal {
+		return decimalTypeInfo(el, lt)
+	}
+
+	switch el.typ {
+	case typeBoolean:
+		return typeinfo.BoolType, identity, nil
+
+	case typeInt32:
+		switch kind {
+		case logicalInteger:
+			if !lt.isSigned {
+				conv := func(v interface{}) (interface{}, error) {
+					return uint32(v.(int32)), nil
+				}
+				switch lt.bitWidth {
+				case 8:
+					return typeinfo.Uint8Type, conv, nil
+				case 16:
+					return typeinfo.Uint16Type, conv, nil
+				default:
+					return typeinfo.Uint32Type, conv, nil
+				}
+			}
+			switch lt.bitWidth {
+			case 8:
+				return typeinfo.Int8Type, identity, nil
+			case 16:
+				return typeinfo.Int16Type, identity, nil
+			}
+		case logicalDate:
+			return typeinfo.DateType, func(v interface{}) (interface{}, error) {
+				return time.Unix(int64(v.(int32))*24*60*60, 0).UTC(), nil
+			}, nil
+		case logicalTime:
+			return typeinfo.TimeType, func(v interface{}) (interface{}, error) {
+				return time.Duration(v.(int32)) * time.Millisecond, nil
+			}, nil
+		}
+		return typeinfo.Int32Type, identity, nil
+
+	case typeInt64:
+		switch kind {
+		case logicalInteger:
+			if !lt.isSigned {
+				return typeinfo.Uint64Type, func(v interface{}) (interface{}, error) {
+					return uint64(v.(int64)), nil
+				}, nil
+			}
+		case logicalTimestamp:
+			unit := unitDuration(lt.unit)
+			return typeinfo.DatetimeType, func(v interface{}) (interface{}, error) {
+				perSecond := int64(time.Second / unit)
+				ts := v.(int64)
+				sec, frac := ts/perSecond, ts%perSecond
+				if frac < 0 {
+					sec, frac = sec-1, frac+perSecond
+				}
+				return time.Unix(sec, frac*int64(unit)).UTC(), nil
+			}, nil
+		case logicalTime:
+			unit := unitDuration(lt.unit)
+			return typeinfo.TimeType, func(v interface{}) (interface{}, error) {
+				return time.Duration(v.(int64)) * unit, nil
+			}, nil
+		}
+		return typeinfo.Int64Type, identity, nil
+
+	case typeInt96:
+		// INT96 columns hold timestamps written by older versions of Impala, Hive and Spark
+		return typeinfo.DatetimeType, func(v interface{}) (interface{}, error) {
+			b := v.([]byte)
+			nanos := int64(binary.LittleEndian.Uint64(b))
+			day := int64(binary.LittleEndian.Uint32(b[8:]))
+			return time.Unix((day-julianDayOfUnixEpoch)*24*60*60, nanos).UTC(), nil
+		}, nil
+
+	case typeFloat:
+		return typeinfo.Float32Type, identity, nil
+
+	case typeDouble:
+		return typeinfo.Float64Type, identity, nil
+
+	case typeByteArray, typeFixedLenByteArray:
+		toString := func(v interface{}) (interface{}, error) {
+			return string(v.([]byte)), nil
+		}
+
+		switch kind {
+		case logicalString, logicalEnum:
+			return typeinfo.StringDefaultType, toString, nil
+		case logicalJSON:
+			return typeinfo.JSONType, toString, nil
+		case logicalUUID:
+			return typeinfo.UuidType, func(v interface{}) (interface{}, error) {
+				return uuid.FromBytes(v.([]byte))
+			}, nil
+		}
+		return typeinfo.LongBlobType, toString, nil
+
+	default:
+		return nil, nil, fmt.Errorf("parquet physical type %d is not supported", el.typ)
+	}
+}
+
+func unitDuration(unit int16) time.Duration {
+	switch unit {
+	case unitMillis:
+		return time.Millisecond
+	case unitNanos:
+		return time.Nanosecond
+	default:
+		return time.Microsecond
+	}
+}
+
+// decimalTypeInfo returns the type info and converter for a DECIMAL column, which may be stored as an INT32, an INT64
+// or the big endian two's complement bytes of the unscaled value.
+func decimalTypeInfo(el *schemaElement, lt *logicalType) (typeinfo.TypeInfo, toSqlValue, error) {
+	scale := lt.scale
+	conv := func(v interface{}) (interface{}, error) {
+		switch val := v.(type) {
+		case int32:
+			return decimal.New(int64(val), -scale), nil
+		case int64:
+			return decimal.New(val, -scale), nil
+		case []byte:
+			return decimal.NewFromBigInt(bigIntFromTwosComplement(val), -scale), nil
+		default:
+			return nil, fmt.Errorf("parquet column %s has an invalid decimal value", el.name)
+		}
+	}
+
+	if lt.precision <= 0 || lt.precision > 65 || lt.scale < 0 || lt.scale > 30 || lt.scale > lt.precision {
+		// wider than MySQL supports, so keep the digits as text
+		return typeinfo.StringDefaultType, func(v interface{}) (interface{}, error) {
+			d, err := conv(v)
+			if err != nil {
+				return nil, err
+			}
+			return d.(decimal.Decimal).String(), nil
+		}, nil
+	}
+
+	sqlType, err := sql.CreateDecimalType(uint8(lt.precision), uint8(lt.scale))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ti, err := typeinfo.FromSqlType(sqlType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ti, conv, nil
+}
+
+func bigIntFromTwosComplement(b []byte) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(len(b))*8))
+	}
+	return i
+}
+
+func twosComplementFromBigInt(i *big.Int) []byte {
+	if i.Sign() >= 0 {
+		b := i.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+
+	// the two's complement of a negative number n is 2^bits + n, using enough bits to keep the sign bit set
+	numBytes := (i.BitLen() + 8) / 8
+	b := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), uint(numBytes)*8), i).Bytes()
+	for len(b) < numBytes {
+		b = append([]byte{0xff}, b...)
+	}
+	return b
+}
+
+// elementForColumn returns the parquet column to which the values of a Dolt column are written, and a function
+// converting the column's values to parquet values. Types without an equivalent in parquet are written as strings,
+// formatted as they are for CSV exports.
+func elementForColumn(col schema.Column) (schemaElement, toParquetValue, error) {
+	el := schemaElement{
+		name:          col.Name,
+		hasType:       true,
+		repetition:    repetitionOptional,
+		hasRepetition: true,
+	}
+	if col.IsPartOfPK || !col.IsNullable() {
+		el.repetition = repetitionRequired
+	}
+
+	setConverted := func(converted int32) {
+		el.convertedType = converted
+		el.hasConverted = true
+	}
+
+	setInteger := func(bitWidth int8, isSigned bool) {
+		el.logicalType = &logicalType{kind: logicalInteger, bitWidth: bitWidth, isSigned: isSigned}
+		switch {
+		case isSigned && bitWidth == 8:
+			setConverted(convertedInt8)
+		case isSigned && bitWidth == 16:
+			setConverted(convertedInt16)
+		case isSigned && bitWidth == 32:
+			setConverted(convertedInt32)
+		case isSigned:
+			setConverted(convertedInt64)
+		case bitWidth == 8:
+			setConverted(convertedUint8)
+		case bitWidth == 16:
+			setConverted(convertedUint16)
+		case bitWidth == 32:
+			setConverted(convertedUint32)
+		default:
+			setConverted(convertedUint64)
+		}
+	}
+
+	formatted := func(v types.Value) (interface{}, error) {
+		str, err := col.TypeInfo.FormatValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(*str), nil
+	}
+
+	sqlType := col.TypeInfo.ToSqlType().Type()
+	switch col.TypeInfo.GetTypeIdentifier() {
+	case typeinfo.BoolTypeIdentifier:
+		el.typ = typeBoolean
+		return el, func(v types.Value) (interface{}, error) {
+			return bool(v.(types.Bool)), nil
+		}, nil
+
+	case typeinfo.IntTypeIdentifier, typeinfo.YearTypeIdentifier:
+		switch sqlType {
+		case sqltypes.Int8:
+			setInteger(8, true)
+		case sqltypes.Int16, sqltypes.Year:
+			setInteger(16, true)
+		case sqltypes.Int64:
+			el.typ = typeInt64
+			setInteger(64, true)
+			return el, func(v types.Value) (interface{}, error) {
+				return int64(v.(types.Int)), nil
+			}, nil
+		default:
+			setInteger(32, true)
+		}
+		el.typ = typeInt32
+		return el, func(v types.Value) (interface{}, error) {
+			return int32(v.(types.Int)), nil
+		}, nil
+
+	case typeinfo.UintTypeIdentifier, typeinfo.BitTypeIdentifier:
+		switch sqlType {
+		case sqltypes.Uint8:
+			setInteger(8, false)
+		case sqltypes.Uint16:
+			setInteger(16, false)
+		case sqltypes.Uint24, sqltypes.Uint32:
+			setInteger(32, false)
+		default:
+			el.typ = typeInt64
+			setInteger(64, false)
+			return el, func(v types.Value) (interface{}, error) {
+				return int64(v.(types.Uint)), nil
+			}, nil
+		}
+		el.typ = typeInt32
+		return el, func(v types.Value) (interface{}, error) {
+			return int32(uint32(v.(types.Uint))), nil
+		}, nil
+
+	case typeinfo.FloatTypeIdentifier:
+		if sqlType == sqltypes.Float32 {
+			el.typ = typeFloat
+			return el, func(v types.Value) (interface{}, error) {
+				return float32(v.(types.Float)), nil
+			}, nil
+		}
+		el.typ = typeDouble
+		return el, func(v types.Value) (interface{}, error) {
+			return float64(v.(types.Float)), nil
+		}, nil
+
+	case typeinfo.DecimalTypeIdentifier:
+		decType := col.TypeInfo.ToSqlType().(sql.DecimalType)
+		el.typ = typeByteArray
+		el.scale = int32(decType.Scale())
+		el.precision = int32(decType.Precision())
+		el.logicalType = &logicalType{kind: logicalDecimal, scale: el.scale, precision: el.precision}
+		setConverted(convertedDecimal)
+		return el, func(v types.Value) (interface{}, error) {
+			unscaled := decimal.Decimal(v.(types.Decimal)).Shift(el.scale).BigInt()
+			return twosComplementFromBigInt(unscaled), nil
+		}, nil
+
+	case typeinfo.DatetimeTypeIdentifier:
+		if sqlType == sqltypes.Date {
+			el.typ = typeInt32
+			el.logicalType = &logicalType{kind: logicalDate}
+			setConverted(convertedDate)
+			return el, func(v types.Value) (interface{}, error) {
+				sec := time.Time(v.(types.Timestamp)).Unix()
+				days := sec / (24 * 60 * 60)
+				if sec < 0 && sec%(24*60*60) != 0 {
+					days--
+				}
+				return int32(days), nil
+			}, nil
+		}
+		el.typ = typeInt64
+		el.logicalType = &logicalType{kind: logicalTimestamp, unit: unitMicros, isAdjustedToUTC: true}
+		setConverted(convertedTimestampMicros)
+		return el, func(v types.Value) (interface{}, error) {
+			t := time.Time(v.(types.Timestamp))
+			return t.Unix()*1000000 + int64(t.Nanosecond()/1000), nil
+		}, nil
+
+	case typeinfo.UuidTypeIdentifier:
+		el.typ = typeFixedLenByteArray
+		el.typeLength = 16
+		el.logicalType = &logicalType{kind: logicalUUID}
+		return el, func(v types.Value) (interface{}, error) {
+			id := v.(types.UUID)
+			return id[:], nil
+		}, nil
+
+	case typeinfo.VarBinaryTypeIdentifier, typeinfo.InlineBlobTypeIdentifier:
+		el.typ = typeByteArray
+		return el, formatted, nil
+
+	case typeinfo.JSONTypeIdentifier:
+		el.typ = typeByteArray
+		el.logicalType = &logicalType{kind: logicalJSON}
+		setConverted(convertedJSON)
+		return el, formatted, nil
+
+	case typeinfo.EnumTypeIdentifier:
+		el.typ = typeByteArray
+		el.logicalType = &logicalType{kind: logicalEnum}
+		setConverted(convertedEnum)
+		return el, formatted, nil
+
+	case typeinfo.VarStringTypeIdentifier,
+		typeinfo.BlobStringTypeIdentifier,
+		typeinfo.SetTypeIdentifier,
+		typeinfo.TimeTypeIdentifier,
+		typeinfo.GeometryTypeIdentifier,
+		typeinfo.TupleTypeIdentifier:
+		el.typ = typeByteArray
+		el.logicalType = &logicalType{kind: logicalString}
+		setConverted(convertedUTF8)
+		return el, formatted, nil
+
+	default:
+		return schemaElement{}, nil, fmt.Errorf("column %s has type %s, which cannot be written to a parquet file", col.Name, col.TypeInfo.String())
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var WriteBufSize = 256 * 1024
+
+// RowGroupSize is the approximate number of bytes of encoded values buffered before they are written as a row group.
+var RowGroupSize = 64 * 1024 * 1024
+
+// PageSize is the approximate number of bytes of encoded values of a single column written in each page.
+var PageSize = 1024 * 1024
+
+const createdBy = "dolt"
+
+// writerColumn buffers the values written to a column of the current row group.
+type writerColumn struct {
+	el        schemaElement
+	tag       uint64
+	toParquet toParquetValue
+
+	// completed pages of the current row group
+	pages             bytes.Buffer
+	uncompressedBytes int64
+	numValues         int64
+
+	// the page being written
+	defLevels []uint64
+	values    bytes.Buffer
+	bools     []bool
+}
+
+func (wc *writerColumn) bufferedBytes() int {
+	return wc.values.Len() + len(wc.bools)/8
+}
+
+// finishPage encodes, compresses and appends the page being written to the column's completed pages.
+func (wc *writerColumn) finishPage() {
+	numValues := len(wc.defLevels)
+	if numValues == 0 {
+		return
+	}
+
+	var body []byte
+	if wc.el.repetition == repetitionOptional {
+		body = encodeLengthPrefixedRLE(wc.defLevels, 1)
+	}
+
+	if wc.el.typ == typeBoolean {
+		body = append(body, encodePlainBooleans(wc.bools)...)
+	} else {
+		body = append(body, wc.values.Bytes()...)
+	}
+
+	compressed := compress(codecSnappy, body)
+	header := writePageHeader(&pageHeader{
+		typ:                  pageData,
+		uncompressedPageSize: int32(len(body)),
+		compressedPageSize:   int32(len(compressed)),
+		dataPageHeader: &dataPageHeader{
+			numValues:               int32(numValues),
+			encoding:                encodingPlain,
+			definitionLevelEncoding: encodingRLE,
+			repetitionLevelEncoding: encodingRLE,
+		},
+	})
+
+	wc.pages.Write(header)
+	wc.pages.Write(compressed)
+	wc.uncompressedBytes += int64(len(header) + len(body))
+	wc.numValues += int64(numValues)
+
+	wc.defLevels = wc.defLevels[:0]
+	wc.values.Reset()
+	wc.bools = wc.bools[:0]
+}
+
+// ParquetWriter writes rows to a parquet file. Values are written with the PLAIN encoding and compressed with snappy.
+type ParquetWriter struct {
+	closer io.Closer
+	bWr    *bufio.Writer
+	offset int64
+	sch    schema.Schema
+	cols   []*writerColumn
+
+	rowGroups    []rowGroup
+	numRows      int64
+	bufferedRows int64
+}
+
+// OpenParquetWriter creates a parquet file at the path given, to which rows of the schema given are written.
+func OpenParquetWriter(path string, fs filesys.WritableFS, outSch schema.Schema) (*ParquetWriter, error) {
+	err := fs.MkDirs(filepath.Dir(path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := fs.OpenForWrite(path, os.ModePerm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pw, err := NewParquetWriter(wr, outSch)
+
+	if err != nil {
+		wr.Close()
+		return nil, err
+	}
+
+	return pw, nil
+}
+
+// NewParquetWriter returns a writer of parquet files to wr, to which rows of the schema given are written. The file
+// is written as rows are, but is not complete until the writer is closed.
+func NewParquetWriter(wr io.WriteCloser, outSch schema.Schema) (*ParquetWriter, error) {
+	var cols []*writerColumn
+	err := outSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		el, toParquet, err := elementForColumn(col)
+		if err != nil {
+			return true, err
+		}
+
+		cols = append(cols, &writerColumn{el: el, tag: tag, toParquet: toParquet})
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cols) == 0 {
+		return nil, errors.New("cannot write a parquet file without columns")
+	}
+
+	bwr := bufio.NewWriterSize(wr, WriteBufSize)
+	err = iohelp.WriteAll(bwr, []byte(magic))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParquetWriter{closer: wr, bWr: bwr, offset: int64(len(magic)), sch: outSch, cols: cols}, nil
+}
+
+// GetSchema gets the schema of the rows that this writer writes
+func (pw *ParquetWriter) GetSchema() schema.Schema {
+	return pw.sch
+}
+
+// WriteRow will write a row to a table
+func (pw *ParquetWriter) WriteRow(ctx context.Context, r row.Row) error {
+	bufferedBytes := 0
+	for _, wc := range pw.cols {
+		val, ok := r.GetColVal(wc.tag)
+		if !ok || types.IsNull(val) {
+			if wc.el.repetition == repetitionRequired {
+				return fmt.Errorf("column %s does not allow null values", wc.el.name)
+			}
+			wc.defLevels = append(wc.defLevels, 0)
+		} else {
+			pv, err := wc.toParquet(val)
+			if err != nil {
+				return err
+			}
+
+			wc.defLevels = append(wc.defLevels, 1)
+			if wc.el.typ == typeBoolean {
+				wc.bools = append(wc.bools, pv.(bool))
+			} else {
+				appendPlain(&wc.values, wc.el.typ, pv)
+			}
+		}
+
+		if wc.bufferedBytes() >= PageSize {
+			wc.finishPage()
+		}
+
+		bufferedBytes += wc.pages.Len() + wc.bufferedBytes()
+	}
+
+	pw.bufferedRows++
+
+	if bufferedBytes >= RowGroupSize {
+		return pw.flushRowGroup()
+	}
+
+	return nil
+}
+
+// flushRowGroup writes the buffered values of each column as a row group.
+func (pw *ParquetWriter) flushRowGroup() error {
+	if pw.bufferedRows == 0 {
+		return nil
+	}
+
+	rg := rowGroup{numRows: pw.bufferedRows}
+	for _, wc := range pw.cols {
+		wc.finishPage()
+
+		md := &columnMetaData{
+			typ:                   wc.el.typ,
+			encodings:             []int32{encodingPlain, encodingRLE},
+			pathInSchema:          []string{wc.el.name},
+			codec:                 codecSnappy,
+			numValues:             wc.numValues,
+			totalUncompressedSize: wc.uncompressedBytes,
+			totalCompressedSize:   int64(wc.pages.Len()),
+			dataPageOffset:        pw.offset,
+		}
+
+		err := iohelp.WriteAll(pw.bWr, wc.pages.Bytes())
+		if err != nil {
+			return err
+		}
+
+		rg.columns = append(rg.columns, columnChunk{fileOffset: pw.offset, metaData: md})
+		rg.totalByteSize += wc.uncompressedBytes
+		pw.offset += int64(wc.pages.Len())
+
+		wc.pages.Reset()
+		wc.uncompressedBytes = 0
+		wc.numValues = 0
+	}
+
+	pw.rowGroups = append(pw.rowGroups, rg)
+	pw.numRows += pw.bufferedRows
+	pw.bufferedRows = 0
+
+	return nil
+}
+
+// Close should flush all writes, release resources being held
+func (pw *ParquetWriter) Close(ctx context.Context) error {
+	if pw.closer == nil {
+		return errors.New("already closed")
+	}
+
+	err := pw.flushRowGroup()
+	if err == nil {
+		err = pw.writeFooter()
+	}
+	if err == nil {
+		err = pw.bWr.Flush()
+	}
+
+	errCl := pw.closer.Close()
+	pw.closer = nil
+
+	if err != nil {
+		return err
+	}
+
+	return errCl
+}
+
+func (pw *ParquetWriter) writeFooter() error {
+	md := &fileMetaData{
+		version:   1,
+		numRows:   pw.numRows,
+		rowGroups: pw.rowGroups,
+		createdBy: createdBy,
+	}
+
+	md.schema = append(md.schema, schemaElement{name: "schema", numChildren: int32(len(pw.cols)), hasNumChildren: true})
+	for _, wc := range pw.cols {
+		md.schema = append(md.schema, wc.el)
+	}
+
+	footer := writeFileMetaData(md)
+	var footerLen [4]byte
+	binary.LittleEndian.PutUint32(footerLen[:], uint32(len(footer)))
+
+	for _, data := range [][]byte{footer, footerLen[:], []byte(magic)} {
+		err := iohelp.WriteAll(pw.bWr, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
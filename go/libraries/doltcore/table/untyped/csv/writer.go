@@ -27,6 +27,7 @@ import (
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -116,6 +117,13 @@ func (csvw *CSVWriter) WriteRow(ctx context.Context, r row.Row) error {
 		var v string
 		if val.Kind() == types.StringKind {
 			v = string(val.(types.String))
+		} else if col, _ := allCols.GetByTag(tag); val.Kind() == types.BlobKind || col.TypeInfo.GetTypeIdentifier() == typeinfo.GeometryTypeIdentifier {
+			// blobs and spatial values are written in their text form rather than as encoded noms values
+			str, err := col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return false, err
+			}
+			v = *str
 		} else {
 			v, err = types.EncodedValue(ctx, val)
 			if err != nil {
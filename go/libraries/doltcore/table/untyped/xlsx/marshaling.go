@@ -15,6 +15,7 @@
 package xlsx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -73,7 +74,8 @@ func decodeXLSXRows(nbf *types.NomsBinFormat, xlData [][][]string, sch schema.Sc
 					return nil, errors.New(v + "is not a valid column")
 				}
 				valString := dataVals[i+1][k]
-				taggedVals[col.Tag], err = col.TypeInfo.ParseValue(&valString)
+				// the columns of an untyped schema are never stored out of row, so no ValueReadWriter is needed
+				taggedVals[col.Tag], err = col.TypeInfo.ParseValue(context.Background(), nil, &valString)
 				if err != nil {
 					return nil, err
 				}
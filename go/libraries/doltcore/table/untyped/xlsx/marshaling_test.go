@@ -15,6 +15,7 @@
 package xlsx
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -44,13 +45,13 @@ func TestDecodeXLSXRows(t *testing.T) {
 
 	taggedVals := make(row.TaggedValues, sch.GetAllCols().Size())
 	str := "1"
-	taggedVals[uint64(0)], _ = typeinfo.StringDefaultType.ParseValue(&str)
+	taggedVals[uint64(0)], _ = typeinfo.StringDefaultType.ParseValue(context.Background(), nil, &str)
 	str = "osheiza"
-	taggedVals[uint64(1)], _ = typeinfo.StringDefaultType.ParseValue(&str)
+	taggedVals[uint64(1)], _ = typeinfo.StringDefaultType.ParseValue(context.Background(), nil, &str)
 	str = "otori"
-	taggedVals[uint64(2)], _ = typeinfo.StringDefaultType.ParseValue(&str)
+	taggedVals[uint64(2)], _ = typeinfo.StringDefaultType.ParseValue(context.Background(), nil, &str)
 	str = "24"
-	taggedVals[uint64(3)], _ = typeinfo.StringDefaultType.ParseValue(&str)
+	taggedVals[uint64(3)], _ = typeinfo.StringDefaultType.ParseValue(context.Background(), nil, &str)
 
 	newRow, err := row.New(types.Format_7_18, sch, taggedVals)
 
@@ -25,6 +25,9 @@ import (
 var forceOpt = &Option{"force", "f", "", OptionalFlag, "force desc", nil}
 var messageOpt = &Option{"message", "m", "msg", OptionalValue, "msg desc", nil}
 var fileTypeOpt = &Option{"file-type", "", "", OptionalValue, "file type", nil}
+var numberOpt = &Option{"number", "n", "num", OptionalValue, "number desc", nil}
+var noMergesOpt = &Option{"no-merges", "", "", OptionalFlag, "no-merges desc", nil}
+var renamesOpt = &Option{"find-renames", "M", "n", OptionalFlagOrValue, "find-renames desc", nil}
 
 func TestParsing(t *testing.T) {
 	tests := []struct {
@@ -178,12 +181,52 @@ func TestParsing(t *testing.T) {
 			args:        []string{"-v"},
 			expectedErr: "error: unknown option `v'",
 		},
+		{
+			name:         "flag prefixed with value option abbrev",
+			options:      []*Option{numberOpt, noMergesOpt},
+			args:         []string{"--no-merges", "-n", "2"},
+			expectedOpts: map[string]string{"no-merges": "", "number": "2"},
+			expectedArgs: []string{},
+		},
 		{
 			name:        "duplicate arg",
 			options:     []*Option{forceOpt, messageOpt},
 			args:        []string{"-f", "-f"},
 			expectedErr: "error: multiple values provided for `force'",
 		},
+		{
+			name:         "flag or value given as flag",
+			options:      []*Option{renamesOpt, messageOpt},
+			args:         []string{"--find-renames", "a"},
+			expectedOpts: map[string]string{"find-renames": ""},
+			expectedArgs: []string{"a"},
+		},
+		{
+			name:         "flag or value given with value",
+			options:      []*Option{renamesOpt, messageOpt},
+			args:         []string{"a", "-M=60%", "-m", "b"},
+			expectedOpts: map[string]string{"find-renames": "60%", "message": "b"},
+			expectedArgs: []string{"a"},
+		},
+		{
+			name:         "flag or value given with value attached to abbrev",
+			options:      []*Option{renamesOpt, messageOpt},
+			args:         []string{"-M40", "-m", "b"},
+			expectedOpts: map[string]string{"find-renames": "40", "message": "b"},
+			expectedArgs: []string{},
+		},
+		{
+			name:        "value attached to flag or value name",
+			options:     []*Option{renamesOpt},
+			args:        []string{"--find-renames40"},
+			expectedErr: "error: unknown option `find-renames40'",
+		},
+		{
+			name:        "flag or value given twice",
+			options:     []*Option{renamesOpt},
+			args:        []string{"--find-renames", "--find-renames=50"},
+			expectedErr: "error: multiple values provided for `find-renames'",
+		},
 	}
 
 	for _, test := range tests {
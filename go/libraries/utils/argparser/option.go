@@ -24,6 +24,10 @@ type OptionType int
 const (
 	OptionalFlag OptionType = iota
 	OptionalValue
+	// OptionalFlagOrValue is an option which may be given as a flag, or with a value attached with an '=', as in
+	// --name=value, or directly to its abbreviation, as in -a10. A value is never taken from the argument which follows
+	// the option.
+	OptionalFlagOrValue
 )
 
 type ValidationFunc func(string) error
@@ -56,7 +60,7 @@ type Option struct {
 	Abbrev string
 	// Brief description of the Option.
 	ValDesc string
-	// The type of this option, either a flag or a value.
+	// The type of this option, either a flag, a value, or a flag with an optional value.
 	OptType OptionType
 	// Longer help text for the option.
 	Desc string
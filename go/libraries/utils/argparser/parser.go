@@ -126,6 +126,16 @@ func (ap *ArgParser) SupportsInt(name, abbrev, valDesc, desc string) *ArgParser
 	return ap
 }
 
+// Adds support for a new argument which may be given as a flag, or with a value attached with an '=', as in
+// --name=value, or directly to its abbreviation, as in -a10. If it is given as a flag, its value is the empty string.
+// See SupportOpt for details on params.
+func (ap *ArgParser) SupportsFlagWithOptionalValue(name, abbrev, valDesc, desc string) *ArgParser {
+	opt := &Option{name, abbrev, valDesc, OptionalFlagOrValue, desc, nil}
+	ap.SupportOption(opt)
+
+	return ap
+}
+
 // modal options in order of descending string length
 func (ap *ArgParser) sortedModalOptions() []string {
 	smo := make([]string, 0, len(ap.Supported))
@@ -148,11 +158,11 @@ func (ap *ArgParser) matchModalOptions(arg string) (matches []*Option, rest stri
 	for kontinue {
 		kontinue = false
 
-		// stop if we see a value option
+		// stop if we see a value option, unless a longer modal option matches
 		for _, vo := range ap.sortedValueOptions() {
 			lv := len(vo)
 			isValOpt := len(rest) >= lv && rest[:lv] == vo
-			if isValOpt {
+			if isValOpt && !matchesLongerOption(candidateFlagNames, rest, lv) {
 				return matches, rest
 			}
 		}
@@ -192,6 +202,44 @@ func (ap *ArgParser) sortedValueOptions() []string {
 	return vos
 }
 
+// matchesLongerOption returns whether any of the option names given is longer than |l| and a prefix of |arg|
+func matchesLongerOption(optNames []string, arg string, l int) bool {
+	for _, on := range optNames {
+		lo := len(on)
+		if lo > l && len(arg) >= lo && arg[:lo] == on {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchFlagOrValueOption returns the option which may be given as a flag or with a value which matches the whole of
+// |arg|, and the value attached to it with an '=', which is empty if none was. If |arg| was given as a short option,
+// with a single '-', the value may also be attached directly to the option's abbreviation.
+func (ap *ArgParser) matchFlagOrValueOption(arg string, isShort bool) (match *Option, value string) {
+	name := arg
+	if idx := strings.IndexByte(arg, '='); idx != -1 {
+		name, value = arg[:idx], arg[idx+1:]
+	}
+
+	opt, ok := ap.NameOrAbbrevToOpt[name]
+	if ok && name != "" && opt.OptType == OptionalFlagOrValue {
+		return opt, value
+	}
+
+	if isShort {
+		for _, opt := range ap.Supported {
+			la := len(opt.Abbrev)
+			if opt.OptType == OptionalFlagOrValue && la > 0 && len(arg) > la && arg[:la] == opt.Abbrev {
+				return opt, arg[la:]
+			}
+		}
+	}
+
+	return nil, ""
+}
+
 func (ap *ArgParser) matchValueOption(arg string) (match *Option, value *string) {
 	for _, on := range ap.sortedValueOptions() {
 		lo := len(on)
@@ -225,12 +273,28 @@ func (ap *ArgParser) Parse(args []string) (*ArgParseResults, error) {
 			continue
 		}
 
+		isShort := !strings.HasPrefix(arg, "--")
 		arg = strings.TrimLeft(arg, "-")
 
 		if arg == helpFlag || arg == helpFlagAbbrev {
 			return nil, ErrHelp
 		}
 
+		if opt, value := ap.matchFlagOrValueOption(arg, isShort); opt != nil {
+			if _, exists := results[opt.Name]; exists {
+				return nil, errors.New("error: multiple values provided for `" + opt.Name + "'")
+			}
+
+			if opt.Validator != nil && value != "" {
+				if err := opt.Validator(value); err != nil {
+					return nil, err
+				}
+			}
+
+			results[opt.Name] = value
+			continue
+		}
+
 		modalOpts, rest := ap.matchModalOptions(arg)
 
 		for _, opt := range modalOpts {
@@ -53,6 +53,10 @@ func OpenCommitEditor(ed string, initialContents string) (string, error) {
 	fmt.Printf("Waiting for command to finish.\n")
 	err = cmd.Wait()
 
+	if err != nil {
+		return "", err
+	}
+
 	data, err := ioutil.ReadFile(filename)
 
 	if err != nil {
@@ -23,6 +23,7 @@ package chunks
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/liquidata-inc/dolt/go/store/hash"
@@ -86,3 +87,18 @@ type ChunkStore interface {
 	// undefined and probably crashy.
 	io.Closer
 }
+
+var ErrUnsupportedOperation = errors.New("operation not supported")
+
+// ChunkStoreGarbageCollector is a ChunkStore that supports garbage collection.
+type ChunkStoreGarbageCollector interface {
+	ChunkStore
+
+	// MarkAndSweepChunks expects |keepChunks| to receive the chunk hashes
+	// that should be kept in the chunk store. Once |keepChunks| is closed
+	// and MarkAndSweepChunks returns, the chunk store will only have the
+	// chunks sent on |keepChunks| and will have removed all other content
+	// from the ChunkStore. |last| must match the current root of the store,
+	// otherwise the collection fails without modifying the store.
+	MarkAndSweepChunks(ctx context.Context, last hash.Hash, keepChunks <-chan []hash.Hash) error
+}
@@ -54,6 +54,12 @@ func (ms *MemoryStorage) NewView() ChunkStore {
 	return &MemoryStoreView{storage: ms, rootHash: ms.rootHash, version: version}
 }
 
+// NewViewWithDefaultFormat vends a MemoryStoreView backed by this
+// MemoryStorage, which uses the default format rather than the storage's own.
+func (ms *MemoryStorage) NewViewWithDefaultFormat() ChunkStore {
+	return &MemoryStoreView{storage: ms, rootHash: ms.rootHash, version: constants.FormatDefaultString}
+}
+
 // Get retrieves the Chunk with the Hash h, returning EmptyChunk if it's not
 // present.
 func (ms *MemoryStorage) Get(ctx context.Context, h hash.Hash) (Chunk, error) {
@@ -32,13 +32,15 @@ import (
 )
 
 const (
-	ParentsField = "parents"
-	ValueField   = "value"
-	MetaField    = "meta"
-	commitName   = "Commit"
+	ParentsField     = "parents"
+	ParentsListField = "parents_list"
+	ValueField       = "value"
+	MetaField        = "meta"
+	commitName       = "Commit"
 )
 
 var commitTemplate = types.MakeStructTemplate(commitName, []string{MetaField, ParentsField, ValueField})
+var commitWithParentsListTemplate = types.MakeStructTemplate(commitName, []string{MetaField, ParentsField, ParentsListField, ValueField})
 
 var valueCommitType = nomdl.MustParseType(`Struct Commit {
         meta: Struct {},
@@ -62,6 +64,27 @@ func NewCommit(value types.Value, parents types.Set, meta types.Struct) (types.S
 	return commitTemplate.NewStruct(meta.Format(), []types.Value{meta, parents, value})
 }
 
+// NewCommitWithParentsList creates a new commit object which also records the
+// order of its parents in a parents_list field:
+//
+// ```
+// struct Commit {
+//   meta: M,
+//   parents: Set<Ref<Cycle<Commit>>>,
+//   parents_list: List<Ref<Cycle<Commit>>>,
+//   value: T,
+// }
+// ```
+// parentsList must hold the same refs as parents. If it is the zero value, the
+// commit is created without the field, as it is by NewCommit.
+func NewCommitWithParentsList(value types.Value, parents types.Set, parentsList types.List, meta types.Struct) (types.Struct, error) {
+	if (parentsList == types.List{}) {
+		return NewCommit(value, parents, meta)
+	}
+
+	return commitWithParentsListTemplate.NewStruct(meta.Format(), []types.Value{meta, parents, parentsList, value})
+}
+
 // FindCommonAncestor returns the most recent common ancestor of c1 and c2, if
 // one exists, setting ok to true. If there is no common ancestor, ok is set
 // to false.
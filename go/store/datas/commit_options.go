@@ -32,6 +32,12 @@ type CommitOptions struct {
 	// creating.
 	Parents types.Set
 
+	// ParentsList, if provided, holds the same parent commits as Parents in
+	// the order they were given, with the commit being built upon first. It
+	// is recorded in the commit so that parents can be numbered by the order
+	// in which they were merged rather than by their hashes.
+	ParentsList types.List
+
 	// Meta is a Struct that describes arbitrary metadata about this Commit,
 	// e.g. a timestamp or descriptive text.
 	Meta types.Struct
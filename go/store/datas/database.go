@@ -68,7 +68,8 @@ type Database interface {
 	// persistent after Commit() returns.
 	// The new Commit struct is constructed using v, opts.Parents, and
 	// opts.Meta. If opts.Parents is the zero value (types.Set{}) then
-	// the current head is used. If opts.Meta is the zero value
+	// the current head is used. If opts.ParentsList is given, the order of
+	// the parents is also recorded. If opts.Meta is the zero value
 	// (types.Struct{}) then a fully initialized empty Struct is passed to
 	// NewCommit.
 	// The returned Dataset is always the newest snapshot, regardless of
@@ -95,6 +96,16 @@ type Database interface {
 	// of a conflict, Commit returns an 'ErrMergeNeeded' error.
 	CommitValue(ctx context.Context, ds Dataset, v types.Value) (Dataset, error)
 
+	// Tag stores an immutable reference to a Commit. It takes a Ref and a
+	// Dataset whose head must be nil (ie a newly created Dataset). The new
+	// Tag struct is constructed with `ref` and metadata about the tag
+	// contained in the struct `opts.Meta`.
+	// The returned Dataset is always the newest snapshot, regardless of
+	// success or failure, and Datasets() is updated to match backing storage
+	// upon return as well. If the Dataset already has a head, Tag returns an
+	// 'ErrTagExists' error.
+	Tag(ctx context.Context, ds Dataset, ref types.Ref, opts TagOptions) (Dataset, error)
+
 	// Delete removes the Dataset named ds.ID() from the map at the root of
 	// the Database. The Dataset data is not necessarily cleaned up at this
 	// time, but may be garbage collected in the future.
@@ -138,6 +149,12 @@ type Database interface {
 
 	Flush(ctx context.Context) error
 
+	// GC traverses the database starting at the Root and removes all
+	// unreferenced data from persistent storage. Returns
+	// chunks.ErrUnsupportedOperation if the ChunkStore backing this
+	// Database does not support garbage collection.
+	GC(ctx context.Context) error
+
 	// chunkStore returns the ChunkStore used to read and write
 	// groups of values to the database efficiently. This interface is a low-
 	// level detail of the database that should infrequently be needed by
@@ -164,3 +181,31 @@ func GetCSStatSummaryForDB(db Database) string {
 	cs := db.chunkStore()
 	return cs.StatsSummary()
 }
+
+// GetCSSizeForDB returns the total size, in bytes, of the table files backing |db|. Returns
+// chunks.ErrUnsupportedOperation if the ChunkStore backing |db| is not a nbs.TableFileStore.
+func GetCSSizeForDB(ctx context.Context, db Database) (uint64, error) {
+	cs := db.chunkStore()
+	if tfs, ok := cs.(nbs.TableFileStore); ok {
+		return tfs.Size(ctx)
+	}
+	return 0, chunks.ErrUnsupportedOperation
+}
+
+// GetCSStatsForDB returns the statistics of the ChunkStore backing |db|. Their type depends on the ChunkStore, and
+// may be nil.
+func GetCSStatsForDB(db Database) interface{} {
+	cs := db.chunkStore()
+	return cs.Stats()
+}
+
+// GetCSTableFileCountForDB returns the number of table files backing |db|. Returns chunks.ErrUnsupportedOperation if
+// the ChunkStore backing |db| is not a nbs.TableFileStore.
+func GetCSTableFileCountForDB(ctx context.Context, db Database) (int, error) {
+	cs := db.chunkStore()
+	if tfs, ok := cs.(nbs.TableFileStore); ok {
+		_, tableFiles, err := tfs.Sources(ctx)
+		return len(tableFiles), err
+	}
+	return 0, chunks.ErrUnsupportedOperation
+}
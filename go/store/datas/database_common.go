@@ -257,7 +257,7 @@ func (db *database) CommitDangling(ctx context.Context, v types.Value, opts Comm
 	if opts.Meta.IsZeroValue() {
 		opts.Meta = types.EmptyStruct(db.Format())
 	}
-	commitStruct, err := NewCommit(v, opts.Parents, opts.Meta)
+	commitStruct, err := NewCommitWithParentsList(v, opts.Parents, opts.ParentsList, opts.Meta)
 
 	if err != nil {
 		return types.Struct{}, err
@@ -419,6 +419,80 @@ func (db *database) doCommit(ctx context.Context, datasetID string, commit types
 	return tryCommitErr
 }
 
+func (db *database) Tag(ctx context.Context, ds Dataset, ref types.Ref, opts TagOptions) (Dataset, error) {
+	return db.doHeadUpdate(
+		ctx,
+		ds,
+		func(ds Dataset) error {
+			if opts.Meta.IsZeroValue() {
+				opts.Meta = types.EmptyStruct(db.Format())
+			}
+
+			st, err := NewTag(ctx, ref, opts.Meta)
+
+			if err != nil {
+				return err
+			}
+
+			return db.doTag(ctx, ds.ID(), st)
+		},
+	)
+}
+
+// doTag manages concurrent access the single logical piece of mutable state: the current Root. It uses the same optimistic
+// locking write algorithm as doCommit (see above). Tags are immutable, so doTag returns an 'ErrTagExists' error if the
+// Dataset already has a head.
+func (db *database) doTag(ctx context.Context, datasetID string, tag types.Struct) error {
+	if is, err := IsTag(tag); err != nil {
+		return err
+	} else if !is {
+		d.Panic("Can't tag with a non-Tag struct to dataset %s", datasetID)
+	}
+
+	var tryCommitErr error
+	for tryCommitErr = ErrOptimisticLockFailed; tryCommitErr == ErrOptimisticLockFailed; {
+		currentRootHash, err := db.rt.Root(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		currentDatasets, err := db.Datasets(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if has, err := currentDatasets.Has(ctx, types.String(datasetID)); err != nil {
+			return err
+		} else if has {
+			return ErrTagExists
+		}
+
+		tagRef, err := db.WriteValue(ctx, tag) // will be orphaned if the tryCommitChunks() below fails
+
+		if err != nil {
+			return err
+		}
+
+		ref, err := types.ToRefOfValue(tagRef, db.Format())
+
+		if err != nil {
+			return err
+		}
+
+		currentDatasets, err = currentDatasets.Edit().Set(types.String(datasetID), ref).Map(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		tryCommitErr = db.tryCommitChunks(ctx, currentDatasets, currentRootHash)
+	}
+
+	return tryCommitErr
+}
+
 func (db *database) Delete(ctx context.Context, ds Dataset) (Dataset, error) {
 	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error { return db.doDelete(ctx, ds.ID()) })
 }
@@ -552,7 +626,7 @@ func buildNewCommit(ctx context.Context, ds Dataset, v types.Value, opts CommitO
 	if meta.IsZeroValue() {
 		meta = types.EmptyStruct(ds.Database().Format())
 	}
-	return NewCommit(v, parents, meta)
+	return NewCommitWithParentsList(v, parents, opts.ParentsList, meta)
 }
 
 func (db *database) doHeadUpdate(ctx context.Context, ds Dataset, updateFunc func(ds Dataset) error) (Dataset, error) {
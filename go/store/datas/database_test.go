@@ -704,3 +704,41 @@ func (suite *DatabaseSuite) TestMetaOption() {
 	c := mustHead(ds)
 	suite.Equal(types.String("arv"), mustGetValue(mustGetValue(c.MaybeGet("meta")).(types.Struct).MaybeGet("author")))
 }
+
+func (suite *DatabaseSuite) TestTag() {
+	ctx := context.Background()
+	ds, err := suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	ds, err = suite.db.CommitValue(ctx, ds, types.String("a"))
+	suite.NoError(err)
+	commitRef := mustHeadRef(ds)
+
+	m, err := types.NewStruct(types.Format_7_18, "M", types.StructData{
+		"tagger": types.String("arv"),
+	})
+	suite.NoError(err)
+
+	tagDS, err := suite.db.GetDataset(ctx, "refs/tags/v1")
+	suite.NoError(err)
+	tagDS, err = suite.db.Tag(ctx, tagDS, commitRef, TagOptions{Meta: m})
+	suite.NoError(err)
+
+	tag := mustHead(tagDS)
+	is, err := IsTag(tag)
+	suite.NoError(err)
+	suite.True(is)
+	suite.True(commitRef.Equals(mustGetValue(tag.MaybeGet(TagCommitRefField))))
+	suite.Equal(types.String("arv"), mustGetValue(mustGetValue(tag.MaybeGet(TagMetaField)).(types.Struct).MaybeGet("tagger")))
+
+	is, err = IsTag(mustHead(ds))
+	suite.NoError(err)
+	suite.False(is)
+
+	// tags are immutable
+	_, err = suite.db.Tag(ctx, tagDS, commitRef, TagOptions{})
+	suite.Equal(ErrTagExists, err)
+
+	tagDS, err = suite.db.GetDataset(ctx, "refs/tags/v1")
+	suite.NoError(err)
+	suite.True(tag.Equals(mustHead(tagDS)))
+}
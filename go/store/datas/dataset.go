@@ -36,7 +36,7 @@ var DatasetRe = regexp.MustCompile(`[a-zA-Z0-9\-_/]+`)
 // entirely legal Dataset name.
 var DatasetFullRe = regexp.MustCompile("^" + DatasetRe.String() + "$")
 
-// Dataset is a named Commit within a Database.
+// Dataset is a named Commit or Tag within a Database.
 type Dataset struct {
 	db   Database
 	id   string
@@ -44,18 +44,26 @@ type Dataset struct {
 }
 
 func newDataset(db Database, id string, head types.Value) (Dataset, error) {
-	headNilOrIsCommit := head == nil
-	if !headNilOrIsCommit {
+	headNilOrIsCommitOrTag := head == nil
+	if !headNilOrIsCommitOrTag {
 		var err error
-		headNilOrIsCommit, err = IsCommit(head)
+		headNilOrIsCommitOrTag, err = IsCommit(head)
 
 		if err != nil {
 			return Dataset{}, err
 		}
+
+		if !headNilOrIsCommitOrTag {
+			headNilOrIsCommitOrTag, err = IsTag(head)
+
+			if err != nil {
+				return Dataset{}, err
+			}
+		}
 	}
 
 	// precondition checks
-	d.PanicIfFalse(headNilOrIsCommit)
+	d.PanicIfFalse(headNilOrIsCommitOrTag)
 	return Dataset{db, id, head}, nil
 }
 
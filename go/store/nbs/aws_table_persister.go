@@ -35,6 +35,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	"github.com/liquidata-inc/dolt/go/store/atomicerr"
+	"github.com/liquidata-inc/dolt/go/store/chunks"
 	"github.com/liquidata-inc/dolt/go/store/util/verbose"
 )
 
@@ -326,6 +327,11 @@ func (s3p awsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 	return newReaderFromIndexData(s3p.indexCache, plan.mergedIndex, name, tra, s3BlockSize)
 }
 
+// PruneTableFiles is not supported for AWS backed stores.
+func (s3p awsTablePersister) PruneTableFiles(ctx context.Context, contents manifestContents) error {
+	return chunks.ErrUnsupportedOperation
+}
+
 func (s3p awsTablePersister) loadIntoCache(ctx context.Context, name addr) error {
 	input := &s3.GetObjectInput{
 		Bucket: aws.String(s3p.bucket),
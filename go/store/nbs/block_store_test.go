@@ -581,3 +581,63 @@ func (suite *BlockStoreSuite) TestChunkStoreGetNonExisting() {
 	suite.NoError(err)
 	suite.True(c.IsEmpty())
 }
+
+func (suite *BlockStoreSuite) TestChunkStoreMarkAndSweep() {
+	ctx := context.Background()
+	keep1, keep2, drop := chunks.NewChunk([]byte("abc")), chunks.NewChunk([]byte("def")), chunks.NewChunk([]byte("ghi"))
+	for _, c := range []chunks.Chunk{keep1, keep2, drop} {
+		err := suite.store.Put(ctx, c)
+		suite.NoError(err)
+	}
+
+	root, err := suite.store.Root(ctx)
+	suite.NoError(err)
+	success, err := suite.store.Commit(ctx, keep1.Hash(), root)
+	suite.NoError(err)
+	suite.True(success)
+
+	keepChunks := make(chan []hash.Hash, 1)
+	keepChunks <- []hash.Hash{keep1.Hash(), keep2.Hash()}
+	close(keepChunks)
+
+	err = suite.store.MarkAndSweepChunks(ctx, keep1.Hash(), keepChunks)
+	suite.NoError(err)
+
+	assertInputInStore([]byte("abc"), keep1.Hash(), suite.store, suite.Assert())
+	assertInputInStore([]byte("def"), keep2.Hash(), suite.store, suite.Assert())
+	ok, err := suite.store.Has(ctx, drop.Hash())
+	suite.NoError(err)
+	suite.False(ok)
+
+	root, err = suite.store.Root(ctx)
+	suite.NoError(err)
+	suite.Equal(keep1.Hash(), root)
+
+	// the swept chunks must also be gone from disk
+	reopened, err := NewLocalStore(ctx, constants.FormatDefaultString, suite.dir, testMemTableSize)
+	suite.NoError(err)
+	ok, err = reopened.Has(ctx, drop.Hash())
+	suite.NoError(err)
+	suite.False(ok)
+	assertInputInStore([]byte("def"), keep2.Hash(), reopened, suite.Assert())
+}
+
+func (suite *BlockStoreSuite) TestChunkStoreMarkAndSweepRootMismatch() {
+	ctx := context.Background()
+	c := chunks.NewChunk([]byte("abc"))
+	err := suite.store.Put(ctx, c)
+	suite.NoError(err)
+
+	root, err := suite.store.Root(ctx)
+	suite.NoError(err)
+	success, err := suite.store.Commit(ctx, c.Hash(), root)
+	suite.NoError(err)
+	suite.True(success)
+
+	keepChunks := make(chan []hash.Hash)
+	close(keepChunks)
+
+	err = suite.store.MarkAndSweepChunks(ctx, hash.Parse("11111111111111111111111111111111"), keepChunks)
+	suite.Error(err)
+	assertInputInStore([]byte("abc"), c.Hash(), suite.store, suite.Assert())
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/liquidata-inc/dolt/go/store/blobstore"
+	"github.com/liquidata-inc/dolt/go/store/chunks"
 )
 
 type blobstorePersister struct {
@@ -63,6 +64,12 @@ func (bsp *blobstorePersister) Open(ctx context.Context, name addr, chunkCount u
 	return newBSChunkSource(ctx, bsp.bs, name, chunkCount, bsp.blockSize, bsp.indexCache, stats)
 }
 
+// PruneTableFiles (Not currently implemented) deletes old table files that are no longer referenced
+// in the manifest.
+func (bsp *blobstorePersister) PruneTableFiles(ctx context.Context, contents manifestContents) error {
+	return chunks.ErrUnsupportedOperation
+}
+
 type bsTableReaderAt struct {
 	key string
 	bs  blobstore.Blobstore
@@ -26,8 +26,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/liquidata-inc/dolt/go/store/util/tempfiles"
 
@@ -208,3 +210,53 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 
 	return ftp.Open(ctx, name, plan.chunkCount, stats)
 }
+
+// PruneTableFiles deletes the table files in |ftp.dir| which are not referenced by |contents|.
+func (ftp *fsTablePersister) PruneTableFiles(ctx context.Context, contents manifestContents) error {
+	ss := contents.getSpecSet()
+
+	fileInfos, err := ioutil.ReadDir(ftp.dir)
+
+	if err != nil {
+		return err
+	}
+
+	err = ftp.fc.ShrinkCache()
+
+	if err != nil {
+		return err
+	}
+
+	for _, info := range fileInfos {
+		if info.IsDir() {
+			continue
+		}
+
+		if strings.HasPrefix(info.Name(), tempTablePrefix) {
+			// temp files may belong to another process that is still writing them
+			continue
+		}
+
+		if len(info.Name()) != 32 {
+			continue // not a table file
+		}
+
+		a, err := parseAddr([]byte(info.Name()))
+
+		if err != nil {
+			continue // not a table file
+		}
+
+		if _, ok := ss[a]; ok {
+			continue // file is referenced in the manifest
+		}
+
+		err = os.Remove(filepath.Join(ftp.dir, info.Name()))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"errors"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/liquidata-inc/dolt/go/store/atomicerr"
 
@@ -140,31 +141,39 @@ func (mt *memTable) get(ctx context.Context, h addr, stats *Stats) ([]byte, erro
 
 func (mt *memTable) getMany(ctx context.Context, reqs []getRecord, foundChunks chan<- *chunks.Chunk, wg *sync.WaitGroup, ae *atomicerr.AtomicError, stats *Stats) bool {
 	var remaining bool
+	var hits uint64
 	for _, r := range reqs {
 		data := mt.chunks[*r.a]
 		if data != nil {
 			c := chunks.NewChunkWithHash(hash.Hash(*r.a), data)
 			foundChunks <- &c
+			hits++
 		} else {
 			remaining = true
 		}
 	}
 
+	atomic.AddUint64(&stats.MemTableHits, hits)
+	atomic.AddUint64(&stats.MemTableMisses, uint64(len(reqs))-hits)
 	return remaining
 }
 
 func (mt *memTable) getManyCompressed(ctx context.Context, reqs []getRecord, foundCmpChunks chan<- CompressedChunk, wg *sync.WaitGroup, ae *atomicerr.AtomicError, stats *Stats) bool {
 	var remaining bool
+	var hits uint64
 	for _, r := range reqs {
 		data := mt.chunks[*r.a]
 		if data != nil {
 			c := chunks.NewChunkWithHash(hash.Hash(*r.a), data)
 			foundCmpChunks <- ChunkToCompressedChunk(c)
+			hits++
 		} else {
 			remaining = true
 		}
 	}
 
+	atomic.AddUint64(&stats.MemTableHits, hits)
+	atomic.AddUint64(&stats.MemTableMisses, uint64(len(reqs))-hits)
 	return remaining
 }
 
@@ -72,3 +72,8 @@ func (nbsMW *NBSMetricWrapper) GetManyCompressed(ctx context.Context, hashes has
 	atomic.AddInt32(&nbsMW.TotalChunkGets, int32(len(hashes)))
 	return nbsMW.nbs.GetManyCompressed(ctx, hashes, cmpChChan)
 }
+
+// MarkAndSweepChunks forwards garbage collection to the wrapped block store.
+func (nbsMW *NBSMetricWrapper) MarkAndSweepChunks(ctx context.Context, last hash.Hash, keepChunks <-chan []hash.Hash) error {
+	return nbsMW.nbs.MarkAndSweepChunks(ctx, last, keepChunks)
+}
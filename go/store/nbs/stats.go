@@ -23,6 +23,7 @@ package nbs
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/liquidata-inc/dolt/go/store/metrics"
 )
@@ -68,6 +69,11 @@ type Stats struct {
 
 	ReadManifestLatency  metrics.Histogram
 	WriteManifestLatency metrics.Histogram
+
+	// MemTableHits and MemTableMisses count the chunks which reads found, and did not find, in the memtable of chunks
+	// not yet persisted to table files. They are updated atomically.
+	MemTableHits   uint64
+	MemTableMisses uint64
 }
 
 func NewStats() *Stats {
@@ -128,6 +134,8 @@ ChunksPerConjoin:                 %s
 TablesPerConjoin:                 %s
 ReadManifestLatency:              %s
 WriteManifestLatency:             %s
+MemTableHits:                     %d
+MemTableMisses:                   %d
 `,
 		s.OpenLatency,
 		s.CommitLatency,
@@ -167,5 +175,8 @@ WriteManifestLatency:             %s
 		s.ChunksPerConjoin,
 		s.TablesPerConjoin,
 		s.ReadManifestLatency,
-		s.WriteManifestLatency)
+		s.WriteManifestLatency,
+
+		atomic.LoadUint64(&s.MemTableHits),
+		atomic.LoadUint64(&s.MemTableMisses))
 }
@@ -31,6 +31,7 @@ import (
 	"reflect"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -399,9 +400,11 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 	}
 
 	if data != nil {
+		atomic.AddUint64(&nbs.stats.MemTableHits, 1)
 		return chunks.NewChunkWithHash(h, data), nil
 	}
 
+	atomic.AddUint64(&nbs.stats.MemTableMisses, 1)
 	data, err = tables.get(ctx, a, nbs.stats)
 
 	if err != nil {
@@ -452,6 +455,8 @@ func (nbs *NomsBlockStore) getManyWithFunc(
 		remaining = true
 		if nbs.mt != nil {
 			remaining = getManyFunc(ctx, nbs.mt, reqs, nil, ae, nbs.stats)
+		} else {
+			atomic.AddUint64(&nbs.stats.MemTableMisses, uint64(len(reqs)))
 		}
 
 		return
@@ -853,7 +858,10 @@ func (nbs *NomsBlockStore) Close() (err error) {
 }
 
 func (nbs *NomsBlockStore) Stats() interface{} {
-	return *nbs.stats
+	stats := *nbs.stats
+	stats.MemTableHits = atomic.LoadUint64(&nbs.stats.MemTableHits)
+	stats.MemTableMisses = atomic.LoadUint64(&nbs.stats.MemTableMisses)
+	return stats
 }
 
 func (nbs *NomsBlockStore) StatsSummary() string {
@@ -991,10 +999,12 @@ func (nbs *NomsBlockStore) chunkSourcesByAddr() (map[addr]chunkSource, error) {
 }
 
 func (nbs *NomsBlockStore) SupportedOperations() TableFileStoreOps {
-	_, canwrite := nbs.p.(*fsTablePersister)
+	_, fsPersister := nbs.p.(*fsTablePersister)
 	return TableFileStoreOps{
 		CanRead:  true,
-		CanWrite: canwrite,
+		CanWrite: fsPersister,
+		CanPrune: fsPersister,
+		CanGC:    fsPersister,
 	}
 }
 
@@ -1061,3 +1071,180 @@ func (nbs *NomsBlockStore) SetRootChunk(ctx context.Context, root, previous hash
 		// I guess this thing infinitely retries without backoff in the case off errOptimisticLockFailedTables
 	}
 }
+
+// MarkAndSweepChunks copies every chunk received on |keepChunks| into new table files, swaps the manifest over to
+// those table files and deletes the table files which are no longer referenced. |last| must match the current root of
+// the store.
+func (nbs *NomsBlockStore) MarkAndSweepChunks(ctx context.Context, last hash.Hash, keepChunks <-chan []hash.Hash) error {
+	ops := nbs.SupportedOperations()
+	if !ops.CanGC || !ops.CanPrune {
+		return chunks.ErrUnsupportedOperation
+	}
+
+	precheck := func() error {
+		nbs.mu.RLock()
+		defer nbs.mu.RUnlock()
+
+		if nbs.upstream.root != last {
+			return errLastRootMismatch
+		}
+
+		if nbs.mt != nil || nbs.tables.Novel() > 0 {
+			return errors.New("cannot garbage collect a chunk store with uncommitted chunks")
+		}
+
+		return nil
+	}
+
+	err := precheck()
+
+	if err != nil {
+		return err
+	}
+
+	specs, err := nbs.copyMarkedChunks(ctx, keepChunks)
+
+	if err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return nbs.swapTables(ctx, last, specs)
+}
+
+func (nbs *NomsBlockStore) copyMarkedChunks(ctx context.Context, keepChunks <-chan []hash.Hash) ([]tableSpec, error) {
+	var specs []tableSpec
+	mt := newMemTable(nbs.mtSize)
+
+	persist := func() error {
+		cnt, err := mt.count()
+
+		if err != nil || cnt == 0 {
+			return err
+		}
+
+		cs, err := nbs.p.Persist(ctx, mt, nil, nbs.stats)
+
+		if err != nil {
+			return err
+		}
+
+		h, err := cs.hash()
+
+		if err != nil {
+			return err
+		}
+
+		cnt, err = cs.count()
+
+		if err != nil {
+			return err
+		}
+
+		specs = append(specs, tableSpec{h, cnt})
+		mt = newMemTable(nbs.mtSize)
+
+		return nil
+	}
+
+	for {
+		var hs []hash.Hash
+		var ok bool
+
+		select {
+		case hs, ok = <-keepChunks:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if !ok {
+			break
+		}
+
+		hashes := hash.NewHashSet(hs...)
+		found := make(chan *chunks.Chunk, len(hashes))
+		err := nbs.GetMany(ctx, hashes, found)
+		close(found)
+
+		if err != nil {
+			return nil, err
+		}
+
+		copied := 0
+		for c := range found {
+			copied++
+			if !mt.addChunk(addr(c.Hash()), c.Data()) {
+				err = persist()
+
+				if err != nil {
+					return nil, err
+				}
+
+				if !mt.addChunk(addr(c.Hash()), c.Data()) {
+					return nil, errors.New("chunk too large to fit in a table file")
+				}
+			}
+		}
+
+		if copied != len(hashes) {
+			return nil, fmt.Errorf("failed to find %d reachable chunks during garbage collection", len(hashes)-copied)
+		}
+	}
+
+	err := persist()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+func (nbs *NomsBlockStore) swapTables(ctx context.Context, last hash.Hash, specs []tableSpec) (err error) {
+	nbs.mm.LockForUpdate()
+	defer func() {
+		unlockErr := nbs.mm.UnlockForUpdate()
+
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	if nbs.upstream.root != last {
+		return errLastRootMismatch
+	}
+
+	newContents := manifestContents{
+		vers:  nbs.upstream.vers,
+		root:  nbs.upstream.root,
+		lock:  generateLockHash(nbs.upstream.root, specs),
+		specs: specs,
+	}
+
+	upstream, err := nbs.mm.Update(ctx, nbs.upstream.lock, newContents, nbs.stats, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if upstream.lock != newContents.lock {
+		return errors.New("concurrent manifest edit during GC, before swapTables. GC failed.")
+	}
+
+	newTables, err := nbs.tables.Rebase(ctx, upstream.specs, nbs.stats)
+
+	if err != nil {
+		return err
+	}
+
+	nbs.upstream = upstream
+	nbs.tables = newTables
+
+	return nbs.p.PruneTableFiles(ctx, upstream)
+}
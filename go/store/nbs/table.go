@@ -286,6 +286,10 @@ type TableFileStoreOps struct {
 	CanRead bool
 	// True is the TableFileStore supports writing table files.
 	CanWrite bool
+	// True is the TableFileStore supports pruning unused table files.
+	CanPrune bool
+	// True is the TableFileStore supports garbage collecting chunks.
+	CanGC bool
 }
 
 // TableFileStore is an interface for interacting with table files directly
@@ -50,6 +50,10 @@ type tablePersister interface {
 
 	// Open a table named |name|, containing |chunkCount| chunks.
 	Open(ctx context.Context, name addr, chunkCount uint32, stats *Stats) (chunkSource, error)
+
+	// PruneTableFiles deletes old table files that are no longer referenced
+	// in the manifest.
+	PruneTableFiles(ctx context.Context, contents manifestContents) error
 }
 
 // indexCache provides sized storage for table indices. While getting and/or
@@ -102,6 +102,13 @@ func newTestValueStore() *ValueStore {
 	return NewValueStore(ts.NewView())
 }
 
+// NewMemoryValueStore creates a ValueStore using the default format, backed by an in-memory chunk store, for values
+// which never need to be persisted.
+func NewMemoryValueStore() *ValueStore {
+	ms := &chunks.MemoryStorage{}
+	return NewValueStore(ms.NewViewWithDefaultFormat())
+}
+
 // NewValueStore returns a ValueStore instance that owns the provided
 // ChunkStore and manages its lifetime. Calling Close on the returned
 // ValueStore will Close() cs.
@@ -550,6 +557,125 @@ func (lvs *ValueStore) Commit(ctx context.Context, current, last hash.Hash) (boo
 	}()
 }
 
+const gcBatchSize = 1 << 14
+
+// GC traverses the ValueStore from the root and removes unreferenced chunks from the ChunkStore. All values written
+// to the ValueStore must have been committed before calling GC.
+func (lvs *ValueStore) GC(ctx context.Context) error {
+	collector, ok := lvs.cs.(chunks.ChunkStoreGarbageCollector)
+
+	if !ok {
+		return chunks.ErrUnsupportedOperation
+	}
+
+	hasPending := func() bool {
+		lvs.bufferMu.RLock()
+		defer lvs.bufferMu.RUnlock()
+		return len(lvs.bufferedChunks) > 0
+	}()
+
+	if hasPending {
+		return errors.New("cannot garbage collect a ValueStore with uncommitted values")
+	}
+
+	root, err := lvs.Root(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if root.IsEmpty() {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keepChunks := make(chan []hash.Hash, 16)
+
+	ae := atomicerr.New()
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := collector.MarkAndSweepChunks(ctx, root, keepChunks)
+
+		if err != nil {
+			ae.SetIfError(err)
+			cancel()
+		}
+	}()
+
+	err = lvs.gcProcessRefs(ctx, hash.NewHashSet(root), keepChunks)
+	close(keepChunks)
+	wg.Wait()
+
+	if err := ae.Get(); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// gcProcessRefs walks the chunk graph breadth first starting at |toVisit|, sending every reachable chunk hash to
+// |keepChunks|.
+func (lvs *ValueStore) gcProcessRefs(ctx context.Context, toVisit hash.HashSet, keepChunks chan<- []hash.Hash) error {
+	visited := hash.NewHashSet()
+
+	for len(toVisit) > 0 {
+		batches := make([][]hash.Hash, 0, len(toVisit)/gcBatchSize+1)
+		batch := make([]hash.Hash, 0, gcBatchSize)
+		for h := range toVisit {
+			visited.Insert(h)
+			batch = append(batch, h)
+
+			if len(batch) == gcBatchSize {
+				batches = append(batches, batch)
+				batch = make([]hash.Hash, 0, gcBatchSize)
+			}
+		}
+
+		if len(batch) > 0 {
+			batches = append(batches, batch)
+		}
+
+		next := hash.NewHashSet()
+		for _, batch := range batches {
+			select {
+			case keepChunks <- batch:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			found := make(chan *chunks.Chunk, len(batch))
+			err := lvs.cs.GetMany(ctx, hash.NewHashSet(batch...), found)
+			close(found)
+
+			if err != nil {
+				return err
+			}
+
+			for c := range found {
+				err = WalkRefs(*c, lvs.Format(), func(r Ref) error {
+					if h := r.TargetHash(); !visited.Has(h) {
+						next.Insert(h)
+					}
+
+					return nil
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		toVisit = next
+	}
+
+	return nil
+}
+
 // Close closes the underlying ChunkStore
 func (lvs *ValueStore) Close() error {
 	return lvs.cs.Close()